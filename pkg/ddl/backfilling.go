@@ -38,8 +38,10 @@ import (
 	"github.com/pingcap/tidb/pkg/store/driver/backoff"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
 	"github.com/pingcap/tidb/pkg/util/timeutil"
@@ -58,6 +60,7 @@ const (
 	typeCleanUpIndexWorker     backfillerType = 2
 	typeAddIndexMergeTmpWorker backfillerType = 3
 	typeReorgPartitionWorker   backfillerType = 4
+	typeHandleRewriteWorker    backfillerType = 5
 )
 
 func (bT backfillerType) String() string {
@@ -72,6 +75,8 @@ func (bT backfillerType) String() string {
 		return "merge temporary index"
 	case typeReorgPartitionWorker:
 		return "reorganize partition"
+	case typeHandleRewriteWorker:
+		return "rewrite handle"
 	default:
 		return "unknown"
 	}
@@ -82,6 +87,7 @@ func (bT backfillerType) String() string {
 // 2: modify-column-type
 // 3: clean-up global index
 // 4: reorganize partition
+// 5: add or drop clustered primary key
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
@@ -627,17 +633,45 @@ func makeupDecodeColMap(sessCtx sessionctx.Context, dbName model.CIStr, t table.
 	for _, col := range t.WritableCols() {
 		writableColInfos = append(writableColInfos, col.ColumnInfo)
 	}
-	exprCols, _, err := expression.ColumnInfos2ColumnsAndNames(sessCtx, dbName, t.Meta().Name, writableColInfos, t.Meta())
+	exprCols, names, err := expression.ColumnInfos2ColumnsAndNames(sessCtx, dbName, t.Meta().Name, writableColInfos, t.Meta())
 	if err != nil {
 		return nil, err
 	}
 	mockSchema := expression.NewSchema(exprCols...)
 
 	decodeColMap := decoder.BuildFullDecodeColMap(t.WritableCols(), mockSchema)
+	for _, col := range t.WritableCols() {
+		if col.State == model.StatePublic || !col.IsGenerated() || !col.GeneratedStored {
+			continue
+		}
+		// The stored generated column being added or modified is evaluated by the backfill workers.
+		genExpr, err := buildAddingGeneratedExpr(sessCtx, t.Meta(), col.ColumnInfo, mockSchema, names)
+		if err != nil {
+			return nil, err
+		}
+		decodeColMap[col.ID] = decoder.Column{Col: col, GenExpr: genExpr}
+	}
 
 	return decodeColMap, nil
 }
 
+func buildAddingGeneratedExpr(sessCtx sessionctx.Context, tblInfo *model.TableInfo, col *model.ColumnInfo,
+	schema *expression.Schema, names types.NameSlice) (expression.Expression, error) {
+	expr, err := generatedexpr.ParseExpression(col.GeneratedExprString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	expr, err = generatedexpr.SimpleResolveName(expr, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	e, err := expression.RewriteAstExpr(sessCtx, expr, schema, names, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e.ResolveIndices(schema)
+}
+
 func setSessCtxLocation(sctx sessionctx.Context, tzLocation *model.TimeZoneLocation) error {
 	// It is set to SystemLocation to be compatible with nil LocationInfo.
 	tz := *timeutil.SystemLocation()
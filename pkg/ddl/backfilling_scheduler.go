@@ -212,7 +212,11 @@ func restoreSessCtx(sessCtx sessionctx.Context) func(sessCtx sessionctx.Context)
 	}
 }
 
-func (*txnBackfillScheduler) expectedWorkerSize() (size int) {
+func (b *txnBackfillScheduler) expectedWorkerSize() (size int) {
+	if b.tp == typeUpdateColumnWorker && isAllocatingAutoID(b.tbl, b.reorgInfo.currElement) {
+		// The values of the auto_increment column being added are allocated in the order of the handles.
+		return 1
+	}
 	workerCnt := int(variable.GetDDLReorgWorkerCounter())
 	return min(workerCnt, maxBackfillWorkerSize)
 }
@@ -274,6 +278,13 @@ func (b *txnBackfillScheduler) adjustWorkerSize() error {
 			}
 			runner = newBackfillWorker(jc.ddlJobCtx, partWorker)
 			worker = partWorker
+		case typeHandleRewriteWorker:
+			rewriteWorker, err := newHandleRewriteWorker(sessCtx, i, b.tbl, b.decodeColMap, reorgInfo, jc)
+			if err != nil {
+				return err
+			}
+			runner = newBackfillWorker(jc.ddlJobCtx, rewriteWorker)
+			worker = rewriteWorker
 		default:
 			return errors.New("unknown backfill type")
 		}
@@ -105,7 +105,7 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 	return tblInfo, columnInfo, col, pos, false, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(d, t, job)
@@ -168,8 +168,19 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 		// Update the job state when all affairs done.
 		job.SchemaState = model.StateWriteReorganization
-		job.MarkNonRevertible()
+		if needReorgToAddColumn(columnInfo) {
+			// Initialize SnapshotVer to 0 for later reorganization check.
+			job.SnapshotVer = 0
+		} else {
+			job.MarkNonRevertible()
+		}
 	case model.StateWriteReorganization:
+		if needReorgToAddColumn(columnInfo) {
+			done, ver, err := w.doReorgWorkForAddColumn(d, t, job, tblInfo, columnInfo)
+			if !done {
+				return ver, err
+			}
+		}
 		// reorganization -> public
 		// Adjust table column offset.
 		offset, err := LocateOffsetToMove(columnInfo.Offset, pos, tblInfo)
@@ -197,6 +208,68 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	return ver, errors.Trace(err)
 }
 
+// isAddingStoredGeneratedColumn checks whether the column is a stored generated column being added,
+// whose values of the existing rows need to be backfilled before it becomes public.
+func isAddingStoredGeneratedColumn(col *model.ColumnInfo) bool {
+	return col.State != model.StatePublic && col.ChangeStateInfo == nil && col.IsGenerated() && col.GeneratedStored
+}
+
+// isAddingAutoIncrementColumn checks whether the column is an auto_increment column being added,
+// whose values of the existing rows need to be allocated before it becomes public.
+func isAddingAutoIncrementColumn(col *model.ColumnInfo) bool {
+	return col.State != model.StatePublic && col.ChangeStateInfo == nil && mysql.HasAutoIncrementFlag(col.GetFlag())
+}
+
+// needReorgToAddColumn checks whether the existing rows need to be backfilled for the column being added.
+func needReorgToAddColumn(col *model.ColumnInfo) bool {
+	return isAddingStoredGeneratedColumn(col) || isAddingAutoIncrementColumn(col)
+}
+
+// isAllocatingAutoID checks whether the element is the auto_increment column being added.
+func isAllocatingAutoID(t table.Table, elem *meta.Element) bool {
+	if elem == nil || !bytes.Equal(elem.TypeKey, meta.ColumnElementKey) {
+		return false
+	}
+	for _, col := range t.WritableCols() {
+		if col.ID == elem.ID {
+			return isAddingAutoIncrementColumn(col.ColumnInfo)
+		}
+	}
+	return false
+}
+
+// doReorgWorkForAddColumn backfills the stored generated column or the auto_increment column for the existing rows.
+func (w *worker) doReorgWorkForAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tblInfo *model.TableInfo, columnInfo *model.ColumnInfo) (done bool, ver int64, err error) {
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible {
+		// Non-revertible means all the sub jobs finished.
+		return true, ver, nil
+	}
+	tbl, err := getTable((*asAutoIDRequirement)(d), job.SchemaID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	done, ver, err = doReorgWorkForModifyColumn(w, d, t, job, tbl, columnInfo, columnInfo, nil)
+	if job.IsRollingback() && job.MultiSchemaInfo == nil {
+		// Convert the job to drop the added column like rollingbackAddColumn,
+		// the multi-schema change job rolls back its sub-jobs by itself.
+		columnInfo.State = model.StateDeleteOnly
+		job.SchemaState = model.StateDeleteOnly
+		job.Args = []interface{}{columnInfo.Name}
+		ver, err1 := updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err1 != nil {
+			return false, ver, errors.Trace(err1)
+		}
+		return false, ver, errors.Trace(err)
+	}
+	if done && job.MultiSchemaInfo != nil {
+		// We need another round to wait for all the others sub-jobs to finish.
+		job.MarkNonRevertible()
+		return false, ver, err
+	}
+	return done, ver, errors.Trace(err)
+}
+
 // CheckAfterPositionExists makes sure the column specified in AFTER clause is exists.
 // For example, ALTER TABLE t ADD COLUMN c3 INT AFTER c1.
 func CheckAfterPositionExists(tblInfo *model.TableInfo, pos *ast.ColumnPosition) error {
@@ -424,6 +497,29 @@ func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 	return true
 }
 
+// needChangeGeneratedColumnData checks whether the modification of the generated column needs to reorganize
+// the stored values of the column or the indexes on it.
+func needChangeGeneratedColumnData(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	if !newCol.IsGenerated() {
+		// The stored values are kept when the stored generated column is changed to a normal column.
+		return false
+	}
+	if oldCol.IsGenerated() && oldCol.GeneratedExprString == newCol.GeneratedExprString {
+		// The values are unchanged, only the virtual column changed to stored needs to be filled.
+		return !oldCol.GeneratedStored && newCol.GeneratedStored
+	}
+	// The expression is changed, the stored values of the old column can't be kept either.
+	if newCol.GeneratedStored || !oldCol.IsVirtualGenerated() {
+		return true
+	}
+	return isColumnWithIndex(oldCol.Name.L, tblInfo.Indices)
+}
+
+// needReorgToModifyColumn checks whether the modify column job needs to reorganize the data.
+func needReorgToModifyColumn(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	return needChangeColumnData(oldCol, newCol) || needChangeGeneratedColumnData(tblInfo, oldCol, newCol)
+}
+
 // ConvertBetweenCharAndVarchar check whether column converted between char and varchar
 // TODO: it is used for plugins. so change plugin's using and remove it.
 func ConvertBetweenCharAndVarchar(oldCol, newCol byte) bool {
@@ -523,7 +619,7 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 
 	if job.IsRollingback() {
 		// For those column-type-change jobs which don't reorg the data.
-		if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+		if !needReorgToModifyColumn(tblInfo, oldCol, modifyInfo.newCol) {
 			return rollbackModifyColumnJob(d, t, tblInfo, job, modifyInfo.newCol, oldCol, modifyInfo.modifyColumnTp)
 		}
 		// For those column-type-change jobs which reorg the data.
@@ -554,11 +650,11 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 		return ver, errors.Trace(err)
 	}
 
-	if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+	if !needReorgToModifyColumn(tblInfo, oldCol, modifyInfo.newCol) {
 		return w.doModifyColumn(d, t, job, dbInfo, tblInfo, modifyInfo.newCol, oldCol, modifyInfo.pos)
 	}
 
-	if err = isGeneratedRelatedColumn(tblInfo, modifyInfo.newCol, oldCol); err != nil {
+	if err = isGeneratedRelatedColumn(tblInfo, oldCol); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -1086,6 +1182,9 @@ func (w *worker) updatePhysicalTableRow(t table.Table, reorgInfo *reorgInfo) err
 				model.ActionRemovePartitioning,
 				model.ActionAlterTablePartitioning:
 				// Expected
+			case model.ActionAddColumn:
+				// The stored generated column being added is backfilled partition by partition.
+				workType = typeUpdateColumnWorker
 			default:
 				// workType = typeUpdateColumnWorker
 				// TODO: Support Modify Column on partitioned table
@@ -1129,7 +1228,8 @@ func (w *worker) updateCurrentElement(t table.Table, reorgInfo *reorgInfo) error
 		}
 	})
 	// TODO: Support partition tables.
-	if bytes.Equal(reorgInfo.currElement.TypeKey, meta.ColumnElementKey) {
+	// The values of the virtual generated column aren't stored, only its indexes need to be backfilled.
+	if bytes.Equal(reorgInfo.currElement.TypeKey, meta.ColumnElementKey) && !isVirtualGeneratedColumn(t.Meta(), reorgInfo.currElement.ID) {
 		//nolint:forcetypeassert
 		err := w.updatePhysicalTableRow(t.(table.PhysicalTable), reorgInfo)
 		if err != nil {
@@ -1138,6 +1238,10 @@ func (w *worker) updateCurrentElement(t table.Table, reorgInfo *reorgInfo) error
 	}
 
 	if _, ok := t.(table.PartitionedTable); ok {
+		// The stored generated column being added has no index to backfill.
+		if reorgInfo.Job.Type == model.ActionAddColumn {
+			return nil
+		}
 		// TODO: remove when modify column of partitioned table is supported
 		// https://github.com/pingcap/tidb/issues/38297
 		return dbterror.ErrCancelledDDLJob.GenWithStack("Modify Column on partitioned table / typeUpdateColumnWorker not yet supported.")
@@ -1193,6 +1297,11 @@ func (w *worker) updateCurrentElement(t table.Table, reorgInfo *reorgInfo) error
 	return nil
 }
 
+func isVirtualGeneratedColumn(tblInfo *model.TableInfo, colID int64) bool {
+	col := model.FindColumnInfoByID(tblInfo.Columns, colID)
+	return col != nil && col.IsVirtualGenerated()
+}
+
 type updateColumnWorker struct {
 	*backfillCtx
 	oldColInfo *model.ColumnInfo
@@ -1203,6 +1312,10 @@ type updateColumnWorker struct {
 	rowDecoder *decoder.RowDecoder
 
 	rowMap map[int64]types.Datum
+	// genExpr is the expression of the generated column being added or modified.
+	genExpr expression.Expression
+	// allocAutoID indicates the values of the auto_increment column being added are allocated for the rows.
+	allocAutoID bool
 
 	checksumBuffer rowcodec.RowData
 	checksumNeeded bool
@@ -1218,7 +1331,10 @@ func newUpdateColumnWorker(sessCtx sessionctx.Context, id int, t table.PhysicalT
 	for _, col := range t.WritableCols() {
 		if col.ID == reorgInfo.currElement.ID {
 			newCol = col.ColumnInfo
-			oldCol = table.FindCol(t.Cols(), getChangingColumnOriginName(newCol)).ColumnInfo
+			// The generated column being added or modified has no old column to cast from, it's evaluated from the other columns.
+			if newCol.ChangeStateInfo != nil && !newCol.IsGenerated() {
+				oldCol = table.FindCol(t.Cols(), getChangingColumnOriginName(newCol)).ColumnInfo
+			}
 			break
 		}
 	}
@@ -1231,7 +1347,7 @@ func newUpdateColumnWorker(sessCtx sessionctx.Context, id int, t table.PhysicalT
 	})
 	// We use global `EnableRowLevelChecksum` to detect whether checksum is enabled in ddl backfill worker because
 	// `SessionVars.IsRowLevelChecksumEnabled` will filter out internal sessions.
-	if variable.EnableRowLevelChecksum.Load() {
+	if variable.EnableRowLevelChecksum.Load() && oldCol != nil {
 		if numNonPubCols := len(t.DeletableCols()) - len(t.Cols()); numNonPubCols > 1 {
 			cols := make([]*model.ColumnInfo, len(t.DeletableCols()))
 			for i, col := range t.DeletableCols() {
@@ -1249,6 +1365,8 @@ func newUpdateColumnWorker(sessCtx sessionctx.Context, id int, t table.PhysicalT
 		newColInfo:     newCol,
 		rowDecoder:     rowDecoder,
 		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
+		genExpr:        decodeColMap[newCol.ID].GenExpr,
+		allocAutoID:    isAddingAutoIncrementColumn(newCol),
 		checksumNeeded: checksumNeeded,
 	}
 }
@@ -1335,30 +1453,20 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 	}
 
 	var recordWarning *terror.Error
-	// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
-	oldWarn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
-	if oldWarn == nil {
-		oldWarn = []stmtctx.SQLWarn{}
-	} else {
-		oldWarn = oldWarn[:0]
-	}
-	w.sessCtx.GetSessionVars().StmtCtx.SetWarnings(oldWarn)
-	val := w.rowMap[w.oldColInfo.ID]
-	col := w.newColInfo
-	if val.Kind() == types.KindNull && col.FieldType.GetType() == mysql.TypeTimestamp && mysql.HasNotNullFlag(col.GetFlag()) {
-		if v, err := expression.GetTimeCurrentTimestamp(w.sessCtx, col.GetType(), col.GetDecimal()); err == nil {
-			// convert null value to timestamp should be substituted with current timestamp if NOT_NULL flag is set.
-			w.rowMap[w.oldColInfo.ID] = v
+	if w.oldColInfo != nil {
+		newColVal, warning, err := w.castChangingColumn()
+		if err != nil {
+			return err
 		}
+		w.rowMap[w.newColInfo.ID] = newColVal
+		recordWarning = warning
 	}
-	newColVal, err := table.CastValue(w.sessCtx, w.rowMap[w.oldColInfo.ID], w.newColInfo, false, false)
-	if err != nil {
-		return w.reformatErrors(err)
-	}
-	warn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
-	if len(warn) != 0 {
-		//nolint:forcetypeassert
-		recordWarning = errors.Cause(w.reformatErrors(warn[0].Err)).(*terror.Error)
+	if w.allocAutoID {
+		newColVal, err := w.allocAutoIncrementValue()
+		if err != nil {
+			return err
+		}
+		w.rowMap[w.newColInfo.ID] = newColVal
 	}
 
 	failpoint.Inject("MockReorgTimeoutInOneRegion", func(val failpoint.Value) {
@@ -1370,11 +1478,19 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 		}
 	})
 
-	w.rowMap[w.newColInfo.ID] = newColVal
 	_, err = w.rowDecoder.EvalRemainedExprColumnMap(w.sessCtx, w.rowMap)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if w.genExpr != nil {
+		// The generated column being added or modified is evaluated again to report the invalid values.
+		newColVal, warning, err := w.evalGeneratedColumn()
+		if err != nil {
+			return err
+		}
+		w.rowMap[w.newColInfo.ID] = newColVal
+		recordWarning = warning
+	}
 	newColumnIDs := make([]int64, 0, len(w.rowMap))
 	newRow := make([]types.Datum, 0, len(w.rowMap))
 	for colID, val := range w.rowMap {
@@ -1394,6 +1510,63 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 	return nil
 }
 
+// allocAutoIncrementValue allocates the value of the auto_increment column being added for the row.
+// The rows are backfilled by only one worker in the order of the handles, so the values are allocated in the same order.
+func (w *updateColumnWorker) allocAutoIncrementValue() (types.Datum, error) {
+	id, err := table.AllocAutoIncrementValue(w.ctx, w.table, w.sessCtx)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	var d types.Datum
+	d.SetAutoID(id, w.newColInfo.GetFlag())
+	d, err = table.CastValue(w.sessCtx, d, w.newColInfo, false, false)
+	return d, errors.Trace(err)
+}
+
+// castChangingColumn casts the value of the old column to the changing column.
+func (w *updateColumnWorker) castChangingColumn() (types.Datum, *terror.Error, error) {
+	val := w.rowMap[w.oldColInfo.ID]
+	col := w.newColInfo
+	if val.Kind() == types.KindNull && col.FieldType.GetType() == mysql.TypeTimestamp && mysql.HasNotNullFlag(col.GetFlag()) {
+		if v, err := expression.GetTimeCurrentTimestamp(w.sessCtx, col.GetType(), col.GetDecimal()); err == nil {
+			// convert null value to timestamp should be substituted with current timestamp if NOT_NULL flag is set.
+			w.rowMap[w.oldColInfo.ID] = v
+		}
+	}
+	return w.castToNewColumn(w.rowMap[w.oldColInfo.ID])
+}
+
+// evalGeneratedColumn evaluates the generated column being added or modified with the decoded row.
+func (w *updateColumnWorker) evalGeneratedColumn() (types.Datum, *terror.Error, error) {
+	val, err := w.genExpr.Eval(w.sessCtx, w.rowDecoder.CurrentRowWithDefaultVal())
+	if err != nil {
+		return val, nil, errors.Trace(err)
+	}
+	return w.castToNewColumn(val)
+}
+
+func (w *updateColumnWorker) castToNewColumn(val types.Datum) (types.Datum, *terror.Error, error) {
+	var recordWarning *terror.Error
+	// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
+	oldWarn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
+	if oldWarn == nil {
+		oldWarn = []stmtctx.SQLWarn{}
+	} else {
+		oldWarn = oldWarn[:0]
+	}
+	w.sessCtx.GetSessionVars().StmtCtx.SetWarnings(oldWarn)
+	newColVal, err := table.CastValue(w.sessCtx, val, w.newColInfo, false, false)
+	if err != nil {
+		return newColVal, nil, w.reformatErrors(err, val)
+	}
+	warn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
+	if len(warn) != 0 {
+		//nolint:forcetypeassert
+		recordWarning = errors.Cause(w.reformatErrors(warn[0].Err, val)).(*terror.Error)
+	}
+	return newColVal, recordWarning, nil
+}
+
 func (w *updateColumnWorker) calcChecksums() []uint32 {
 	if !w.checksumNeeded {
 		return nil
@@ -1427,16 +1600,28 @@ func (w *updateColumnWorker) calcChecksums() []uint32 {
 }
 
 // reformatErrors casted error because `convertTo` function couldn't package column name and datum value for some errors.
-func (w *updateColumnWorker) reformatErrors(err error) error {
+func (w *updateColumnWorker) reformatErrors(err error, val types.Datum) error {
+	colName := w.newColInfo.Name
+	if w.oldColInfo != nil {
+		colName = w.oldColInfo.Name
+	} else if w.newColInfo.ChangeStateInfo != nil {
+		colName = model.NewCIStr(getChangingColumnOriginName(w.newColInfo))
+	}
+	return reformatCastErrors(err, colName, val)
+}
+
+// reformatCastErrors reformats the errors of casting val to a changed column definition,
+// to include the column name and the value instead of the row count.
+func reformatCastErrors(err error, colName model.CIStr, val types.Datum) error {
 	// Since row count is not precious in concurrent reorganization, here we substitute row count with datum value.
 	if types.ErrTruncated.Equal(err) || types.ErrDataTooLong.Equal(err) {
-		dStr := datumToStringNoErr(w.rowMap[w.oldColInfo.ID])
-		err = types.ErrTruncated.GenWithStack("Data truncated for column '%s', value is '%s'", w.oldColInfo.Name, dStr)
+		dStr := datumToStringNoErr(val)
+		err = types.ErrTruncated.GenWithStack("Data truncated for column '%s', value is '%s'", colName, dStr)
 	}
 
 	if types.ErrWarnDataOutOfRange.Equal(err) {
-		dStr := datumToStringNoErr(w.rowMap[w.oldColInfo.ID])
-		err = types.ErrWarnDataOutOfRange.GenWithStack("Out of range value for column '%s', the value is '%s'", w.oldColInfo.Name, dStr)
+		dStr := datumToStringNoErr(val)
+		err = types.ErrWarnDataOutOfRange.GenWithStack("Out of range value for column '%s', the value is '%s'", colName, dStr)
 	}
 	return err
 }
@@ -185,14 +185,10 @@ AddLoop:
 	tk.MustExec("alter table test_on_update_e add column c2 year not null;")
 	tk.MustQuery("select c2 from test_on_update_e").Check(testkit.Rows("0"))
 
-	// test add unsupported constraint
+	// test add auto_increment column without key
 	tk.MustExec("create table t_add_unsupported_constraint (a int);")
 	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int AUTO_INCREMENT;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint AUTO_INCREMENT when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int KEY;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint PRIMARY KEY when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int UNIQUE;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint UNIQUE KEY when altering 'test.t_add_unsupported_constraint'")
+	require.EqualError(t, err, "[autoid:1075]Incorrect table definition; there can be only one auto column and it must be defined as a key")
 
 	// ===========
 	// DROP COLUMN
@@ -527,6 +523,214 @@ func TestAddGeneratedColumnAndInsert(t *testing.T) {
 	require.NoError(t, checkErr)
 }
 
+func TestAddStoredGeneratedColumn(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int primary key, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	d := dom.DDL()
+	hook := &callback.TestDDLCallback{Do: dom}
+	var checkErr error
+	onJobUpdatedExportedFunc := func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionAddColumn {
+			return
+		}
+		switch job.SchemaState {
+		case model.StateDeleteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (4, 4)")
+		case model.StateWriteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (5, 5)")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("update t1 set b = 10 where a = 1")
+			}
+		case model.StateWriteReorganization:
+			_, checkErr = tk1.Exec("insert into t1 values (6, 6) on duplicate key update b = b + 10")
+		}
+	}
+	hook.OnJobUpdatedExported.Store(&onJobUpdatedExportedFunc)
+	d.SetHook(hook)
+
+	tk.MustExec("alter table t1 add column c int as (a + b) stored")
+	require.NoError(t, checkErr)
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 10 11", "2 2 4", "3 3 6", "4 4 8", "5 5 10", "6 16 22"))
+	tk.MustQuery("select c from t1 use index() where c > 10").Sort().Check(testkit.Rows("11", "22"))
+	tk.MustExec("admin check table t1")
+	tk.MustQuery("select extra from information_schema.columns where table_schema = 'test' and table_name = 't1' and column_name = 'c'").Check(testkit.Rows("STORED GENERATED"))
+
+	// The added column can be indexed.
+	tk.MustExec("alter table t1 add index idx(c)")
+	tk.MustExec("admin check table t1")
+
+	// The job is rolled back when the backfill fails.
+	d.SetHook(&callback.TestDDLCallback{Do: dom})
+	tk.MustExec("create table t2 (a varchar(10))")
+	tk.MustExec("insert into t2 values ('1'), ('x')")
+	tk.MustGetErrCode("alter table t2 add column b int as (a + 1) stored", errno.ErrTruncatedWrongValue)
+	tk.MustQuery("select * from t2 order by a").Check(testkit.Rows("1", "x"))
+	tk.MustExec("admin check table t2")
+
+	// The rows of all the partitions are backfilled.
+	tk.MustExec("create table t3 (a int, b int) partition by hash(a) partitions 3")
+	tk.MustExec("insert into t3 values (1, 1), (2, 2), (3, 3), (4, 4)")
+	tk.MustExec("alter table t3 add column c int as (a + b) stored")
+	tk.MustQuery("select * from t3 order by a").Check(testkit.Rows("1 1 2", "2 2 4", "3 3 6", "4 4 8"))
+	tk.MustQuery("select a, c from t3 partition (p1) order by a").Check(testkit.Rows("1 2", "4 8"))
+	tk.MustExec("admin check table t3")
+	tk.MustExec("alter table t3 add column d int as (c * 2) stored, add column e int default 1")
+	tk.MustQuery("select a, d, e from t3 order by a").Check(testkit.Rows("1 4 1", "2 8 1", "3 12 1", "4 16 1"))
+	tk.MustExec("admin check table t3")
+}
+
+func TestModifyGeneratedColumnWithReorg(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int primary key, b int, c int as (a + b), index idx(c))")
+	tk.MustExec("insert into t1 (a, b) values (1, 1), (2, 2), (3, 3)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	d := dom.DDL()
+	hook := &callback.TestDDLCallback{Do: dom}
+	var checkErr error
+	onJobUpdatedExportedFunc := func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionModifyColumn {
+			return
+		}
+		switch job.SchemaState {
+		case model.StateDeleteOnly:
+			_, checkErr = tk1.Exec("insert into t1 (a, b) values (10, 10)")
+		case model.StateWriteOnly:
+			_, checkErr = tk1.Exec("update t1 set b = b + 10 where a in (1, 10)")
+		case model.StateWriteReorganization:
+			_, checkErr = tk1.Exec("delete from t1 where a = 2")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("insert into t1 (a, b) values (3, 3) on duplicate key update b = 30")
+			}
+		}
+	}
+	hook.OnJobUpdatedExported.Store(&onJobUpdatedExportedFunc)
+	d.SetHook(hook)
+
+	// Change the expression of the indexed virtual column.
+	tk.MustExec("alter table t1 modify column c int as (a * b)")
+	require.NoError(t, checkErr)
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 11 11", "3 30 90", "10 20 200"))
+	tk.MustQuery("select c from t1 use index(idx) order by c").Check(testkit.Rows("11", "90", "200"))
+	tk.MustExec("admin check table t1")
+	d.SetHook(&callback.TestDDLCallback{Do: dom})
+
+	// Change the virtual column to stored column.
+	tk.MustExec("alter table t1 modify column c bigint as (a - b) stored")
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 11 -10", "3 30 -27", "10 20 -10"))
+	tk.MustQuery("select extra from information_schema.columns where table_schema = 'test' and table_name = 't1' and column_name = 'c'").Check(testkit.Rows("STORED GENERATED"))
+	tk.MustExec("admin check table t1")
+
+	// Change the expression of the stored column and convert it back to virtual column.
+	tk.MustExec("alter table t1 modify column c bigint as (a + 100) stored")
+	tk.MustQuery("select c from t1 use index(idx) order by c").Check(testkit.Rows("101", "103", "110"))
+	tk.MustExec("alter table t1 modify column c bigint as (a + b) virtual")
+	tk.MustQuery("select c from t1 use index(idx) order by c").Check(testkit.Rows("12", "30", "33"))
+	tk.MustExec("admin check table t1")
+
+	// The job is rolled back when the generated values can't be stored.
+	tk.MustGetErrCode("alter table t1 modify column c tinyint as (a * 100) stored", errno.ErrDataOutOfRange)
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 11 12", "3 30 33", "10 20 30"))
+	tk.MustExec("admin check table t1")
+}
+
+func TestAddColumnWithKeyConstraint(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	d := dom.DDL()
+	hook := &callback.TestDDLCallback{Do: dom}
+	var checkErr error
+	onJobUpdatedExportedFunc := func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionMultiSchemaChange {
+			return
+		}
+		addColumn, addIndex := job.MultiSchemaInfo.SubJobs[0], job.MultiSchemaInfo.SubJobs[1]
+		switch {
+		case addColumn.SchemaState == model.StateDeleteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (4, 4)")
+		case addColumn.SchemaState == model.StateWriteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (5, 5)")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("update t1 set b = 10 where a = 1")
+			}
+		case addIndex.SchemaState == model.StateWriteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (6, 6)")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("update t1 set b = 20 where a = 2")
+			}
+		}
+	}
+	hook.OnJobUpdatedExported.Store(&onJobUpdatedExportedFunc)
+	d.SetHook(hook)
+
+	// The column and the index are added by one multi-schema change job.
+	tk.MustExec("alter table t1 add column id bigint auto_increment unique")
+	require.NoError(t, checkErr)
+	d.SetHook(&callback.TestDDLCallback{Do: dom})
+	tk.MustQuery("select a, b from t1 order by a").Check(testkit.Rows("1 10", "2 20", "3 3", "4 4", "5 5", "6 6"))
+	tk.MustQuery("select count(distinct id), count(*) from t1 where id > 0").Check(testkit.Rows("6 6"))
+	tk.MustQuery("select count(*) from t1 use index(id) where id > 0").Check(testkit.Rows("6"))
+	tk.MustExec("admin check table t1")
+	tk.MustQuery("show create table t1").CheckContain("`id` bigint(20) NOT NULL AUTO_INCREMENT,\n  UNIQUE KEY `id` (`id`)\n)")
+	tk.MustQuery("select job_type from information_schema.ddl_jobs where table_name = 't1' limit 1").Check(testkit.Rows("alter table multi-schema change"))
+
+	// The auto IDs of the existing rows are allocated in the order of the handles.
+	tk.MustExec("create table t2 (a int primary key, b int)")
+	tk.MustExec("insert into t2 values (3, 3), (1, 1), (5, 5), (2, 2), (4, 4)")
+	tk.MustExec("alter table t2 add column id int auto_increment unique")
+	tk.MustQuery("select a from t2 order by id").Check(testkit.Rows("1", "2", "3", "4", "5"))
+	tk.MustQuery("select max(id) - min(id) from t2").Check(testkit.Rows("4"))
+	tk.MustExec("insert into t2 (a, b) values (6, 6)")
+	tk.MustQuery("select a from t2 where id > (select max(id) from t2 where a < 6)").Check(testkit.Rows("6"))
+	tk.MustQuery("show create table t2").CheckContain("UNIQUE KEY `id` (`id`)")
+	tk.MustExec("admin check table t2")
+
+	// The added column can be the primary key.
+	tk.MustExec("create table t3 (a int)")
+	tk.MustExec("insert into t3 values (1), (2)")
+	tk.MustExec("alter table t3 add column id int auto_increment primary key, add column c int unique")
+	tk.MustQuery("select count(distinct id), count(c) from t3").Check(testkit.Rows("2 0"))
+	tk.MustQuery("show create table t3").CheckContain("PRIMARY KEY (`id`) /*T![clustered_index] NONCLUSTERED */")
+	tk.MustQuery("show create table t3").CheckContain("UNIQUE KEY `c` (`c`)")
+	tk.MustExec("admin check table t3")
+	tk.MustGetErrCode("alter table t3 add column d int primary key", errno.ErrMultiplePriKey)
+	// The job is rolled back when the index can't be built.
+	tk.MustGetErrCode("alter table t3 add column d int not null unique", errno.ErrDupEntry)
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't3'").Check(testkit.Rows("3"))
+	tk.MustExec("admin check table t3")
+
+	tk.MustExec("create table t4 (a int)")
+	tk.MustExec("alter table t4 add column id int auto_increment, add index idx(id)")
+	tk.MustGetErrCode("alter table t4 add column id2 int auto_increment unique", errno.ErrWrongAutoKey)
+	tk.MustGetErrCode("alter table t3 add column id2 varchar(10) auto_increment unique", errno.ErrWrongAutoKey)
+	tk.MustExec("create table t5 (a int)")
+	tk.MustGetErrCode("alter table t5 add column id varchar(10) auto_increment unique", errno.ErrWrongFieldSpec)
+	tk.MustGetErrCode("alter table t5 add column id int auto_increment, add index idx(a, id)", errno.ErrWrongAutoKey)
+	tk.MustExec("create table t6 (a int) partition by hash(a) partitions 2")
+	tk.MustGetErrCode("alter table t6 add column id int auto_increment unique", errno.ErrUnsupportedDDLOperation)
+}
+
 func TestColumnTypeChangeGenUniqueChangingName(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
 
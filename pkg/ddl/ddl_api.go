@@ -622,6 +622,9 @@ func (d *ddl) DropSchema(ctx sessionctx.Context, stmt *ast.DropDatabaseStmt) (er
 	if err != nil {
 		return err
 	}
+	if err = checkDatabaseHasMaterializedViewReferred(is, old); err != nil {
+		return err
+	}
 	job := &model.Job{
 		SchemaID:       old.ID,
 		SchemaName:     old.Name.L,
@@ -1984,6 +1987,22 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		// A FULLTEXT index is built on the hidden column of the tokens.
+		if constr.Tp == ast.ConstraintFulltext {
+			keys, option, err := BuildFullTextIndexParts(tbInfo, constr.Keys, constr.Option)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			constr.Keys, constr.Option = keys, option
+		}
+		// A SPATIAL index is built on the hidden column of the cells.
+		if constr.Tp == ast.ConstraintSpatial {
+			keys, option, err := BuildSpatialIndexParts(tbInfo, constr.Keys, constr.Option)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			constr.Keys, constr.Option = keys, option
+		}
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
 		if err != nil {
@@ -2046,11 +2065,6 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
 			primary, unique bool
@@ -2379,6 +2393,10 @@ func BuildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
+	// The new table is a plain table even if the referred one is a materialized view or its base table.
+	tblInfo.MaterializedView = nil
+	tblInfo.MaterializedViews = nil
+	tblInfo.IsMaterializedViewLog = false
 	// Ignore TiFlash replicas for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
@@ -3629,14 +3647,18 @@ func NeedToOverwriteColCharset(options []*ast.TableOption) bool {
 // `ALTER TABLE ADD COLUMN c1 INT, ADD COLUMN c2 INT`.
 func resolveAlterTableAddColumns(spec *ast.AlterTableSpec) []*ast.AlterTableSpec {
 	specs := make([]*ast.AlterTableSpec, 0, len(spec.NewColumns)+len(spec.NewConstraints))
+	var constraints []*ast.Constraint
 	for _, col := range spec.NewColumns {
+		col, colConstraints := splitColumnKeyOptions(col)
+		constraints = append(constraints, colConstraints...)
 		t := *spec
 		t.NewColumns = []*ast.ColumnDef{col}
 		t.NewConstraints = []*ast.Constraint{}
 		specs = append(specs, &t)
 	}
+	constraints = append(constraints, spec.NewConstraints...)
 	// Split the add constraints from AlterTableSpec.
-	for _, con := range spec.NewConstraints {
+	for _, con := range constraints {
 		t := *spec
 		t.NewColumns = []*ast.ColumnDef{}
 		t.NewConstraints = []*ast.Constraint{}
@@ -3647,6 +3669,42 @@ func resolveAlterTableAddColumns(spec *ast.AlterTableSpec) []*ast.AlterTableSpec
 	return specs
 }
 
+// containsColumnKeyOption checks whether any of the columns is defined with the PRIMARY KEY or UNIQUE option.
+func containsColumnKeyOption(colDefs []*ast.ColumnDef) bool {
+	for _, colDef := range colDefs {
+		if containsColumnOption(colDef, ast.ColumnOptionPrimaryKey) || containsColumnOption(colDef, ast.ColumnOptionUniqKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitColumnKeyOptions splits the PRIMARY KEY and UNIQUE options of the column added by ALTER TABLE into the
+// constraints added after the column, the column of the PRIMARY KEY is defined as NOT NULL instead.
+func splitColumnKeyOptions(colDef *ast.ColumnDef) (*ast.ColumnDef, []*ast.Constraint) {
+	var constraints []*ast.Constraint
+	options := make([]*ast.ColumnOption, 0, len(colDef.Options))
+	keys := []*ast.IndexPartSpecification{{Column: colDef.Name, Length: types.UnspecifiedLength}}
+	for _, option := range colDef.Options {
+		switch option.Tp {
+		case ast.ColumnOptionPrimaryKey:
+			constraints = append(constraints, &ast.Constraint{Tp: ast.ConstraintPrimaryKey, Keys: keys,
+				Option: &ast.IndexOption{PrimaryKeyTp: option.PrimaryKeyTp}})
+			options = append(options, &ast.ColumnOption{Tp: ast.ColumnOptionNotNull})
+		case ast.ColumnOptionUniqKey:
+			constraints = append(constraints, &ast.Constraint{Tp: ast.ConstraintUniqKey, Keys: keys})
+		default:
+			options = append(options, option)
+		}
+	}
+	if len(constraints) == 0 {
+		return colDef, nil
+	}
+	newColDef := *colDef
+	newColDef.Options = options
+	return &newColDef, constraints
+}
+
 // ResolveAlterTableSpec resolves alter table algorithm and removes ignore table spec in specs.
 // returns valid specs, and the occurred error.
 func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec) ([]*ast.AlterTableSpec, error) {
@@ -3660,7 +3718,7 @@ func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 		if isIgnorableSpec(spec.Tp) {
 			continue
 		}
-		if spec.Tp == ast.AlterTableAddColumns && (len(spec.NewColumns) > 1 || len(spec.NewConstraints) > 0) {
+		if spec.Tp == ast.AlterTableAddColumns && (len(spec.NewColumns) > 1 || len(spec.NewConstraints) > 0 || containsColumnKeyOption(spec.NewColumns)) {
 			validSpecs = append(validSpecs, resolveAlterTableAddColumns(spec)...)
 		} else {
 			validSpecs = append(validSpecs, spec)
@@ -3720,6 +3778,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
 		}
 	}
+	if err := checkAlterMaterializedView(is, tb.Meta(), validSpecs); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkAddAutoIncrementColumn(ident, tb.Meta(), validSpecs); err != nil {
+		return errors.Trace(err)
+	}
 	if isMultiSchemaChanges(validSpecs) && (sctx.GetSessionVars().EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load()) {
 		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
 	}
@@ -3809,7 +3873,11 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("the switch of check constraint is off"))
@@ -4090,8 +4158,6 @@ func (d *ddl) getSchemaAndTableByIdent(ctx sessionctx.Context, tableIdent ast.Id
 func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	for _, constraint := range col.Options {
 		switch constraint.Tp {
-		case ast.ColumnOptionAutoIncrement:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint AUTO_INCREMENT when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		case ast.ColumnOptionPrimaryKey:
 			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint PRIMARY KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		case ast.ColumnOptionUniqKey:
@@ -4105,6 +4171,56 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	return nil
 }
 
+// checkAddAutoIncrementColumn checks the auto_increment column added by ALTER TABLE. There can be only one auto column
+// in the table, and it must be the first column of an index added by the same statement.
+func checkAddAutoIncrementColumn(ti ast.Ident, tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) error {
+	var autoIncCol *ast.ColumnDef
+	for _, spec := range specs {
+		if spec.Tp != ast.AlterTableAddColumns {
+			continue
+		}
+		for _, colDef := range spec.NewColumns {
+			if !containsColumnOption(colDef, ast.ColumnOptionAutoIncrement) {
+				continue
+			}
+			if autoIncCol != nil || tblInfo.GetAutoIncrementColInfo() != nil {
+				return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+			}
+			autoIncCol = colDef
+		}
+	}
+	if autoIncCol == nil {
+		return nil
+	}
+	if tblInfo.ContainsAutoRandomBits() {
+		return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomIncompatibleWithAutoIncErrMsg)
+	}
+	// The values of the existing rows are allocated by the update column workers, which don't support partitioned tables yet.
+	if tblInfo.GetPartitionInfo() != nil {
+		return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint AUTO_INCREMENT when altering partitioned table '%s.%s'", autoIncCol.Name, ti.Schema, ti.Name)
+	}
+	switch autoIncCol.Tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong,
+		mysql.TypeFloat, mysql.TypeDouble, mysql.TypeLonglong, mysql.TypeInt24:
+	default:
+		return types.ErrWrongFieldSpec.GenWithStackByArgs(autoIncCol.Name.Name.O)
+	}
+	for _, spec := range specs {
+		if spec.Tp != ast.AlterTableAddConstraint {
+			continue
+		}
+		switch spec.Constraint.Tp {
+		case ast.ConstraintPrimaryKey, ast.ConstraintKey, ast.ConstraintIndex,
+			ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
+			key := spec.Constraint.Keys[0]
+			if key.Column != nil && key.Column.Name.L == autoIncCol.Name.Name.L {
+				return nil
+			}
+		}
+	}
+	return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+}
+
 func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, error) {
 	err := checkUnsupportedColumnConstraint(specNewColumn, ti)
 	if err != nil {
@@ -4143,10 +4259,6 @@ func CreateNewColumn(ctx sessionctx.Context, schema *model.DBInfo, spec *ast.Alt
 				return nil, errors.Trace(err)
 			}
 
-			if option.Stored {
-				return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
-			}
-
 			_, dependColNames, err := findDependedColumnNames(schema.Name, t.Meta().Name, specNewColumn)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -4265,6 +4377,11 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		Args:           []interface{}{col, spec.Position, 0, spec.IfNotExists},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 	}
+	// The stored generated column and the auto_increment column need to be backfilled for the existing rows.
+	if (col.IsGenerated() && col.GeneratedStored) || mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		job.ReorgMeta = NewDDLReorgMeta(ctx)
+		job.CtxVars = []interface{}{true}
+	}
 
 	err = d.DoDDLJob(ctx, job)
 	err = d.callHookOnChanged(job, err)
@@ -4654,6 +4771,7 @@ func checkReorgPartitionDefs(ctx sessionctx.Context, action model.ActionType, tb
 	case model.ActionRemovePartitioning, model.ActionAlterTablePartitioning:
 		clonedMeta.Partition = partInfo
 		clonedMeta.ID = partInfo.NewTableID
+		clonedMeta.Columns = partInfo.ReplaceDDLChangedColumns(clonedMeta.Columns)
 	case model.ActionReorganizePartition:
 		clonedMeta.Partition.AddingDefinitions = partInfo.Definitions
 		clonedMeta.Partition.Definitions = getReorganizedDefinitions(clonedMeta.Partition, firstPartIdx, lastPartIdx, idMap)
@@ -5456,7 +5574,7 @@ func ProcessColumnOptions(ctx sessionctx.Context, col *table.Column, options []*
 		case ast.ColumnOptionReference:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
 		case ast.ColumnOptionFulltext:
-			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with full text"))
+			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
 		case ast.ColumnOptionCheck:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with check"))
 		// Ignore ColumnOptionAutoRandom. It will be handled later.
@@ -5505,7 +5623,70 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		return nil, errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
 	}
 
-	return GetModifiableColumnJob(ctx, sctx, is, ident, originalColName, schema, t, spec)
+	job, err := GetModifiableColumnJob(ctx, sctx, is, ident, originalColName, schema, t, spec)
+	if err != nil {
+		return nil, err
+	}
+	if needRepartition, ok := job.CtxVars[1].(bool); ok && needRepartition {
+		return d.buildModifyPartitioningColumnJob(sctx, t, job)
+	}
+	return job, nil
+}
+
+// buildModifyPartitioningColumnJob converts the job of modifying a partitioning column
+// into a job reorganizing all partitions into the same partitioning scheme, with the
+// column definition changed. The rows are converted to the new column definition
+// while they are copied into the new partitions.
+func (d *ddl) buildModifyPartitioningColumnJob(sctx sessionctx.Context, t table.Table, modifyJob *model.Job) (*model.Job, error) {
+	newCol := (*modifyJob.Args[0].(**model.ColumnInfo)).Clone()
+	oldCol := model.FindColumnInfoByID(t.Meta().Columns, newCol.ID)
+	originDefVal, err := GetOriginDefaultValueForModifyColumn(sctx, newCol, oldCol)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = newCol.SetOriginDefaultValue(originDefVal); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	piOld := t.Meta().GetPartitionInfo()
+	partNames := make([]string, 0, len(piOld.Definitions))
+	newPartInfo := &model.PartitionInfo{
+		Type:        piOld.Type,
+		Expr:        piOld.Expr,
+		Columns:     append([]model.CIStr(nil), piOld.Columns...),
+		Enable:      piOld.Enable,
+		Num:         piOld.Num,
+		Definitions: make([]model.PartitionDefinition, 0, len(piOld.Definitions)),
+	}
+	for i := range piOld.Definitions {
+		partNames = append(partNames, piOld.Definitions[i].Name.L)
+		newPartInfo.Definitions = append(newPartInfo.Definitions, piOld.Definitions[i].Clone())
+	}
+	if err = d.assignPartitionIDs(newPartInfo.Definitions); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Like ALTER TABLE ... PARTITION BY, a new table ID is used, so the old data
+	// can be removed by the table ID in the final state.
+	newID, err := d.genGlobalIDs(1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newPartInfo.NewTableID = newID[0]
+	newPartInfo.DDLType = piOld.Type
+	newPartInfo.DDLChangedColumns = []*model.ColumnInfo{newCol}
+
+	job := &model.Job{
+		SchemaID:       modifyJob.SchemaID,
+		TableID:        modifyJob.TableID,
+		SchemaName:     modifyJob.SchemaName,
+		TableName:      modifyJob.TableName,
+		Type:           model.ActionAlterTablePartitioning,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []interface{}{partNames, newPartInfo},
+		ReorgMeta:      modifyJob.ReorgMeta,
+		CDCWriteSource: modifyJob.CDCWriteSource,
+	}
+	return job, nil
 }
 
 func checkModifyColumnWithGeneratedColumnsConstraint(allCols []*table.Column, oldColName model.CIStr) error {
@@ -5653,102 +5834,116 @@ func GetModifiableColumnJob(
 		}
 		return nil, errors.Trace(err)
 	}
-	needChangeColData := needChangeColumnData(col.ColumnInfo, newCol.ColumnInfo)
-	if needChangeColData {
-		if err = isGeneratedRelatedColumn(t.Meta(), newCol.ColumnInfo, col.ColumnInfo); err != nil {
-			return nil, errors.Trace(err)
-		}
-		if t.Meta().Partition != nil {
-			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
-		}
-	}
-
-	// Check that the column change does not affect the partitioning column
-	// It must keep the same type, int [unsigned], [var]char, date[time]
+	isPartitioningColumn := false
 	if t.Meta().Partition != nil {
 		pt, ok := t.(table.PartitionedTable)
 		if !ok {
 			// Should never happen!
 			return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(newCol.Name.O)
 		}
-		isPartitioningColumn := false
 		for _, name := range pt.GetPartitionColumnNames() {
 			if strings.EqualFold(name.L, col.Name.L) {
 				isPartitioningColumn = true
 				break
 			}
 		}
-		if isPartitioningColumn {
-			// TODO: update the partitioning columns with new names if column is renamed
-			// Would be an extension from MySQL which does not support it.
-			if col.Name.L != newCol.Name.L {
-				return nil, dbterror.ErrDependentByPartitionFunctional.GenWithStackByArgs(col.Name.L)
-			}
-			if !isColTypeAllowedAsPartitioningCol(t.Meta().Partition.Type, newCol.FieldType) {
-				return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(newCol.Name.O)
-			}
-			pi := pt.Meta().GetPartitionInfo()
-			if len(pi.Columns) == 0 {
-				// non COLUMNS partitioning, only checks INTs, not their actual range
-				// There are many edge cases, like when truncating SQL Mode is allowed
-				// which will change the partitioning expression value resulting in a
-				// different partition. Better be safe and not allow decreasing of length.
-				// TODO: Should we allow it in strict mode? Wait for a use case / request.
-				if newCol.FieldType.GetFlen() < col.FieldType.GetFlen() {
-					return nil, dbterror.ErrUnsupportedModifyCollation.GenWithStack("Unsupported modify column, decreasing length of int may result in truncation and change of partition")
-				}
-			}
-			// Basically only allow changes of the length/decimals for the column
-			// Note that enum is not allowed, so elems are not checked
-			// TODO: support partition by ENUM
-			if newCol.FieldType.EvalType() != col.FieldType.EvalType() ||
-				newCol.FieldType.GetFlag() != col.FieldType.GetFlag() ||
-				newCol.FieldType.GetCollate() != col.FieldType.GetCollate() ||
-				newCol.FieldType.GetCharset() != col.FieldType.GetCharset() {
-				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column, since it would require reorganize all partitions")
-			}
-			// Generate a new PartitionInfo and validate it together with the new column definition
-			// Checks if all partition definition values are compatible.
-			// Similar to what buildRangePartitionDefinitions would do in terms of checks.
-
-			tblInfo := pt.Meta()
-			newTblInfo := *tblInfo
-			// Replace col with newCol and see if we can generate a new SHOW CREATE TABLE
-			// and reparse it and build new partition definitions (which will do additional
-			// checks columns vs partition definition values
-			newCols := make([]*model.ColumnInfo, 0, len(newTblInfo.Columns))
-			for _, c := range newTblInfo.Columns {
-				if c.ID == col.ID {
-					newCols = append(newCols, newCol.ColumnInfo)
-					continue
-				}
-				newCols = append(newCols, c)
-			}
-			newTblInfo.Columns = newCols
+	}
+	needChangeColData := needReorgToModifyColumn(t.Meta(), col.ColumnInfo, newCol.ColumnInfo)
+	if needChangeColData {
+		if err = isGeneratedRelatedColumn(t.Meta(), col.ColumnInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if t.Meta().Partition != nil && !isPartitioningColumn {
+			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
+		}
+	}
 
-			var buf bytes.Buffer
-			AppendPartitionInfo(tblInfo.GetPartitionInfo(), &buf, mysql.ModeNone)
-			// The parser supports ALTER TABLE ... PARTITION BY ... even if the ddl code does not yet :)
-			// Ignoring warnings
-			stmt, _, err := parser.New().ParseSQL("ALTER TABLE t " + buf.String())
-			if err != nil {
-				// Should never happen!
-				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("cannot parse generated PartitionInfo")
-			}
-			at, ok := stmt[0].(*ast.AlterTableStmt)
-			if !ok || len(at.Specs) != 1 || at.Specs[0].Partition == nil {
-				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("cannot parse generated PartitionInfo")
-			}
-			pAst := at.Specs[0].Partition
-			sv := sctx.GetSessionVars().StmtCtx
-			oldTypeFlags := sv.TypeFlags()
-			newTypeFlags := oldTypeFlags.WithTruncateAsWarning(false).WithIgnoreTruncateErr(false)
-			sv.SetTypeFlags(newTypeFlags)
-			_, err = buildPartitionDefinitionsInfo(sctx, pAst.Definitions, &newTblInfo, uint64(len(newTblInfo.Partition.Definitions)))
-			sv.SetTypeFlags(oldTypeFlags)
-			if err != nil {
-				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("New column does not match partition definitions: %s", err.Error())
+	// Check that the column change is compatible with the partitioning.
+	// If the column data needs to be changed, or the change may move rows
+	// to other partitions, all partitions are reorganized together with
+	// the column change.
+	needRepartition := false
+	if isPartitioningColumn {
+		pt := t.(table.PartitionedTable)
+		// TODO: update the partitioning columns with new names if column is renamed
+		// Would be an extension from MySQL which does not support it.
+		if col.Name.L != newCol.Name.L {
+			return nil, dbterror.ErrDependentByPartitionFunctional.GenWithStackByArgs(col.Name.L)
+		}
+		if !isColTypeAllowedAsPartitioningCol(t.Meta().Partition.Type, newCol.FieldType) {
+			return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(newCol.Name.O)
+		}
+		pi := pt.Meta().GetPartitionInfo()
+		if len(pi.Columns) == 0 {
+			// non COLUMNS partitioning, only checks INTs, not their actual range
+			// There are many edge cases, like when truncating SQL Mode is allowed
+			// which will change the partitioning expression value resulting in a
+			// different partition. Better be safe and reorganize all partitions
+			// when decreasing the length.
+			if newCol.FieldType.GetFlen() < col.FieldType.GetFlen() {
+				needRepartition = true
+			}
+		}
+		// Only changes of the length/decimals for the column keep the rows
+		// in the same partitions, anything else reorganizes all partitions.
+		// Note that enum is not allowed, so elems are not checked
+		// TODO: support partition by ENUM
+		if needChangeColData ||
+			newCol.FieldType.EvalType() != col.FieldType.EvalType() ||
+			newCol.FieldType.GetFlag() != col.FieldType.GetFlag() ||
+			newCol.FieldType.GetCollate() != col.FieldType.GetCollate() ||
+			newCol.FieldType.GetCharset() != col.FieldType.GetCharset() {
+			needRepartition = true
+		}
+		if needRepartition {
+			if mysql.HasPriKeyFlag(col.GetFlag()) {
+				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column, since it has primary key flag")
+			}
+			if spec.Position != nil && spec.Position.Tp != ast.ColumnPositionNone {
+				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the position of the partitioning column, since it would require reorganize all partitions")
+			}
+		}
+		// Generate a new PartitionInfo and validate it together with the new column definition
+		// Checks if all partition definition values are compatible.
+		// Similar to what buildRangePartitionDefinitions would do in terms of checks.
+
+		tblInfo := pt.Meta()
+		newTblInfo := *tblInfo
+		// Replace col with newCol and see if we can generate a new SHOW CREATE TABLE
+		// and reparse it and build new partition definitions (which will do additional
+		// checks columns vs partition definition values
+		newCols := make([]*model.ColumnInfo, 0, len(newTblInfo.Columns))
+		for _, c := range newTblInfo.Columns {
+			if c.ID == col.ID {
+				newCols = append(newCols, newCol.ColumnInfo)
+				continue
 			}
+			newCols = append(newCols, c)
+		}
+		newTblInfo.Columns = newCols
+
+		var buf bytes.Buffer
+		AppendPartitionInfo(tblInfo.GetPartitionInfo(), &buf, mysql.ModeNone)
+		// The parser supports ALTER TABLE ... PARTITION BY ... even if the ddl code does not yet :)
+		// Ignoring warnings
+		stmt, _, err := parser.New().ParseSQL("ALTER TABLE t " + buf.String())
+		if err != nil {
+			// Should never happen!
+			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("cannot parse generated PartitionInfo")
+		}
+		at, ok := stmt[0].(*ast.AlterTableStmt)
+		if !ok || len(at.Specs) != 1 || at.Specs[0].Partition == nil {
+			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("cannot parse generated PartitionInfo")
+		}
+		pAst := at.Specs[0].Partition
+		sv := sctx.GetSessionVars().StmtCtx
+		oldTypeFlags := sv.TypeFlags()
+		newTypeFlags := oldTypeFlags.WithTruncateAsWarning(false).WithIgnoreTruncateErr(false)
+		sv.SetTypeFlags(newTypeFlags)
+		_, err = buildPartitionDefinitionsInfo(sctx, pAst.Definitions, &newTblInfo, uint64(len(newTblInfo.Partition.Definitions)))
+		sv.SetTypeFlags(oldTypeFlags)
+		if err != nil {
+			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStack("New column does not match partition definitions: %s", err.Error())
 		}
 	}
 
@@ -5822,7 +6017,7 @@ func GetModifiableColumnJob(
 		Type:           model.ActionModifyColumn,
 		BinlogInfo:     &model.HistoryInfo{},
 		ReorgMeta:      NewDDLReorgMeta(sctx),
-		CtxVars:        []interface{}{needChangeColData},
+		CtxVars:        []interface{}{needChangeColData, needRepartition},
 		Args:           []interface{}{&newCol.ColumnInfo, originalColName, spec.Position, modifyColumnTp, newAutoRandBits},
 		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
 	}
@@ -6530,6 +6725,10 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
+	// The column IDs are stored in a varchar(32) column of mysql.stats_extended, so the number of columns is limited.
+	if (len(colIDs) < 2 || len(colIDs) > 4) && stats.StatsType == ast.StatsTypeMultiHistogram {
+		return errors.New("Only support MultiHistogram statistics type on 2 to 4 columns")
+	}
 	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
@@ -6807,6 +7006,12 @@ func (d *ddl) dropTableObject(
 			if tableInfo.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Table")
 			}
+			if tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Drop Table")
+			}
+			if err := checkMaterializedViewObject(tableInfo.Meta(), "Drop Table"); err != nil {
+				return err
+			}
 		case viewObject:
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
@@ -6887,6 +7092,12 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
+	if tb.Meta().IsMaterializedView() {
+		return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Truncate Table")
+	}
+	if err := checkMaterializedViewObject(tb.Meta(), "Truncate Table"); err != nil {
+		return errors.Trace(err)
+	}
 	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
 	referredFK := checkTableHasForeignKeyReferred(d.GetInfoSchemaWithInterceptor(ctx), ti.Schema.L, ti.Name.L, []ast.Ident{{Name: ti.Name, Schema: ti.Schema}}, fkCheck)
 	if referredFK != nil {
@@ -6975,6 +7186,9 @@ func (d *ddl) renameTable(ctx sessionctx.Context, oldIdent, newIdent ast.Ident,
 		if tbl.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 			return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Table"))
 		}
+		if err := checkMaterializedViewObject(tbl.Meta(), "Rename Table"); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	job := &model.Job{
@@ -7023,6 +7237,9 @@ func (d *ddl) renameTables(ctx sessionctx.Context, oldIdents, newIdents []ast.Id
 			if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Tables"))
 			}
+			if err := checkMaterializedViewObject(t.Meta(), "Rename Tables"); err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		tableIDs = append(tableIDs, tableID)
@@ -7165,10 +7382,6 @@ func GetName4AnonymousIndex(t table.Table, colName model.CIStr, idxName model.CI
 
 func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) error {
-	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
-		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key is not supported. " +
-			"Please consider adding NONCLUSTERED primary key instead")
-	}
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -7193,7 +7406,7 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		}
 	}
 
-	tblInfo := t.Meta()
+	tblInfo := tableInfoWithAddingColumns(ctx, t.Meta())
 	// Check before the job is put to the queue.
 	// This check is redundant, but useful. If DDL check fail before the job is put
 	// to job queue, the fail path logic is super fast.
@@ -7208,6 +7421,10 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		return err
 	}
 
+	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
+		return d.addClusteredPrimaryKey(ctx, schema, t, indexName, indexPartSpecifications, indexOption)
+	}
+
 	global := false
 	if tblInfo.GetPartitionInfo() != nil {
 		ck, err := checkPartitionKeysConstraint(tblInfo.GetPartitionInfo(), indexColumns, tblInfo)
@@ -7255,6 +7472,83 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 	return errors.Trace(err)
 }
 
+// addClusteredPrimaryKey adds a clustered primary key by rewriting the table under the new handle encoding.
+func (d *ddl) addClusteredPrimaryKey(ctx sessionctx.Context, schema *model.DBInfo, t table.Table, indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) error {
+	tblInfo := t.Meta()
+	if err := checkHandleRewritable(tblInfo, "add"); err != nil {
+		return errors.Trace(err)
+	}
+	if indexOption.Visibility == ast.IndexVisibilityInvisible {
+		return dbterror.ErrPKIndexCantBeInvisible
+	}
+	if _, err := validateCommentLength(ctx.GetSessionVars(), indexName.String(), &indexOption.Comment, dbterror.ErrTooLongIndexComment); err != nil {
+		return errors.Trace(err)
+	}
+
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionAddClusteredPrimaryKey,
+		BinlogInfo:     &model.HistoryInfo{},
+		ReorgMeta:      NewDDLReorgMeta(ctx),
+		Args:           []interface{}{genIDs[0], indexName, indexPartSpecifications, indexOption, ctx.GetSessionVars().SQLMode},
+		Priority:       ctx.GetSessionVars().DDLReorgPriority,
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// dropClusteredPrimaryKey drops the clustered primary key by rewriting the table with _tidb_rowid as the handle.
+func (d *ddl) dropClusteredPrimaryKey(ctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo, t table.Table) error {
+	tblInfo := t.Meta()
+	if err := checkHandleRewritable(tblInfo, "drop"); err != nil {
+		return errors.Trace(err)
+	}
+	if tblInfo.ContainsAutoRandomBits() {
+		return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomAlterErrMsg)
+	}
+	pkInfo := getClusteredPrimaryKey(tblInfo)
+	for _, idxCol := range pkInfo.Columns {
+		if mysql.HasAutoIncrementFlag(tblInfo.Columns[idxCol.Offset].GetFlag()) && !isColumnIndexedBySecondaryIndex(tblInfo, idxCol.Name) {
+			return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+		}
+	}
+	if err := checkIndexNeededInForeignKey(is, schema.Name.L, tblInfo, pkInfo); err != nil {
+		return err
+	}
+
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropClusteredPrimaryKey,
+		BinlogInfo:     &model.HistoryInfo{},
+		ReorgMeta:      NewDDLReorgMeta(ctx),
+		Args:           []interface{}{genIDs[0]},
+		Priority:       ctx.GetSessionVars().DDLReorgPriority,
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func precheckBuildHiddenColumnInfo(
 	indexPartSpecifications []*ast.IndexPartSpecification,
 	indexName model.CIStr,
@@ -7390,10 +7684,6 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
@@ -7403,6 +7693,9 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
 	}
+	if t.Meta().IsMaterializedViewLog {
+		return errors.Trace(dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Create Index"))
+	}
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -7432,7 +7725,22 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		return errors.Trace(err)
 	}
 
-	tblInfo := t.Meta()
+	tblInfo := tableInfoWithAddingColumns(ctx, t.Meta())
+
+	// A FULLTEXT index is built on the hidden column of the tokens.
+	if keyType == ast.IndexKeyTypeFullText {
+		indexPartSpecifications, indexOption, err = BuildFullTextIndexParts(tblInfo, indexPartSpecifications, indexOption)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// A SPATIAL index is built on the hidden column of the cells.
+	if keyType == ast.IndexKeyTypeSpatial {
+		indexPartSpecifications, indexOption, err = BuildSpatialIndexParts(tblInfo, indexPartSpecifications, indexOption)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Build hidden columns if necessary.
 	hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
@@ -7773,6 +8081,9 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Index"))
 	}
+	if t.Meta().IsMaterializedViewLog {
+		return errors.Trace(dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Drop Index"))
+	}
 
 	if isHypo {
 		return d.dropHypoIndexFromCtx(ctx, ti.Schema, ti.Name, indexName, ifExists)
@@ -7789,6 +8100,10 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 		return infoschema.ErrTableWithoutPrimaryKey
 	}
 
+	if isPK && t.Meta().HasClusteredIndex() {
+		return d.dropClusteredPrimaryKey(ctx, is, schema, t)
+	}
+
 	if indexInfo == nil {
 		err = dbterror.ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", indexName)
 		if ifExists {
@@ -7838,9 +8153,6 @@ func CheckIsDropPrimaryKey(indexName model.CIStr, indexInfo *model.IndexInfo, t
 		if indexInfo == nil && !t.Meta().PKIsHandle {
 			return isPK, dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs("PRIMARY")
 		}
-		if t.Meta().IsCommonHandle || t.Meta().PKIsHandle {
-			return isPK, dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported drop primary key when the table is using clustered index")
-		}
 	}
 
 	return isPK, nil
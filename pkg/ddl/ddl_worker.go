@@ -676,7 +676,8 @@ func JobNeedGC(job *model.Job) bool {
 			model.ActionDropColumn, model.ActionModifyColumn,
 			model.ActionAddIndex, model.ActionAddPrimaryKey,
 			model.ActionReorganizePartition, model.ActionRemovePartitioning,
-			model.ActionAlterTablePartitioning:
+			model.ActionAlterTablePartitioning, model.ActionAddClusteredPrimaryKey,
+			model.ActionDropClusteredPrimaryKey, model.ActionDropMaterializedView:
 			return true
 		case model.ActionMultiSchemaChange:
 			for i, sub := range job.MultiSchemaInfo.SubJobs {
@@ -1227,6 +1228,10 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onCreateView(d, t, job)
 	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence:
 		ver, err = onDropTableOrView(d, t, job)
+	case model.ActionCreateMaterializedView:
+		ver, err = onCreateMaterializedView(d, t, job)
+	case model.ActionDropMaterializedView:
+		ver, err = onDropMaterializedView(d, t, job)
 	case model.ActionDropTablePartition:
 		ver, err = w.onDropTablePartition(d, t, job)
 	case model.ActionTruncateTablePartition:
@@ -1234,7 +1239,7 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionExchangeTablePartition:
 		ver, err = w.onExchangeTablePartition(d, t, job)
 	case model.ActionAddColumn:
-		ver, err = onAddColumn(d, t, job)
+		ver, err = w.onAddColumn(d, t, job)
 	case model.ActionDropColumn:
 		ver, err = onDropColumn(d, t, job)
 	case model.ActionModifyColumn:
@@ -1320,6 +1325,8 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
 		ver, err = w.onReorganizePartition(d, t, job)
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		ver, err = w.onRewriteHandle(d, t, job)
 	case model.ActionAlterTTLInfo:
 		ver, err = onTTLInfoChange(d, t, job)
 	case model.ActionAlterTTLRemove:
@@ -1642,6 +1649,21 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 				}
 			}
 		}
+	case model.ActionCreateMaterializedView:
+		diff.TableID = job.TableID
+		diff.AffectedOpts = buildMaterializedViewAffects(job.CtxVars[0].(*model.MaterializedViewInfo), true)
+	case model.ActionDropMaterializedView:
+		diff.OldTableID = job.TableID
+		diff.AffectedOpts = buildMaterializedViewAffects(job.CtxVars[0].(*model.MaterializedViewInfo), false)
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		diff.TableID = job.TableID
+		diff.OldTableID = job.TableID
+		if len(job.CtxVars) > 0 {
+			// Final part, the table is switched to the rewritten physical table.
+			if newTableID, ok := job.CtxVars[0].(int64); ok {
+				diff.TableID = newTableID
+			}
+		}
 	case model.ActionRemovePartitioning, model.ActionAlterTablePartitioning:
 		diff.TableID = job.TableID
 		diff.OldTableID = job.TableID
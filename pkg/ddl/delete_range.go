@@ -398,6 +398,24 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, wrapper DelRangeExecWrap
 				}
 			}
 		}
+	case model.ActionDropMaterializedView:
+		var tableIDs []int64
+		if err := job.DecodeArgs(&tableIDs); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(doBatchDeleteTablesRange(ctx, wrapper, job.ID, tableIDs, ea, "drop materialized view: table IDs"))
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		var droppedTableID, tableID int64
+		var indexIDs []int64
+		if err := job.DecodeArgs(&droppedTableID, &indexIDs, &tableID); err != nil {
+			return errors.Trace(err)
+		}
+		if err := doBatchDeleteTablesRange(ctx, wrapper, job.ID, []int64{droppedTableID}, ea, "rewrite handle: table ID"); err != nil {
+			return errors.Trace(err)
+		}
+		if len(indexIDs) > 0 {
+			return errors.Trace(doBatchDeleteIndiceRange(ctx, wrapper, job.ID, tableID, indexIDs, ea, "rewrite handle: origin index ID"))
+		}
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
@@ -0,0 +1,126 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
+)
+
+// BuildFullTextIndexParts converts the key parts of a FULLTEXT index to the key part of a
+// multi-valued index on the tokens of the columns, which is
+// `cast(tidb_fulltext_tokenize(parser, col...) as char(84) array)`. The returned index option
+// is a copy with the FULLTEXT index type.
+//
+// It's not an inverted index like InnoDB, so there are some limits:
+//   - The tokens longer than fulltext.MaxTokenLength characters are not indexed, and they are
+//     ignored by the queries too.
+//   - The index only stores whether a row contains a token, the relevance of MATCH ... AGAINST is
+//     computed from the rows read from the table.
+//   - The index is only used by the index merge plans built for the MATCH ... AGAINST filters, in
+//     which each term of the query is an index range. The queries in BOOLEAN MODE whose matched
+//     rows may contain none of the terms, e.g. only with the excluded terms, and the ORDER BY
+//     MATCH ... AGAINST without the filter can't use it.
+func BuildFullTextIndexParts(
+	tblInfo *model.TableInfo,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+) ([]*ast.IndexPartSpecification, *ast.IndexOption, error) {
+	option := &ast.IndexOption{}
+	if indexOption != nil {
+		*option = *indexOption
+	}
+	option.Tp = model.IndexTypeFulltext
+	if _, ok := fulltext.GetParser(option.ParserName.L); !ok {
+		return nil, nil, dbterror.ErrUnsupportedIndexType.GenWithStack("full-text parser %s is not supported", option.ParserName.O)
+	}
+
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)
+	restoreCtx.WritePlain("cast(" + ast.TiDBFullTextTokenize + "(")
+	restoreCtx.WriteString(option.ParserName.L)
+	for _, idxPart := range indexPartSpecifications {
+		if idxPart.Expr != nil {
+			return nil, nil, dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT index on expression is not supported")
+		}
+		col := model.FindColumnInfo(tblInfo.Columns, idxPart.Column.Name.L)
+		if col == nil {
+			return nil, nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStackByArgs(idxPart.Column.Name)
+		}
+		if !isFullTextColumnType(&col.FieldType) || idxPart.Length != types.UnspecifiedLength {
+			return nil, nil, dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name)
+		}
+		restoreCtx.WritePlain(", ")
+		restoreCtx.WriteName(col.Name.O)
+	}
+	restoreCtx.WritePlainf(") as char(%d) array)", fulltext.MaxTokenLength)
+
+	expr, err := generatedexpr.ParseExpression(sb.String())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	parts := []*ast.IndexPartSpecification{{Expr: expr, Length: types.UnspecifiedLength}}
+	return parts, option, nil
+}
+
+// isFullTextColumnType returns whether a FULLTEXT index can be built on the column, which must be
+// a CHAR, VARCHAR or TEXT column with a non-binary charset.
+func isFullTextColumnType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		return tp.GetCharset() != charset.CharsetBin
+	}
+	return false
+}
+
+// buildFullTextIndexInfo returns the full-text meta data of the index built on the hidden column
+// of the tokens, the full-text columns are the arguments of the column's expression.
+func buildFullTextIndexInfo(hiddenCol *model.ColumnInfo, indexOption *ast.IndexOption) (*model.FullTextIndexInfo, error) {
+	expr, err := generatedexpr.ParseExpression(hiddenCol.GeneratedExprString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cast, ok := expr.(*ast.FuncCastExpr)
+	if !ok {
+		return nil, errors.Errorf("unexpected expression of FULLTEXT index: %s", hiddenCol.GeneratedExprString)
+	}
+	tokenize, ok := cast.Expr.(*ast.FuncCallExpr)
+	if !ok || tokenize.FnName.L != ast.TiDBFullTextTokenize {
+		return nil, errors.Errorf("unexpected expression of FULLTEXT index: %s", hiddenCol.GeneratedExprString)
+	}
+	info := &model.FullTextIndexInfo{
+		Columns: make([]model.CIStr, 0, len(tokenize.Args)-1),
+		Parser:  indexOption.ParserName,
+	}
+	for _, arg := range tokenize.Args[1:] {
+		colName, ok := arg.(*ast.ColumnNameExpr)
+		if !ok {
+			return nil, errors.Errorf("unexpected expression of FULLTEXT index: %s", hiddenCol.GeneratedExprString)
+		}
+		info.Columns = append(info.Columns, colName.Name.Name)
+	}
+	return info, nil
+}
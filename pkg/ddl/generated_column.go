@@ -162,12 +162,9 @@ func hasDependentByGeneratedColumn(tblInfo *model.TableInfo, colName model.CIStr
 	return false, "", false
 }
 
-func isGeneratedRelatedColumn(tblInfo *model.TableInfo, newCol, col *model.ColumnInfo) error {
-	if newCol.IsGenerated() || col.IsGenerated() {
-		// TODO: Make it compatible with MySQL error.
-		msg := fmt.Sprintf("newCol IsGenerated %v, oldCol IsGenerated %v", newCol.IsGenerated(), col.IsGenerated())
-		return dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs(msg)
-	}
+// isGeneratedRelatedColumn checks whether the column whose data is reorganized is depended on by generated columns,
+// the generated columns depending on it can't be recomputed by the reorganization.
+func isGeneratedRelatedColumn(tblInfo *model.TableInfo, col *model.ColumnInfo) error {
 	if ok, dep, _ := hasDependentByGeneratedColumn(tblInfo, col.Name); ok {
 		msg := fmt.Sprintf("oldCol is a dependent column '%s' for generated column", dep)
 		return dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs(msg)
@@ -192,16 +189,18 @@ func (c *generatedColumnChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 
 // checkModifyGeneratedColumn checks the modification between
 // old and new is valid or not by such rules:
-//  1. the modification can't change stored status;
+//  1. the modification can't change between virtual generated column and normal column;
 //  2. if the new is generated, check its refer rules.
 //  3. check if the modified expr contains non-deterministic functions
 //  4. check whether new column refers to any auto-increment columns.
-//  5. check if the new column is indexed or stored
+//
+// The modification of the expression or the stored status is done by reorganizing
+// the column data and its indexes, see needChangeGeneratedColumnData.
 func checkModifyGeneratedColumn(sctx sessionctx.Context, schemaName model.CIStr, tbl table.Table, oldCol, newCol *table.Column, newColDef *ast.ColumnDef, pos *ast.ColumnPosition) error {
 	// rule 1.
-	oldColIsStored := !oldCol.IsGenerated() || oldCol.GeneratedStored
-	newColIsStored := !newCol.IsGenerated() || newCol.GeneratedStored
-	if oldColIsStored != newColIsStored {
+	oldColIsVirtual := oldCol.IsGenerated() && !oldCol.GeneratedStored
+	newColIsVirtual := newCol.IsGenerated() && !newCol.GeneratedStored
+	if (oldColIsVirtual && !newCol.IsGenerated()) || (newColIsVirtual && !oldCol.IsGenerated()) {
 		return dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status")
 	}
 
@@ -266,11 +265,6 @@ func checkModifyGeneratedColumn(sctx sessionctx.Context, schemaName model.CIStr,
 				return errors.Trace(err)
 			}
 		}
-
-		// rule 5.
-		if err := checkIndexOrStored(tbl, oldCol, newCol); err != nil {
-			return errors.Trace(err)
-		}
 	}
 	return nil
 }
@@ -380,25 +374,6 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 	return nil
 }
 
-func checkIndexOrStored(tbl table.Table, oldCol, newCol *table.Column) error {
-	if oldCol.GeneratedExprString == newCol.GeneratedExprString {
-		return nil
-	}
-
-	if newCol.GeneratedStored {
-		return dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("modifying a stored column")
-	}
-
-	for _, idx := range tbl.Indices() {
-		for _, col := range idx.Meta().Columns {
-			if col.Name.L == newCol.Name.L {
-				return dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("modifying an indexed column")
-			}
-		}
-	}
-	return nil
-}
-
 // checkAutoIncrementRef checks if an generated column depends on an auto-increment column and raises an error if so.
 // See https://dev.mysql.com/doc/refman/5.7/en/create-table-generated-columns.html for details.
 func checkAutoIncrementRef(name string, dependencies map[string]struct{}, tbInfo *model.TableInfo) error {
@@ -0,0 +1,581 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	tidbutil "github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
+	kvutil "github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// Adding or dropping a clustered primary key changes the encoding of the row handle, so
+// the table is rewritten into another physical table:
+//
+//  1. none -> delete only: TableInfo.HandleRewrite is built with a new physical table ID,
+//     the rewritten columns and indexes, and an internal origin index mapping the handle
+//     of every row to its handle in the rewritten table.
+//  2. delete only -> write only -> write reorganization: the writes to the table are
+//     double written into the rewritten table, see tables.handleRewriteTable.
+//  3. write reorganization: the existing rows are backfilled into the rewritten table,
+//     the progress is checkpointed in the DDL reorg meta.
+//  4. write reorganization -> public: the table is switched to the rewritten physical
+//     table, and the old one is cleaned up by the delete-range.
+
+// checkHandleRewritable checks whether the table can be rewritten under a new handle encoding.
+func checkHandleRewritable(tblInfo *model.TableInfo, op string) error {
+	var reason string
+	switch {
+	case tblInfo.Partition != nil:
+		reason = "partitioned table"
+	case tblInfo.TempTableType != model.TempTableNone:
+		reason = "temporary table"
+	case tblInfo.TableCacheStatusType != model.TableCacheStatusDisable:
+		reason = "cached table"
+	case tblInfo.TiFlashReplica != nil:
+		reason = "table with TiFlash replica"
+	case tblInfo.PlacementPolicyRef != nil:
+		reason = "table with placement policy"
+	default:
+		return nil
+	}
+	return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s clustered primary key on %s", op, reason)
+}
+
+// getClusteredPrimaryKey returns the clustered primary key of the table. If the
+// primary key is the integer handle, an index info is built for it.
+func getClusteredPrimaryKey(tblInfo *model.TableInfo) *model.IndexInfo {
+	if tblInfo.IsCommonHandle {
+		return tables.FindPrimaryIndex(tblInfo)
+	}
+	pkCol := tblInfo.GetPkColInfo()
+	return &model.IndexInfo{
+		Name:    model.NewCIStr(mysql.PrimaryKeyName),
+		Columns: []*model.IndexColumn{{Name: pkCol.Name, Offset: pkCol.Offset, Length: types.UnspecifiedLength}},
+		State:   model.StatePublic,
+		Primary: true,
+		Unique:  true,
+		Tp:      model.IndexTypeBtree,
+	}
+}
+
+// isSingleIntPKIndex checks whether the primary key is a single integer column, which is
+// used as the handle directly, see isSingleIntPK.
+func isSingleIntPKIndex(tblInfo *model.TableInfo, pkInfo *model.IndexInfo) bool {
+	if len(pkInfo.Columns) != 1 {
+		return false
+	}
+	switch tblInfo.Columns[pkInfo.Columns[0].Offset].GetType() {
+	case mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24:
+		return true
+	}
+	return false
+}
+
+func isColumnIndexedBySecondaryIndex(tblInfo *model.TableInfo, colName model.CIStr) bool {
+	for _, idx := range tblInfo.Indices {
+		if idx.Primary {
+			continue
+		}
+		for _, col := range idx.Columns {
+			if col.Name.L == colName.L {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *worker) onRewriteHandle(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	if job.IsRollingback() {
+		return onRollbackRewriteHandle(d, t, job)
+	}
+	schemaID := job.SchemaID
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	rw := tblInfo.HandleRewrite
+	if rw == nil {
+		// none -> delete only
+		rw, err = buildHandleRewriteInfo(job, tblInfo)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		rw.DDLState = model.StateDeleteOnly
+		tblInfo.HandleRewrite = rw
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateDeleteOnly
+		return ver, nil
+	}
+
+	switch rw.DDLState {
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		if err = checkHandleRewriteKeyNotNull(w, t, job, tblInfo); err != nil {
+			job.State = model.JobStateRollingback
+			return ver, errors.Trace(err)
+		}
+		rw.DDLState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		if err = checkHandleRewriteKeyNotNull(w, t, job, tblInfo); err != nil {
+			job.State = model.JobStateRollingback
+			return ver, errors.Trace(err)
+		}
+		rw.DDLState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		tbl, err := getTable((*asAutoIDRequirement)(d), schemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		done, ver, err := doReorgWorkForRewriteHandle(w, d, t, job, tbl)
+		if !done {
+			return ver, err
+		}
+		return finishRewriteHandle(d, t, job, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("table", rw.DDLState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// buildHandleRewriteInfo builds the rewritten columns and indexes of the table after
+// the clustered primary key is added or dropped.
+func buildHandleRewriteInfo(job *model.Job, tblInfo *model.TableInfo) (*model.HandleRewriteInfo, error) {
+	var (
+		newTableID              int64
+		indexName               model.CIStr
+		indexPartSpecifications []*ast.IndexPartSpecification
+		indexOption             *ast.IndexOption
+	)
+	rewritten := tblInfo.Clone()
+	rw := &model.HandleRewriteInfo{}
+	switch job.Type {
+	case model.ActionAddClusteredPrimaryKey:
+		if err := job.DecodeArgs(&newTableID, &indexName, &indexPartSpecifications, &indexOption); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if tblInfo.HasClusteredIndex() || tables.FindPrimaryIndex(tblInfo) != nil {
+			return nil, infoschema.ErrMultiplePriKey
+		}
+		if tblInfo.ShardRowIDBits > 0 {
+			return nil, dbterror.ErrUnsupportedShardRowIDBits
+		}
+		pkInfo, err := BuildIndexInfo(nil, tblInfo.Columns, indexName, true, true, false,
+			indexPartSpecifications, indexOption, model.StatePublic)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		AddIndexColumnFlag(rewritten, pkInfo)
+		if err = UpdateColsNull2NotNull(rewritten, pkInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isSingleIntPKIndex(rewritten, pkInfo) {
+			rw.PKIsHandle = true
+		} else {
+			pkInfo.ID = AllocateIndexID(tblInfo)
+			rewritten.Indices = append(rewritten.Indices, pkInfo)
+			rw.IsCommonHandle = true
+			rw.CommonHandleVersion = 1
+		}
+	case model.ActionDropClusteredPrimaryKey:
+		if err := job.DecodeArgs(&newTableID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !tblInfo.HasClusteredIndex() {
+			return nil, dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs(mysql.PrimaryKeyName)
+		}
+		pkInfo := getClusteredPrimaryKey(tblInfo)
+		rewritten.Indices = rewritten.Indices[:0]
+		for _, idx := range tblInfo.Indices {
+			if !idx.Primary {
+				rewritten.Indices = append(rewritten.Indices, idx.Clone())
+			}
+		}
+		DropIndexColumnFlag(rewritten, pkInfo)
+	}
+	rw.TableID = newTableID
+	rw.Columns = rewritten.Columns
+	rw.Indices = rewritten.Indices
+	rw.OriginIndexID = AllocateIndexID(tblInfo)
+	return rw, nil
+}
+
+// checkHandleRewriteKeyNotNull checks there is no null value in the columns of the clustered primary
+// key being added, and prevents the null values from being inserted.
+func checkHandleRewriteKeyNotNull(w *worker, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) error {
+	if job.Type != model.ActionAddClusteredPrimaryKey {
+		return nil
+	}
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return err
+	}
+	nullCols := make([]*model.ColumnInfo, 0, len(tblInfo.Columns))
+	for i, col := range tblInfo.HandleRewrite.Columns {
+		oldCol := tblInfo.Columns[i]
+		if !mysql.HasPriKeyFlag(col.GetFlag()) {
+			continue
+		}
+		if !mysql.HasNotNullFlag(oldCol.GetFlag()) || mysql.HasPreventNullInsertFlag(oldCol.GetFlag()) {
+			nullCols = append(nullCols, oldCol)
+		}
+	}
+	if len(nullCols) == 0 {
+		return nil
+	}
+	return modifyColsFromNull2NotNull(w, dbInfo, tblInfo, nullCols, &model.ColumnInfo{Name: model.NewCIStr("")}, false)
+}
+
+func onRollbackRewriteHandle(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	rw := tblInfo.HandleRewrite
+	if rw == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	for _, col := range tblInfo.Columns {
+		col.DelFlag(mysql.PreventNullInsertFlag)
+	}
+	tblInfo.HandleRewrite = nil
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	// A background job will be created to delete the rewritten table.
+	job.Args = []interface{}{rw.TableID, []int64(nil), rw.TableID}
+	return ver, nil
+}
+
+func doReorgWorkForRewriteHandle(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table) (done bool, ver int64, err error) {
+	job.ReorgMeta.ReorgTp = model.ReorgTypeTxn
+	sctx, err1 := w.sessPool.Get()
+	if err1 != nil {
+		return false, ver, errors.Trace(err1)
+	}
+	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	rw := tbl.Meta().HandleRewrite
+	elements := []*meta.Element{{ID: rw.TableID, TypeKey: meta.ColumnElementKey}}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID, job.ReorgMeta), d, rh, job, dbInfo, tbl, elements, false)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "onRewriteHandle",
+			func() {
+				reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("rewrite table `%v` panic", tbl.Meta().Name)
+			}, false)
+		physTbl, ok := tbl.(table.PhysicalTable)
+		if !ok {
+			return dbterror.ErrCancelledDDLJob.GenWithStack("internal error for table %d", tbl.Meta().ID)
+		}
+		return w.writePhysicalTableRecord(w.sessPool, physTbl, typeHandleRewriteWorker, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, ver, nil
+		}
+
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) || dbterror.ErrNotOwner.Equal(err) {
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.BgLogger().Warn("rewrite table job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
+				zap.String("category", "ddl"), zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.BgLogger().Warn("rewrite table job failed, convert job to rollback", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Error(err))
+		job.State = model.JobStateRollingback
+		return false, ver, errors.Trace(err)
+	}
+	return true, ver, nil
+}
+
+// finishRewriteHandle switches the table to the rewritten physical table.
+func finishRewriteHandle(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (ver int64, _ error) {
+	rw := tblInfo.HandleRewrite
+	oldTableID := tblInfo.ID
+	autoIDs, err := t.GetAutoIDAccessors(job.SchemaID, oldTableID).Get()
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	rewrittenAutoIDs, err := t.GetAutoIDAccessors(job.SchemaID, rw.TableID).Get()
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// The row IDs allocated by the rewritten table must not be allocated again.
+	autoIDs.RowID = max(autoIDs.RowID, rewrittenAutoIDs.RowID)
+	if err = t.DropTableOrView(job.SchemaID, job.SchemaName, oldTableID, tblInfo.Name.L); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(job.SchemaID, oldTableID).Del(); err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	newTblInfo := tblInfo.HandleRewriteTableInfo()
+	newTblInfo.State = model.StatePublic
+	if err = t.GetAutoIDAccessors(job.SchemaID, newTblInfo.ID).Put(autoIDs); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.CreateTableOrView(job.SchemaID, job.SchemaName, newTblInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.SchemaState = model.StatePublic
+	job.CtxVars = []interface{}{newTblInfo.ID}
+	ver, err = updateVersionAndTableInfo(d, t, job, newTblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, newTblInfo)
+	asyncNotifyEvent(d, statsutil.NewRewriteHandleEvent(job.Type, oldTableID, newTblInfo))
+	// A background job will be created to delete the old table and the origin index.
+	job.Args = []interface{}{oldTableID, []int64{rw.OriginIndexID}, newTblInfo.ID}
+	return ver, nil
+}
+
+// handleRewriteWorker backfills the rows of the table into the rewritten table.
+type handleRewriteWorker struct {
+	*backfillCtx
+	rewritten table.PhysicalTable
+
+	// The following attributes are used to reduce memory allocation.
+	rowDecoder *decoder.RowDecoder
+	rowMap     map[int64]types.Datum
+}
+
+func newHandleRewriteWorker(sessCtx sessionctx.Context, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) (*handleRewriteWorker, error) {
+	rewritten, err := tables.GetHandleRewriteTable(t)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &handleRewriteWorker{
+		backfillCtx: newBackfillCtx(reorgInfo.d, id, sessCtx, reorgInfo.SchemaName, t, jc, "rewrite_handle_rate", false),
+		rewritten:   rewritten,
+		rowDecoder:  decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:      make(map[int64]types.Datum, len(decodeColMap)),
+	}, nil
+}
+
+func (w *handleRewriteWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (*handleRewriteWorker) String() string {
+	return typeHandleRewriteWorker.String()
+}
+
+func (w *handleRewriteWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
+
+// BackfillData will rewrite w.batchCnt rows once, default value of w.batchCnt is 128.
+func (w *handleRewriteWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceAndTaskType(context.Background(), w.jobContext.ddlJobSourceType(), kvutil.ExplicitTypeDDL)
+	errInTxn = kv.RunInNewTxn(ctx, w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		updateTxnEntrySizeLimitIfNeeded(txn)
+		txn.SetOption(kv.Priority, handleRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(handleRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+		txn.SetOption(kv.ResourceGroupName, w.jobContext.resourceGroupName)
+
+		taskDone := false
+		var lastAccessedHandle kv.Key
+		err := iterateSnapshotKeys(w.jobContext, w.sessCtx.GetStore(), handleRange.priority, w.table.RecordPrefix(), txn.StartTS(), handleRange.startKey, handleRange.endKey,
+			func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+				taskDone = recordKey.Cmp(handleRange.endKey) >= 0
+				if taskDone || taskCtx.scanCount >= w.batchCnt {
+					return false, nil
+				}
+				taskCtx.scanCount++
+				added, err := w.rewriteRow(ctx, txn, handle, recordKey, rawRow)
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+				if added {
+					taskCtx.addedCount++
+				}
+				lastAccessedHandle = recordKey
+				if recordKey.Cmp(handleRange.endKey) == 0 {
+					taskDone = true
+					return false, nil
+				}
+				return true, nil
+			})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if taskCtx.scanCount == 0 {
+			taskDone = true
+		}
+		taskCtx.nextKey = getNextHandleKey(handleRange, taskDone, lastAccessedHandle)
+		taskCtx.done = taskDone
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "RewriteHandleBackfillData", 3000)
+	return
+}
+
+// rewriteRow writes the row into the rewritten table with its indexes. It returns false if
+// the row has been written into the rewritten table by the DML statements.
+func (w *handleRewriteWorker) rewriteRow(ctx context.Context, txn kv.Transaction, handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+	tblInfo := w.table.Meta()
+	originKey := tables.HandleRewriteOriginKey(tblInfo, handle)
+	_, err := txn.Get(ctx, originKey)
+	if err == nil {
+		return false, nil
+	} else if !kv.IsErrNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	// Lock the row key to make the concurrent update or delete on the row conflict with the backfilling.
+	if err = txn.LockKeys(context.Background(), new(kv.LockCtx), recordKey); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	defer w.cleanRowMap()
+	sysTZ := w.sessCtx.GetSessionVars().StmtCtx.TimeZone()
+	if _, err = w.rowDecoder.DecodeTheExistedColumnMap(w.sessCtx, handle, rawRow, sysTZ, w.rowMap); err != nil {
+		return false, errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("table", err))
+	}
+	if _, err = w.rowDecoder.EvalRemainedExprColumnMap(w.sessCtx, w.rowMap); err != nil {
+		return false, errors.Trace(err)
+	}
+	rewrittenInfo := w.rewritten.Meta()
+	cols := w.rewritten.Cols()
+	row := make([]types.Datum, len(cols))
+	for _, col := range cols {
+		row[col.Offset] = w.rowMap[col.ID]
+	}
+
+	var newHandle kv.Handle
+	if rewrittenInfo.PKIsHandle || rewrittenInfo.IsCommonHandle {
+		newHandle, err = tables.BuildHandleFromRow(w.sessCtx, rewrittenInfo, row)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		_, err = txn.Get(ctx, tablecodec.EncodeRecordKey(w.rewritten.RecordPrefix(), newHandle))
+		if err == nil {
+			// Another row with the same primary key has been written into the rewritten table.
+			return false, kv.ErrKeyExists.FastGenByArgs(newHandle.String(), tblInfo.Name.String()+".PRIMARY")
+		} else if !kv.IsErrNotFound(err) {
+			return false, errors.Trace(err)
+		}
+	} else {
+		newHandle, err = tables.AllocHandle(ctx, w.sessCtx, w.rewritten)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+
+	colIDs := make([]int64, 0, len(cols))
+	vals := make([]types.Datum, 0, len(cols))
+	for _, col := range cols {
+		if tables.CanSkip(rewrittenInfo, col, &row[col.Offset]) {
+			continue
+		}
+		colIDs = append(colIDs, col.ID)
+		vals = append(vals, row[col.Offset])
+	}
+	rd := &w.sessCtx.GetSessionVars().RowEncoder
+	rowVal, err := tablecodec.EncodeRow(sysTZ, vals, colIDs, nil, nil, rd)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err = txn.Set(tablecodec.EncodeRecordKey(w.rewritten.RecordPrefix(), newHandle), rowVal); err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, idx := range w.rewritten.Indices() {
+		idxInfo := idx.Meta()
+		if rewrittenInfo.IsCommonHandle && idxInfo.Primary {
+			continue
+		}
+		idxVals, err := idx.FetchValues(row, nil)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		rsData := tables.TryGetHandleRestoredDataWrapper(rewrittenInfo, row, nil, idxInfo)
+		if _, err = idx.Create(w.sessCtx, txn, idxVals, newHandle, rsData, table.WithIgnoreAssertion, table.FromBackfill); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	if err = txn.Set(originKey, tables.HandleRewriteOriginValue(newHandle)); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+func (w *handleRewriteWorker) cleanRowMap() {
+	for id := range w.rowMap {
+		delete(w.rowMap, id)
+	}
+}
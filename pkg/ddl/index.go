@@ -140,8 +140,9 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
 	for _, colName := range indexPartSpecifications {
-		lastCol = tblInfo.FindPublicColumnByName(colName.Column.Name.L)
-		if lastCol == nil {
+		// The column may be added by the previous sub-job of the multi-schema change.
+		lastCol = model.FindColumnInfo(tblInfo.Columns, colName.Column.Name.L)
+		if lastCol == nil || lastCol.State == model.StateDeleteOnly || lastCol.State == model.StateDeleteReorganization {
 			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStackByArgs(colName.Column.Name)
 		}
 		// Virtual columns cannot be used in primary key.
@@ -183,6 +184,14 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// Geometry column can only be indexed by a SPATIAL index.
+	if col.FieldType.GetType() == mysql.TypeGeometry {
+		if col.Hidden {
+			return dbterror.ErrFunctionalIndexOnJSONOrGeometryFunction
+		}
+		return errors.Trace(dbterror.ErrBlobKeyWithoutLength.GenWithStackByArgs(col.Name.O))
+	}
+
 	// Length must be specified and non-zero for BLOB and TEXT column indexes.
 	if types.IsTypeBlob(col.FieldType.GetType()) {
 		if indexColumnLen == types.UnspecifiedLength {
@@ -312,6 +321,18 @@ func BuildIndexInfo(
 		} else {
 			idxInfo.Tp = indexOption.Tp
 		}
+		if indexOption.Tp == model.IndexTypeFulltext {
+			hiddenCol := model.FindColumnInfo(allTableColumns, idxColumns[0].Name.L)
+			if idxInfo.FullText, err = buildFullTextIndexInfo(hiddenCol, indexOption); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if indexOption.Tp == model.IndexTypeSpatial {
+			hiddenCol := model.FindColumnInfo(allTableColumns, idxColumns[0].Name.L)
+			if idxInfo.Spatial, err = buildSpatialIndexInfo(hiddenCol); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	} else {
 		// Use btree as default index type.
 		idxInfo.Tp = model.IndexTypeBtree
@@ -110,6 +110,16 @@ func (d *ddl) getJob(se *sess.Session, tp jobType, filter func(*model.Job) (bool
 			return nil, errors.Trace(err)
 		}
 
+		if tp == jobTypeReorg {
+			isRunnable, err := d.processJobBySchedule(se, &job)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !isRunnable {
+				continue
+			}
+		}
+
 		isRunnable, err := d.processJobDuringUpgrade(se, &job)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -160,25 +170,8 @@ func (d *ddl) processJobDuringUpgrade(sess *sess.Session, job *model.Job) (isRun
 		if job.IsPausing() || hasSysDB(job) {
 			return true, nil
 		}
-		var errs []error
 		// During binary upgrade, pause all running DDL jobs
-		errs, err = PauseJobsBySystem(sess.Session(), []int64{job.ID})
-		if len(errs) > 0 && errs[0] != nil {
-			err = errs[0]
-		}
-
-		if err != nil {
-			isCannotPauseDDLJobErr := dbterror.ErrCannotPauseDDLJob.Equal(err)
-			logutil.BgLogger().Warn("pause the job failed", zap.String("category", "ddl-upgrading"), zap.Stringer("job", job),
-				zap.Bool("isRunnable", isCannotPauseDDLJobErr), zap.Error(err))
-			if isCannotPauseDDLJobErr {
-				return true, nil
-			}
-		} else {
-			logutil.BgLogger().Warn("pause the job successfully", zap.String("category", "ddl-upgrading"), zap.Stringer("job", job))
-		}
-
-		return false, nil
+		return pauseJobBySystem(sess, job, "ddl-upgrading"), nil
 	}
 
 	if job.IsPausedBySystem() {
@@ -203,6 +196,23 @@ func (d *ddl) processJobDuringUpgrade(sess *sess.Session, job *model.Job) (isRun
 	return true, nil
 }
 
+// pauseJobBySystem pauses the job by the system and returns whether the job is still runnable, which is
+// true only if the job can't be paused. The job failing to be paused for other reasons is skipped this time.
+func pauseJobBySystem(se *sess.Session, job *model.Job, category string) (isRunnable bool) {
+	errs, err := PauseJobsBySystem(se.Session(), []int64{job.ID})
+	if len(errs) > 0 && errs[0] != nil {
+		err = errs[0]
+	}
+	if err != nil {
+		isCannotPauseDDLJobErr := dbterror.ErrCannotPauseDDLJob.Equal(err)
+		logutil.BgLogger().Warn("pause the job failed", zap.String("category", category), zap.Stringer("job", job),
+			zap.Bool("isRunnable", isCannotPauseDDLJobErr), zap.Error(err))
+		return isCannotPauseDDLJobErr
+	}
+	logutil.BgLogger().Warn("pause the job successfully", zap.String("category", category), zap.Stringer("job", job))
+	return false
+}
+
 func (d *ddl) getGeneralJob(sess *sess.Session) (*model.Job, error) {
 	return d.getJob(sess, jobTypeGeneral, func(job *model.Job) (bool, error) {
 		if !d.runningJobs.checkRunnable(job) {
@@ -570,7 +580,8 @@ func job2TableIDs(job *model.Job) string {
 
 func job2UniqueIDs(job *model.Job, schema bool) string {
 	switch job.Type {
-	case model.ActionExchangeTablePartition, model.ActionRenameTables, model.ActionRenameTable:
+	case model.ActionExchangeTablePartition, model.ActionRenameTables, model.ActionRenameTable,
+		model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
 		var ids []int64
 		if schema {
 			ids = job.CtxVars[0].([]int64)
@@ -588,7 +599,7 @@ func job2UniqueIDs(job *model.Job, schema bool) string {
 		}
 		slices.Sort(s)
 		return strings.Join(s, ",")
-	case model.ActionTruncateTable:
+	case model.ActionTruncateTable, model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
 		return strconv.FormatInt(job.TableID, 10) + "," + strconv.FormatInt(job.Args[0].(int64), 10)
 	}
 	if schema {
@@ -0,0 +1,545 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/mview"
+)
+
+// CreateMaterializedView implements the DDL interface.
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	ident := ast.Ident{Schema: s.ViewName.Schema, Name: s.ViewName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	if is.TableExists(ident.Schema, ident.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(ident)
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if err := checkTooLongTable(ident.Name); err != nil {
+		return err
+	}
+	tbInfo, err := BuildMaterializedViewTableInfo(ctx, s)
+	if err != nil {
+		return err
+	}
+	mv := tbInfo.MaterializedView
+
+	// Only the views aggregating a single table are refreshed fast or used to answer queries,
+	// they are referenced by the base table.
+	var base table.Table
+	sel, err := mview.Parse(mv.SelectStmt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	def, err := mview.Analyze(sel)
+	if err == nil {
+		base, err = is.TableByName(def.Schema, def.Table)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if reason := checkMaterializedViewBase(base.Meta()); reason != "" {
+			err = dbterror.ErrMaterializedViewNoFastRefresh.GenWithStackByArgs(reason)
+			base = nil
+		}
+	}
+	if err != nil && (mv.RefreshMethod == model.RefreshFast || !dbterror.ErrMaterializedViewNoFastRefresh.Equal(err)) {
+		return err
+	}
+
+	idCnt := 1
+	if mv.RefreshMethod == model.RefreshFast {
+		idCnt++
+	}
+	genIDs, err := d.genGlobalIDs(idCnt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo.ID = genIDs[0]
+	schemaIDs := []int64{schema.ID}
+	tableIDs := []int64{tbInfo.ID}
+	involvingSchemas := []model.InvolvingSchemaInfo{{Database: schema.Name.L, Table: tbInfo.Name.L}}
+	if base != nil {
+		baseSchema, _ := is.SchemaByTable(base.Meta())
+		mv.BaseSchemaID = baseSchema.ID
+		mv.BaseTableID = base.Meta().ID
+		schemaIDs = append(schemaIDs, mv.BaseSchemaID)
+		tableIDs = append(tableIDs, mv.BaseTableID)
+		involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: baseSchema.Name.L, Table: base.Meta().Name.L})
+		if mv.RefreshMethod == model.RefreshFast {
+			mv.LogTableID = genIDs[1]
+			tableIDs = append(tableIDs, mv.LogTableID)
+		}
+	}
+
+	job := &model.Job{
+		SchemaID:            schema.ID,
+		TableID:             tbInfo.ID,
+		SchemaName:          schema.Name.L,
+		TableName:           tbInfo.Name.L,
+		Type:                model.ActionCreateMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		Args:                []interface{}{tbInfo},
+		CtxVars:             []interface{}{schemaIDs, tableIDs},
+		InvolvingSchemaInfo: involvingSchemas,
+		CDCWriteSource:      ctx.GetSessionVars().CDCWriteSource,
+	}
+	err = d.DoDDLJob(ctx, job)
+	if err != nil && s.IfNotExists && infoschema.ErrTableExists.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		err = nil
+	}
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// BuildMaterializedViewTableInfo builds the table storing the rows of a materialized view. The columns
+// and their types are filled by the planner, the table ID and the base table are left uninitialized.
+func BuildMaterializedViewTableInfo(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) (*model.TableInfo, error) {
+	if len(s.Cols) != len(s.ColTypes) {
+		return nil, errors.Errorf("the types of the columns of the materialized view are not resolved")
+	}
+	tblCharset, tblCollate := "", ""
+	if v, ok := ctx.GetSessionVars().GetSystemVar(variable.CharacterSetConnection); ok {
+		tblCharset = v
+	}
+	if v, ok := ctx.GetSessionVars().GetSystemVar(variable.CollationConnection); ok {
+		tblCollate = v
+	}
+	cols := make([]*table.Column, 0, len(s.Cols))
+	for i, name := range s.Cols {
+		if err := checkTooLongColumn(name); err != nil {
+			return nil, err
+		}
+		tp := s.ColTypes[i].Clone()
+		if tp.GetType() == mysql.TypeNull {
+			// Like CREATE TABLE ... SELECT in MySQL, NULL is stored as BINARY(0).
+			tp = types.NewFieldType(mysql.TypeString)
+			tp.SetFlen(0)
+		}
+		tp.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag | mysql.AutoIncrementFlag |
+			mysql.OnUpdateNowFlag | mysql.NoDefaultValueFlag)
+		colCharset, colCollate := tp.GetCharset(), tp.GetCollate()
+		if colCharset == "" {
+			colCharset, colCollate = tblCharset, tblCollate
+		}
+		if err := setCharsetCollationFlenDecimal(tp, name.O, colCharset, colCollate, ctx.GetSessionVars()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cols = append(cols, table.ToColumn(&model.ColumnInfo{
+			Name:      name,
+			Offset:    i,
+			FieldType: *tp,
+			State:     model.StatePublic,
+			Version:   model.CurrLatestColumnInfoVersion,
+		}))
+	}
+	tbInfo, err := BuildTableInfo(ctx, s.ViewName.Name, cols, nil, tblCharset, tblCollate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(restoreFlag, &sb)
+	restoreCtx.DefaultDB = ctx.GetSessionVars().CurrentDB
+	if err := s.Select.Restore(restoreCtx); err != nil {
+		return nil, err
+	}
+	tbInfo.MaterializedView = &model.MaterializedViewInfo{
+		SelectStmt:    sb.String(),
+		RefreshMethod: s.Refresh.Method,
+	}
+	if s.Refresh.Interval != nil {
+		if tbInfo.MaterializedView.RefreshInterval, err = evalMaterializedViewInterval(ctx, s.Refresh); err != nil {
+			return nil, err
+		}
+	}
+	return tbInfo, nil
+}
+
+// evalMaterializedViewInterval returns the interval to refresh the view in seconds.
+func evalMaterializedViewInterval(ctx sessionctx.Context, refresh ast.MaterializedViewRefresh) (int64, error) {
+	v, err := expression.EvalAstExpr(ctx, refresh.Interval)
+	if err != nil {
+		return 0, err
+	}
+	clause := fmt.Sprintf("EVERY %s", refresh.Unit.String())
+	if v.IsNull() {
+		return 0, types.ErrWrongValue.GenWithStackByArgs(clause, "NULL")
+	}
+	value, err := v.ToString()
+	if err != nil {
+		return 0, err
+	}
+	y, m, d, n, _, err := types.ParseDurationValue(refresh.Unit.String(), value)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if y != 0 || m != 0 {
+		return 0, dbterror.ErrNotSupportedYet.GenWithStackByArgs(clause)
+	}
+	duration := time.Duration(d)*24*time.Hour + time.Duration(n)
+	if duration < time.Second {
+		return 0, types.ErrWrongValue.GenWithStackByArgs(clause, value)
+	}
+	return int64(duration / time.Second), nil
+}
+
+// checkMaterializedViewBase returns the reason why the table can't be the base table of a fast refreshed view.
+func checkMaterializedViewBase(tblInfo *model.TableInfo) string {
+	switch {
+	case tblInfo.IsView(), tblInfo.IsSequence():
+		return "the base table is a view or a sequence"
+	case tblInfo.IsMaterializedView(), tblInfo.IsMaterializedViewLog:
+		return "the base table is a materialized view"
+	case tblInfo.TempTableType != model.TempTableNone:
+		return "the base table is a temporary table"
+	case tblInfo.TableCacheStatusType != model.TableCacheStatusDisable:
+		return "the base table is a cached table"
+	case tblInfo.HandleRewrite != nil:
+		return "the base table is being rewritten"
+	}
+	return ""
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *ddl) DropMaterializedView(ctx sessionctx.Context, s *ast.DropMaterializedViewStmt) error {
+	ident := ast.Ident{Schema: s.ViewName.Schema, Name: s.ViewName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(ident.Schema)
+	var tb table.Table
+	var err error
+	if ok {
+		tb, err = is.TableByName(ident.Schema, ident.Name)
+	}
+	if !ok || infoschema.ErrTableNotExists.Equal(err) {
+		err = infoschema.ErrTableDropExists.GenWithStackByArgs(ident.String())
+		if s.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !tb.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "MATERIALIZED VIEW")
+	}
+
+	mv := tb.Meta().MaterializedView
+	schemaIDs := []int64{schema.ID}
+	tableIDs := []int64{tb.Meta().ID}
+	if mv.BaseTableID != 0 {
+		schemaIDs = append(schemaIDs, mv.BaseSchemaID)
+		tableIDs = append(tableIDs, mv.BaseTableID)
+		if mv.LogTableID != 0 {
+			tableIDs = append(tableIDs, mv.LogTableID)
+		}
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tb.Meta().ID,
+		SchemaName:     schema.Name.L,
+		SchemaState:    schema.State,
+		TableName:      tb.Meta().Name.L,
+		Type:           model.ActionDropMaterializedView,
+		BinlogInfo:     &model.HistoryInfo{},
+		CtxVars:        []interface{}{schemaIDs, tableIDs},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if s.IfExists && (infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableNotExists.Equal(err)) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTableDropExists.GenWithStackByArgs(ident.String()))
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func onCreateMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	tbInfo := &model.TableInfo{}
+	if err := job.DecodeArgs(tbInfo); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	mv := tbInfo.MaterializedView
+	if mv == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Errorf("table %s is not a materialized view", tbInfo.Name)
+	}
+	tbInfo.State = model.StateNone
+	err := checkTableNotExists(d, t, schemaID, tbInfo.Name.L)
+	if err != nil {
+		if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+
+	var baseInfo *model.TableInfo
+	if mv.BaseTableID != 0 {
+		baseInfo, err = getTableInfo(t, mv.BaseTableID, mv.BaseSchemaID)
+		if err != nil {
+			if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableNotExists.Equal(err) {
+				job.State = model.JobStateCancelled
+			}
+			return ver, errors.Trace(err)
+		}
+		if reason := checkMaterializedViewBase(baseInfo); reason != "" {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrMaterializedViewNoFastRefresh.GenWithStackByArgs(reason)
+		}
+		ref := &model.MaterializedViewRef{ViewID: tbInfo.ID, LogTableID: mv.LogTableID}
+		baseInfo.MaterializedViews = append(baseInfo.MaterializedViews, ref)
+		if mv.LogTableID != 0 {
+			baseDB, err := t.GetDatabase(mv.BaseSchemaID)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			logInfo := baseInfo.MaterializedViewLogTableInfo(ref)
+			logInfo.UpdateTS = t.StartTS
+			if err = t.CreateTableOrView(mv.BaseSchemaID, baseDB.Name.L, logInfo); err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		if err = t.UpdateTable(mv.BaseSchemaID, baseInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+
+	// none -> public
+	tbInfo.State = model.StatePublic
+	tbInfo.UpdateTS = t.StartTS
+	if err = createTableOrViewWithCheck(t, job, schemaID, tbInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.CtxVars = []interface{}{mv}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tbInfo)
+	asyncNotifyEvent(d, statsutil.NewCreateTableEvent(tbInfo))
+	if mv.LogTableID != 0 {
+		asyncNotifyEvent(d, statsutil.NewCreateTableEvent(baseInfo.MaterializedViewLogTableInfo(
+			baseInfo.MaterializedViews[len(baseInfo.MaterializedViews)-1])))
+	}
+	return ver, nil
+}
+
+func onDropMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := checkTableExistAndCancelNonExistJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if !tblInfo.IsMaterializedView() {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+	if err = t.DropTableOrView(job.SchemaID, job.SchemaName, tblInfo.ID, tblInfo.Name.L); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Del(); err != nil {
+		return ver, errors.Trace(err)
+	}
+	droppedInfos := []*model.TableInfo{tblInfo}
+
+	mv := *tblInfo.MaterializedView
+	if mv.BaseTableID != 0 {
+		baseInfo, err := getTableInfo(t, mv.BaseTableID, mv.BaseSchemaID)
+		if err != nil && !infoschema.ErrDatabaseNotExists.Equal(err) && !infoschema.ErrTableNotExists.Equal(err) {
+			return ver, errors.Trace(err)
+		}
+		if baseInfo != nil {
+			for i, ref := range baseInfo.MaterializedViews {
+				if ref.ViewID == tblInfo.ID {
+					baseInfo.MaterializedViews = append(baseInfo.MaterializedViews[:i], baseInfo.MaterializedViews[i+1:]...)
+					break
+				}
+			}
+			if err = t.UpdateTable(mv.BaseSchemaID, baseInfo); err != nil {
+				return ver, errors.Trace(err)
+			}
+		} else {
+			// The base table doesn't exist any more, so does the change log.
+			mv.BaseTableID, mv.LogTableID = 0, 0
+		}
+	}
+	if mv.LogTableID != 0 {
+		logInfo, err := getTableInfo(t, mv.LogTableID, mv.BaseSchemaID)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		baseDB, err := t.GetDatabase(mv.BaseSchemaID)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = t.DropTableOrView(mv.BaseSchemaID, baseDB.Name.L, logInfo.ID, logInfo.Name.L); err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = t.GetAutoIDAccessors(mv.BaseSchemaID, logInfo.ID).Del(); err != nil {
+			return ver, errors.Trace(err)
+		}
+		droppedInfos = append(droppedInfos, logInfo)
+	}
+
+	job.CtxVars = []interface{}{&mv}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	tblInfo.State = model.StateNone
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	droppedIDs := make([]int64, 0, len(droppedInfos))
+	for _, info := range droppedInfos {
+		droppedIDs = append(droppedIDs, info.ID)
+		asyncNotifyEvent(d, statsutil.NewDropTableEvent(info))
+	}
+	job.Args = []interface{}{droppedIDs}
+	return ver, nil
+}
+
+// buildMaterializedViewAffects returns the change log and the base table affected by creating or
+// dropping the materialized view.
+func buildMaterializedViewAffects(mv *model.MaterializedViewInfo, create bool) []*model.AffectedOption {
+	var affects []*model.AffectedOption
+	if mv.LogTableID != 0 {
+		opt := &model.AffectedOption{SchemaID: mv.BaseSchemaID, OldSchemaID: mv.BaseSchemaID}
+		if create {
+			opt.TableID = mv.LogTableID
+		} else {
+			opt.OldTableID = mv.LogTableID
+		}
+		affects = append(affects, opt)
+	}
+	if mv.BaseTableID != 0 {
+		affects = append(affects, &model.AffectedOption{
+			SchemaID:    mv.BaseSchemaID,
+			TableID:     mv.BaseTableID,
+			OldSchemaID: mv.BaseSchemaID,
+			OldTableID:  mv.BaseTableID,
+		})
+	}
+	return affects
+}
+
+// checkMaterializedViewObject checks whether the operation is allowed on the table, which
+// rewrites the table or changes its name.
+func checkMaterializedViewObject(tblInfo *model.TableInfo, op string) error {
+	if tblInfo.IsMaterializedViewLog {
+		return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs(op)
+	}
+	if len(tblInfo.MaterializedViews) > 0 {
+		return dbterror.ErrOptOnMaterializedViewBase.GenWithStackByArgs(op)
+	}
+	return nil
+}
+
+// checkAlterMaterializedView checks whether the ALTER TABLE specs are allowed on the table.
+// Only the indexes of materialized views can be altered, and the specs changing the columns
+// or the rows without writing the change logs are not allowed on the base tables.
+func checkAlterMaterializedView(is infoschema.InfoSchema, tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) error {
+	if tblInfo.IsMaterializedViewLog {
+		return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Alter Table")
+	}
+	for _, spec := range specs {
+		if tblInfo.IsMaterializedView() {
+			switch spec.Tp {
+			case ast.AlterTableDropIndex, ast.AlterTableRenameIndex, ast.AlterTableIndexInvisible:
+				continue
+			case ast.AlterTableAddConstraint:
+				if spec.Constraint.Tp == ast.ConstraintKey || spec.Constraint.Tp == ast.ConstraintIndex {
+					continue
+				}
+			}
+			return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Alter Table")
+		}
+		if err := checkMaterializedViewObject(tblInfo, "Alter Table"); err != nil {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns, ast.AlterTableDropColumn, ast.AlterTableModifyColumn,
+				ast.AlterTableChangeColumn, ast.AlterTableRenameColumn, ast.AlterTableRenameTable,
+				ast.AlterTableDropPrimaryKey, ast.AlterTableDropPartition, ast.AlterTableDropFirstPartition,
+				ast.AlterTableTruncatePartition, ast.AlterTableExchangePartition, ast.AlterTablePartition,
+				ast.AlterTableRemovePartitioning, ast.AlterTableCache:
+				return err
+			case ast.AlterTableAddConstraint:
+				if spec.Constraint.Tp == ast.ConstraintPrimaryKey {
+					return err
+				}
+			}
+		}
+		if spec.Tp == ast.AlterTableExchangePartition {
+			nt, err := is.TableByName(spec.NewTable.Schema, spec.NewTable.Name)
+			if err == nil {
+				if nt.Meta().IsMaterializedView() {
+					return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Exchange Partition")
+				}
+				if err := checkMaterializedViewObject(nt.Meta(), "Exchange Partition"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkDatabaseHasMaterializedViewReferred checks whether the database can be dropped, the
+// materialized views and their base tables must be dropped together.
+func checkDatabaseHasMaterializedViewReferred(is infoschema.InfoSchema, dbInfo *model.DBInfo) error {
+	for _, tbl := range is.SchemaTables(dbInfo.Name) {
+		tblInfo := tbl.Meta()
+		if mv := tblInfo.MaterializedView; mv != nil && mv.BaseSchemaID != 0 && mv.BaseSchemaID != dbInfo.ID {
+			return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("Drop Database")
+		}
+		for _, ref := range tblInfo.MaterializedViews {
+			if view, ok := is.TableByID(ref.ViewID); ok {
+				if viewSchema, ok := is.SchemaByTable(view.Meta()); ok && viewSchema.ID != dbInfo.ID {
+					return dbterror.ErrOptOnMaterializedViewBase.GenWithStackByArgs("Drop Database")
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -247,6 +247,10 @@ func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) (err error
 		indexPartSpecifications := job.Args[2].([]*ast.IndexPartSpecification)
 		info.AddIndexes = append(info.AddIndexes, indexName)
 		for _, indexPartSpecification := range indexPartSpecifications {
+			// The index can be built on the column added by the previous sub-job.
+			if isAddedBySubJob(info, indexPartSpecification.Column.Name) {
+				continue
+			}
 			info.RelativeColumns = append(info.RelativeColumns, indexPartSpecification.Column.Name)
 		}
 		if hiddenCols, ok := job.Args[4].([]*model.ColumnInfo); ok {
@@ -293,6 +297,42 @@ func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) (err error
 	return nil
 }
 
+// isAddedBySubJob checks whether the column is added by the "add column" sub-jobs.
+func isAddedBySubJob(info *model.MultiSchemaInfo, colName model.CIStr) bool {
+	for _, sub := range info.SubJobs {
+		if sub.Type == model.ActionAddColumn && sub.Args[0].(*table.Column).Name.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
+
+// tableInfoWithAddingColumns returns a copy of the table info with the columns added by the "add column" sub-jobs
+// of the multi-schema change, so that the indexes on these columns can be checked before the job is put to the queue.
+func tableInfoWithAddingColumns(ctx sessionctx.Context, tblInfo *model.TableInfo) *model.TableInfo {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	if info == nil {
+		return tblInfo
+	}
+	var newTblInfo *model.TableInfo
+	for _, sub := range info.SubJobs {
+		if sub.Type != model.ActionAddColumn {
+			continue
+		}
+		if newTblInfo == nil {
+			newTblInfo = tblInfo.Clone()
+		}
+		col := sub.Args[0].(*table.Column).ColumnInfo.Clone()
+		col.Offset = len(newTblInfo.Columns)
+		col.State = model.StatePublic
+		newTblInfo.Columns = append(newTblInfo.Columns, col)
+	}
+	if newTblInfo == nil {
+		return tblInfo
+	}
+	return newTblInfo
+}
+
 func checkOperateSameColAndIdx(info *model.MultiSchemaInfo) error {
 	modifyCols := make(map[string]struct{})
 	modifyIdx := make(map[string]struct{})
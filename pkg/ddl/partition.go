@@ -44,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/opcode"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
 	"github.com/pingcap/tidb/pkg/table"
@@ -1963,6 +1964,7 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 				tblInfo.Partition.NewTableID = 0
 				tblInfo.Partition.DDLExpr = ""
 				tblInfo.Partition.DDLColumns = nil
+				tblInfo.Partition.DDLChangedColumns = nil
 				tblInfo.Partition.DDLType = model.PartitionTypeNone
 			}
 		}
@@ -2784,6 +2786,18 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			return ver, errors.Trace(err)
 		}
 
+		// The columns changed by MODIFY COLUMN of a partitioning column
+		// must still exist, refresh their offsets from the current table.
+		for _, changedCol := range partInfo.DDLChangedColumns {
+			col := model.FindColumnInfoByID(tblInfo.Columns, changedCol.ID)
+			if col == nil || col.State != model.StatePublic {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(infoschema.ErrColumnNotExists.GenWithStackByArgs(changedCol.Name, tblInfo.Name))
+			}
+			changedCol.Offset = col.Offset
+			changedCol.State = col.State
+		}
+
 		// Re-check that the dropped/added partitions are compatible with current definition
 		firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(partNames, tblInfo.Partition)
 		if err != nil {
@@ -2798,6 +2812,7 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 
 		// move the adding definition into tableInfo.
 		updateAddingPartitionInfo(partInfo, tblInfo)
+		tblInfo.Partition.DDLChangedColumns = partInfo.DDLChangedColumns
 		orgDefs := tblInfo.Partition.Definitions
 		_ = updateDroppingPartitionInfo(tblInfo, partNames)
 		// Reset original partitions, and keep DroppedDefinitions
@@ -2971,6 +2986,11 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			tblInfo.Partition.Expr, tblInfo.Partition.DDLExpr = tblInfo.Partition.DDLExpr, tblInfo.Partition.Expr
 			tblInfo.Partition.Columns, tblInfo.Partition.DDLColumns = tblInfo.Partition.DDLColumns, tblInfo.Partition.Columns
 		}
+		// Also use the changed column definitions, and keep the old ones
+		// for the double write to the DroppingDefinitions.
+		for i, changedCol := range tblInfo.Partition.DDLChangedColumns {
+			tblInfo.Columns[changedCol.Offset], tblInfo.Partition.DDLChangedColumns[i] = changedCol, tblInfo.Columns[changedCol.Offset]
+		}
 
 		// Now all the data copying is done, but we cannot simply remove the droppingDefinitions
 		// since they are a part of the normal Definitions that other nodes with
@@ -3034,6 +3054,7 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 				tblInfo.Partition.DDLType = model.PartitionTypeNone
 				tblInfo.Partition.DDLExpr = ""
 				tblInfo.Partition.DDLColumns = nil
+				tblInfo.Partition.DDLChangedColumns = nil
 				tblInfo.Partition.NewTableID = 0
 			}
 			err = t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Put(autoIDs)
@@ -3173,6 +3194,8 @@ type reorgPartitionWorker struct {
 	writeColOffsetMap map[int64]int
 	maxOffset         int
 	reorgedTbl        table.PartitionedTable
+	// changedCols are the new column definitions, when also modifying columns.
+	changedCols []*model.ColumnInfo
 }
 
 func newReorgPartitionWorker(sessCtx sessionctx.Context, i int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) (*reorgPartitionWorker, error) {
@@ -3205,6 +3228,7 @@ func newReorgPartitionWorker(sessCtx sessionctx.Context, i int, t table.Physical
 		writeColOffsetMap: writeColOffsetMap,
 		maxOffset:         maxOffset,
 		reorgedTbl:        reorgedTbl,
+		changedCols:       t.Meta().Partition.DDLChangedColumns,
 	}, nil
 }
 
@@ -3295,6 +3319,16 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 				return false, errors.Trace(err)
 			}
 
+			vals := rawRow
+			var recordWarning *terror.Error
+			if len(w.changedCols) > 0 {
+				// MODIFY COLUMN, so convert the row to the new column definitions.
+				vals, recordWarning, err = w.castChangedColumns()
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+			}
+
 			// Set the partitioning columns and calculate which partition to write to
 			for colID, offset := range w.writeColOffsetMap {
 				d, ok := w.rowMap[colID]
@@ -3311,7 +3345,7 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 			newKey := tablecodec.EncodeTablePrefix(pid)
 			newKey = append(newKey, recordKey[len(newKey):]...)
 			w.rowRecords = append(w.rowRecords, &rowRecord{
-				key: newKey, vals: rawRow,
+				key: newKey, vals: vals, warning: recordWarning,
 			})
 
 			w.cleanRowMap()
@@ -3331,6 +3365,49 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 	return w.rowRecords, getNextHandleKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
 }
 
+// castChangedColumns casts the decoded row to the changed column definitions,
+// and returns the encoded row.
+func (w *reorgPartitionWorker) castChangedColumns() ([]byte, *terror.Error, error) {
+	var recordWarning *terror.Error
+	sc := w.sessCtx.GetSessionVars().StmtCtx
+	for _, col := range w.changedCols {
+		val := w.rowMap[col.ID]
+		// Since every reorgPartitionWorker handle their own work individually, we can cache warning in statement context when casting datum.
+		oldWarn := sc.GetWarnings()
+		if oldWarn == nil {
+			oldWarn = []stmtctx.SQLWarn{}
+		} else {
+			oldWarn = oldWarn[:0]
+		}
+		sc.SetWarnings(oldWarn)
+		newVal, err := table.CastValue(w.sessCtx, val, col, false, false)
+		if err != nil {
+			return nil, nil, reformatCastErrors(err, col.Name, val)
+		}
+		if newVal.IsNull() && mysql.HasNotNullFlag(col.GetFlag()) {
+			return nil, nil, dbterror.ErrInvalidUseOfNull
+		}
+		if warn := sc.GetWarnings(); len(warn) != 0 && recordWarning == nil {
+			//nolint:forcetypeassert
+			recordWarning = errors.Cause(reformatCastErrors(warn[0].Err, col.Name, val)).(*terror.Error)
+		}
+		w.rowMap[col.ID] = newVal
+	}
+
+	colIDs := make([]int64, 0, len(w.rowMap))
+	row := make([]types.Datum, 0, len(w.rowMap))
+	for colID, val := range w.rowMap {
+		colIDs = append(colIDs, colID)
+		row = append(row, val)
+	}
+	rd := &w.sessCtx.GetSessionVars().RowEncoder
+	newRowVal, err := tablecodec.EncodeRow(sc.TimeZone(), row, colIDs, nil, nil, rd)
+	if err = sc.HandleError(err); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return newRowVal, recordWarning, nil
+}
+
 func (w *reorgPartitionWorker) cleanRowMap() {
 	for id := range w.rowMap {
 		delete(w.rowMap, id)
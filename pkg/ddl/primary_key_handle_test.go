@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/external"
 	"github.com/pingcap/tidb/pkg/testkit/testutil"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/testutils"
@@ -309,3 +311,59 @@ func TestCreateClusteredIndex(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, tbl.Meta().IsCommonHandle)
 }
+
+func TestAddDropClusteredPrimaryKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// Add an integer clustered primary key to a table without primary key.
+	tk.MustExec("create table t1 (a int, b int, c varchar(10), unique key uk(c), key idx(b))")
+	tk.MustExec("insert into t1 values (1, 10, 'a'), (2, 20, 'b'), (3, 30, 'c')")
+	tk.MustExec("alter table t1 add primary key (a) clustered")
+	tk.MustExec("admin check table t1")
+	tbl := external.GetTableByName(t, tk, "test", "t1")
+	require.True(t, tbl.Meta().PKIsHandle)
+	require.Nil(t, tbl.Meta().HandleRewrite)
+	tk.MustQuery("select a, b, c from t1 use index(idx) where b > 10 order by b").Check(testkit.Rows("2 20 b", "3 30 c"))
+	tk.MustQuery("select * from t1 where a = 2").Check(testkit.Rows("2 20 b"))
+	tk.MustExec("insert into t1 values (4, 40, 'd')")
+	tk.MustGetErrCode("insert into t1 values (4, 50, 'e')", errno.ErrDupEntry)
+	tk.MustExec("admin check table t1")
+
+	// Drop it again, the table falls back to _tidb_rowid.
+	tk.MustExec("alter table t1 drop primary key")
+	tk.MustExec("admin check table t1")
+	tbl = external.GetTableByName(t, tk, "test", "t1")
+	require.False(t, tbl.Meta().PKIsHandle)
+	require.False(t, tbl.Meta().IsCommonHandle)
+	tk.MustExec("insert into t1 values (4, 50, 'e')")
+	tk.MustQuery("select count(*) from t1 where a = 4").Check(testkit.Rows("2"))
+	tk.MustExec("admin check table t1")
+
+	// Duplicate values roll the job back.
+	tk.MustGetErrCode("alter table t1 add primary key (a) clustered", errno.ErrDupEntry)
+	tk.MustExec("admin check table t1")
+	tbl = external.GetTableByName(t, tk, "test", "t1")
+	require.Nil(t, tbl.Meta().HandleRewrite)
+	require.Nil(t, tbl.Meta().GetPrimaryKey())
+
+	// NULL values can't be part of the primary key.
+	tk.MustExec("create table t2 (a varchar(10), b int, c int)")
+	tk.MustExec("insert into t2 values ('x', 1, 1), (null, 2, 2)")
+	tk.MustGetErrCode("alter table t2 add primary key (a, b) clustered", errno.ErrInvalidUseOfNull)
+	tk.MustExec("delete from t2 where a is null")
+
+	// Composite clustered primary keys use common handles.
+	tk.MustExec("alter table t2 add index idx(c)")
+	tk.MustExec("alter table t2 add primary key (a, b) clustered")
+	tk.MustExec("admin check table t2")
+	tbl = external.GetTableByName(t, tk, "test", "t2")
+	require.True(t, tbl.Meta().IsCommonHandle)
+	tk.MustExec("insert into t2 values ('y', 1, 3)")
+	tk.MustExec("update t2 set c = 10 where a = 'x'")
+	tk.MustQuery("select * from t2 use index(idx) order by c").Check(testkit.Rows("y 1 3", "x 1 10"))
+	tk.MustExec("alter table t2 drop primary key")
+	tk.MustExec("admin check table t2")
+	tk.MustQuery("select * from t2 order by a").Check(testkit.Rows("x 1 10", "y 1 3"))
+}
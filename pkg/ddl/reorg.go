@@ -356,6 +356,8 @@ func updateBackfillProgress(w *worker, reorgInfo *reorgInfo, tblInfo *model.Tabl
 	case model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
 		metrics.GetBackfillProgressByLabel(metrics.LblReorgPartition, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		metrics.GetBackfillProgressByLabel(metrics.LblRewriteHandle, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
 	}
 }
 
@@ -0,0 +1,133 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
+	"github.com/pingcap/tidb/pkg/parser/duration"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// reorgWindow is the maintenance window of the reorg jobs. It starts at each time scheduled by the
+// cron expression and lasts for the duration, the reorganization is paused outside the window.
+type reorgWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+func newReorgWindow(schedule, dur string) (*reorgWindow, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, errors.Errorf("invalid reorg schedule '%s': %v", schedule, err)
+	}
+	d, err := duration.ParseDuration(dur)
+	if err != nil || d <= 0 {
+		return nil, errors.Errorf("invalid reorg schedule duration '%s'", dur)
+	}
+	return &reorgWindow{schedule: sched, duration: d}, nil
+}
+
+// contains checks whether the time is inside the window.
+func (w *reorgWindow) contains(t time.Time) bool {
+	// The window containing t must start in (t-duration, t].
+	return !w.schedule.Next(t.Add(-w.duration)).After(t)
+}
+
+// getReorgWindow returns the maintenance window of the reorg job, the job's own window takes
+// precedence over the global one. It returns nil if the job can run at any time.
+func getReorgWindow(job *model.Job) *reorgWindow {
+	schedule, dur := variable.DDLReorgSchedule.Load(), variable.DDLReorgScheduleDuration.Load()
+	if job.ReorgMeta != nil && job.ReorgMeta.Schedule != "" {
+		schedule, dur = job.ReorgMeta.Schedule, job.ReorgMeta.ScheduleDuration
+	}
+	if schedule == "" || dur == "" {
+		return nil
+	}
+	w, err := newReorgWindow(schedule, dur)
+	if err != nil {
+		logutil.BgLogger().Warn("ignore the invalid reorg schedule", zap.String("category", "ddl"),
+			zap.Int64("jobID", job.ID), zap.Error(err))
+		return nil
+	}
+	return w
+}
+
+// processJobBySchedule pauses the reorg job outside its maintenance window, and resumes the job paused by
+// the system once the window opens again.
+// The jobs being run by the workers are excluded by runningJobs when the jobs are fetched, so a job is only
+// paused between its steps, the step running when the window closes isn't interrupted.
+func (d *ddl) processJobBySchedule(se *sess.Session, job *model.Job) (isRunnable bool, err error) {
+	// The jobs paused during upgrade are handled by processJobDuringUpgrade.
+	if d.stateSyncer.IsUpgradingState() {
+		return true, nil
+	}
+	w := getReorgWindow(job)
+	if w == nil {
+		return true, nil
+	}
+	if w.contains(time.Now()) {
+		if !job.IsPausedBySystem() {
+			return true, nil
+		}
+		errs, err := ResumeJobsBySystem(se.Session(), []int64{job.ID})
+		if len(errs) > 0 && errs[0] != nil {
+			err = errs[0]
+		}
+		if err != nil {
+			logutil.BgLogger().Warn("resume the job inside the reorg window failed", zap.String("category", "ddl-reorg-schedule"),
+				zap.Stringer("job", job), zap.Error(err))
+			return false, err
+		}
+		logutil.BgLogger().Info("resume the job inside the reorg window", zap.String("category", "ddl-reorg-schedule"), zap.Stringer("job", job))
+		// The resumed job runs when it's fetched again.
+		return false, nil
+	}
+	if job.IsPaused() {
+		return false, nil
+	}
+	// The pausing job is paused by the worker, and the job which can't be paused, e.g. the rolling back
+	// job, runs until it's done. The jobs of the system schemas are never paused, like during upgrade.
+	if job.IsPausing() || !job.IsPausable() || hasSysDB(job) {
+		return true, nil
+	}
+	return pauseJobBySystem(se, job, "ddl-reorg-schedule"), nil
+}
+
+// AlterJobsSchedule sets the maintenance window of the reorg jobs according to user command.
+// The jobs use the global window again if the schedule is empty.
+func AlterJobsSchedule(se sessionctx.Context, ids []int64, schedule, dur string) ([]error, error) {
+	if schedule != "" {
+		if _, err := newReorgWindow(schedule, dur); err != nil {
+			return nil, err
+		}
+	}
+	return processJobs(func(_ *sess.Session, job *model.Job, _ model.AdminCommandOperator) error {
+		if !job.MayNeedReorg() || job.ReorgMeta == nil {
+			return dbterror.ErrCannotAlterDDLJob.GenWithStackByArgs(job.ID, "the job doesn't reorganize data")
+		}
+		job.ReorgMeta.Schedule = schedule
+		job.ReorgMeta.ScheduleDuration = dur
+		return nil
+	}, se, ids, model.AdminCommandByEndUser)
+}
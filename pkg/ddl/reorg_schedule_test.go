@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgWindow(t *testing.T) {
+	w, err := newReorgWindow("0 1 * * *", "4h")
+	require.NoError(t, err)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	for _, c := range []struct {
+		offset   time.Duration
+		contains bool
+	}{
+		{0, false},
+		{59 * time.Minute, false},
+		{time.Hour, true},
+		{3 * time.Hour, true},
+		{5*time.Hour - time.Second, true},
+		{5 * time.Hour, false},
+		{23 * time.Hour, false},
+		{25 * time.Hour, true},
+	} {
+		require.Equal(t, c.contains, w.contains(day.Add(c.offset)), c.offset.String())
+	}
+
+	_, err = newReorgWindow("0 1 * *", "4h")
+	require.ErrorContains(t, err, "invalid reorg schedule '0 1 * *'")
+	_, err = newReorgWindow("0 1 * * *", "4x")
+	require.EqualError(t, err, "invalid reorg schedule duration '4x'")
+	_, err = newReorgWindow("0 1 * * *", "0s")
+	require.EqualError(t, err, "invalid reorg schedule duration '0s'")
+
+	// The window of the job takes precedence over the global one.
+	job := &model.Job{ReorgMeta: &model.DDLReorgMeta{}}
+	require.Nil(t, getReorgWindow(job))
+	variable.DDLReorgSchedule.Store("0 1 * * *")
+	variable.DDLReorgScheduleDuration.Store("4h")
+	defer func() {
+		variable.DDLReorgSchedule.Store("")
+		variable.DDLReorgScheduleDuration.Store("")
+	}()
+	require.True(t, getReorgWindow(job).contains(day.Add(2*time.Hour)))
+	job.ReorgMeta.Schedule, job.ReorgMeta.ScheduleDuration = "0 6 * * *", "1h"
+	require.False(t, getReorgWindow(job).contains(day.Add(2*time.Hour)))
+	require.True(t, getReorgWindow(job).contains(day.Add(6*time.Hour)))
+}
@@ -155,7 +155,7 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	if err != nil {
 		return ver, err
 	}
-	if !needChangeColumnData(oldCol, jp.newCol) {
+	if !needReorgToModifyColumn(tblInfo, oldCol, jp.newCol) {
 		// Normal-type rolling back
 		if job.SchemaState == model.StateNone {
 			// When change null to not null, although state is unchanged with none, the oldCol flag's has been changed to preNullInsertFlag.
@@ -183,6 +183,24 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	return ver, dbterror.ErrCancelledDDLJob
 }
 
+func rollingbackRewriteHandle(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if needNotifyAndStopReorgWorker(job) {
+		// The backfill workers are started. we have to ask them to exit.
+		w.jobLogger(job).Info("run the cancelling DDL job", zap.String("job", job.String()))
+		d.notifyReorgWorkerJobStateChange(job)
+		// Give the this kind of ddl one more round to run, the dbterror.ErrCancelledDDLJob should be fetched from the bottom up.
+		return w.onRewriteHandle(d, t, job)
+	}
+	if job.SchemaState == model.StateNone {
+		// The job hasn't been handled and we cancel it directly.
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	// The job has been in its middle state and we roll it back here.
+	job.State = model.JobStateRollingback
+	return ver, dbterror.ErrCancelledDDLJob
+}
+
 func rollingbackAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	tblInfo, columnInfo, col, _, _, err := checkAddColumn(t, job)
 	if err != nil {
@@ -491,6 +509,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		ver, err = rollingbackRewriteHandle(w, d, t, job)
 	case model.ActionDropForeignKey, model.ActionTruncateTablePartition:
 		ver, err = cancelOnlyNotHandledJob(job, model.StatePublic)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
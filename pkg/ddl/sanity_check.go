@@ -147,6 +147,19 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 		}
 		physicalCnt := mathutil.Max(len(partitionIDs), 1)
 		return physicalCnt * len(indexIDs), nil
+	case model.ActionDropMaterializedView:
+		var tableIDs []int64
+		if err := job.DecodeArgs(&tableIDs); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(tableIDs), nil
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		var droppedTableID, tableID int64
+		var indexIDs []int64
+		if err := job.DecodeArgs(&droppedTableID, &indexIDs, &tableID); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(indexIDs) + 1, nil
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
@@ -415,6 +415,18 @@ func (*Checker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStmt) er
 	panic("implement me")
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (*Checker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropMaterializedView implements the DDL interface.
+func (*Checker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 // CreatePlacementPolicy implements the DDL interface.
 func (*Checker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	//TODO implement me
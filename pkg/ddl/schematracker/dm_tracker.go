@@ -416,6 +416,19 @@ func (d SchemaTracker) createIndex(
 		return dbterror.ErrDupKeyName.GenWithStack("index already exist %s", indexName)
 	}
 
+	if keyType == ast.IndexKeyTypeFullText {
+		indexPartSpecifications, indexOption, err = ddl.BuildFullTextIndexParts(tblInfo, indexPartSpecifications, indexOption)
+		if err != nil {
+			return err
+		}
+	}
+	if keyType == ast.IndexKeyTypeSpatial {
+		indexPartSpecifications, indexOption, err = ddl.BuildSpatialIndexParts(tblInfo, indexPartSpecifications, indexOption)
+		if err != nil {
+			return err
+		}
+	}
+
 	hiddenCols, err := ddl.BuildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
 	if err != nil {
 		return err
@@ -947,10 +960,15 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
 				err = d.createIndex(sctx, ident, ast.IndexKeyTypeUnique, model.NewCIStr(constr.Name),
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
+			case ast.ConstraintFulltext:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintForeignKey,
-				ast.ConstraintFulltext,
 				ast.ConstraintCheck:
 			default:
 				// Nothing to do now.
@@ -1157,6 +1175,16 @@ func (SchemaTracker) AlterSequence(_ sessionctx.Context, _ *ast.AlterSequenceStm
 	return nil
 }
 
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
 // CreatePlacementPolicy implements the DDL interface, it's no-op in DM's case.
 func (SchemaTracker) CreatePlacementPolicy(_ sessionctx.Context, _ *ast.CreatePlacementPolicyStmt) error {
 	return nil
@@ -0,0 +1,100 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
+)
+
+// BuildSpatialIndexParts converts the key part of a SPATIAL index to the key part of a
+// multi-valued index on the cells covering the geometry column, which is
+// `cast(tidb_spatial_cells(col) as unsigned array)`. The returned index option is a copy with the
+// SPATIAL index type.
+func BuildSpatialIndexParts(
+	tblInfo *model.TableInfo,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+) ([]*ast.IndexPartSpecification, *ast.IndexOption, error) {
+	option := &ast.IndexOption{}
+	if indexOption != nil {
+		*option = *indexOption
+	}
+	option.Tp = model.IndexTypeSpatial
+
+	if len(indexPartSpecifications) != 1 {
+		return nil, nil, dbterror.ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	idxPart := indexPartSpecifications[0]
+	if idxPart.Expr != nil {
+		return nil, nil, dbterror.ErrSpatialFunctionalIndex
+	}
+	col := model.FindColumnInfo(tblInfo.Columns, idxPart.Column.Name.L)
+	if col == nil {
+		return nil, nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStackByArgs(idxPart.Column.Name)
+	}
+	if col.GetType() != mysql.TypeGeometry {
+		return nil, nil, dbterror.ErrSpatialMustHaveGeomCol
+	}
+	if idxPart.Length != types.UnspecifiedLength {
+		return nil, nil, dbterror.ErrIncorrectPrefixKey
+	}
+	if !mysql.HasNotNullFlag(col.GetFlag()) {
+		return nil, nil, dbterror.ErrSpatialCantHaveNull
+	}
+
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)
+	restoreCtx.WritePlain("cast(" + ast.TiDBSpatialCells + "(")
+	restoreCtx.WriteName(col.Name.O)
+	restoreCtx.WritePlain(") as unsigned array)")
+
+	expr, err := generatedexpr.ParseExpression(sb.String())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	parts := []*ast.IndexPartSpecification{{Expr: expr, Length: types.UnspecifiedLength}}
+	return parts, option, nil
+}
+
+// buildSpatialIndexInfo returns the spatial meta data of the index built on the hidden column of
+// the cells, the geometry column is the argument of the column's expression.
+func buildSpatialIndexInfo(hiddenCol *model.ColumnInfo) (*model.SpatialIndexInfo, error) {
+	expr, err := generatedexpr.ParseExpression(hiddenCol.GeneratedExprString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cast, ok := expr.(*ast.FuncCastExpr)
+	if !ok {
+		return nil, errors.Errorf("unexpected expression of SPATIAL index: %s", hiddenCol.GeneratedExprString)
+	}
+	cells, ok := cast.Expr.(*ast.FuncCallExpr)
+	if !ok || cells.FnName.L != ast.TiDBSpatialCells || len(cells.Args) != 1 {
+		return nil, errors.Errorf("unexpected expression of SPATIAL index: %s", hiddenCol.GeneratedExprString)
+	}
+	colName, ok := cells.Args[0].(*ast.ColumnNameExpr)
+	if !ok {
+		return nil, errors.Errorf("unexpected expression of SPATIAL index: %s", hiddenCol.GeneratedExprString)
+	}
+	return &model.SpatialIndexInfo{Column: colName.Name.Name}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adminpause
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseOutsideReorgSchedule(t *testing.T) {
+	store := testkit.CreateMockStoreWithSchemaLease(t, dbTestLease)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+
+	tk.MustGetErrCode("set global tidb_ddl_reorg_schedule = '0 1 * *'", errno.ErrWrongValueForVar)
+	tk.MustGetErrCode("set global tidb_ddl_reorg_schedule_duration = '-1h'", errno.ErrWrongValueForVar)
+	// The global window opens at the half past of the current minute every hour, it's closed now.
+	tk.MustExec(fmt.Sprintf("set global tidb_ddl_reorg_schedule = '%d * * * *'", (time.Now().Minute()+30)%60))
+	tk.MustExec("set global tidb_ddl_reorg_schedule_duration = '1m'")
+	defer func() {
+		tk.MustExec("set global tidb_ddl_reorg_schedule = default")
+		tk.MustExec("set global tidb_ddl_reorg_schedule_duration = default")
+	}()
+
+	// The jobs of the system schemas are not paused.
+	tk.MustExec("create table mysql.reorg_schedule_test (a int)")
+	tk.MustExec("alter table mysql.reorg_schedule_test add index idx(a)")
+	tk.MustExec("drop table mysql.reorg_schedule_test")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	var wg util.WaitGroupWrapper
+	wg.Run(func() {
+		tk1.MustExec("alter table t add index idx(a)")
+	})
+
+	var jobID string
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("admin show ddl jobs where state = 'paused'").Rows()
+		if len(rows) == 0 {
+			return false
+		}
+		jobID = rows[0][0].(string)
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+	// The job is paused by the system, it can't be resumed by the user.
+	tk.MustQuery("admin resume ddl jobs " + jobID).CheckContain("job has been paused by [System]")
+
+	err := tk.ExecToErr(fmt.Sprintf("admin alter ddl jobs %s schedule '* * *' duration '1h'", jobID))
+	require.ErrorContains(t, err, "invalid reorg schedule '* * *'")
+	// The window of the job opens every minute and lasts an hour, so the job is resumed.
+	tk.MustQuery(fmt.Sprintf("admin alter ddl jobs %s schedule '* * * * *' duration '1h'", jobID)).
+		Check(testkit.Rows(jobID + " successful"))
+	wg.Wait()
+	tk.MustExec("admin check table t")
+	tk.MustQuery("admin alter ddl jobs " + jobID + " schedule default").CheckContain("not found")
+}
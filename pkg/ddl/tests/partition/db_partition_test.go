@@ -2961,7 +2961,6 @@ func TestIssue40135Ver2(t *testing.T) {
 }
 
 func TestAlterModifyPartitionColTruncateWarning(t *testing.T) {
-	t.Skip("waiting for supporting Modify Partition Column again")
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
 	schemaName := "truncWarn"
@@ -2973,11 +2972,91 @@ func TestAlterModifyPartitionColTruncateWarning(t *testing.T) {
 	tk.MustContainErrMsg(`alter table t modify a varchar(5)`, "[types:1265]Data truncated for column 'a', value is '")
 	tk.MustExec(`set sql_mode = ''`)
 	tk.MustExec(`alter table t modify a varchar(5)`)
-	// Fix the duplicate warning, see https://github.com/pingcap/tidb/issues/38699
-	tk.MustQuery(`show warnings`).Check(testkit.Rows(""+
-		"Warning 1265 Data truncated for column 'a', value is ' 654321'",
-		"Warning 1265 Data truncated for column 'a', value is ' 654321'"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows(
+		"Warning 1265 2 warnings with this error code, first warning: Data truncated for column 'a', value is ' 654321'"))
 	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select a from t partition (p1)`).Check(testkit.Rows(" 6543"))
+	tk.MustQuery(`select a from t partition (p2)`).Check(testkit.Rows("12345"))
+}
+
+func TestModifyPartitioningColumn(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+
+	// Changing the type moves the rows to other partitions.
+	tk.MustExec(`create table t (a int, b varchar(20), key(a), key(b)) partition by key (a) partitions 3`)
+	for i := 1; i <= 20; i++ {
+		tk.MustExec(fmt.Sprintf(`insert into t values (%d, 'x%d')`, i, i))
+	}
+	states := make(map[model.SchemaState]struct{})
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionAlterTablePartitioning {
+			return
+		}
+		if _, ok := states[job.SchemaState]; ok {
+			return
+		}
+		states[job.SchemaState] = struct{}{}
+		switch job.SchemaState {
+		case model.StateDeleteOnly:
+			tk2.MustExec(`insert into t values (101, 'DeleteOnly')`)
+		case model.StateWriteOnly:
+			tk2.MustExec(`insert into t values (102, 'WriteOnly')`)
+			tk2.MustExec(`update t set b = 'upd' where a = 1`)
+		case model.StateWriteReorganization:
+			tk2.MustExec(`insert into t values (103, 'WriteReorg')`)
+			tk2.MustExec(`update t set a = 104 where a = 2`)
+			tk2.MustExec(`delete from t where a = 3`)
+		case model.StateDeleteReorganization:
+			tk2.MustExec(`insert into t values ('105', 'DeleteReorg')`)
+			tk2.MustExec(`update t set b = 'upd' where a = '4'`)
+			tk2.MustExec(`delete from t where a = '5'`)
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec(`alter table t modify a varchar(10)`)
+	dom.DDL().SetHook(&callback.TestDDLCallback{Do: dom})
+	require.Len(t, states, 5)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`show create table t`).Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` varchar(10) DEFAULT NULL,\n" +
+		"  `b` varchar(20) DEFAULT NULL,\n" +
+		"  KEY `a` (`a`),\n" +
+		"  KEY `b` (`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY (`a`) PARTITIONS 3"))
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("22"))
+	tk.MustQuery(`select b from t where a in ('1', '4') order by b`).Check(testkit.Rows("upd", "upd"))
+	for _, a := range []string{"1", "4", "6", "20", "101", "102", "103", "104", "105"} {
+		tk.MustQuery(fmt.Sprintf(`select count(*) from t where a = '%s'`, a)).Check(testkit.Rows("1"))
+	}
+	for _, a := range []string{"2", "3", "5"} {
+		tk.MustQuery(fmt.Sprintf(`select count(*) from t where a = '%s'`, a)).Check(testkit.Rows("0"))
+	}
+
+	// Values not fitting the new type rolls back the job in strict mode.
+	tk.MustExec(`create table t2 (a int, b int, key(a)) partition by range (a) (partition p0 values less than (100), partition p1 values less than (maxvalue))`)
+	tk.MustExec(`insert into t2 values (1, 1), (50, 50), (200, 200), (70000, 70000)`)
+	tk.MustContainErrMsg(`alter table t2 modify a smallint`, "[types:1690]constant 70000 overflows smallint")
+	tk.MustExec(`admin check table t2`)
+	tk.MustQuery(`select a from t2 order by a`).Check(testkit.Rows("1", "50", "200", "70000"))
+	tk.MustQuery(`select data_type from information_schema.columns where table_schema = 'test' and table_name = 't2' and column_name = 'a'`).Check(testkit.Rows("int"))
+	tk.MustExec(`delete from t2 where a = 70000`)
+	tk.MustExec(`alter table t2 modify a smallint`)
+	tk.MustExec(`admin check table t2`)
+	tk.MustQuery(`select a from t2 partition (p0) order by a`).Check(testkit.Rows("1", "50"))
+	tk.MustQuery(`select a from t2 partition (p1)`).Check(testkit.Rows("200"))
+
+	// Unsupported cases.
+	tk.MustExec(`create table t3 (a int primary key, b int) partition by hash (a) partitions 2`)
+	tk.MustGetErrMsg(`alter table t3 modify a smallint`, "[ddl:8200]Unsupported modify column: this column has primary key flag")
+	tk.MustExec(`create table t4 (a int, b int) partition by hash (a) partitions 2`)
+	tk.MustGetErrMsg(`alter table t4 modify a smallint first`, "[ddl:8200]Unsupported modify column: can't change the position of the partitioning column, since it would require reorganize all partitions")
+	tk.MustGetErrMsg(`alter table t4 modify b smallint`, "[ddl:8200]Unsupported modify column: table is partition table")
 }
 
 func TestRemoveKeyPartitioning(t *testing.T) {
@@ -3226,10 +3305,10 @@ func TestRemovePartitioningAutoIDs(t *testing.T) {
 	tk3.MustExec(`COMMIT`)
 	tk3.MustQuery(`select _tidb_rowid, a, b from t`).Sort().Check(testkit.Rows(
 		"13 11 11", "14 2 2", "15 12 12", "17 16 18",
-		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "30 29 9"))
+		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "29 28 9"))
 	tk2.MustQuery(`select _tidb_rowid, a, b from t`).Sort().Check(testkit.Rows(
 		"13 11 11", "14 2 2", "15 12 12", "17 16 18",
-		"19 18 4", "23 22 6", "27 26 8", "32 31 10"))
+		"19 18 4", "23 22 6", "27 26 8", "31 30 10"))
 
 	waitFor(4, "t", "write reorganization")
 	tk3.MustExec(`BEGIN`)
@@ -3250,17 +3329,17 @@ func TestRemovePartitioningAutoIDs(t *testing.T) {
 	tk3.MustExec(`COMMIT`)
 	tk3.MustQuery(`select _tidb_rowid, a, b from t`).Sort().Check(testkit.Rows(
 		"13 11 11", "14 2 2", "15 12 12", "17 16 18",
-		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "27 26 8", "30 29 9",
-		"32 31 10", "35 34 21", "38 37 22", "41 40 23"))
+		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "27 26 8", "29 28 9",
+		"31 30 10", "33 32 21", "35 34 22", "37 36 23"))
 
 	//waitFor(4, "t", "public")
 	//tk2.MustExec(`commit`)
-	// TODO: Investigate and fix, but it is also related to https://github.com/pingcap/tidb/issues/46904
-	require.ErrorContains(t, <-alterChan, "[kv:1062]Duplicate entry '26' for key 't.PRIMARY'")
+	require.NoError(t, <-alterChan)
 	tk3.MustQuery(`select _tidb_rowid, a, b from t`).Sort().Check(testkit.Rows(
 		"13 11 11", "14 2 2", "15 12 12", "17 16 18",
-		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "27 26 8", "30 29 9",
-		"32 31 10", "35 34 21", "38 37 22", "41 40 23"))
+		"19 18 4", "21 20 5", "23 22 6", "25 24 7", "27 26 8", "29 28 9",
+		"31 30 10", "33 32 21", "35 34 22", "37 36 23"))
+	tk3.MustExec(`admin check table t`)
 }
 
 func TestAlterLastIntervalPartition(t *testing.T) {
@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/event"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -84,6 +85,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/memoryusagealarm"
+	"github.com/pingcap/tidb/pkg/util/mview"
 	"github.com/pingcap/tidb/pkg/util/replayer"
 	"github.com/pingcap/tidb/pkg/util/servermemorylimit"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
@@ -108,6 +110,10 @@ var (
 
 	// LoadSchemaDiffVersionGapThreshold is the threshold for version gap to reload domain by loading schema diffs
 	LoadSchemaDiffVersionGapThreshold int64 = 100
+
+	// NewInstancePlanCache creates the plan cache shared by all sessions of the instance. It's set by the
+	// planner package to avoid cycle import.
+	NewInstancePlanCache func() sessionctx.InstancePlanCache
 )
 
 const (
@@ -173,10 +179,12 @@ type Domain struct {
 		sync.RWMutex
 		expiredTimeStamp types.Time
 	}
+	instancePlanCache sessionctx.InstancePlanCache
 
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[event.Scheduler]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
 	resourceGroupsController *rmclient.ResourceGroupsController
@@ -526,6 +534,12 @@ func (do *Domain) SetExpiredTimeStamp4PC(time types.Time) {
 	do.expiredTimeStamp4PC.expiredTimeStamp = time
 }
 
+// InstancePlanCache gets the plan cache shared by all sessions of the instance, it's nil if the planner
+// package isn't loaded.
+func (do *Domain) InstancePlanCache() sessionctx.InstancePlanCache {
+	return do.instancePlanCache
+}
+
 // DDL gets DDL from domain.
 func (do *Domain) DDL() ddl.DDL {
 	return do.ddl
@@ -1025,6 +1039,11 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+		logutil.BgLogger().Info("eventScheduler exited.")
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.etcdClient != nil {
@@ -1091,6 +1110,9 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 	do.sysProcesses = SysProcesses{mu: &sync.RWMutex{}, procMap: make(map[uint64]sessionctx.Context)}
 	do.initDomainSysVars()
 	do.expiredTimeStamp4PC.expiredTimeStamp = types.NewTime(types.ZeroCoreTime, mysql.TypeTimestamp, types.DefaultFsp)
+	if NewInstancePlanCache != nil {
+		do.instancePlanCache = NewInstancePlanCache()
+	}
 	return do
 }
 
@@ -2988,6 +3010,21 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the event scheduler, sessFactory
+// creates the sessions to run the events as their definers.
+func (do *Domain) StartEventScheduler(sessFactory event.SessionFactory) {
+	eventScheduler := event.NewScheduler(do.sysSessionPool, do.etcdClient, sessFactory, do.ddl.OwnerManager().IsOwner)
+	// The materialized views are refreshed by the timers run with the events.
+	mview.RegisterRefreshHook(eventScheduler)
+	do.eventScheduler.Store(eventScheduler)
+	eventScheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain
+func (do *Domain) EventScheduler() *event.Scheduler {
+	return do.eventScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
@@ -828,6 +828,8 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
@@ -892,10 +894,17 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTableFunctionMustHaveAlias                            = 3667
+	ErrTableFunctionForbiddenJoinType                        = 3668
+	ErrJSONTableValueOutOfRange                              = 3669
 	ErrInvalidDefaultUTF8MB4Collation                        = 3721
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
@@ -1137,6 +1146,13 @@ const (
 	ErrPausedDDLJob       = 8262
 	ErrBDRRestrictedDDL   = 8263
 
+	// Materialized view errors.
+	ErrOptOnMaterializedView         = 8264
+	ErrOptOnMaterializedViewBase     = 8265
+	ErrMaterializedViewNoFastRefresh = 8266
+
+	ErrCannotAlterDDLJob = 8267
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
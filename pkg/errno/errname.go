@@ -844,6 +844,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPasswordExpireAnonymousUser:                           mysql.Message("The password for anonymous user cannot be expired.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -887,11 +889,18 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrWindowFunctionIgnoresFrame:                            mysql.Message("Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition", nil),
 	ErrInvalidNumberOfArgs:                                   mysql.Message("Too many arguments for function %s; maximum allowed is %d", nil),
 	ErrFieldInGroupingNotGroupBy:                             mysql.Message("Argument %s of GROUPING function is not in GROUP BY", nil),
+	ErrLongitudeOutOfRange:                                   mysql.Message("Longitude %f is out of range in function %s. It must be within (-180.000000, 180.000000].", nil),
+	ErrLatitudeOutOfRange:                                    mysql.Message("Latitude %f is out of range in function %s. It must be within [-90.000000, 90.000000].", nil),
 	ErrRoleNotGranted:                                        mysql.Message("%s is not granted to %s", nil),
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, maximum statement execution time exceeded", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' might not be affected by SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar column '%s' of JSON_TABLE '%s'.", nil),
+	ErrTableFunctionMustHaveAlias:                            mysql.Message("Every table function must have an alias.", nil),
+	ErrTableFunctionForbiddenJoinType:                        mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
+	ErrJSONTableValueOutOfRange:                              mysql.Message("Value is out of range for JSON_TABLE's column '%s'", nil),
 	ErrInvalidDefaultUTF8MB4Collation:                        mysql.Message("Invalid default collation %s: utf8mb4_0900_ai_ci or utf8mb4_general_ci or utf8mb4_bin expected", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
@@ -1165,4 +1174,10 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
 	ErrBDRRestrictedDDL:   mysql.Message("The operation is not allowed while the bdr role of this cluster is set to %s.", nil),
+
+	ErrOptOnMaterializedView:         mysql.Message("'%s' is unsupported on materialized views.", nil),
+	ErrOptOnMaterializedViewBase:     mysql.Message("'%s' is unsupported on the base tables of materialized views.", nil),
+	ErrMaterializedViewNoFastRefresh: mysql.Message("The materialized view can not be refreshed fast: %s.", nil),
+
+	ErrCannotAlterDDLJob: mysql.Message("Job [%v] can't be altered: %s", nil),
 }
@@ -0,0 +1,385 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+)
+
+const (
+	// timerKeyPrefix is the key prefix of the event timers, the key of an
+	// event timer is `timerKeyPrefix + schema + "/" + name`.
+	timerKeyPrefix = "/tidb/event/"
+	// timerHookClass is the hook class of the event timers.
+	timerHookClass = "tidb.event"
+	// oneTimeInterval is the schedule interval of the one time events, it
+	// doesn't matter since a one time event is disabled or dropped after it
+	// runs.
+	oneTimeInterval = time.Minute
+)
+
+// The types of the events.
+const (
+	TypeOneTime   = "ONE TIME"
+	TypeRecurring = "RECURRING"
+)
+
+// The status of the events.
+const (
+	StatusEnabled           = "ENABLED"
+	StatusDisabled          = "DISABLED"
+	StatusSlavesideDisabled = "SLAVESIDE_DISABLED"
+)
+
+// Info is the definition of an event, it is stored as the data of the event timer.
+type Info struct {
+	Schema      string `json:"schema"`
+	Name        string `json:"name"`
+	DefinerUser string `json:"definer_user"`
+	DefinerHost string `json:"definer_host"`
+	Body        string `json:"body"`
+	Type        string `json:"type"`
+	// ExecuteAt is the time to run a one time event.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are the interval of a recurring event,
+	// Starts and Ends limits the time range of it.
+	IntervalValue string    `json:"interval_value,omitempty"`
+	IntervalField string    `json:"interval_field,omitempty"`
+	Starts        time.Time `json:"starts"`
+	Ends          time.Time `json:"ends"`
+	Preserve      bool      `json:"preserve"`
+	// Status is only used to tell DISABLED from SLAVESIDE_DISABLED, whether
+	// the event is enabled is decided by the timer.
+	Status string `json:"status"`
+	// The following fields are the context to run the event.
+	Comment             string    `json:"comment,omitempty"`
+	SQLMode             string    `json:"sql_mode"`
+	TimeZone            string    `json:"time_zone"`
+	CharsetClient       string    `json:"charset_client"`
+	CollationConnection string    `json:"collation_connection"`
+	DBCollation         string    `json:"db_collation"`
+	Created             time.Time `json:"created"`
+	LastAltered         time.Time `json:"last_altered"`
+}
+
+// Definer returns the definer of the event.
+func (info *Info) Definer() *auth.UserIdentity {
+	return &auth.UserIdentity{Username: info.DefinerUser, Hostname: info.DefinerHost}
+}
+
+// Validate validates the schedule of the event.
+func (info *Info) Validate() error {
+	if info.Type == TypeOneTime {
+		return nil
+	}
+	if _, err := parseInterval(info.IntervalValue, info.IntervalField); err != nil {
+		return err
+	}
+	if !info.Ends.IsZero() && info.Ends.Before(info.Starts) {
+		return exeerrors.ErrEventEndsBeforeStarts
+	}
+	return nil
+}
+
+// Location returns the time zone of the event.
+func (info *Info) Location() *time.Location {
+	if loc, err := timeutil.ParseTimeZone(info.TimeZone); err == nil {
+		return loc
+	}
+	return timeutil.SystemLocation()
+}
+
+// sched returns the schedule policy and the initial watermark of the event timer.
+func (info *Info) sched() (tp timerapi.SchedPolicyType, expr string, watermark time.Time, err error) {
+	if info.Type == TypeOneTime {
+		// The first event of the interval policy runs at watermark + interval.
+		return timerapi.SchedEventInterval, formatDuration(oneTimeInterval), info.ExecuteAt.Add(-oneTimeInterval), nil
+	}
+	interval, err := parseInterval(info.IntervalValue, info.IntervalField)
+	if err != nil {
+		return "", "", watermark, err
+	}
+	if interval.months == 0 {
+		return timerapi.SchedEventInterval, formatDuration(interval.duration), info.Starts.Add(-interval.duration), nil
+	}
+	// The intervals in months are scheduled by the cron policy in the time
+	// zone of the event, the event runs at the same time of the same day as
+	// STARTS in the months.
+	starts := info.Starts.In(info.Location())
+	months := make([]string, 0, 12/interval.months)
+	for m := int(starts.Month()); len(months) < cap(months); m += interval.months {
+		months = append(months, fmt.Sprint((m-1)%12+1))
+	}
+	sort.Slice(months, func(i, j int) bool {
+		return len(months[i]) < len(months[j]) || len(months[i]) == len(months[j]) && months[i] < months[j]
+	})
+	expr = fmt.Sprintf("%d %d %d %s *", starts.Minute(), starts.Hour(), starts.Day(), strings.Join(months, ","))
+	return timerapi.SchedEventCron, expr, info.Starts.Add(-time.Second), nil
+}
+
+// completed returns whether the event has no more runs after the watermark.
+func (info *Info) completed(timer *timerapi.TimerRecord, watermark time.Time) (bool, error) {
+	if info.Type == TypeOneTime {
+		return !watermark.Before(info.ExecuteAt), nil
+	}
+	if info.Ends.IsZero() {
+		return false, nil
+	}
+	policy, err := timer.CreateSchedEventPolicy()
+	if err != nil {
+		return false, err
+	}
+	if loc := timer.Location; loc != nil {
+		watermark = watermark.In(loc)
+	}
+	next, ok := policy.NextEventTime(watermark)
+	return !ok || next.After(info.Ends), nil
+}
+
+// nextWatermark returns the watermark after the event runs at now, which is
+// expected to run at scheduled. The runs missed for the scheduler is stopped
+// are skipped instead of being caught up.
+func (info *Info) nextWatermark(timer *timerapi.TimerRecord, scheduled, now time.Time) time.Time {
+	if info.Type == TypeOneTime {
+		return info.ExecuteAt
+	}
+	if timer.SchedPolicyType == timerapi.SchedEventCron {
+		return now
+	}
+	interval, err := parseInterval(info.IntervalValue, info.IntervalField)
+	if err != nil || scheduled.After(now) {
+		return scheduled
+	}
+	skipped := now.Sub(scheduled) / interval.duration
+	return scheduled.Add(skipped * interval.duration)
+}
+
+type eventInterval struct {
+	months   int
+	duration time.Duration
+}
+
+// parseInterval parses the interval of a recurring event. Only the intervals
+// in seconds and the intervals in months which divide a year are supported.
+func parseInterval(value, field string) (interval eventInterval, err error) {
+	field = strings.ToUpper(field)
+	if strings.Contains(field, "MICROSECOND") {
+		return interval, dbterror.ErrNotSupportedYet.GenWithStackByArgs(field)
+	}
+	y, m, d, n, _, err := types.ParseDurationValue(field, value)
+	if err != nil {
+		return interval, errors.Trace(err)
+	}
+	months := y*12 + m
+	duration := time.Duration(d)*24*time.Hour + time.Duration(n)
+	if months < 0 || duration < 0 || months == 0 && duration == 0 {
+		return interval, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	if months > 0 {
+		if duration > 0 || 12%months != 0 {
+			return interval, dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("EVERY '%s' %s", value, field))
+		}
+		return eventInterval{months: int(months)}, nil
+	}
+	if duration%time.Second != 0 {
+		return interval, dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("EVERY '%s' %s", value, field))
+	}
+	return eventInterval{duration: duration}, nil
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// Event is an event read from the timer store.
+type Event struct {
+	Info
+	// Enable indicates whether the event is enabled.
+	Enable bool
+	// LastExecuted is the last time the event runs.
+	LastExecuted time.Time
+
+	timerID string
+}
+
+// GetStatus returns the status of the event.
+func (e *Event) GetStatus() string {
+	if e.Enable {
+		return StatusEnabled
+	}
+	if e.Status == StatusSlavesideDisabled {
+		return StatusSlavesideDisabled
+	}
+	return StatusDisabled
+}
+
+// summary is the summary data of the event timer.
+type summary struct {
+	LastExecuted time.Time `json:"last_executed"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+func timerKey(schema, name string) string {
+	return timerKeyPrefix + strings.ToLower(schema) + "/" + strings.ToLower(name)
+}
+
+func newEvent(timer *timerapi.TimerRecord) (*Event, error) {
+	e := &Event{Enable: timer.Enable, timerID: timer.ID}
+	if err := json.Unmarshal(timer.Data, &e.Info); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(timer.SummaryData) > 0 {
+		var s summary
+		if err := json.Unmarshal(timer.SummaryData, &s); err != nil {
+			return nil, errors.Trace(err)
+		}
+		e.LastExecuted = s.LastExecuted
+	}
+	return e, nil
+}
+
+// GetEvent returns the event, nil is returned if the event doesn't exist.
+func GetEvent(ctx context.Context, cli timerapi.TimerClient, schema, name string) (*Event, error) {
+	timer, err := cli.GetTimerByKey(ctx, timerKey(schema, name))
+	if err != nil {
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newEvent(timer)
+}
+
+// GetEvents returns all the events sorted by schema and name.
+func GetEvents(ctx context.Context, cli timerapi.TimerClient) ([]*Event, error) {
+	timers, err := cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(timers))
+	for _, timer := range timers {
+		e, err := newEvent(timer)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Schema != events[j].Schema {
+			return events[i].Schema < events[j].Schema
+		}
+		return events[i].Name < events[j].Name
+	})
+	return events, nil
+}
+
+func newTimerSpec(info *Info, enable bool) (*timerapi.TimerSpec, error) {
+	tp, expr, watermark, err := info.sched()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &timerapi.TimerSpec{
+		Key:             timerKey(info.Schema, info.Name),
+		Data:            data,
+		TimeZone:        timerTimeZone(info.TimeZone),
+		SchedPolicyType: tp,
+		SchedPolicyExpr: expr,
+		HookClass:       timerHookClass,
+		Watermark:       watermark,
+		Enable:          enable,
+	}, nil
+}
+
+// CreateEvent creates the timer of an event.
+func CreateEvent(ctx context.Context, cli timerapi.TimerClient, info *Info, enable bool) error {
+	spec, err := newTimerSpec(info, enable)
+	if err != nil {
+		return err
+	}
+	_, err = cli.CreateTimer(ctx, *spec)
+	return err
+}
+
+// AlterEvent updates the event, the timer is recreated if the event is
+// renamed. resched indicates whether the schedule of the event is changed.
+func AlterEvent(ctx context.Context, cli timerapi.TimerClient, e *Event, resched bool) error {
+	timer, err := cli.GetTimerByID(ctx, e.timerID)
+	if err != nil {
+		return err
+	}
+	if timer.Key != timerKey(e.Schema, e.Name) {
+		spec, err := newTimerSpec(&e.Info, e.Enable)
+		if err != nil {
+			return err
+		}
+		if !resched {
+			spec.Watermark = timer.Watermark
+		}
+		if _, err = cli.CreateTimer(ctx, *spec); err != nil {
+			return err
+		}
+		_, err = cli.DeleteTimer(ctx, e.timerID)
+		return err
+	}
+	data, err := json.Marshal(&e.Info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	opts := []timerapi.UpdateTimerOption{timerapi.WithSetData(data), timerapi.WithSetEnable(e.Enable)}
+	if resched {
+		tp, expr, watermark, err := e.sched()
+		if err != nil {
+			return err
+		}
+		opts = append(opts,
+			timerapi.WithSetSchedExpr(tp, expr),
+			timerapi.WithSetWatermark(watermark),
+			timerapi.WithSetTimeZone(timerTimeZone(e.TimeZone)),
+		)
+	}
+	return cli.UpdateTimer(ctx, e.timerID, opts...)
+}
+
+// DropEvent drops the event.
+func DropEvent(ctx context.Context, cli timerapi.TimerClient, e *Event) error {
+	_, err := cli.DeleteTimer(ctx, e.timerID)
+	return err
+}
+
+// timerTimeZone returns the time zone of the event timer, the cluster's time
+// zone is used if the event uses the system time zone.
+func timerTimeZone(tz string) string {
+	if strings.EqualFold(tz, "SYSTEM") {
+		return ""
+	}
+	return tz
+}
@@ -0,0 +1,268 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		value    string
+		field    string
+		months   int
+		duration time.Duration
+		err      error
+	}{
+		{value: "10", field: "SECOND", duration: 10 * time.Second},
+		{value: "1:30", field: "HOUR_MINUTE", duration: 90 * time.Minute},
+		{value: "2", field: "WEEK", duration: 14 * 24 * time.Hour},
+		{value: "1", field: "MONTH", months: 1},
+		{value: "1", field: "QUARTER", months: 3},
+		{value: "1", field: "YEAR", months: 12},
+		{value: "0", field: "DAY", err: exeerrors.ErrEventIntervalNotPositiveOrTooBig},
+		{value: "-1", field: "MINUTE", err: exeerrors.ErrEventIntervalNotPositiveOrTooBig},
+		{value: "5", field: "MONTH", err: dbterror.ErrNotSupportedYet},
+		{value: "1-1", field: "YEAR_MONTH", err: dbterror.ErrNotSupportedYet},
+		{value: "1", field: "MICROSECOND", err: dbterror.ErrNotSupportedYet},
+	}
+
+	for _, c := range cases {
+		interval, err := parseInterval(c.value, c.field)
+		if c.err != nil {
+			require.ErrorIs(t, err, c.err, "%s %s", c.value, c.field)
+			continue
+		}
+		require.NoError(t, err, "%s %s", c.value, c.field)
+		require.Equal(t, c.months, interval.months)
+		require.Equal(t, c.duration, interval.duration)
+	}
+}
+
+func TestEventSched(t *testing.T) {
+	now := time.Date(2024, 2, 10, 8, 30, 0, 0, time.UTC)
+
+	info := &Info{Type: TypeOneTime, ExecuteAt: now}
+	tp, expr, watermark, err := info.sched()
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventInterval, tp)
+	require.Equal(t, "60s", expr)
+	require.Equal(t, now.Add(-time.Minute), watermark)
+
+	info = &Info{Type: TypeRecurring, IntervalValue: "1", IntervalField: "HOUR", Starts: now}
+	tp, expr, watermark, err = info.sched()
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventInterval, tp)
+	require.Equal(t, "3600s", expr)
+	require.Equal(t, now.Add(-time.Hour), watermark)
+
+	info = &Info{Type: TypeRecurring, IntervalValue: "1", IntervalField: "QUARTER", Starts: now, TimeZone: "UTC"}
+	tp, expr, watermark, err = info.sched()
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventCron, tp)
+	require.Equal(t, "30 8 10 2,5,8,11 *", expr)
+	require.Equal(t, now.Add(-time.Second), watermark)
+
+	info = &Info{Type: TypeRecurring, IntervalValue: "1", IntervalField: "HOUR", Starts: now, Ends: now.Add(-time.Hour)}
+	require.ErrorIs(t, info.Validate(), exeerrors.ErrEventEndsBeforeStarts)
+}
+
+func TestEventCRUD(t *testing.T) {
+	ctx := context.Background()
+	store := timerapi.NewMemoryTimerStore()
+	defer store.Close()
+	cli := timerapi.NewDefaultTimerClient(store)
+
+	e, err := GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.Nil(t, e)
+
+	now := time.Now().Truncate(time.Second)
+	for _, name := range []string{"e2", "e1"} {
+		info := &Info{Schema: "test", Name: name, Type: TypeRecurring, IntervalValue: "1", IntervalField: "DAY", Starts: now, Body: "select 1", TimeZone: "SYSTEM"}
+		require.NoError(t, CreateEvent(ctx, cli, info, true))
+	}
+
+	events, err := GetEvents(ctx, cli)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "e1", events[0].Name)
+	require.Equal(t, "e2", events[1].Name)
+	require.Equal(t, StatusEnabled, events[0].GetStatus())
+
+	e, err = GetEvent(ctx, cli, "TEST", "E1")
+	require.NoError(t, err)
+	require.Equal(t, "select 1", e.Body)
+
+	// alter the event
+	e.Enable = false
+	e.Body = "select 2"
+	e.IntervalValue = "2"
+	require.NoError(t, AlterEvent(ctx, cli, e, true))
+	timer, err := cli.GetTimerByKey(ctx, timerKey("test", "e1"))
+	require.NoError(t, err)
+	require.False(t, timer.Enable)
+	require.Equal(t, "172800s", timer.SchedPolicyExpr)
+	require.Equal(t, now.Add(-48*time.Hour).Unix(), timer.Watermark.Unix())
+
+	// rename the event
+	e, err = GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.Equal(t, StatusDisabled, e.GetStatus())
+	e.Name = "e3"
+	require.NoError(t, AlterEvent(ctx, cli, e, false))
+	e, err = GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.Nil(t, e)
+	e, err = GetEvent(ctx, cli, "test", "e3")
+	require.NoError(t, err)
+	require.Equal(t, "select 2", e.Body)
+	require.False(t, e.Enable)
+
+	require.NoError(t, DropEvent(ctx, cli, e))
+	events, err = GetEvents(ctx, cli)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "e2", events[0].Name)
+}
+
+type mockSession struct {
+	vars     *variable.SessionVars
+	user     *auth.UserIdentity
+	executed []string
+}
+
+func (s *mockSession) Execute(_ context.Context, sql string) ([]sqlexec.RecordSet, error) {
+	s.executed = append(s.executed, sql)
+	return nil, nil
+}
+
+func (s *mockSession) GetSessionVars() *variable.SessionVars {
+	return s.vars
+}
+
+func (s *mockSession) AuthWithoutVerification(user *auth.UserIdentity) bool {
+	s.user = user
+	return true
+}
+
+func (*mockSession) Close() {}
+
+type mockSchedEvent struct {
+	eventID string
+	timer   *timerapi.TimerRecord
+}
+
+func (e *mockSchedEvent) EventID() string {
+	return e.eventID
+}
+
+func (e *mockSchedEvent) Timer() *timerapi.TimerRecord {
+	return e.timer
+}
+
+func TestEventHook(t *testing.T) {
+	ctx := context.Background()
+	store := timerapi.NewMemoryTimerStore()
+	defer store.Close()
+	cli := timerapi.NewDefaultTimerClient(store)
+
+	se := &mockSession{vars: variable.NewSessionVars(nil)}
+	hook := newEventHook(cli, func() (Session, error) { return se, nil })
+	defer hook.Stop()
+
+	trigger := func(key, eventID string) {
+		timer, err := cli.GetTimerByKey(ctx, key)
+		require.NoError(t, err)
+		require.NoError(t, store.Update(ctx, timer.ID, &timerapi.TimerUpdate{
+			EventStatus: timerapi.NewOptionalVal(timerapi.SchedEventTrigger),
+			EventID:     timerapi.NewOptionalVal(eventID),
+			EventStart:  timerapi.NewOptionalVal(time.Now()),
+		}))
+		timer, err = cli.GetTimerByID(ctx, timer.ID)
+		require.NoError(t, err)
+		require.NoError(t, hook.OnSchedEvent(ctx, &mockSchedEvent{eventID: eventID, timer: timer}))
+		hook.wg.Wait()
+	}
+
+	// a recurring event advances the watermark after running
+	now := time.Now().Truncate(time.Second)
+	info := &Info{
+		Schema: "test", Name: "e1", DefinerUser: "u1", DefinerHost: "%",
+		Type: TypeRecurring, IntervalValue: "10", IntervalField: "MINUTE", Starts: now, Body: "insert into t values (1)",
+	}
+	require.NoError(t, CreateEvent(ctx, cli, info, true))
+	trigger(timerKey("test", "e1"), "event1")
+	require.Equal(t, []string{"insert into t values (1)"}, se.executed)
+	require.Equal(t, "test", se.vars.CurrentDB)
+	require.Equal(t, "u1", se.user.Username)
+	timer, err := cli.GetTimerByKey(ctx, timerKey("test", "e1"))
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventIdle, timer.EventStatus)
+	require.Equal(t, now.Unix(), timer.Watermark.Unix())
+	require.True(t, timer.Enable)
+	var s summary
+	require.NoError(t, json.Unmarshal(timer.SummaryData, &s))
+	require.False(t, s.LastExecuted.IsZero())
+	require.Empty(t, s.LastError)
+
+	// a one time event is dropped after running
+	se.executed = nil
+	info = &Info{Schema: "test", Name: "e2", Type: TypeOneTime, ExecuteAt: now, Body: "select 1"}
+	require.NoError(t, CreateEvent(ctx, cli, info, true))
+	trigger(timerKey("test", "e2"), "event2")
+	require.Equal(t, []string{"select 1"}, se.executed)
+	e, err := GetEvent(ctx, cli, "test", "e2")
+	require.NoError(t, err)
+	require.Nil(t, e)
+
+	// a one time event with ON COMPLETION PRESERVE is disabled after running
+	se.executed = nil
+	info.Name, info.Preserve = "e3", true
+	require.NoError(t, CreateEvent(ctx, cli, info, true))
+	trigger(timerKey("test", "e3"), "event3")
+	require.Equal(t, []string{"select 1"}, se.executed)
+	e, err = GetEvent(ctx, cli, "test", "e3")
+	require.NoError(t, err)
+	require.False(t, e.Enable)
+	require.False(t, e.LastExecuted.IsZero())
+
+	// a recurring event is not run after it ends
+	se.executed = nil
+	info = &Info{
+		Schema: "test", Name: "e4", Type: TypeRecurring, IntervalValue: "1", IntervalField: "HOUR",
+		Starts: now.Add(-2 * time.Hour), Ends: now.Add(-time.Hour).Add(-time.Second), Body: "select 1",
+	}
+	require.NoError(t, CreateEvent(ctx, cli, info, true))
+	timer, err = cli.GetTimerByKey(ctx, timerKey("test", "e4"))
+	require.NoError(t, err)
+	require.NoError(t, cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetWatermark(now.Add(-2*time.Hour))))
+	trigger(timerKey("test", "e4"), "event4")
+	require.Empty(t, se.executed)
+	e, err = GetEvent(ctx, cli, "test", "e4")
+	require.NoError(t, err)
+	require.Nil(t, e)
+}
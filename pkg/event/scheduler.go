@@ -0,0 +1,339 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	schedulerLoopInterval   = time.Second
+	closeEventRetryInterval = 10 * time.Second
+)
+
+// Session is the session to run the statements of the events.
+type Session interface {
+	// Execute executes the sql statements.
+	Execute(ctx context.Context, sql string) ([]sqlexec.RecordSet, error)
+	// GetSessionVars returns the session variables.
+	GetSessionVars() *variable.SessionVars
+	// AuthWithoutVerification logs in the session as the user without verification.
+	AuthWithoutVerification(user *auth.UserIdentity) bool
+	// Close closes the session.
+	Close()
+}
+
+// SessionFactory creates a new session with the privilege manager to run the events.
+type SessionFactory func() (Session, error)
+
+// HookFactory creates the hook to run the timers of a hook class, the sessions to run
+// the timers are created by sessFactory.
+type HookFactory func(cli timerapi.TimerClient, sessFactory SessionFactory) timerapi.Hook
+
+type hookRegistration struct {
+	keyPrefix string
+	hookClass string
+	factory   HookFactory
+}
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Scheduler schedules the events. The events are stored as timers and only
+// the owner runs them, so each event is run on exactly one TiDB node.
+type Scheduler struct {
+	store       *timerapi.TimerStore
+	cli         timerapi.TimerClient
+	sessFactory SessionFactory
+	isOwner     func() bool
+	hooks       []hookRegistration
+	rt          *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new event scheduler.
+func NewScheduler(pool sessionPool, etcd *clientv3.Client, sessFactory SessionFactory, isOwner func() bool) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		store:       store,
+		cli:         timerapi.NewDefaultTimerClient(store),
+		sessFactory: sessFactory,
+		isOwner:     isOwner,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	s.RegisterHook(timerKeyPrefix, timerHookClass, func(cli timerapi.TimerClient, sessFactory SessionFactory) timerapi.Hook {
+		return newEventHook(cli, sessFactory)
+	})
+	return s
+}
+
+// RegisterHook registers the hook to run the timers whose keys start with keyPrefix, the
+// timers should be created with hookClass. It should be called before the scheduler starts.
+func (s *Scheduler) RegisterHook(keyPrefix, hookClass string, factory HookFactory) {
+	s.hooks = append(s.hooks, hookRegistration{keyPrefix: keyPrefix, hookClass: hookClass, factory: factory})
+}
+
+// TimerClient returns the client to manage the events.
+func (s *Scheduler) TimerClient() timerapi.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the scheduler and waits the running events to exit.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+		logutil.BgLogger().Info("event scheduler loop exited")
+	}()
+
+	ticker := time.NewTicker(schedulerLoopInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.isOwner() {
+			s.resume()
+		} else {
+			s.pause()
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	conds := make([]timerapi.Cond, 0, len(s.hooks))
+	builder := timerrt.NewTimerRuntimeBuilder("event", s.store)
+	for _, hook := range s.hooks {
+		factory := hook.factory
+		conds = append(conds, &timerapi.TimerCond{Key: timerapi.NewOptionalVal(hook.keyPrefix), KeyPrefix: true})
+		builder.RegisterHookFactory(hook.hookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return factory(cli, s.sessFactory)
+		})
+	}
+	s.rt = builder.SetCond(timerapi.Or(conds...)).Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+type eventHook struct {
+	cli         timerapi.TimerClient
+	sessFactory SessionFactory
+	ctx         context.Context
+	cancel      func()
+	wg          sync.WaitGroup
+	nowFunc     func() time.Time
+}
+
+func newEventHook(cli timerapi.TimerClient, sessFactory SessionFactory) *eventHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventHook{
+		cli:         cli,
+		sessFactory: sessFactory,
+		ctx:         ctx,
+		cancel:      cancel,
+		nowFunc:     time.Now,
+	}
+}
+
+func (h *eventHook) Start() {}
+
+func (h *eventHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !variable.EnableEventScheduler.Load() {
+		r.Delay = time.Minute
+	}
+	return
+}
+
+func (h *eventHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	eventID := event.EventID()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+	)
+
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	var info Info
+	if err := json.Unmarshal(timer.Data, &info); err != nil {
+		logger.Error("invalid event timer data", zap.ByteString("data", timer.Data))
+		return err
+	}
+
+	h.wg.Add(1)
+	go h.runEvent(logger, &info, timer, eventID)
+	return nil
+}
+
+func (h *eventHook) runEvent(logger *zap.Logger, info *Info, timer *timerapi.TimerRecord, eventID string) {
+	defer h.wg.Done()
+
+	scheduled, _, err := timer.NextEventTime()
+	if err != nil {
+		logger.Error("invalid event schedule", zap.Error(err))
+		return
+	}
+
+	summaryData := timer.SummaryData
+	// The recurring events whose ENDS passed are completed without running.
+	expired := info.Type == TypeRecurring && !info.Ends.IsZero() && scheduled.After(info.Ends)
+	if !expired {
+		logger.Info("start to run event", zap.Time("scheduled", scheduled))
+		s := summary{LastExecuted: h.nowFunc()}
+		if err := h.execute(info); err != nil {
+			logger.Warn("failed to run event", zap.Error(err))
+			s.LastError = err.Error()
+		}
+		if summaryData, err = json.Marshal(&s); err != nil {
+			logger.Error("marshal summary error", zap.Error(err))
+			summaryData = timer.SummaryData
+		}
+	}
+
+	watermark := info.nextWatermark(timer, scheduled, h.nowFunc())
+	completed, err := info.completed(timer, watermark)
+	if err != nil {
+		logger.Error("invalid event schedule", zap.Error(err))
+	}
+	completed = completed || expired
+
+	ticker := time.NewTicker(closeEventRetryInterval)
+	defer ticker.Stop()
+	for {
+		if err = h.closeEvent(info, timer.ID, eventID, watermark, summaryData, completed); err == nil {
+			return
+		}
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) || errors.ErrorEqual(err, timerapi.ErrEventIDNotMatch) {
+			logger.Warn("stop closing event because the timer is changed", zap.Error(err))
+			return
+		}
+		logger.Error("failed to close event", zap.Error(err))
+
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *eventHook) closeEvent(info *Info, timerID, eventID string, watermark time.Time, summaryData []byte, completed bool) error {
+	err := h.cli.CloseTimerEvent(h.ctx, timerID, eventID, timerapi.WithSetWatermark(watermark), timerapi.WithSetSummaryData(summaryData))
+	if err != nil || !completed {
+		return err
+	}
+
+	if info.Preserve {
+		return h.cli.UpdateTimer(h.ctx, timerID, timerapi.WithSetEnable(false))
+	}
+	_, err = h.cli.DeleteTimer(h.ctx, timerID)
+	return err
+}
+
+// execute runs the statement of the event as the definer.
+func (h *eventHook) execute(info *Info) error {
+	se, err := h.sessFactory()
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+
+	vars := se.GetSessionVars()
+	vars.CurrentDB = info.Schema
+	for _, v := range []struct{ name, value string }{
+		{variable.SQLModeVar, info.SQLMode},
+		{variable.TimeZone, info.TimeZone},
+		{variable.CharacterSetClient, info.CharsetClient},
+		{variable.CollationConnection, info.CollationConnection},
+		{variable.CollationDatabase, info.DBCollation},
+	} {
+		if v.value == "" {
+			continue
+		}
+		if err = vars.SetSystemVar(v.name, v.value); err != nil {
+			return err
+		}
+	}
+
+	if !se.AuthWithoutVerification(info.Definer()) {
+		return errors.Errorf("the definer %s of the event does not exist", info.Definer().String())
+	}
+
+	rss, err := se.Execute(h.ctx, info.Body)
+	for _, rs := range rss {
+		if _, drainErr := sqlexec.DrainRecordSet(h.ctx, rs, vars.MaxChunkSize); drainErr != nil && err == nil {
+			err = drainErr
+		}
+		if closeErr := rs.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -1455,6 +1455,7 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 		}
 	}
 
+	a.Ctx.UpdateIndexUsage(sessVars.StmtCtx.UsedIndexes)
 	a.Ctx.ReportUsageStats()
 }
 
@@ -278,7 +278,7 @@ func (e *AnalyzeColumnsExec) buildStats(ranges []*ranger.Range, needExtStats boo
 		fms = append(fms, collectors[i].FMSketch)
 	}
 	if needExtStats {
-		extStats, err = statistics.BuildExtendedStats(e.ctx, e.TableID.GetStatisticsID(), e.colsInfo, collectors)
+		extStats, err = statistics.BuildExtendedStats(e.ctx, e.TableID.GetStatisticsID(), e.colsInfo, collectors, nil)
 		if err != nil {
 			return nil, nil, nil, nil, nil, err
 		}
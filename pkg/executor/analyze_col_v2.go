@@ -468,7 +468,7 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 
 	count = rootRowCollector.Base().Count
 	if needExtStats {
-		extStats, err = statistics.BuildExtendedStats(e.ctx, e.TableID.GetStatisticsID(), e.colsInfo, sampleCollectors)
+		extStats, err = statistics.BuildExtendedStats(e.ctx, e.TableID.GetStatisticsID(), e.colsInfo, sampleCollectors, rootRowCollector.Base().Samples)
 		if err != nil {
 			return 0, nil, nil, nil, nil, err
 		}
@@ -154,7 +154,7 @@ func getKeysNeedCheckOneRow(ctx sessionctx.Context, t table.Table, row []types.D
 	for _, col := range t.WritableCols() {
 		// if there is a changing column, append the dependency column for index fetch values
 		if col.ChangeStateInfo != nil && col.State != model.StatePublic {
-			value, err := table.CastValue(ctx, row[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+			value, err := tables.GetChangingColValFromRow(ctx, t.Meta(), col, row)
 			if err != nil {
 				return nil, err
 			}
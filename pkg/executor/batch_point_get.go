@@ -57,6 +57,7 @@ type BatchPointGetExec struct {
 	txn         kv.Transaction
 	lock        bool
 	waitTime    int64
+	skipLocked  bool
 	inited      uint32
 	values      [][]byte
 	index       int
@@ -212,6 +213,8 @@ func datumsContainNull(vals []types.Datum) bool {
 func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	var handleVals map[string][]byte
 	var indexKeys []kv.Key
+	// rowIdxKeys records the unique index key of each handle, it's used to lock the rows separately for SKIP LOCKED.
+	var rowIdxKeys []kv.Key
 	var err error
 	batchGetter := e.batchGetter
 	rc := e.Ctx().GetSessionVars().IsPessimisticReadConsistency()
@@ -300,6 +303,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 				return err1
 			}
 			e.handles = append(e.handles, handle)
+			rowIdxKeys = append(rowIdxKeys, key)
 			if rc {
 				indexKeys = append(indexKeys, key)
 			}
@@ -369,6 +373,10 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 
 	keys := make([]kv.Key, 0, len(e.handles))
 	newHandles := make([]kv.Handle, 0, len(e.handles))
+	var newRowIdxKeys []kv.Key
+	if len(rowIdxKeys) > 0 {
+		newRowIdxKeys = make([]kv.Key, 0, len(e.handles))
+	}
 	for i, handle := range e.handles {
 		var tID int64
 		if len(e.physIDs) > 0 {
@@ -400,12 +408,25 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		key := tablecodec.EncodeRowKeyWithHandle(tID, handle)
 		keys = append(keys, key)
 		newHandles = append(newHandles, handle)
+		if len(rowIdxKeys) > 0 {
+			newRowIdxKeys = append(newRowIdxKeys, rowIdxKeys[i])
+		}
 	}
 	e.handles = newHandles
+	rowIdxKeys = newRowIdxKeys
 
 	var values map[string][]byte
-	// Lock keys (include exists and non-exists keys) before fetch all values for Repeatable Read Isolation.
-	if e.lock && !rc {
+	if e.lock && !rc && e.skipLocked {
+		// Lock keys (include exists and non-exists keys) row by row, and skip the rows locked by others.
+		keys, err = e.lockRowsSkipLocked(ctx, keys, rowIdxKeys)
+		if err != nil {
+			return err
+		}
+		if err = e.lockNonExistIdxKeysSkipLocked(ctx, indexKeys, handleVals); err != nil {
+			return err
+		}
+	} else if e.lock && !rc {
+		// Lock keys (include exists and non-exists keys) before fetch all values for Repeatable Read Isolation.
 		lockKeys := make([]kv.Key, len(keys)+len(indexKeys))
 		copy(lockKeys, keys)
 		copy(lockKeys[len(keys):], indexKeys)
@@ -462,7 +483,10 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		}
 	}
 	// Lock exists keys only for Read Committed Isolation.
-	if e.lock && rc {
+	if e.lock && rc && e.skipLocked {
+		e.handles = handles
+		return e.skipLockedRowsForRC(ctx, existKeys)
+	} else if e.lock && rc {
 		err = LockKeys(ctx, e.Ctx(), e.waitTime, existKeys...)
 		if err != nil {
 			return err
@@ -472,6 +496,70 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	return nil
 }
 
+// lockRowsSkipLocked locks the row key and the unique index key of every row separately without waiting.
+// The rows locked by other transactions are removed from the returned keys and e.handles.
+func (e *BatchPointGetExec) lockRowsSkipLocked(ctx context.Context, keys, rowIdxKeys []kv.Key) ([]kv.Key, error) {
+	lockedKeys := keys[:0]
+	lockedHandles := e.handles[:0]
+	for i, key := range keys {
+		rowKeys := []kv.Key{key}
+		if len(rowIdxKeys) > 0 {
+			rowKeys = append(rowKeys, rowIdxKeys[i])
+		}
+		locked, err := lockKeysSkipLocked(ctx, e.Ctx(), rowKeys)
+		if err != nil {
+			return nil, err
+		}
+		if !locked {
+			continue
+		}
+		lockedKeys = append(lockedKeys, key)
+		lockedHandles = append(lockedHandles, e.handles[i])
+	}
+	e.handles = lockedHandles
+	return lockedKeys, nil
+}
+
+// lockNonExistIdxKeysSkipLocked locks the unique index keys which don't point to any row in Repeatable Read Isolation.
+// There is no row to return for these keys, so the keys locked by others are just ignored.
+func (e *BatchPointGetExec) lockNonExistIdxKeysSkipLocked(ctx context.Context, indexKeys []kv.Key, handleVals map[string][]byte) error {
+	for _, idxKey := range indexKeys {
+		if len(handleVals[string(idxKey)]) > 0 {
+			continue
+		}
+		if _, err := lockKeysSkipLocked(ctx, e.Ctx(), []kv.Key{idxKey}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipLockedRowsForRC locks the existing rows separately without waiting for Read Committed Isolation,
+// and removes the rows locked by other transactions from e.values and e.handles.
+func (e *BatchPointGetExec) skipLockedRowsForRC(ctx context.Context, existKeys []kv.Key) error {
+	// existKeys contains the row key and optionally the index key of each row in order.
+	keysPerRow := 1
+	if len(e.handles) > 0 && len(existKeys) == 2*len(e.handles) {
+		keysPerRow = 2
+	}
+	values := e.values[:0]
+	handles := e.handles[:0]
+	for i := range e.handles {
+		locked, err := lockKeysSkipLocked(ctx, e.Ctx(), existKeys[i*keysPerRow:(i+1)*keysPerRow])
+		if err != nil {
+			return err
+		}
+		if !locked {
+			continue
+		}
+		values = append(values, e.values[i])
+		handles = append(handles, e.handles[i])
+	}
+	e.values = values
+	e.handles = handles
+	return nil
+}
+
 // LockKeys locks the keys for pessimistic transaction.
 func LockKeys(ctx context.Context, sctx sessionctx.Context, lockWaitTime int64, keys ...kv.Key) error {
 	txnCtx := sctx.GetSessionVars().TxnCtx
@@ -57,6 +57,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/property"
 	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
@@ -216,6 +217,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildCancelDDLJobs(v)
 	case *plannercore.PauseDDLJobs:
 		return b.buildPauseDDLJobs(v)
+	case *plannercore.AlterDDLJobs:
+		return b.buildAlterDDLJobs(v)
 	case *plannercore.ResumeDDLJobs:
 		return b.buildResumeDDLJobs(v)
 	case *plannercore.ShowNextRowID:
@@ -272,6 +275,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -344,6 +349,19 @@ func (b *executorBuilder) buildPauseDDLJobs(v *plannercore.PauseDDLJobs) exec.Ex
 	return e
 }
 
+func (b *executorBuilder) buildAlterDDLJobs(v *plannercore.AlterDDLJobs) exec.Executor {
+	e := &AlterDDLJobsExec{
+		CommandDDLJobsExec: &CommandDDLJobsExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			jobIDs:       v.JobIDs,
+			execute: func(se sessionctx.Context, ids []int64) ([]error, error) {
+				return ddl.AlterJobsSchedule(se, ids, v.Schedule, v.ScheduleDuration)
+			},
+		},
+	}
+	return e
+}
+
 func (b *executorBuilder) buildResumeDDLJobs(v *plannercore.ResumeDDLJobs) exec.Executor {
 	e := &ResumeDDLJobsExec{
 		CommandDDLJobsExec: &CommandDDLJobsExec{
@@ -794,23 +812,41 @@ func (b *executorBuilder) buildLimit(v *plannercore.PhysicalLimit) exec.Executor
 	n := int(min(v.Count, uint64(b.ctx.GetSessionVars().MaxChunkSize)))
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), childExec)
 	base.SetInitCap(n)
-	e := &LimitExec{
-		BaseExecutor: base,
-		begin:        v.Offset,
-		end:          v.Offset + v.Count,
-	}
 
 	childUsedSchema := markChildrenUsedCols(v.Schema().Columns, v.Children()[0].Schema())[0]
-	e.columnIdxsUsedByChild = make([]int, 0, len(childUsedSchema))
+	columnIdxsUsedByChild := make([]int, 0, len(childUsedSchema))
 	for i, used := range childUsedSchema {
 		if used {
-			e.columnIdxsUsedByChild = append(e.columnIdxsUsedByChild, i)
+			columnIdxsUsedByChild = append(columnIdxsUsedByChild, i)
 		}
 	}
-	if len(e.columnIdxsUsedByChild) == len(childUsedSchema) {
-		e.columnIdxsUsedByChild = nil // indicates that all columns are used. LimitExec will improve performance for this condition.
+	if len(columnIdxsUsedByChild) == len(childUsedSchema) {
+		columnIdxsUsedByChild = nil // indicates that all columns are used. LimitExec will improve performance for this condition.
+	}
+	if len(v.PartitionBy) > 0 {
+		executor_metrics.ExecutorCounterPartitionTopNExec.Inc()
+		return &sortexec.PartitionTopNExec{
+			SortExec:              sortexec.SortExec{BaseExecutor: base, ExecSchema: v.Schema()},
+			PartitionBy:           partitionByItemsToCols(v.PartitionBy),
+			Count:                 v.Offset + v.Count,
+			ColumnIdxsUsedByChild: columnIdxsUsedByChild,
+		}
+	}
+	return &LimitExec{
+		BaseExecutor:          base,
+		begin:                 v.Offset,
+		end:                   v.Offset + v.Count,
+		columnIdxsUsedByChild: columnIdxsUsedByChild,
 	}
-	return e
+}
+
+// partitionByItemsToCols converts the partition by items of a TopN or Limit to columns.
+func partitionByItemsToCols(items []property.SortItem) []*expression.Column {
+	cols := make([]*expression.Column, 0, len(items))
+	for _, item := range items {
+		cols = append(cols, item.Col)
+	}
+	return cols
 }
 
 func (b *executorBuilder) buildPrepare(v *plannercore.Prepare) exec.Executor {
@@ -920,6 +956,12 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			WorkloadType: s.Tp,
 			OptionList:   s.DynamicCalibrateResourceOptionList,
 		}
+	case *ast.RecommendIndexStmt:
+		return &RecommendIndexExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
+			SQL:          s.SQL,
+			Options:      s.Options,
+		}
 	case *ast.AddQueryWatchStmt:
 		return &querywatch.AddExecutor{
 			BaseExecutor:         exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
@@ -1336,6 +1378,8 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) exec.Execut
 	return &SelectIntoExec{
 		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:        v.IntoOpt,
+		options:        v.Options,
+		outputNames:    v.TargetNames,
 		LineFieldsInfo: v.LineFieldsInfo,
 	}
 }
@@ -1897,6 +1941,20 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	path, err := buildJSONTablePath(v.Path, v.Schema())
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		path:         path,
+		name:         v.Name.O,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2070,6 +2128,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableEvents),
+			strings.ToLower(infoschema.TableRoutines),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2110,7 +2170,11 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableRunawayWatches),
 			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
-			strings.ToLower(infoschema.TableKeywords):
+			strings.ToLower(infoschema.TableKeywords),
+			strings.ToLower(infoschema.TableTiDBAutoAnalyzeQueue),
+			strings.ToLower(infoschema.TableInstancePlanCache),
+			strings.ToLower(infoschema.TableTiDBIndexUsage),
+			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
 			return &MemTableReaderExec{
 				BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -2252,6 +2316,14 @@ func (b *executorBuilder) buildTopN(v *plannercore.PhysicalTopN) exec.Executor {
 		ByItems:      v.ByItems,
 		ExecSchema:   v.Schema(),
 	}
+	if len(v.PartitionBy) > 0 {
+		executor_metrics.ExecutorCounterPartitionTopNExec.Inc()
+		return &sortexec.PartitionTopNExec{
+			SortExec:    sortExec,
+			PartitionBy: partitionByItemsToCols(v.PartitionBy),
+			Count:       v.Offset + v.Count,
+		}
+	}
 	executor_metrics.ExecutorCounterTopNExec.Inc()
 	return &sortexec.TopNExec{
 		SortExec: sortExec,
@@ -3689,6 +3761,7 @@ func (b *executorBuilder) buildIndexReader(v *plannercore.PhysicalIndexReader) e
 	ret.ranges = is.Ranges
 	sctx := b.ctx.GetSessionVars().StmtCtx
 	sctx.IndexNames = append(sctx.IndexNames, is.Table.Name.O+":"+is.Index.Name.O)
+	sctx.AppendUsedIndex(is.Table.ID, is.Index.ID)
 
 	if !b.ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
 		return ret
@@ -3890,6 +3963,7 @@ func (b *executorBuilder) buildIndexLookUpReader(v *plannercore.PhysicalIndexLoo
 
 	sctx := b.ctx.GetSessionVars().StmtCtx
 	sctx.IndexNames = append(sctx.IndexNames, is.Table.Name.O+":"+is.Index.Name.O)
+	sctx.AppendUsedIndex(is.Table.ID, is.Index.ID)
 	sctx.TableIDs = append(sctx.TableIDs, ts.Table.ID)
 
 	if !b.ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
@@ -4038,11 +4112,14 @@ func (b *executorBuilder) buildIndexMergeReader(v *plannercore.PhysicalIndexMerg
 		if is, ok := v.PartialPlans[i][0].(*plannercore.PhysicalIndexScan); ok {
 			ret.ranges = append(ret.ranges, is.Ranges)
 			sctx.IndexNames = append(sctx.IndexNames, is.Table.Name.O+":"+is.Index.Name.O)
+			sctx.AppendUsedIndex(is.Table.ID, is.Index.ID)
 		} else {
 			ret.ranges = append(ret.ranges, v.PartialPlans[i][0].(*plannercore.PhysicalTableScan).Ranges)
 			if ret.table.Meta().IsCommonHandle {
 				tblInfo := ret.table.Meta()
-				sctx.IndexNames = append(sctx.IndexNames, tblInfo.Name.O+":"+tables.FindPrimaryIndex(tblInfo).Name.O)
+				pkIdx := tables.FindPrimaryIndex(tblInfo)
+				sctx.IndexNames = append(sctx.IndexNames, tblInfo.Name.O+":"+pkIdx.Name.O)
+				sctx.AppendUsedIndex(tblInfo.ID, pkIdx.ID)
 			}
 		}
 	}
@@ -5020,6 +5097,7 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 		desc:         plan.Desc,
 		lock:         plan.Lock,
 		waitTime:     plan.LockWaitTime,
+		skipLocked:   plan.LockSkipLocked,
 		partExpr:     plan.PartitionExpr,
 		partPos:      plan.PartitionColPos,
 		planPhysIDs:  plan.PartitionIDs,
@@ -5047,6 +5125,7 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 	if plan.IndexInfo != nil {
 		sctx := b.ctx.GetSessionVars().StmtCtx
 		sctx.IndexNames = append(sctx.IndexNames, plan.TblInfo.Name.O+":"+plan.IndexInfo.Name.O)
+		sctx.AppendUsedIndex(plan.TblInfo.ID, plan.IndexInfo.ID)
 	}
 
 	failpoint.Inject("assertBatchPointReplicaOption", func(val failpoint.Value) {
@@ -159,6 +159,10 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateTable(x)
 	case *ast.CreateViewStmt:
 		err = e.executeCreateView(ctx, x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
+	case *ast.DropMaterializedViewStmt:
+		err = e.executeDropMaterializedView(ctx, x)
 	case *ast.DropIndexStmt:
 		err = e.executeDropIndex(x)
 	case *ast.DropDatabaseStmt:
@@ -329,6 +333,10 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	}
 
 	err := domain.GetDomain(e.Ctx()).DDL().DropSchema(e.Ctx(), s)
+	if err == nil {
+		// The stored procedures belong to the schema, drop them with the schema.
+		err = dropProceduresInSchema(e.Ctx(), dbName.O)
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
@@ -0,0 +1,386 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/event"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+func getEventTimerClient(sctx sessionctx.Context) (timerapi.TimerClient, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, errors.New("event scheduler is not started")
+	}
+	return scheduler.TimerClient(), nil
+}
+
+// getVisibleEvents returns the events in the schema that the current user
+// has the EVENT privilege, the events in all schemas are returned if the
+// schema is empty.
+func getVisibleEvents(ctx context.Context, sctx sessionctx.Context, schema string) ([]*event.Event, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, nil
+	}
+	events, err := event.GetEvents(ctx, scheduler.TimerClient())
+	if err != nil {
+		return nil, err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	visible := events[:0]
+	for _, e := range events {
+		if schema != "" && !strings.EqualFold(e.Schema, schema) {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(e.Schema), "", "", mysql.EventPriv) {
+			continue
+		}
+		visible = append(visible, e)
+	}
+	return visible, nil
+}
+
+// eventTime converts the time to a datetime in the location, nil is returned
+// for the zero time.
+func eventTime(t time.Time, loc *time.Location) any {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+}
+
+func isEventStmt(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
+	}
+	return false
+}
+
+func (e *SimpleExec) evalEventTime(expr ast.ExprNode, clause string) (time.Time, error) {
+	v, err := expression.EvalAstExpr(e.Ctx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	vars := e.Ctx().GetSessionVars()
+	d, err := v.ConvertTo(vars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, "NULL")
+	}
+	return d.GetMysqlTime().GoTime(vars.Location())
+}
+
+// setEventSchedule sets the schedule of the event.
+func (e *SimpleExec) setEventSchedule(info *event.Info, schedule *ast.EventSchedule, now time.Time) (err error) {
+	info.ExecuteAt, info.Starts, info.Ends = time.Time{}, time.Time{}, time.Time{}
+	info.IntervalValue, info.IntervalField = "", ""
+	switch schedule.Tp {
+	case ast.EventScheduleAt:
+		info.Type = event.TypeOneTime
+		if info.ExecuteAt, err = e.evalEventTime(schedule.At, "AT"); err != nil {
+			return err
+		}
+	case ast.EventScheduleEvery:
+		info.Type = event.TypeRecurring
+		v, err := expression.EvalAstExpr(e.Ctx(), schedule.Interval)
+		if err != nil {
+			return err
+		}
+		if v.IsNull() {
+			return exeerrors.ErrEventIntervalNotPositiveOrTooBig
+		}
+		if info.IntervalValue, err = v.ToString(); err != nil {
+			return err
+		}
+		info.IntervalField = schedule.Unit.String()
+		info.Starts = now
+		if schedule.Starts != nil {
+			if info.Starts, err = e.evalEventTime(schedule.Starts, "STARTS"); err != nil {
+				return err
+			}
+		}
+		if schedule.Ends != nil {
+			if info.Ends, err = e.evalEventTime(schedule.Ends, "ENDS"); err != nil {
+				return err
+			}
+		}
+	}
+	return info.Validate()
+}
+
+// setEventContext sets the context to run the event to the current session's.
+func (e *SimpleExec) setEventContext(info *event.Info) {
+	vars := e.Ctx().GetSessionVars()
+	info.SQLMode, _ = vars.GetSystemVar(variable.SQLModeVar)
+	info.TimeZone, _ = vars.GetSystemVar(variable.TimeZone)
+	info.CharsetClient, info.CollationConnection = vars.GetCharsetInfo()
+	info.DBCollation, _ = vars.GetSystemVar(variable.CollationDatabase)
+}
+
+// isEventExpired returns whether the event has no runs after now.
+func isEventExpired(info *event.Info, now time.Time) bool {
+	if info.Type == event.TypeOneTime {
+		return info.ExecuteAt.Before(now)
+	}
+	return !info.Ends.IsZero() && info.Ends.Before(now)
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	if isEventStmt(s.Body) {
+		return exeerrors.ErrEventRecursionForbidden
+	}
+	if _, ok := e.is.SchemaByName(s.EventName.Schema); !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	old, err := event.GetEvent(ctx, cli, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now().Truncate(time.Second)
+	info := &event.Info{
+		Schema:      s.EventName.Schema.O,
+		Name:        s.EventName.Name.O,
+		DefinerUser: s.Definer.Username,
+		DefinerHost: s.Definer.Hostname,
+		Body:        s.Body.Text(),
+		Preserve:    s.OnCompletion == ast.EventOnCompletionPreserve,
+		Comment:     s.Comment,
+		Created:     now,
+		LastAltered: now,
+	}
+	if s.Status == ast.EventStatusSlavesideDisabled {
+		info.Status = event.StatusSlavesideDisabled
+	}
+	e.setEventContext(info)
+	if err = e.setEventSchedule(info, s.Schedule, now); err != nil {
+		return err
+	}
+
+	enable := s.Status == ast.EventStatusUnspecified || s.Status == ast.EventStatusEnable
+	if isEventExpired(info, now) {
+		if !info.Preserve {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast)
+			return nil
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		enable = false
+	}
+	return event.CreateEvent(ctx, cli, info, enable)
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	if s.Body != nil && isEventStmt(s.Body) {
+		return exeerrors.ErrEventRecursionForbidden
+	}
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	ev, err := event.GetEvent(ctx, cli, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	}
+
+	if s.NewName != nil {
+		if s.NewName.Schema.L == s.EventName.Schema.L && s.NewName.Name.L == s.EventName.Name.L {
+			return exeerrors.ErrEventSameName
+		}
+		if _, ok := e.is.SchemaByName(s.NewName.Schema); !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.NewName.Schema.O)
+		}
+		existed, err := event.GetEvent(ctx, cli, s.NewName.Schema.O, s.NewName.Name.O)
+		if err != nil {
+			return err
+		}
+		if existed != nil {
+			return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.NewName.Name.O)
+		}
+		ev.Schema, ev.Name = s.NewName.Schema.O, s.NewName.Name.O
+	}
+
+	now := time.Now().Truncate(time.Second)
+	resched := s.Schedule != nil
+	if resched {
+		// The schedule is evaluated in the current time zone.
+		ev.TimeZone, _ = e.Ctx().GetSessionVars().GetSystemVar(variable.TimeZone)
+		if err = e.setEventSchedule(&ev.Info, s.Schedule, now); err != nil {
+			return err
+		}
+	}
+	if s.OnCompletion != ast.EventOnCompletionUnspecified {
+		ev.Preserve = s.OnCompletion == ast.EventOnCompletionPreserve
+	}
+	switch s.Status {
+	case ast.EventStatusEnable:
+		ev.Enable, ev.Status = true, ""
+	case ast.EventStatusDisable:
+		ev.Enable, ev.Status = false, ""
+	case ast.EventStatusSlavesideDisabled:
+		ev.Enable, ev.Status = false, event.StatusSlavesideDisabled
+	}
+	if s.Comment != nil {
+		ev.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		ev.Body = s.Body.Text()
+		e.setEventContext(&ev.Info)
+	}
+
+	if resched && isEventExpired(&ev.Info, now) {
+		if !ev.Preserve {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotAlterInThePast)
+			return nil
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		ev.Enable = false
+	}
+	ev.LastAltered = now
+	return event.AlterEvent(ctx, cli, ev, resched)
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	ev, err := event.GetEvent(ctx, cli, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	return event.DropEvent(ctx, cli, ev)
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	events, err := getVisibleEvents(ctx, e.Ctx(), e.DBName.O)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		loc := ev.Location()
+		e.appendRow([]any{
+			ev.Schema,
+			ev.Name,
+			ev.TimeZone,
+			ev.Definer().String(),
+			ev.Type,
+			eventTime(ev.ExecuteAt, loc),
+			nullIfEmpty(ev.IntervalValue),
+			nullIfEmpty(ev.IntervalField),
+			eventTime(ev.Starts, loc),
+			eventTime(ev.Ends, loc),
+			ev.GetStatus(),
+			0,
+			ev.CharsetClient,
+			ev.CollationConnection,
+			ev.DBCollation,
+		})
+	}
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	events, err := getVisibleEvents(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+	sessLoc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(events))
+	for _, ev := range events {
+		loc := ev.Location()
+		onCompletion := ast.EventOnCompletionNotPreserve
+		if ev.Preserve {
+			onCompletion = ast.EventOnCompletionPreserve
+		}
+		record := types.MakeDatums(
+			infoschema.CatalogVal,               // EVENT_CATALOG
+			ev.Schema,                           // EVENT_SCHEMA
+			ev.Name,                             // EVENT_NAME
+			ev.Definer().String(),               // DEFINER
+			ev.TimeZone,                         // TIME_ZONE
+			"SQL",                               // EVENT_BODY
+			ev.Body,                             // EVENT_DEFINITION
+			ev.Type,                             // EVENT_TYPE
+			eventTime(ev.ExecuteAt, loc),        // EXECUTE_AT
+			nullIfEmpty(ev.IntervalValue),       // INTERVAL_VALUE
+			nullIfEmpty(ev.IntervalField),       // INTERVAL_FIELD
+			ev.SQLMode,                          // SQL_MODE
+			eventTime(ev.Starts, loc),           // STARTS
+			eventTime(ev.Ends, loc),             // ENDS
+			ev.GetStatus(),                      // STATUS
+			onCompletion.String(),               // ON_COMPLETION
+			eventTime(ev.Created, sessLoc),      // CREATED
+			eventTime(ev.LastAltered, sessLoc),  // LAST_ALTERED
+			eventTime(ev.LastExecuted, sessLoc), // LAST_EXECUTED
+			ev.Comment,                          // EVENT_COMMENT
+			0,                                   // ORIGINATOR
+			ev.CharsetClient,                    // CHARACTER_SET_CLIENT
+			ev.CollationConnection,              // COLLATION_CONNECTION
+			ev.DBCollation,                      // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
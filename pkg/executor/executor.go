@@ -61,6 +61,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -278,6 +279,11 @@ type ResumeDDLJobsExec struct {
 	*CommandDDLJobsExec
 }
 
+// AlterDDLJobsExec indicates an Executor for altering the maintenance window of DDL jobs.
+type AlterDDLJobsExec struct {
+	*CommandDDLJobsExec
+}
+
 // ShowNextRowIDExec represents a show the next row ID executor.
 type ShowNextRowIDExec struct {
 	exec.BaseExecutor
@@ -1134,6 +1140,14 @@ type SelectLockExec struct {
 	// due to issues with chunk handling between the TableReaderExecutor and the
 	// SelectReader result.
 	tblID2PhysTblIDColIdx map[int64]int
+
+	// skipLocked indicates the rows locked by other transactions are skipped, which is used by
+	// "SELECT .. FOR UPDATE SKIP LOCKED" and "SELECT .. FOR SHARE SKIP LOCKED".
+	// For these statements, every row is locked before it's returned, and the rows failed to be locked
+	// are filtered out, so the keys are not buffered until the child executor is drained.
+	skipLocked  bool
+	childChunk  *chunk.Chunk
+	childCursor int
 }
 
 // Open implements the Executor Open interface.
@@ -1152,45 +1166,36 @@ func (e *SelectLockExec) Open(ctx context.Context) error {
 			}
 		}
 	}
+	e.skipLocked = e.Lock.LockType == ast.SelectLockForUpdateSkipLocked || e.Lock.LockType == ast.SelectLockForShareSkipLocked
+	if e.skipLocked {
+		e.childChunk = exec.TryNewCacheChunk(e.Children(0))
+		e.childCursor = 0
+	}
 	return e.BaseExecutor.Open(ctx)
 }
 
 // Next implements the Executor Next interface.
 func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	// If there's no handle or it's not a `SELECT FOR UPDATE` statement.
+	needLock := len(e.tblID2Handle) > 0 && plannercore.IsSelectForUpdateLockType(e.Lock.LockType)
+	if needLock && e.skipLocked {
+		return e.nextSkipLocked(ctx, req)
+	}
 	req.GrowAndReset(e.MaxChunkSize())
 	err := exec.Next(ctx, e.Children(0), req)
 	if err != nil {
 		return err
 	}
-	// If there's no handle or it's not a `SELECT FOR UPDATE` statement.
-	if len(e.tblID2Handle) == 0 || (!plannercore.IsSelectForUpdateLockType(e.Lock.LockType)) {
+	if !needLock {
 		return nil
 	}
 
 	if req.NumRows() > 0 {
 		iter := chunk.NewIterator4Chunk(req)
 		for row := iter.Begin(); row != iter.End(); row = iter.Next() {
-			for tblID, cols := range e.tblID2Handle {
-				for _, col := range cols {
-					handle, err := col.BuildHandle(row)
-					if err != nil {
-						return err
-					}
-					physTblID := tblID
-					if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
-						physTblID = row.GetInt64(physTblColIdx)
-						if physTblID == 0 {
-							// select * from t1 left join t2 on t1.c = t2.c for update
-							// The join right side might be added NULL in left join
-							// In that case, physTblID is 0, so skip adding the lock.
-							//
-							// Note, we can't distinguish whether it's the left join case,
-							// or a bug that TiKV return without correct physical ID column.
-							continue
-						}
-					}
-					e.keys = append(e.keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
-				}
+			e.keys, err = e.appendRowKeys(e.keys, row)
+			if err != nil {
+				return err
 			}
 		}
 		return nil
@@ -1212,6 +1217,91 @@ func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return doLockKeys(ctx, e.Ctx(), lockCtx, e.keys...)
 }
 
+// nextSkipLocked locks the rows from the child executor one by one without waiting, and only returns the
+// rows which are locked successfully. The rows locked by other transactions are skipped.
+func (e *SelectLockExec) nextSkipLocked(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for {
+		if e.childCursor >= e.childChunk.NumRows() {
+			err := exec.Next(ctx, e.Children(0), e.childChunk)
+			if err != nil {
+				return err
+			}
+			e.childCursor = 0
+			if e.childChunk.NumRows() == 0 {
+				return nil
+			}
+			for id := range e.tblID2Handle {
+				e.UpdateDeltaForTableID(id)
+			}
+		}
+		for ; e.childCursor < e.childChunk.NumRows(); e.childCursor++ {
+			if req.IsFull() {
+				return nil
+			}
+			row := e.childChunk.GetRow(e.childCursor)
+			keys, err := e.appendRowKeys(nil, row)
+			if err != nil {
+				return err
+			}
+			locked, err := lockKeysSkipLocked(ctx, e.Ctx(), keys)
+			if err != nil {
+				return err
+			}
+			if locked {
+				req.AppendRow(row)
+			}
+		}
+		if req.NumRows() > 0 {
+			return nil
+		}
+	}
+}
+
+// appendRowKeys appends the row keys need to be locked for the row to keys.
+func (e *SelectLockExec) appendRowKeys(keys []kv.Key, row chunk.Row) ([]kv.Key, error) {
+	for tblID, cols := range e.tblID2Handle {
+		for _, col := range cols {
+			handle, err := col.BuildHandle(row)
+			if err != nil {
+				return nil, err
+			}
+			physTblID := tblID
+			if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
+				physTblID = row.GetInt64(physTblColIdx)
+				if physTblID == 0 {
+					// select * from t1 left join t2 on t1.c = t2.c for update
+					// The join right side might be added NULL in left join
+					// In that case, physTblID is 0, so skip adding the lock.
+					//
+					// Note, we can't distinguish whether it's the left join case,
+					// or a bug that TiKV return without correct physical ID column.
+					continue
+				}
+			}
+			keys = append(keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
+		}
+	}
+	return keys, nil
+}
+
+// lockKeysSkipLocked locks the keys of one row without waiting. It returns false instead of an error if any of
+// the keys is locked by other transactions, so that the caller can skip the row. The keys of different rows must
+// not be locked in one call, otherwise a row can't be skipped separately.
+func lockKeysSkipLocked(ctx context.Context, sctx sessionctx.Context, keys []kv.Key) (bool, error) {
+	if len(keys) == 0 {
+		return true, nil
+	}
+	err := LockKeys(ctx, sctx, tikvstore.LockNoWait, keys...)
+	if err != nil {
+		if storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 func newLockCtx(sctx sessionctx.Context, lockWaitTime int64, numKeys int) (*tikvstore.LockCtx, error) {
 	seVars := sctx.GetSessionVars()
 	forUpdateTS, err := sessiontxn.GetTxnManager(sctx).GetStmtForUpdateTS()
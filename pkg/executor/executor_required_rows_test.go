@@ -757,3 +757,45 @@ func (mp *mockPlan) Schema() *expression.Schema {
 func (mp *mockPlan) MemoryUsage() (sum int64) {
 	return
 }
+
+func TestWindowRequiredRows(t *testing.T) {
+	maxChunkSize := defaultCtx().GetSessionVars().MaxChunkSize
+	// The window executors return the rows of each child chunk in one chunk,
+	// regardless of the required rows.
+	testCases := []struct {
+		totalRows    int
+		requiredRows []int
+		expectedRows []int
+	}{
+		{
+			totalRows:    10,
+			requiredRows: []int{1, 3},
+			expectedRows: []int{10, 0},
+		},
+		{
+			totalRows:    maxChunkSize + 10,
+			requiredRows: []int{5, maxChunkSize, maxChunkSize},
+			expectedRows: []int{maxChunkSize, 10, 0},
+		},
+	}
+
+	for _, pipelined := range []bool{false, true} {
+		for _, testCase := range testCases {
+			sctx := defaultCtx()
+			sctx.GetSessionVars().EnablePipelinedWindowExec = pipelined
+			ctx := context.Background()
+			ds := newRequiredRowsDataSource(sctx, testCase.totalRows, nil)
+			exe := buildWindowExecutor(sctx, ast.WindowFuncRowNumber, 1, nil, ds, ds.Schema(), ds.Schema().Columns[1:2], 1, true)
+			require.NoError(t, exe.Open(ctx))
+			chk := exec.NewFirstChunk(exe)
+			for i := range testCase.requiredRows {
+				chk.SetRequiredRows(testCase.requiredRows[i], maxChunkSize)
+				require.NoError(t, exe.Next(ctx, chk))
+				require.Equal(t, testCase.expectedRows[i], chk.NumRows())
+			}
+			require.NoError(t, exe.Close())
+			// The memory tracker of the window partition is detached once the executor is closed.
+			require.Empty(t, sctx.GetSessionVars().StmtCtx.MemTracker.GetChildrenForTest())
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"math/bits"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -456,40 +457,11 @@ func (c *hashRowContainer) PutChunkSelected(chk *chunk.Chunk, selected, ignoreNu
 		return err
 	}
 	numRows := chk.NumRows()
-	c.hCtx.initHash(numRows)
-
-	hCtx := c.hCtx
-	// By now, the combination of 1 and 2 can't take a run at same time.
-	// 1: write the row data of join key to hashVals. (normal EQ key should ignore the null values.) null-EQ for Except statement is an exception.
-	for keyIdx, colIdx := range c.hCtx.keyColIdx {
-		ignoreNull := len(ignoreNulls) > keyIdx && ignoreNulls[keyIdx]
-		err := codec.HashChunkSelected(c.sc.TypeCtx(), hCtx.hashVals, chk, hCtx.allTypes[keyIdx], colIdx, hCtx.buf, hCtx.hasNull, selected, ignoreNull)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	hasNullMark, err := c.hashChunkSelected(c.hCtx, chk, selected, ignoreNulls)
+	if err != nil {
+		return err
 	}
-	// 2: write the row data of NA join key to hashVals. (NA EQ key should collect all rows including null value as one bucket.)
 	isNAAJ := len(c.hCtx.naKeyColIdx) > 0
-	hasNullMark := make([]bool, len(hCtx.hasNull))
-	for keyIdx, colIdx := range c.hCtx.naKeyColIdx {
-		// NAAJ won't ignore any null values, but collect them as one hash bucket.
-		err := codec.HashChunkSelected(c.sc.TypeCtx(), hCtx.hashVals, chk, hCtx.allTypes[keyIdx], colIdx, hCtx.buf, hCtx.hasNull, selected, false)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		// todo: we can collect the bitmap in codec.HashChunkSelected to avoid loop here, but the params modification is quite big.
-		// after fetch one NA column, collect the null value to null bitmap for every row. (use hasNull flag to accelerate)
-		// eg: if a NA Join cols is (a, b, c), for every build row here we maintained a 3-bit map to mark which column are null for them.
-		for rowIdx := 0; rowIdx < numRows; rowIdx++ {
-			if hCtx.hasNull[rowIdx] {
-				hCtx.naColNullBitMap[rowIdx].UnsafeSet(keyIdx)
-				// clean and try fetch next NA join col.
-				hCtx.hasNull[rowIdx] = false
-				// just a mark variable for whether there is a null in at least one NA join column.
-				hasNullMark[rowIdx] = true
-			}
-		}
-	}
 	for i := 0; i < numRows; i++ {
 		if isNAAJ {
 			if selected != nil && !selected[i] {
@@ -519,6 +491,98 @@ func (c *hashRowContainer) PutChunkSelected(chk *chunk.Chunk, selected, ignoreNu
 	return nil
 }
 
+// hashChunkSelected writes the hash values of the join keys of the chunk into hCtx.hashVals, and returns which
+// rows have null values in the NA join keys. It can be called in multiple goroutines with different hCtx.
+func (c *hashRowContainer) hashChunkSelected(hCtx *hashContext, chk *chunk.Chunk, selected, ignoreNulls []bool) (hasNullMark []bool, err error) {
+	numRows := chk.NumRows()
+	hCtx.initHash(numRows)
+
+	// By now, the combination of 1 and 2 can't take a run at same time.
+	// 1: write the row data of join key to hashVals. (normal EQ key should ignore the null values.) null-EQ for Except statement is an exception.
+	for keyIdx, colIdx := range hCtx.keyColIdx {
+		ignoreNull := len(ignoreNulls) > keyIdx && ignoreNulls[keyIdx]
+		err := codec.HashChunkSelected(c.sc.TypeCtx(), hCtx.hashVals, chk, hCtx.allTypes[keyIdx], colIdx, hCtx.buf, hCtx.hasNull, selected, ignoreNull)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// 2: write the row data of NA join key to hashVals. (NA EQ key should collect all rows including null value as one bucket.)
+	hasNullMark = make([]bool, len(hCtx.hasNull))
+	for keyIdx, colIdx := range hCtx.naKeyColIdx {
+		// NAAJ won't ignore any null values, but collect them as one hash bucket.
+		err := codec.HashChunkSelected(c.sc.TypeCtx(), hCtx.hashVals, chk, hCtx.allTypes[keyIdx], colIdx, hCtx.buf, hCtx.hasNull, selected, false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// todo: we can collect the bitmap in codec.HashChunkSelected to avoid loop here, but the params modification is quite big.
+		// after fetch one NA column, collect the null value to null bitmap for every row. (use hasNull flag to accelerate)
+		// eg: if a NA Join cols is (a, b, c), for every build row here we maintained a 3-bit map to mark which column are null for them.
+		for rowIdx := 0; rowIdx < numRows; rowIdx++ {
+			if hCtx.hasNull[rowIdx] {
+				hCtx.naColNullBitMap[rowIdx].UnsafeSet(keyIdx)
+				// clean and try fetch next NA join col.
+				hCtx.hasNull[rowIdx] = false
+				// just a mark variable for whether there is a null in at least one NA join column.
+				hasNullMark[rowIdx] = true
+			}
+		}
+	}
+	return hasNullMark, nil
+}
+
+// hashKeyAndPtr is a row to be put into the partitioned hash table.
+type hashKeyAndPtr struct {
+	key uint64
+	ptr chunk.RowPtr
+}
+
+const hashKeyAndPtrSize = int64(unsafe.Sizeof(hashKeyAndPtr{}))
+
+// partitionedChunk holds the rows of a chunk split by the partitions of the partitioned hash table.
+type partitionedChunk struct {
+	chkIdx uint32
+	// rows[i] are the rows belonging to the i-th partition.
+	rows      [][]hashKeyAndPtr
+	naEntries []*naEntry
+	memUsage  int64
+}
+
+// partitionChunkSelected hashes the join keys of the chkIdx-th chunk which has been added into the rowContainer,
+// and splits the selected rows by the partitions of the hash table. It can be called in multiple goroutines with
+// different hCtx.
+func (c *hashRowContainer) partitionChunkSelected(hCtx *hashContext, chk *chunk.Chunk, chkIdx uint32, selected, ignoreNulls []bool) (*partitionedChunk, error) {
+	hashTable := c.hashTable.(*partitionedHashTable)
+	hasNullMark, err := c.hashChunkSelected(hCtx, chk, selected, ignoreNulls)
+	if err != nil {
+		return nil, err
+	}
+	isNAAJ := len(hCtx.naKeyColIdx) > 0
+	part := &partitionedChunk{
+		chkIdx: chkIdx,
+		rows:   make([][]hashKeyAndPtr, len(hashTable.partitions)),
+	}
+	for i := 0; i < chk.NumRows(); i++ {
+		if selected != nil && !selected[i] {
+			continue
+		}
+		rowPtr := chunk.RowPtr{ChkIdx: chkIdx, RowIdx: uint32(i)}
+		if isNAAJ && hasNullMark[i] {
+			part.naEntries = append(part.naEntries, &naEntry{rowPtr, hCtx.naColNullBitMap[i].Clone()})
+			continue
+		}
+		if !isNAAJ && hCtx.hasNull[i] {
+			continue
+		}
+		key := hCtx.hashVals[i].Sum64()
+		partIdx := hashTable.partitionIdx(key)
+		part.rows[partIdx] = append(part.rows[partIdx], hashKeyAndPtr{key: key, ptr: rowPtr})
+	}
+	for _, rows := range part.rows {
+		part.memUsage += int64(cap(rows)) * hashKeyAndPtrSize
+	}
+	return part, nil
+}
+
 // NumChunks returns the number of chunks in the rowContainer
 func (c *hashRowContainer) NumChunks() int {
 	return c.rowContainer.NumChunks()
@@ -622,7 +686,6 @@ type baseHashTable interface {
 	Iter(func(uint64, *entry))
 }
 
-// TODO (fangzhuhe) remove unsafeHashTable later if it not used anymore
 // unsafeHashTable stores multiple rowPtr of rows for a given key with minimum GC overhead.
 // A given key can store multiple values.
 // It is not thread-safe, should only be used in one goroutine.
@@ -740,3 +803,78 @@ func (ht *concurrentMapHashTable) GetAndCleanMemoryDelta() int64 {
 	}
 	return memDelta
 }
+
+// partitionedHashTable is a hash table radix-partitioned by the high bits of the hash key, each partition is an
+// unsafeHashTable. Different partitions can be built in different goroutines concurrently, but one partition can
+// only be built in one goroutine.
+type partitionedHashTable struct {
+	partitions []*unsafeHashTable
+	shift      uint
+}
+
+// partitionsPerBuildWorker is the number of the partitions per build worker, more partitions than the build
+// workers helps to balance the workload of them.
+const partitionsPerBuildWorker = 4
+
+// newPartitionedHashTable creates a partitionedHashTable for the concurrency build workers.
+func newPartitionedHashTable(concurrency uint) *partitionedHashTable {
+	partitionBits := uint(bits.Len(concurrency*partitionsPerBuildWorker - 1))
+	ht := &partitionedHashTable{
+		partitions: make([]*unsafeHashTable, 1<<partitionBits),
+		shift:      64 - partitionBits,
+	}
+	for i := range ht.partitions {
+		ht.partitions[i] = newUnsafeHashTable(0)
+	}
+	return ht
+}
+
+func (ht *partitionedHashTable) partitionIdx(hashKey uint64) int {
+	return int(hashKey >> ht.shift)
+}
+
+// Put puts the key/rowPtr pairs to the partition of the key.
+func (ht *partitionedHashTable) Put(hashKey uint64, rowPtr chunk.RowPtr) {
+	ht.partitions[ht.partitionIdx(hashKey)].Put(hashKey, rowPtr)
+}
+
+// putPartition puts the rows of the partition in the chunks into the partition. The chunks should be sorted by
+// chkIdx, so the order of the rows with the same key is the same as putting them one by one.
+func (ht *partitionedHashTable) putPartition(partIdx int, chunks []*partitionedChunk) {
+	partition := ht.partitions[partIdx]
+	for _, part := range chunks {
+		for _, row := range part.rows[partIdx] {
+			partition.Put(row.key, row.ptr)
+		}
+	}
+}
+
+// Get gets the values of the "key" and appends them to "values".
+func (ht *partitionedHashTable) Get(hashKey uint64) *entry {
+	return ht.partitions[ht.partitionIdx(hashKey)].Get(hashKey)
+}
+
+// Len returns the number of rowPtrs in the partitionedHashTable.
+func (ht *partitionedHashTable) Len() uint64 {
+	var length uint64
+	for _, partition := range ht.partitions {
+		length += partition.Len()
+	}
+	return length
+}
+
+// GetAndCleanMemoryDelta gets and cleans the memDelta of all the partitions.
+func (ht *partitionedHashTable) GetAndCleanMemoryDelta() int64 {
+	var memDelta int64
+	for _, partition := range ht.partitions {
+		memDelta += partition.GetAndCleanMemoryDelta()
+	}
+	return memDelta
+}
+
+// Iter gets the every value of the hash table.
+func (ht *partitionedHashTable) Iter(traverse func(key uint64, e *entry)) {
+	for _, partition := range ht.partitions {
+		partition.Iter(traverse)
+	}
+}
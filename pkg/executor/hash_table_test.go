@@ -180,3 +180,50 @@ func TestConcurrentMapHashTableMemoryUsage(t *testing.T) {
 	require.Equal(t, mapMemoryExpected+entryMemoryExpected, m.GetAndCleanMemoryDelta())
 	require.Equal(t, int64(0), m.GetAndCleanMemoryDelta())
 }
+
+func TestPartitionedHashTable(t *testing.T) {
+	sctx := mock.NewContext()
+	numRows := 100
+	chk0, colTypes := initBuildChunk(numRows)
+	chk1, _ := initBuildChunk(numRows)
+	newHashContext := func() *hashContext {
+		return &hashContext{
+			allTypes:  colTypes[1:3],
+			keyColIdx: []int{1, 2},
+		}
+	}
+
+	serialRC := newHashRowContainer(sctx, newHashContext(), colTypes)
+	require.NoError(t, serialRC.PutChunk(chk0, nil))
+	require.NoError(t, serialRC.PutChunk(chk1, nil))
+
+	hashTable := newPartitionedHashTable(3)
+	require.Len(t, hashTable.partitions, 16)
+	partitionedRC := newHashRowContainer(sctx, newHashContext(), colTypes)
+	partitionedRC.hashTable = hashTable
+	var chunks []*partitionedChunk
+	// The chunks can be split by different goroutines in any order.
+	for i, chk := range []*chunk.Chunk{chk1, chk0} {
+		chkIdx := uint32(1 - i)
+		part, err := partitionedRC.partitionChunkSelected(newHashContext(), chk, chkIdx, nil, nil)
+		require.NoError(t, err)
+		require.True(t, part.memUsage > 0)
+		chunks = append(chunks, part)
+	}
+	chunks[0], chunks[1] = chunks[1], chunks[0]
+	for i := range hashTable.partitions {
+		hashTable.putPartition(i, chunks)
+	}
+	require.Equal(t, serialRC.Len(), partitionedRC.Len())
+	require.True(t, hashTable.GetAndCleanMemoryDelta() > 0)
+
+	// The partitioned hash table keeps the order of the rows with the same key.
+	serialRC.hashTable.Iter(func(key uint64, e *entry) {
+		partitionedEntry := hashTable.Get(key)
+		for ; e != nil; e, partitionedEntry = e.next, partitionedEntry.next {
+			require.NotNil(t, partitionedEntry)
+			require.Equal(t, e.ptr, partitionedEntry.ptr)
+		}
+		require.Nil(t, partitionedEntry)
+	})
+}
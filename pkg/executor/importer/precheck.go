@@ -44,7 +44,8 @@ var GetEtcdClient = getEtcdClient
 // CheckRequirements checks the requirements for IMPORT INTO.
 // we check the following things here:
 //  1. target table should be empty
-//  2. no CDC or PiTR tasks running
+//  2. changes of target table are not logged for fast refreshed materialized views
+//  3. no CDC or PiTR tasks running
 //
 // todo: check if there's running lightning tasks?
 // we check them one by one, and return the first error we meet.
@@ -57,6 +58,9 @@ func (e *LoadDataController) CheckRequirements(ctx context.Context, conn sqlexec
 	if err := e.checkTableEmpty(ctx, conn); err != nil {
 		return err
 	}
+	if err := e.checkMViewLogs(); err != nil {
+		return err
+	}
 	if err := e.checkCDCPiTRTasks(ctx); err != nil {
 		return err
 	}
@@ -93,6 +97,15 @@ func (e *LoadDataController) checkTableEmpty(ctx context.Context, conn sqlexec.S
 	return nil
 }
 
+// checkMViewLogs checks the target table has no change logs, the imported KV pairs bypass
+// the change logs, so the fast refreshed materialized views on it would miss the rows.
+func (e *LoadDataController) checkMViewLogs() error {
+	if e.Table.Meta().HasMaterializedViewLogs() {
+		return exeerrors.ErrLoadDataPreCheckFailed.FastGenByArgs("target table has fast refreshed materialized views")
+	}
+	return nil
+}
+
 func (*LoadDataController) checkCDCPiTRTasks(ctx context.Context) error {
 	cli, err := GetEtcdClient()
 	if err != nil {
@@ -102,6 +102,18 @@ func TestCheckRequirements(t *testing.T) {
 	// create table again, now checkTableEmpty pass
 	_, err = conn.Execute(ctx, "create table test.t(id int primary key)")
 	require.NoError(t, err)
+	// fast refreshed materialized view on the table
+	_, err = conn.Execute(ctx, "create materialized view test.mv refresh fast as select id, count(*) from test.t group by id")
+	require.NoError(t, err)
+	is = tk.Session().GetDomainInfoSchema().(infoschema.InfoSchema)
+	c.Table, err = is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	err = c.CheckRequirements(ctx, conn)
+	require.ErrorIs(t, err, exeerrors.ErrLoadDataPreCheckFailed)
+	require.ErrorContains(t, err, "materialized views")
+	_, err = conn.Execute(ctx, "drop materialized view test.mv")
+	require.NoError(t, err)
+	c.Table = tableObj
 
 	clientAddr, embedEtcd := createMockETCD(t)
 	require.NotNil(t, embedEtcd)
@@ -16,13 +16,16 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -144,3 +147,56 @@ func (IndexAdviseVarKeyType) String() string {
 
 // IndexAdviseVarKey is a variable key for index advise.
 const IndexAdviseVarKey IndexAdviseVarKeyType = 0
+
+// RecommendIndexExec represents a recommend index executor.
+type RecommendIndexExec struct {
+	exec.BaseExecutor
+
+	SQL     string
+	Options []*ast.RecommendIndexOption
+	opts    *indexadvisor.Options
+	done    bool
+}
+
+// Open implements the Executor Open interface.
+func (e *RecommendIndexExec) Open(ctx context.Context) error {
+	opts, err := indexadvisor.ParseOptions(e.Options)
+	if err != nil {
+		return err
+	}
+	e.opts = opts
+	return e.BaseExecutor.Open(ctx)
+}
+
+// Next implements the Executor Next interface.
+func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	recommendations, err := indexadvisor.AdviseIndexes(ctx, e.Ctx(), e.SQL, e.opts)
+	if err != nil {
+		return err
+	}
+	for _, r := range recommendations {
+		impacted := ""
+		if len(r.TopImpactedQueries) > 0 {
+			data, err := json.Marshal(r.TopImpactedQueries)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			impacted = string(data)
+		}
+		req.AppendString(0, r.Schema)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.Columns, ","))
+		req.AppendString(4, r.Reason)
+		req.AppendString(5, impacted)
+		req.AppendString(6, r.Statement())
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/stretchr/testify/require"
@@ -65,3 +66,24 @@ func TestIndexAdvise(t *testing.T) {
 	require.Equal(t, uint64(4), ia.MaxIndexNum.PerTable)
 	require.Equal(t, uint64(5), ia.MaxIndexNum.PerDB)
 }
+
+func TestRecommendIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, key idx_c(c))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec("insert into t values (?, ?, ?)", i, i, i)
+	}
+	tk.MustExec("analyze table t")
+
+	tk.MustGetErrMsg("recommend index run with max_num_index = 0", "Recommend Index: option max_num_index should be in [1, 64]")
+	tk.MustGetErrMsg("recommend index run with foo = 1", "Recommend Index: unknown option foo")
+
+	rows := tk.MustQuery("recommend index run for 'select a, b from t where a = 1 and b > 10'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"test", "t", "idx_a_b", "a,b"}, rows[0][:4])
+	require.Equal(t, "CREATE INDEX `idx_a_b` ON `test`.`t`(`a`, `b`)", rows[0][6])
+	tk.MustQuery("recommend index run for 'select * from t where c = 1'").Check(testkit.Rows())
+}
@@ -132,6 +132,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
+		case infoschema.TableRoutines:
+			err = e.setDataFromRoutines(ctx, sctx)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -200,6 +204,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromTiDBCheckConstraints(sctx, dbs)
 		case infoschema.TableKeywords:
 			err = e.setDataFromKeywords()
+		case infoschema.TableTiDBAutoAnalyzeQueue:
+			err = e.setDataForAutoAnalyzeQueue(sctx)
+		case infoschema.TableInstancePlanCache:
+			e.setDataForInstancePlanCache(sctx)
+		case infoschema.TableTiDBIndexUsage:
+			e.setDataForIndexUsage(sctx, dbs)
+		case infoschema.ClusterTableTiDBIndexUsage:
+			err = e.setDataForClusterIndexUsage(sctx, dbs)
 		}
 		if err != nil {
 			return nil, err
@@ -522,6 +534,9 @@ func (e *memtableRetriever) setDataFromTables(sctx sessionctx.Context, schemas [
 			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.AllPrivMask) {
 				continue
 			}
+			if table.IsMaterializedViewLog {
+				continue
+			}
 			pkType := "NONCLUSTERED"
 			if !table.IsView() {
 				if table.GetPartitionInfo() != nil {
@@ -3452,6 +3467,120 @@ func (e *memtableRetriever) setDataFromKeywords() error {
 	return nil
 }
 
+// setDataForAutoAnalyzeQueue builds the auto-analyze priority queue and lists the jobs in priority order.
+func (e *memtableRetriever) setDataForAutoAnalyzeQueue(sctx sessionctx.Context) error {
+	statsHandle := domain.GetDomain(sctx).StatsHandle()
+	if statsHandle == nil {
+		return nil
+	}
+	jobs, err := statsHandle.GetAutoAnalyzeQueue()
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	rows := make([][]types.Datum, 0, len(jobs))
+	for _, job := range jobs {
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, job.TableSchema, job.TableName, "", mysql.AllPrivMask) {
+			continue
+		}
+		row := types.MakeDatums(
+			job.TableSchema,                           // TABLE_SCHEMA
+			job.TableName,                             // TABLE_NAME
+			strings.Join(job.Partitions, ","),         // PARTITION_NAMES
+			strings.Join(job.Indexes, ","),            // INDEX_NAMES
+			job.ChangePercentage,                      // CHANGE_PERCENTAGE
+			job.TableSize,                             // TABLE_SIZE
+			int64(job.LastAnalysisDuration.Seconds()), // LAST_ANALYSIS_DURATION
+			job.Weight,                                // WEIGHT
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForInstancePlanCache(sctx sessionctx.Context) {
+	// The cached plans contain the statements of all users.
+	pc := plannercore.GetInstancePlanCache(sctx)
+	if pc == nil || !hasPriv(sctx, mysql.ProcessPriv) {
+		return
+	}
+	entries := pc.Entries()
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(entries))
+	for _, entry := range entries {
+		row := types.MakeDatums(
+			entry.SQLDigest,     // SQL_DIGEST
+			entry.SQLText,       // SQL_TEXT
+			entry.DB,            // DB
+			entry.SchemaVersion, // SCHEMA_VERSION
+			entry.PlanDigest,    // PLAN_DIGEST
+			entry.Binding,       // BINDING
+			entry.MemoryUsage,   // MEMORY_USAGE
+			entry.Hits,          // HITS
+			types.NewTime(types.FromGoTime(entry.CreateTime.In(loc)), mysql.TypeDatetime, types.MaxFsp),     // CREATE_TIME
+			types.NewTime(types.FromGoTime(entry.LastAccessTime.In(loc)), mysql.TypeDatetime, types.MaxFsp), // LAST_ACCESS_TIME
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataForIndexUsage(sctx sessionctx.Context, schemas []*model.DBInfo) {
+	statsHandle := domain.GetDomain(sctx).StatsHandle()
+	if statsHandle == nil {
+		return
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tb := range schema.Tables {
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, tb.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, idxInfo := range tb.Indices {
+				if idxInfo.State != model.StatePublic {
+					continue
+				}
+				usage := statsHandle.GetIndexUsage(tb.ID, idxInfo.ID)
+				var lastAccessTime any
+				if !usage.LastUsedAt.IsZero() {
+					lastAccessTime = types.NewTime(types.FromGoTime(usage.LastUsedAt.In(loc)), mysql.TypeDatetime, 0)
+				}
+				row := types.MakeDatums(
+					schema.Name.O,             // TABLE_SCHEMA
+					tb.Name.O,                 // TABLE_NAME
+					idxInfo.Name.O,            // INDEX_NAME
+					usage.QueryTotal,          // QUERY_TOTAL
+					usage.KvReqTotal,          // KV_REQ_TOTAL
+					usage.RowAccessTotal,      // ROWS_ACCESS_TOTAL
+					usage.PercentageAccess[0], // PERCENTAGE_ACCESS_0
+					usage.PercentageAccess[1], // PERCENTAGE_ACCESS_0_1
+					usage.PercentageAccess[2], // PERCENTAGE_ACCESS_1_10
+					usage.PercentageAccess[3], // PERCENTAGE_ACCESS_10_20
+					usage.PercentageAccess[4], // PERCENTAGE_ACCESS_20_50
+					usage.PercentageAccess[5], // PERCENTAGE_ACCESS_50_100
+					usage.PercentageAccess[6], // PERCENTAGE_ACCESS_100
+					lastAccessTime,            // LAST_ACCESS_TIME
+				)
+				rows = append(rows, row)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataForClusterIndexUsage(sctx sessionctx.Context, schemas []*model.DBInfo) error {
+	e.setDataForIndexUsage(sctx, schemas)
+	rows, err := infoschema.AppendHostInfoToRows(sctx, e.rows)
+	if err != nil {
+		return err
+	}
+	e.rows = rows
+	return nil
+}
+
 func checkRule(rule *label.Rule) (dbName, tableName string, partitionName string, err error) {
 	s := strings.Split(rule.ID, "/")
 	if len(s) < 3 {
@@ -16,10 +16,13 @@ package executor
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"runtime/trace"
+	"slices"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -1132,6 +1135,10 @@ func (e *HashJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 			naKeyColIdx: e.buildWorker.buildNAKeyColIdx,
 		}
 		e.rowContainer = newHashRowContainer(e.Ctx(), hCtx, exec.RetTypes(e.buildWorker.buildSideExec))
+		if e.concurrency > 1 {
+			// the partitions of the hash table are built by multiple build workers concurrently.
+			e.rowContainer.hashTable = newPartitionedHashTable(e.concurrency)
+		}
 		// we shallow copies rowContainer for each probe worker to avoid lock contention
 		for i := uint(0); i < e.concurrency; i++ {
 			if i == 0 {
@@ -1199,7 +1206,6 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) {
 		},
 	)
 
-	// TODO: Parallel build hash table. Currently not support because `unsafeHashTable` is not thread-safe.
 	err := e.buildWorker.buildHashTableForList(buildSideResultCh)
 	if err != nil {
 		e.buildFinished <- errors.Trace(err)
@@ -1236,6 +1242,9 @@ func (w *buildWorker) buildHashTableForList(buildSideResultCh <-chan *chunk.Chun
 		})
 		w.hashJoinCtx.sessCtx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(actionSpill)
 	}
+	if hashTable, ok := rowContainer.hashTable.(*partitionedHashTable); ok {
+		return w.buildPartitionedHashTable(buildSideResultCh, hashTable)
+	}
 	for chk := range buildSideResultCh {
 		if w.hashJoinCtx.finished.Load() {
 			return nil
@@ -1264,6 +1273,166 @@ func (w *buildWorker) buildHashTableForList(buildSideResultCh <-chan *chunk.Chun
 	return nil
 }
 
+// buildTask is a build side chunk which has been added into the row container, it's sent to the build workers to
+// build the partitioned hash table.
+type buildTask struct {
+	chk      *chunk.Chunk
+	chkIdx   uint32
+	selected []bool
+}
+
+// buildPartitionedHashTable builds the partitioned hash table by concurrency build workers in two phases:
+//  1. the chunks are added into the row container in order, then the build workers hash the join keys and
+//     split the rows by the partitions of the hash table concurrently.
+//  2. the build workers put the rows into the partitions concurrently, each partition is built by one worker
+//     in the order of the chunks, so the hash table is the same as the one built serially.
+func (w *buildWorker) buildPartitionedHashTable(buildSideResultCh <-chan *chunk.Chunk, hashTable *partitionedHashTable) error {
+	rowContainer := w.hashJoinCtx.rowContainer
+	memTracker := rowContainer.GetMemTracker()
+	concurrency := int(w.hashJoinCtx.concurrency)
+	taskCh := make(chan *buildTask, concurrency)
+	errCh := make(chan error, concurrency)
+	doneCh := make(chan struct{})
+	var closeDoneCh sync.Once
+	handleErr := func(err error) {
+		errCh <- err
+		closeDoneCh.Do(func() { close(doneCh) })
+	}
+
+	results := make([][]*partitionedChunk, concurrency)
+	elapses := make([]time.Duration, concurrency)
+	var wg util.WaitGroupWrapper
+	for i := 0; i < concurrency; i++ {
+		workerID := i
+		wg.RunWithRecover(func() {
+			var err error
+			results[workerID], elapses[workerID], err = w.partitionBuildSideChunks(taskCh)
+			if err != nil {
+				handleErr(err)
+			}
+		}, func(r interface{}) {
+			if r != nil {
+				handleErr(util.GetRecoverError(r))
+			}
+		})
+	}
+	err := w.dispatchBuildSideChunks(buildSideResultCh, taskCh, doneCh)
+	wg.Wait()
+	var chunks []*partitionedChunk
+	var memUsage int64
+	for _, result := range results {
+		for _, part := range result {
+			memUsage += part.memUsage
+		}
+		chunks = append(chunks, result...)
+	}
+	defer memTracker.Consume(-memUsage)
+	if err != nil {
+		return err
+	}
+	select {
+	case err = <-errCh:
+		return err
+	default:
+	}
+	if w.hashJoinCtx.finished.Load() {
+		return nil
+	}
+
+	start := time.Now()
+	slices.SortFunc(chunks, func(a, b *partitionedChunk) int {
+		return cmp.Compare(a.chkIdx, b.chkIdx)
+	})
+	if rowContainer.hashNANullBucket != nil {
+		for _, part := range chunks {
+			rowContainer.hashNANullBucket.entries = append(rowContainer.hashNANullBucket.entries, part.naEntries...)
+		}
+	}
+	var nextPartIdx atomic.Int64
+	for i := 0; i < concurrency; i++ {
+		wg.RunWithRecover(func() {
+			for {
+				partIdx := int(nextPartIdx.Add(1) - 1)
+				if partIdx >= len(hashTable.partitions) || w.hashJoinCtx.finished.Load() {
+					return
+				}
+				hashTable.putPartition(partIdx, chunks)
+				memTracker.Consume(hashTable.partitions[partIdx].GetAndCleanMemoryDelta())
+			}
+		}, func(r interface{}) {
+			if r != nil {
+				errCh <- util.GetRecoverError(r)
+			}
+		})
+	}
+	wg.Wait()
+	rowContainer.stat.buildTableElapse += slices.Max(elapses) + time.Since(start)
+	select {
+	case err = <-errCh:
+		return err
+	default:
+	}
+	return nil
+}
+
+// dispatchBuildSideChunks adds the build side chunks into the row container and sends them to the build workers.
+func (w *buildWorker) dispatchBuildSideChunks(buildSideResultCh <-chan *chunk.Chunk, taskCh chan<- *buildTask, doneCh <-chan struct{}) error {
+	defer close(taskCh)
+	rowContainer := w.hashJoinCtx.rowContainer
+	for chk := range buildSideResultCh {
+		if w.hashJoinCtx.finished.Load() {
+			return nil
+		}
+		task := &buildTask{chk: chk, chkIdx: uint32(rowContainer.NumChunks())}
+		if err := rowContainer.rowContainer.Add(chk); err != nil {
+			return err
+		}
+		if w.hashJoinCtx.useOuterToBuild {
+			var bitMap = bitmap.NewConcurrentBitmap(chk.NumRows())
+			w.hashJoinCtx.outerMatchedStatus = append(w.hashJoinCtx.outerMatchedStatus, bitMap)
+			w.hashJoinCtx.memTracker.Consume(bitMap.BytesConsumed())
+			if len(w.hashJoinCtx.outerFilter) > 0 {
+				var err error
+				task.selected, err = expression.VectorizedFilter(w.hashJoinCtx.sessCtx, w.hashJoinCtx.outerFilter, chunk.NewIterator4Chunk(chk), nil)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		failpoint.Inject("ConsumeRandomPanic", nil)
+		select {
+		case taskCh <- task:
+		case <-doneCh:
+			return nil
+		}
+	}
+	return nil
+}
+
+// partitionBuildSideChunks hashes the build side chunks and splits the rows by the partitions of the hash table.
+func (w *buildWorker) partitionBuildSideChunks(taskCh <-chan *buildTask) (chunks []*partitionedChunk, elapse time.Duration, _ error) {
+	rowContainer := w.hashJoinCtx.rowContainer
+	hCtx := &hashContext{
+		allTypes:    rowContainer.hCtx.allTypes,
+		keyColIdx:   rowContainer.hCtx.keyColIdx,
+		naKeyColIdx: rowContainer.hCtx.naKeyColIdx,
+	}
+	for task := range taskCh {
+		if w.hashJoinCtx.finished.Load() {
+			continue
+		}
+		start := time.Now()
+		part, err := rowContainer.partitionChunkSelected(hCtx, task.chk, task.chkIdx, task.selected, w.hashJoinCtx.isNullEQ)
+		if err != nil {
+			return chunks, elapse, err
+		}
+		rowContainer.GetMemTracker().Consume(part.memUsage)
+		chunks = append(chunks, part)
+		elapse += time.Since(start)
+	}
+	return chunks, elapse, nil
+}
+
 // NestedLoopApplyExec is the executor for apply.
 type NestedLoopApplyExec struct {
 	exec.BaseExecutor
@@ -0,0 +1,254 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec represents the JSON_TABLE table function executor. It
+// evaluates the JSON document when opened, so it is reopened by Apply for
+// every outer row when JSON_TABLE references the preceding tables.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	expr expression.Expression
+	path *jsonTablePath
+	name string
+
+	typeCtx types.Context
+	rows    [][]types.Datum
+	cursor  int
+}
+
+// jsonTablePath is the executing form of plannercore.JSONTablePath.
+type jsonTablePath struct {
+	pathExpr types.JSONPathExpression
+	columns  []*jsonTableColumn
+	nested   []*jsonTablePath
+}
+
+type jsonTableColumn struct {
+	*plannercore.JSONTableColumn
+
+	pathExpr types.JSONPathExpression
+	tp       *types.FieldType
+}
+
+func buildJSONTablePath(p *plannercore.JSONTablePath, schema *expression.Schema) (*jsonTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	jtPath := &jsonTablePath{pathExpr: pathExpr}
+	for _, col := range p.Columns {
+		c := &jsonTableColumn{JSONTableColumn: col, tp: schema.Columns[col.Offset].RetType}
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			c.pathExpr, err = types.ParseJSONPathExpr(col.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		jtPath.columns = append(jtPath.columns, c)
+	}
+	for _, nested := range p.Nested {
+		n, err := buildJSONTablePath(nested, schema)
+		if err != nil {
+			return nil, err
+		}
+		jtPath.nested = append(jtPath.nested, n)
+	}
+	return jtPath, nil
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(context.Context) error {
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	typeCtx := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+	e.typeCtx = typeCtx.WithFlags(types.StrictFlags)
+	doc, isNull, err := e.expr.EvalJSON(e.Ctx(), chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	row := make([]types.Datum, e.Schema().Len())
+	_, err = e.appendRows(e.path, doc, row)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// appendRows generates the rows of path p from doc. Each value matched by the
+// path produces the rows of its NESTED PATHs one by one, while the columns of
+// the sibling NESTED PATHs are NULL. If none of the NESTED PATHs matches
+// anything, a single row is produced with the nested columns being NULL.
+func (e *JSONTableExec) appendRows(p *jsonTablePath, doc types.BinaryJSON, row []types.Datum) (int, error) {
+	matches := extractJSONTableMatches(doc, p.pathExpr)
+	for i, m := range matches {
+		for _, col := range p.columns {
+			d, err := e.evalColumn(col, m, i+1)
+			if err != nil {
+				return 0, err
+			}
+			row[col.Offset] = d
+		}
+		produced := 0
+		for _, nested := range p.nested {
+			n, err := e.appendRows(nested, m, row)
+			if err != nil {
+				return 0, err
+			}
+			produced += n
+			resetJSONTableColumns(nested, row)
+		}
+		if produced == 0 {
+			e.rows = append(e.rows, append([]types.Datum(nil), row...))
+		}
+	}
+	return len(matches), nil
+}
+
+func resetJSONTableColumns(p *jsonTablePath, row []types.Datum) {
+	for _, col := range p.columns {
+		row[col.Offset].SetNull()
+	}
+	for _, nested := range p.nested {
+		resetJSONTableColumns(nested, row)
+	}
+}
+
+// extractJSONTableMatches returns the values matched by the path. Extract
+// wraps the values into an array when the path may match multiple values.
+func extractJSONTableMatches(doc types.BinaryJSON, pathExpr types.JSONPathExpression) []types.BinaryJSON {
+	val, found := doc.Extract([]types.JSONPathExpression{pathExpr})
+	if !found {
+		return nil
+	}
+	if !pathExpr.CouldMatchMultipleValues() {
+		return []types.BinaryJSON{val}
+	}
+	matches := make([]types.BinaryJSON, 0, val.GetElemCount())
+	for i := 0; i < val.GetElemCount(); i++ {
+		matches = append(matches, val.ArrayGetElem(i))
+	}
+	return matches
+}
+
+func (e *JSONTableExec) evalColumn(col *jsonTableColumn, doc types.BinaryJSON, ordinality int) (types.Datum, error) {
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExistsPath:
+		exists := int64(0)
+		if _, found := doc.Extract([]types.JSONPathExpression{col.pathExpr}); found {
+			exists = 1
+		}
+		d := types.NewIntDatum(exists)
+		return d.ConvertTo(e.typeCtx, col.tp)
+	}
+	val, found := doc.Extract([]types.JSONPathExpression{col.pathExpr})
+	if !found {
+		return e.onResponse(col, col.OnEmpty, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	if col.pathExpr.CouldMatchMultipleValues() && col.tp.GetType() != mysql.TypeJSON {
+		return e.onResponse(col, col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.name))
+	}
+	d, err := e.convertJSON(col, val)
+	if err != nil {
+		return e.onResponse(col, col.OnError, err)
+	}
+	return d, nil
+}
+
+// onResponse handles the ON EMPTY or ON ERROR clause of the column.
+func (e *JSONTableExec) onResponse(col *jsonTableColumn, resp *ast.JSONTableOnResponse, err error) (types.Datum, error) {
+	if resp == nil || resp.Tp == ast.JSONTableOnResponseNull {
+		return types.Datum{}, nil
+	}
+	if resp.Tp == ast.JSONTableOnResponseError {
+		return types.Datum{}, err
+	}
+	val, err := types.ParseBinaryJSONFromString(resp.Default)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return e.convertJSON(col, val)
+}
+
+// convertJSON converts the JSON value to the type of the column.
+func (e *JSONTableExec) convertJSON(col *jsonTableColumn, val types.BinaryJSON) (types.Datum, error) {
+	if col.tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(val), nil
+	}
+	var d types.Datum
+	switch val.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.name)
+	case types.JSONTypeCodeLiteral:
+		switch val.Value[0] {
+		case types.JSONLiteralNil:
+			return d, nil
+		case types.JSONLiteralTrue:
+			d.SetInt64(1)
+		default:
+			d.SetInt64(0)
+		}
+	case types.JSONTypeCodeInt64:
+		d.SetInt64(val.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d.SetUint64(val.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d.SetFloat64(val.GetFloat64())
+	case types.JSONTypeCodeString:
+		d.SetString(string(val.GetString()), col.tp.GetCollate())
+	default:
+		s, err := val.Unquote()
+		if err != nil {
+			return d, err
+		}
+		d.SetString(s, col.tp.GetCollate())
+	}
+	converted, err := d.ConvertTo(e.typeCtx, col.tp)
+	if err != nil && types.ErrOverflow.Equal(err) {
+		return converted, exeerrors.ErrJSONTableValueOutOfRange.GenWithStackByArgs(col.Name.O)
+	}
+	return converted, err
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/mview"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	dom := domain.GetDomain(e.Ctx())
+	if err := dom.DDL().CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	if !e.Ctx().GetSessionVars().StmtCtx.IsDDLJobInQueue {
+		// The view exists and IF NOT EXISTS is specified.
+		return nil
+	}
+
+	is := dom.InfoSchema()
+	tbl, err := is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	view := tbl.Meta()
+	if err = refreshMaterializedView(ctx, &e.BaseExecutor, is, s.ViewName.Schema, view, true); err != nil {
+		return err
+	}
+	if interval := view.MaterializedView.RefreshInterval; interval > 0 {
+		cli, err := getEventTimerClient(e.Ctx())
+		if err != nil {
+			return err
+		}
+		return mview.CreateRefreshTimer(ctx, cli, view.ID, interval, time.Now())
+	}
+	return nil
+}
+
+func (e *DDLExec) executeDropMaterializedView(ctx context.Context, s *ast.DropMaterializedViewStmt) error {
+	tbl, lookupErr := e.is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err := domain.GetDomain(e.Ctx()).DDL().DropMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	// The timer of a view which is dropped in other ways is dropped when it's triggered.
+	if lookupErr != nil || !tbl.Meta().IsMaterializedView() || tbl.Meta().MaterializedView.RefreshInterval == 0 {
+		return nil
+	}
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	return mview.DropRefreshTimer(ctx, cli, tbl.Meta().ID)
+}
+
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	tbl, err := e.is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(s.ViewName.Schema, s.ViewName.Name, "MATERIALIZED VIEW")
+	}
+
+	// Commit the old transaction, like DDL.
+	if err := sessiontxn.NewTxnInStmt(ctx, e.Ctx()); err != nil {
+		return err
+	}
+	defer func() { e.Ctx().GetSessionVars().SetInTxn(false) }()
+	return refreshMaterializedView(ctx, &e.BaseExecutor, e.is, s.ViewName.Schema, tbl.Meta(), s.Complete)
+}
+
+// refreshMaterializedViewSQLs returns the statements to refresh the view. The view is refreshed
+// fast by its change log unless it's refreshed completely or it has no change log.
+func refreshMaterializedViewSQLs(is infoschema.InfoSchema, viewSchema model.CIStr, view *model.TableInfo, complete bool) ([]string, error) {
+	mv := view.MaterializedView
+	cols := make([]model.CIStr, 0, len(view.Columns))
+	for _, col := range view.Cols() {
+		cols = append(cols, col.Name)
+	}
+	var logSchema, logName string
+	if mv.LogTableID != 0 {
+		db, ok := is.SchemaByID(mv.BaseSchemaID)
+		if !ok {
+			return nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(fmt.Sprintf("(Schema ID %d)", mv.BaseSchemaID))
+		}
+		logSchema, logName = db.Name.O, model.MaterializedViewLogTableName(view.ID).O
+	}
+	if complete || logName == "" {
+		return mview.CompleteRefreshSQLs(viewSchema.O, view.Name.O, cols, mv.SelectStmt, logSchema, logName), nil
+	}
+	sel, err := mview.Parse(mv.SelectStmt)
+	if err != nil {
+		return nil, err
+	}
+	def, err := mview.Analyze(sel)
+	if err != nil {
+		return nil, err
+	}
+	return def.FastRefreshSQLs(viewSchema.O, view.Name.O, cols, logSchema, logName), nil
+}
+
+// refreshMaterializedView refreshes the view in an internal transaction. The transaction is
+// optimistic so the change log is read and cleared at the same snapshot.
+func refreshMaterializedView(ctx context.Context, e *exec.BaseExecutor, is infoschema.InfoSchema, viewSchema model.CIStr, view *model.TableInfo, complete bool) error {
+	sqls, err := refreshMaterializedViewSQLs(is, viewSchema, view, complete)
+	if err != nil {
+		return err
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, se)
+
+	sqlExec := se.(sqlexec.SQLExecutor)
+	if _, err = sqlExec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	for _, sql := range sqls {
+		if _, err = sqlExec.ExecuteInternal(ctx, sql); err != nil {
+			return err
+		}
+	}
+	_, err = sqlExec.ExecuteInternal(ctx, "COMMIT")
+	return err
+}
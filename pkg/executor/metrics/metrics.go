@@ -70,6 +70,7 @@ var (
 	ExecutorStreamAggExec                   prometheus.Counter
 	ExecutorCounterSortExec                 prometheus.Counter
 	ExecutorCounterTopNExec                 prometheus.Counter
+	ExecutorCounterPartitionTopNExec        prometheus.Counter
 	ExecutorCounterNestedLoopApplyExec      prometheus.Counter
 	ExecutorCounterIndexLookUpJoin          prometheus.Counter
 	ExecutorCounterIndexLookUpExecutor      prometheus.Counter
@@ -162,6 +163,7 @@ func InitMetricsVars() {
 	ExecutorStreamAggExec = metrics.ExecutorCounter.WithLabelValues("StreamAggExec")
 	ExecutorCounterSortExec = metrics.ExecutorCounter.WithLabelValues("SortExec")
 	ExecutorCounterTopNExec = metrics.ExecutorCounter.WithLabelValues("TopNExec")
+	ExecutorCounterPartitionTopNExec = metrics.ExecutorCounter.WithLabelValues("PartitionTopNExec")
 	ExecutorCounterNestedLoopApplyExec = metrics.ExecutorCounter.WithLabelValues("NestedLoopApplyExec")
 	ExecutorCounterIndexLookUpJoin = metrics.ExecutorCounter.WithLabelValues("IndexLookUpJoin")
 	ExecutorCounterIndexLookUpExecutor = metrics.ExecutorCounter.WithLabelValues("IndexLookUpExecutor")
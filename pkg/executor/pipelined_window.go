@@ -28,12 +28,6 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 )
 
-type dataInfo struct {
-	chk         *chunk.Chunk
-	remaining   uint64
-	accumulated uint64
-}
-
 // PipelinedWindowExec is the executor for window functions.
 type PipelinedWindowExec struct {
 	exec.BaseExecutor
@@ -45,17 +39,16 @@ type PipelinedWindowExec struct {
 	end                *core.FrameBound
 	groupChecker       *vecgroupchecker.VecGroupChecker
 
-	// childResult stores the child chunk. Note that even if remaining is 0, e.rows might still references rows in data[0].chk after returned it to upper executor, since there is no guarantee what the upper executor will do to the returned chunk, it might destroy the data (as in the benchmark test, it reused the chunk to pull data, and it will be chk.Reset(), causing panicking). So dataIdx, accumulated and dropped are added to ensure that chunk will only be returned if there is no row reference.
+	// childResult stores the child chunk.
 	childResult *chunk.Chunk
-	data        []dataInfo
-	dataIdx     int
+	// childColIdxs are the offsets of the child columns in the output.
+	childColIdxs []int
+	// batchSizes are the rows of the child chunks whose results are not
+	// returned yet, see WindowExec.batchSizes.
+	batchSizes []int
 
 	// done indicates the child executor is drained or something unexpected happened.
-	done         bool
-	accumulated  uint64
-	dropped      uint64
-	rowToConsume uint64
-	newPartition bool
+	done bool
 
 	curRowIdx uint64
 	// curStartRow and curEndRow defines the current frame range
@@ -63,14 +56,13 @@ type PipelinedWindowExec struct {
 	lastEndRow     uint64
 	stagedStartRow uint64
 	stagedEndRow   uint64
-	rowStart       uint64
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
 
-	// rows keeps rows starting from curStartRow
-	rows                     []chunk.Row
-	rowCnt                   uint64
+	// partition buffers the rows of the current partition, the rows before the
+	// current frame are dropped once they are not needed any more.
+	partition                *windowPartition
 	whole                    bool
 	isRangeFrame             bool
 	emptyFrame               bool
@@ -79,191 +71,144 @@ type PipelinedWindowExec struct {
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
+	if e.partition != nil {
+		e.partition.close()
+		e.partition = nil
+	}
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
 // Open implements the Executor Open interface
 func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
-	e.rowToConsume = 0
 	e.done = false
-	e.accumulated = 0
-	e.dropped = 0
-	e.data = make([]dataInfo, 0)
-	e.dataIdx = 0
+	e.batchSizes = e.batchSizes[:0]
 	e.slidingWindowFuncs = make([]aggfuncs.SlidingWindowAggFunc, len(e.windowFuncs))
 	for i, windowFunc := range e.windowFuncs {
 		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
 			e.slidingWindowFuncs[i] = slidingWindowAggFunc
 		}
 	}
-	e.rows = make([]chunk.Row, 0)
-	return e.BaseExecutor.Open(ctx)
-}
-
-func (e *PipelinedWindowExec) firstResultChunkNotReady() bool {
-	if !e.done && len(e.data) == 0 {
-		return true
+	if err = e.BaseExecutor.Open(ctx); err != nil {
+		return err
 	}
-	// chunk can't be ready unless, 1. all of the rows in the chunk is filled, 2. e.rows doesn't contain rows in the chunk
-	return len(e.data) > 0 && (e.data[0].remaining != 0 || e.data[0].accumulated > e.dropped)
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	if e.partition == nil {
+		e.partition = newWindowPartition(e.Ctx(), e.ID(), exec.RetTypes(e.Children(0)), e.MaxChunkSize())
+	}
+	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
+	e.childColIdxs = make([]int, 0, len(columns))
+	for _, col := range columns {
+		e.childColIdxs = append(e.childColIdxs, col.Index)
+	}
+	e.reset()
+	return nil
 }
 
 // Next implements the Executor Next interface.
 func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err error) {
 	chk.Reset()
 
-	for e.firstResultChunkNotReady() {
+	for {
+		if len(e.batchSizes) > 0 && chk.NumRows() == e.batchSizes[0] {
+			e.batchSizes = e.batchSizes[1:]
+			break
+		}
 		// we firstly gathering enough rows and consume them, until we are able to produce.
 		// for unbounded frame, it needs consume the whole partition before being able to produce, in this case
-		// e.p.enoughToProduce will be false until so.
+		// enoughToProduce will be false until so.
 		var enough bool
 		enough, err = e.enoughToProduce(e.Ctx())
 		if err != nil {
 			return
 		}
-		if !enough {
-			if !e.done && e.rowToConsume == 0 {
-				err = e.getRowsInPartition(ctx)
-				if err != nil {
-					return err
-				}
-			}
-			if e.done || e.newPartition {
-				e.finish()
-				// if we continued, the rows will not be consumed, so next time we should consume it instead of calling e.getRowsInPartition
-				enough, err = e.enoughToProduce(e.Ctx())
-				if err != nil {
-					return
-				}
-				if enough {
-					continue
-				}
-				e.newPartition = false
-				e.reset()
-				if e.rowToConsume == 0 {
-					// no more data
-					break
-				}
-			}
-			e.rowCnt += e.rowToConsume
-			e.rowToConsume = 0
-		}
-
-		// e.p is ready to produce data
-		if len(e.data) > e.dataIdx && e.data[e.dataIdx].remaining != 0 {
-			produced, err := e.produce(e.Ctx(), e.data[e.dataIdx].chk, e.data[e.dataIdx].remaining)
+		if enough {
+			err = e.produce(e.Ctx(), chk, uint64(e.batchSizes[0]-chk.NumRows()))
 			if err != nil {
-				return err
+				return
 			}
-			e.data[e.dataIdx].remaining -= produced
-			if e.data[e.dataIdx].remaining == 0 {
-				e.dataIdx++
+			continue
+		}
+		if e.whole {
+			// all the rows of the partition have been produced.
+			if e.done {
+				break
 			}
+			e.reset()
+			continue
+		}
+		err = e.getRowsInPartition(ctx)
+		if err != nil {
+			return err
 		}
-	}
-	if len(e.data) > 0 {
-		chk.SwapColumns(e.data[0].chk)
-		e.data = e.data[1:]
-		e.dataIdx--
 	}
 	return nil
 }
 
+// getRowsInPartition appends the rows of the next group to the partition if
+// it belongs to the current partition, otherwise marks the partition whole.
 func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
-	e.newPartition = true
-	if len(e.rows) == 0 {
-		// if getRowsInPartition is called for the first time, we ignore it as a new partition
-		e.newPartition = false
-	}
-
 	if e.groupChecker.IsExhausted() {
 		var drained, samePartition bool
 		drained, err = e.fetchChild(ctx)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		// we return immediately to use a combination of true newPartition but 0 in e.rowToConsume to indicate the data source is drained,
 		if drained {
 			e.done = true
+			e.whole = true
 			return nil
 		}
 		samePartition, err = e.groupChecker.SplitIntoGroups(e.childResult)
-		if samePartition {
-			// the only case that when getRowsInPartition gets called, it is not a new partition.
-			e.newPartition = false
-		}
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if !samePartition && e.partition.numRows > 0 {
+			e.whole = true
+			return nil
+		}
 	}
 	begin, end := e.groupChecker.GetNextGroup()
-	e.rowToConsume += uint64(end - begin)
-	for i := begin; i < end; i++ {
-		e.rows = append(e.rows, e.childResult.GetRow(i))
+	err = e.partition.appendRows(e.childResult, begin, end)
+	if err != nil {
+		return err
 	}
-	return
+	if end != e.childResult.NumRows() {
+		e.whole = true
+	}
+	return nil
 }
 
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
-	// TODO: reuse chunks
-	childResult := exec.TryNewCacheChunk(e.Children(0))
-	err = exec.Next(ctx, e.Children(0), childResult)
+	err = exec.Next(ctx, e.Children(0), e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
+	if e.childResult.NumRows() == 0 {
 		return true, nil
 	}
-
-	// TODO: reuse chunks
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
-	e.accumulated += uint64(numRows)
-	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
-
-	e.childResult = childResult
+	e.batchSizes = append(e.batchSizes, e.childResult.NumRows())
 	return false, nil
 }
 
-func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
-	return e.rows[i-e.rowStart]
-}
-
-func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
-	return e.rows[start-e.rowStart : end-e.rowStart]
-}
-
-// finish is called upon a whole partition is consumed
-func (e *PipelinedWindowExec) finish() {
-	e.whole = true
-}
-
 func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	if e.start.UnBounded {
 		return 0, nil
 	}
 	if e.isRangeFrame {
 		var start uint64
-		for start = max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
+		curRow, err := e.partition.getRow(e.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
+		for start = max(e.lastStartRow, e.stagedStartRow); start < e.partition.numRows; start++ {
+			row, err := e.partition.getRow(start)
+			if err != nil {
+				return 0, err
+			}
 			var res int64
-			var err error
 			for i := range e.orderByCols {
-				res, _, err = e.start.CmpFuncs[i](ctx, e.start.CompareCols[i], e.start.CalcFuncs[i], e.getRow(start), e.getRow(e.curRowIdx))
+				res, _, err = e.start.CmpFuncs[i](ctx, e.start.CompareCols[i], e.start.CalcFuncs[i], row, curRow)
 				if err != nil {
 					return 0, err
 				}
@@ -295,15 +240,22 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 
 func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	if e.end.UnBounded {
-		return e.rowCnt, nil
+		return e.partition.numRows, nil
 	}
 	if e.isRangeFrame {
 		var end uint64
-		for end = max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
+		curRow, err := e.partition.getRow(e.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
+		for end = max(e.lastEndRow, e.stagedEndRow); end < e.partition.numRows; end++ {
+			row, err := e.partition.getRow(end)
+			if err != nil {
+				return 0, err
+			}
 			var res int64
-			var err error
 			for i := range e.orderByCols {
-				res, _, err = e.end.CmpFuncs[i](ctx, e.end.CalcFuncs[i], e.end.CompareCols[i], e.getRow(e.curRowIdx), e.getRow(end))
+				res, _, err = e.end.CmpFuncs[i](ctx, e.end.CalcFuncs[i], e.end.CompareCols[i], curRow, row)
 				if err != nil {
 					return 0, err
 				}
@@ -333,14 +285,15 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	}
 }
 
-// produce produces rows and append it to chk, return produced means number of rows appended into chunk, available means
-// number of rows processed but not fetched
-func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk, remained uint64) (produced uint64, err error) {
+// produce produces at most remained rows and appends them to chk.
+func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk, remained uint64) (err error) {
 	var (
 		start  uint64
 		end    uint64
+		row    chunk.Row
 		enough bool
 	)
+	numRows := e.partition.numRows
 	for remained > 0 {
 		enough, err = e.enoughToProduce(ctx)
 		if err != nil {
@@ -357,12 +310,17 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		if err != nil {
 			return
 		}
-		if end > e.rowCnt {
-			end = e.rowCnt
+		if end > numRows {
+			end = numRows
 		}
-		if start >= e.rowCnt {
-			start = e.rowCnt
+		if start >= numRows {
+			start = numRows
 		}
+		row, err = e.partition.getRow(e.curRowIdx)
+		if err != nil {
+			return
+		}
+		chk.AppendPartialRowByColIdxs(0, row, e.childColIdxs)
 		// if start >= end, we should return a default value, and we reset the frame to empty.
 		if start >= end {
 			for i, wf := range e.windowFuncs {
@@ -384,17 +342,11 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 				slidingWindowAggFunc := e.slidingWindowFuncs[i]
 				if e.lastStartRow != start || e.lastEndRow != end {
 					if slidingWindowAggFunc != nil && e.initializedSlidingWindow {
-						err = slidingWindowAggFunc.Slide(ctx, e.getRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
+						err = e.partition.slide(ctx, slidingWindowAggFunc, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
 					} else {
-						// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
-						// whether elements inside deque are out of current window.
-						if minMaxSlidingWindowAggFunc, ok := wf.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-							// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
-							minMaxSlidingWindowAggFunc.SetWindowStart(start)
-						}
 						// TODO(zhifeng): track memory usage here
 						wf.ResetPartialResult(e.partialResults[i])
-						_, err = wf.UpdatePartialResult(ctx, e.getRows(start, end), e.partialResults[i])
+						err = e.partition.updatePartialResult(ctx, wf, e.partialResults[i], start, end)
 					}
 				}
 				if err != nil {
@@ -410,21 +362,15 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		e.curRowIdx++
 		e.lastStartRow, e.lastEndRow = start, end
 
-		produced++
 		remained--
 	}
-	extend := min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
-	if extend > e.rowStart {
-		numDrop := extend - e.rowStart
-		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
-		e.rowStart = extend
-	}
+	e.partition.dropBefore(min(e.curRowIdx, e.lastEndRow, e.lastStartRow))
 	return
 }
 
 func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bool, err error) {
-	if e.curRowIdx >= e.rowCnt {
+	numRows := e.partition.numRows
+	if e.curRowIdx >= numRows {
 		return false, nil
 	}
 	if e.whole {
@@ -438,7 +384,7 @@ func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bo
 	if err != nil {
 		return
 	}
-	return end < e.rowCnt && start < e.rowCnt, nil
+	return end < numRows && start < numRows, nil
 }
 
 // reset resets the processor
@@ -450,11 +396,7 @@ func (e *PipelinedWindowExec) reset() {
 	e.emptyFrame = false
 	e.curRowIdx = 0
 	e.whole = false
-	numDrop := e.rowCnt - e.rowStart
-	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
-	e.rowStart = 0
-	e.rowCnt = 0
+	e.partition.reset()
 	e.initializedSlidingWindow = false
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -86,6 +87,7 @@ func (b *executorBuilder) buildPointGet(p *plannercore.PointGetPlan) exec.Execut
 	if p.IndexInfo != nil {
 		sctx := b.ctx.GetSessionVars().StmtCtx
 		sctx.IndexNames = append(sctx.IndexNames, p.TblInfo.Name.O+":"+p.IndexInfo.Name.O)
+		sctx.AppendUsedIndex(p.TblInfo.ID, p.IndexInfo.ID)
 	}
 
 	failpoint.Inject("assertPointReplicaOption", func(val failpoint.Value) {
@@ -132,6 +134,7 @@ type PointGetExecutor struct {
 	done             bool
 	lock             bool
 	lockWaitTime     int64
+	skipLocked       bool
 	rowDecoder       *rowcodec.ChunkDecoder
 
 	columns []*model.ColumnInfo
@@ -156,10 +159,12 @@ func (e *PointGetExecutor) Init(p *plannercore.PointGetPlan) {
 	if e.tblInfo.TempTableType == model.TempTableNone {
 		e.lock = p.Lock
 		e.lockWaitTime = p.LockWaitTime
+		e.skipLocked = p.LockSkipLocked
 	} else {
 		// Temporary table should not do any lock operations
 		e.lock = false
 		e.lockWaitTime = 0
+		e.skipLocked = false
 	}
 	e.rowDecoder = decoder
 	e.partitionDef = p.PartitionDef
@@ -212,6 +217,16 @@ func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
 	}
 	e.done = true
 
+	err := e.getRow(ctx, req)
+	// For `SELECT .. FOR UPDATE SKIP LOCKED`, the row locked by others is skipped.
+	if err != nil && e.skipLocked && storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+		req.Reset()
+		return nil
+	}
+	return err
+}
+
+func (e *PointGetExecutor) getRow(ctx context.Context, req *chunk.Chunk) error {
 	var tblID int64
 	var err error
 	if e.partitionDef != nil {
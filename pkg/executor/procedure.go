@@ -0,0 +1,563 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
+)
+
+// StoredProcedure is a stored procedure saved in the mysql.routines table.
+type StoredProcedure struct {
+	Schema              string
+	Name                string
+	ParamList           string
+	Body                string
+	Definer             string
+	SQLMode             string
+	CharsetClient       string
+	CollationConnection string
+	DBCollation         string
+	// Created and LastAltered are in UTC.
+	Created     types.Time
+	LastAltered types.Time
+}
+
+// createSQL returns the CREATE PROCEDURE statement of the procedure, the
+// identifiers are quoted with backticks which work in all SQL modes.
+func (p *StoredProcedure) createSQL() string {
+	return fmt.Sprintf("CREATE PROCEDURE %s(%s)\n%s", stringutil.Escape(p.Name, mysql.ModeNone), p.ParamList, p.Body)
+}
+
+// DefinerIdentity returns the account of the definer, the procedure is executed with its privileges.
+// Nil is returned if the procedure is created without a user, e.g. by an internal session.
+func (p *StoredProcedure) DefinerIdentity() *auth.UserIdentity {
+	idx := strings.LastIndexByte(p.Definer, '@')
+	if idx < 0 {
+		return nil
+	}
+	return &auth.UserIdentity{
+		Username:     p.Definer[:idx],
+		Hostname:     p.Definer[idx+1:],
+		AuthUsername: p.Definer[:idx],
+		AuthHostname: p.Definer[idx+1:],
+	}
+}
+
+// Parse parses the procedure in the SQL mode it was created in.
+func (p *StoredProcedure) Parse(sctx sessionctx.Context) (*ast.ProcedureInfo, error) {
+	sqlMode, err := mysql.GetSQLMode(p.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	procParser := parser.New()
+	procParser.SetSQLMode(sqlMode)
+	procParser.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	stmt, err := procParser.ParseOneStmt(p.createSQL(), p.CharsetClient, p.CollationConnection)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := stmt.(*ast.ProcedureInfo)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of procedure %s.%s", p.Schema, p.Name)
+	}
+	return info, nil
+}
+
+const selectProceduresSQL = `SELECT routine_schema, routine_name, param_list, body, definer, sql_mode,
+	character_set_client, collation_connection, db_collation,
+	CONVERT_TZ(created, @@TIME_ZONE, '+00:00'), CONVERT_TZ(last_altered, @@TIME_ZONE, '+00:00')
+	FROM mysql.routines WHERE routine_type = 'PROCEDURE'`
+
+func loadStoredProcedures(ctx context.Context, sctx sessionctx.Context, cond string, args ...any) ([]*StoredProcedure, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, selectProceduresSQL+cond+" ORDER BY routine_schema, routine_name", args...)
+	if err != nil {
+		return nil, err
+	}
+	procs := make([]*StoredProcedure, 0, len(rows))
+	for _, row := range rows {
+		procs = append(procs, &StoredProcedure{
+			Schema:              row.GetString(0),
+			Name:                row.GetString(1),
+			ParamList:           row.GetString(2),
+			Body:                row.GetString(3),
+			Definer:             row.GetString(4),
+			SQLMode:             row.GetString(5),
+			CharsetClient:       row.GetString(6),
+			CollationConnection: row.GetString(7),
+			DBCollation:         row.GetString(8),
+			Created:             row.GetTime(9),
+			LastAltered:         row.GetTime(10),
+		})
+	}
+	return procs, nil
+}
+
+// GetStoredProcedure returns the stored procedure, nil is returned if it doesn't exist.
+// The schema and the name of procedures are case-insensitive.
+func GetStoredProcedure(ctx context.Context, sctx sessionctx.Context, schema, name string) (*StoredProcedure, error) {
+	procs, err := loadStoredProcedures(ctx, sctx, " AND LOWER(routine_schema) = %? AND LOWER(routine_name) = %?",
+		strings.ToLower(schema), strings.ToLower(name))
+	if err != nil || len(procs) == 0 {
+		return nil, err
+	}
+	return procs[0], nil
+}
+
+// getVisibleProcedures returns the procedures in the schemas that the current
+// user has any routine privileges on.
+func getVisibleProcedures(ctx context.Context, sctx sessionctx.Context) ([]*StoredProcedure, error) {
+	procs, err := loadStoredProcedures(ctx, sctx, "")
+	if err != nil {
+		return nil, err
+	}
+	visible := procs[:0]
+	for _, p := range procs {
+		if isProcedureVisible(sctx, p.Schema) {
+			visible = append(visible, p)
+		}
+	}
+	return visible, nil
+}
+
+func isProcedureVisible(sctx sessionctx.Context, schema string) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil {
+		return true
+	}
+	activeRoles := sctx.GetSessionVars().ActiveRoles
+	for _, priv := range []mysql.PrivilegeType{mysql.ExecutePriv, mysql.CreateRoutinePriv, mysql.AlterRoutinePriv} {
+		if checker.RequestVerification(activeRoles, strings.ToLower(schema), "", "", priv) {
+			return true
+		}
+	}
+	return false
+}
+
+// procedureTime converts the UTC time to a datetime in the session time zone.
+func procedureTime(sctx sessionctx.Context, t types.Time) (types.Time, error) {
+	gt, err := t.GoTime(time.UTC)
+	if err != nil {
+		return types.ZeroDatetime, err
+	}
+	return types.NewTime(types.FromGoTime(gt.In(sctx.GetSessionVars().Location())), mysql.TypeDatetime, 0), nil
+}
+
+// procedureChecker checks the procedure body when creating it, so that the
+// undefined labels, cursors and variables are reported before the procedure
+// is called.
+type procedureChecker struct {
+	// scopes are the variables and cursors declared in the blocks, the first
+	// scope is the parameters.
+	scopes []procedureCheckScope
+	labels []procedureCheckLabel
+}
+
+type procedureCheckScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+type procedureCheckLabel struct {
+	name   string
+	isLoop bool
+}
+
+func checkProcedure(info *ast.ProcedureInfo) error {
+	params := make(map[string]struct{}, len(info.ProcedureParam))
+	for _, param := range info.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params[name] = struct{}{}
+	}
+	c := &procedureChecker{scopes: []procedureCheckScope{{vars: params}}}
+	return c.checkStmt(info.ProcedureBody)
+}
+
+func (c *procedureChecker) hasVar(name string) bool {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].vars[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *procedureChecker) hasCursor(name string) bool {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].cursors[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkLabeled(name string, isLoop bool, labelErr bool, end string, stmt ast.StmtNode) error {
+	if labelErr {
+		return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+	}
+	for _, l := range c.labels {
+		if strings.EqualFold(l.name, name) {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, procedureCheckLabel{name: name, isLoop: isLoop})
+	defer func() {
+		c.labels = c.labels[:len(c.labels)-1]
+	}()
+	return c.checkStmt(stmt)
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		return c.checkLabeled(x.LabelName, false, x.LabelError, x.LabelEnd, x.Block)
+	case *ast.ProcedureLabelLoop:
+		return c.checkLabeled(x.LabelName, true, x.LabelError, x.LabelEnd, x.Block)
+	case *ast.ProcedureJump:
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			l := c.labels[i]
+			if strings.EqualFold(l.name, x.Name) && (x.IsLeave || l.isLoop) {
+				return nil
+			}
+		}
+		if x.IsLeave {
+			return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("LEAVE", x.Name)
+		}
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("ITERATE", x.Name)
+	case *ast.ProcedureIfInfo:
+		return c.checkIf(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if !c.hasVar(name) {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	if !c.hasCursor(name) {
+		return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkIf(block *ast.ProcedureIfBlock) error {
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIf(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	scope := procedureCheckScope{vars: make(map[string]struct{}), cursors: make(map[string]struct{})}
+	c.scopes = append(c.scopes, scope)
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	conditions := make(map[string]struct{})
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			for _, name := range x.DeclNames {
+				if _, ok := scope.vars[name]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[name] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if _, ok := scope.cursors[x.CurName]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			scope.cursors[x.CurName] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			for _, cond := range x.ErrorCon {
+				var key string
+				switch y := cond.(type) {
+				case *ast.ProcedureErrorVal:
+					key = fmt.Sprintf("code:%d", y.ErrorNum)
+				case *ast.ProcedureErrorState:
+					if !isValidHandlerSQLState(y.CodeStatus) {
+						return exeerrors.ErrSpBadSQLstate.GenWithStackByArgs(y.CodeStatus)
+					}
+					key = "state:" + y.CodeStatus
+				case *ast.ProcedureErrorCon:
+					key = fmt.Sprintf("class:%d", y.ErrorCon)
+				}
+				if _, ok := conditions[key]; ok {
+					return exeerrors.ErrSpDupHandler
+				}
+				conditions[key] = struct{}{}
+			}
+			if err := c.checkStmt(x.Operate); err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+// isValidHandlerSQLState checks the SQLSTATE value of a handler, which has 5
+// characters and doesn't indicate success.
+func isValidHandlerSQLState(state string) bool {
+	if len(state) != 5 || strings.HasPrefix(state, "00") {
+		return false
+	}
+	for _, c := range state {
+		if !(c >= '0' && c <= '9') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	if err := checkProcedure(s); err != nil {
+		return err
+	}
+	if _, ok := e.is.SchemaByName(s.ProcedureName.Schema); !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ProcedureName.Schema.O)
+	}
+	old, err := GetStoredProcedure(ctx, e.Ctx(), s.ProcedureName.Schema.O, s.ProcedureName.Name.O)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs("PROCEDURE", s.ProcedureName.Name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	vars := e.Ctx().GetSessionVars()
+	var definer string
+	if vars.User != nil {
+		definer = vars.User.String()
+	}
+	sqlMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	charsetClient, collationConnection := vars.GetCharsetInfo()
+	dbCollation, _ := vars.GetSystemVar(variable.CollationDatabase)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err = e.Ctx().(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		`INSERT INTO mysql.routines (routine_schema, routine_name, routine_type, param_list, body, definer, sql_mode,
+		character_set_client, collation_connection, db_collation) VALUES (%?, %?, 'PROCEDURE', %?, %?, %?, %?, %?, %?, %?)`,
+		s.ProcedureName.Schema.O, s.ProcedureName.Name.O, s.ProcedureParamStr, s.ProcedureBody.Text(), definer, sqlMode,
+		charsetClient, collationConnection, dbCollation)
+	return err
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	proc, err := GetStoredProcedure(ctx, e.Ctx(), s.ProcedureName.Schema.O, s.ProcedureName.Name.O)
+	if err != nil {
+		return err
+	}
+	if proc == nil {
+		err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", s.ProcedureName.Schema.O+"."+s.ProcedureName.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err = e.Ctx().(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		"DELETE FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = 'PROCEDURE'",
+		proc.Schema, proc.Name)
+	return err
+}
+
+// dropProceduresInSchema drops the procedures in the dropped schema.
+func dropProceduresInSchema(sctx sessionctx.Context, schema string) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	_, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		"DELETE FROM mysql.routines WHERE LOWER(routine_schema) = %?", strings.ToLower(schema))
+	return err
+}
+
+func (e *ShowExec) fetchShowCreateProcedure(ctx context.Context) error {
+	name := e.Table
+	proc, err := GetStoredProcedure(ctx, e.Ctx(), name.Schema.O, name.Name.O)
+	if err != nil {
+		return err
+	}
+	if proc == nil || !isProcedureVisible(e.Ctx(), proc.Schema) {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", name.Name.O)
+	}
+	e.appendRow([]any{
+		proc.Name,
+		proc.SQLMode,
+		proc.createSQL(),
+		proc.CharsetClient,
+		proc.CollationConnection,
+		proc.DBCollation,
+	})
+	return nil
+}
+
+func (e *ShowExec) fetchShowProcedureStatus(ctx context.Context) error {
+	procs, err := getVisibleProcedures(ctx, e.Ctx())
+	if err != nil {
+		return err
+	}
+	for _, proc := range procs {
+		created, err := procedureTime(e.Ctx(), proc.Created)
+		if err != nil {
+			return err
+		}
+		modified, err := procedureTime(e.Ctx(), proc.LastAltered)
+		if err != nil {
+			return err
+		}
+		e.appendRow([]any{
+			proc.Schema,
+			proc.Name,
+			"PROCEDURE",
+			proc.Definer,
+			modified,
+			created,
+			procedureSecurityType,
+			"",
+			proc.CharsetClient,
+			proc.CollationConnection,
+			proc.DBCollation,
+		})
+	}
+	return nil
+}
+
+// procedureSecurityType is the security type of procedures, the statements
+// in procedures are executed with the privileges of the caller.
+const procedureSecurityType = "INVOKER"
+
+func (e *memtableRetriever) setDataFromRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	procs, err := getVisibleProcedures(ctx, sctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(procs))
+	for _, proc := range procs {
+		created, err := procedureTime(sctx, proc.Created)
+		if err != nil {
+			return err
+		}
+		lastAltered, err := procedureTime(sctx, proc.LastAltered)
+		if err != nil {
+			return err
+		}
+		record := types.MakeDatums(
+			proc.Name,                // SPECIFIC_NAME
+			infoschema.CatalogVal,    // ROUTINE_CATALOG
+			proc.Schema,              // ROUTINE_SCHEMA
+			proc.Name,                // ROUTINE_NAME
+			"PROCEDURE",              // ROUTINE_TYPE
+			"",                       // DATA_TYPE
+			nil,                      // CHARACTER_MAXIMUM_LENGTH
+			nil,                      // CHARACTER_OCTET_LENGTH
+			nil,                      // NUMERIC_PRECISION
+			nil,                      // NUMERIC_SCALE
+			nil,                      // DATETIME_PRECISION
+			nil,                      // CHARACTER_SET_NAME
+			nil,                      // COLLATION_NAME
+			nil,                      // DTD_IDENTIFIER
+			"SQL",                    // ROUTINE_BODY
+			proc.Body,                // ROUTINE_DEFINITION
+			nil,                      // EXTERNAL_NAME
+			"SQL",                    // EXTERNAL_LANGUAGE
+			"SQL",                    // PARAMETER_STYLE
+			"NO",                     // IS_DETERMINISTIC
+			"CONTAINS SQL",           // SQL_DATA_ACCESS
+			nil,                      // SQL_PATH
+			procedureSecurityType,    // SECURITY_TYPE
+			created,                  // CREATED
+			lastAltered,              // LAST_ALTERED
+			proc.SQLMode,             // SQL_MODE
+			"",                       // ROUTINE_COMMENT
+			proc.Definer,             // DEFINER
+			proc.CharsetClient,       // CHARACTER_SET_CLIENT
+			proc.CollationConnection, // COLLATION_CONNECTION
+			proc.DBCollation,         // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
@@ -15,37 +15,93 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
+	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
 )
 
+const (
+	selectIntoFormatCSV     = "csv"
+	selectIntoFormatParquet = "parquet"
+
+	selectIntoCompressOption    = "compress"
+	selectIntoMaxFileSizeOption = "max_file_size"
+
+	// selectIntoTarget is used in ErrLoadDataInvalidURI and ErrLoadDataCantAccess.
+	selectIntoTarget = "select into outfile"
+	// selectIntoChunkSize is the buffer size used when writing to a local file.
+	selectIntoChunkSize = 64 * 1024
+)
+
+// selectIntoOptions is the set of supported options, value is true if the
+// option requires a value.
+var selectIntoOptions = map[string]bool{
+	selectIntoCompressOption:    true,
+	selectIntoMaxFileSizeOption: true,
+}
+
+var selectIntoCompressTypes = map[string]storage.CompressType{
+	"none":   storage.NoCompression,
+	"gzip":   storage.Gzip,
+	"snappy": storage.Snappy,
+	"zstd":   storage.Zstd,
+}
+
+var selectIntoParquetCodecs = map[storage.CompressType]parquet.CompressionCodec{
+	storage.NoCompression: parquet.CompressionCodec_UNCOMPRESSED,
+	storage.Gzip:          parquet.CompressionCodec_GZIP,
+	storage.Snappy:        parquet.CompressionCodec_SNAPPY,
+	storage.Zstd:          parquet.CompressionCodec_ZSTD,
+}
+
 // SelectIntoExec represents a SelectInto executor.
 type SelectIntoExec struct {
 	exec.BaseExecutor
-	intoOpt *ast.SelectIntoOption
+	intoOpt     *ast.SelectIntoOption
+	options     []*core.LoadDataOpt
+	outputNames types.NameSlice
 	core.LineFieldsInfo
 
-	lineBuf   []byte
-	realBuf   []byte
-	fieldBuf  []byte
-	escapeBuf []byte
-	enclosed  bool
-	writer    *bufio.Writer
-	dstFile   *os.File
-	chk       *chunk.Chunk
-	started   bool
+	format       string
+	compressType storage.CompressType
+	// maxFileSize is the size of the uncompressed data after which a new file
+	// is started, 0 means the output is never split.
+	maxFileSize int64
+	// store is nil when writing to the local disk of the TiDB node.
+	store    storage.ExternalStorage
+	fileName string
+	fileIdx  int
+	fileSize int64
+
+	lineBuf       []byte
+	realBuf       []byte
+	fieldBuf      []byte
+	escapeBuf     []byte
+	enclosed      bool
+	writer        storage.ExternalFileWriter
+	parquetWriter *writer.CSVWriter
+	parquetMeta   []string
+	chk           *chunk.Chunk
+	started       bool
 }
 
 // Open implements the Executor Open interface.
@@ -54,15 +110,16 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
-
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
-	if err != nil {
-		return errors.Trace(err)
+	if err := s.initOptions(); err != nil {
+		return err
+	}
+	if err := s.initStorage(ctx); err != nil {
+		return err
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
+	if err := s.openFile(ctx); err != nil {
+		return err
+	}
 	s.chk = exec.TryNewCacheChunk(s.Children(0))
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -70,6 +127,214 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	return s.BaseExecutor.Open(ctx)
 }
 
+func (s *SelectIntoExec) initOptions() error {
+	s.format = selectIntoFormatCSV
+	if s.intoOpt.Format != nil {
+		s.format = strings.ToLower(*s.intoOpt.Format)
+	}
+	switch s.format {
+	case selectIntoFormatCSV:
+	case selectIntoFormatParquet:
+		if s.intoOpt.FieldsInfo != nil || s.intoOpt.LinesInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs("FIELDS/LINES", "parquet format")
+		}
+	default:
+		return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(*s.intoOpt.Format)
+	}
+
+	specifiedOptions := make(map[string]*core.LoadDataOpt, len(s.options))
+	for _, opt := range s.options {
+		hasValue, ok := selectIntoOptions[opt.Name]
+		if !ok {
+			return exeerrors.ErrUnknownOption.FastGenByArgs(opt.Name)
+		}
+		if hasValue && opt.Value == nil || !hasValue && opt.Value != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if _, ok = specifiedOptions[opt.Name]; ok {
+			return exeerrors.ErrDuplicateOption.FastGenByArgs(opt.Name)
+		}
+		specifiedOptions[opt.Name] = opt
+	}
+
+	if opt, ok := specifiedOptions[selectIntoCompressOption]; ok {
+		v, isNull, err := opt.Value.EvalString(s.Ctx(), chunk.Row{})
+		if err != nil || isNull {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		tp, ok := selectIntoCompressTypes[strings.ToLower(v)]
+		if !ok {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		s.compressType = tp
+	}
+	if opt, ok := specifiedOptions[selectIntoMaxFileSizeOption]; ok {
+		var (
+			v   int64
+			err error
+		)
+		// accept both a plain integer and a human-friendly string like '256MB'
+		if opt.Value.GetType().GetType() == mysql.TypeLonglong && !mysql.HasIsBooleanFlag(opt.Value.GetType().GetFlag()) {
+			v, _, err = opt.Value.EvalInt(s.Ctx(), chunk.Row{})
+		} else {
+			var str string
+			str, _, err = opt.Value.EvalString(s.Ctx(), chunk.Row{})
+			if err == nil {
+				v, err = units.RAMInBytes(str)
+			}
+		}
+		if err != nil || v <= 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		s.maxFileSize = v
+	}
+	return nil
+}
+
+// initStorage prepares the external storage for remote URIs. Files on the
+// local disk are still created by os.OpenFile, see openFile.
+func (s *SelectIntoExec) initStorage(ctx context.Context) error {
+	u, err := storage.ParseRawURL(s.intoOpt.FileName)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, err.Error())
+	}
+	if storage.IsLocal(u) {
+		s.fileName = s.intoOpt.FileName
+		if u.Scheme != "" {
+			s.fileName = u.Path
+		}
+		return nil
+	}
+
+	s.fileName = strings.Trim(u.Path, "/")
+	if s.fileName == "" {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, "file name is empty")
+	}
+	u.Path = ""
+	b, err := storage.ParseBackendFromURL(u, nil)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	store, err := storage.NewWithDefaultOpt(ctx, b)
+	if err != nil {
+		return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	if s.format != selectIntoFormatParquet {
+		store = storage.WithCompression(store, s.compressType, storage.DecompressConfig{})
+	}
+	s.store = store
+	return nil
+}
+
+// splitFileName inserts the file index before the first '.' of the base name,
+// e.g. 'dir/result.csv.gz' becomes 'dir/result.1.csv.gz'.
+func splitFileName(name string, idx int) string {
+	dir, base := filepath.Split(name)
+	if pos := strings.IndexByte(base, '.'); pos > 0 {
+		return fmt.Sprintf("%s%s.%d%s", dir, base[:pos], idx, base[pos:])
+	}
+	return fmt.Sprintf("%s%s.%d", dir, base, idx)
+}
+
+// localFileWriter wraps a local file as a storage.ExternalFileWriter.
+type localFileWriter struct {
+	*os.File
+}
+
+// Write implements storage.ExternalFileWriter interface.
+func (w localFileWriter) Write(_ context.Context, p []byte) (int, error) {
+	return w.File.Write(p)
+}
+
+// Close implements storage.ExternalFileWriter interface.
+func (w localFileWriter) Close(_ context.Context) error {
+	return w.File.Close()
+}
+
+// parquetFileWriter adapts a storage.ExternalFileWriter to io.Writer.
+type parquetFileWriter struct {
+	ctx context.Context
+	w   storage.ExternalFileWriter
+}
+
+func (w parquetFileWriter) Write(p []byte) (int, error) {
+	return w.w.Write(w.ctx, p)
+}
+
+func (s *SelectIntoExec) openFile(ctx context.Context) error {
+	name := s.fileName
+	if s.maxFileSize > 0 {
+		name = splitFileName(name, s.fileIdx)
+	}
+	compressType := s.compressType
+	if s.format == selectIntoFormatParquet {
+		// parquet compresses the column chunks by itself
+		compressType = storage.NoCompression
+	}
+
+	if s.store == nil {
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.writer = storage.NewUploaderWriter(localFileWriter{f}, selectIntoChunkSize, compressType)
+	} else {
+		exists, err := s.store.FileExists(ctx, name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if exists {
+			return errors.Errorf("file %s already exists in %s", name, s.store.URI())
+		}
+		s.writer, err = s.store.Create(ctx, name, nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	s.fileSize = 0
+
+	if s.format == selectIntoFormatParquet {
+		if s.parquetMeta == nil {
+			s.initParquetSchema()
+		}
+		pw, err := writer.NewCSVWriterFromWriter(s.parquetMeta, parquetFileWriter{ctx: ctx, w: s.writer}, 1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pw.CompressionType = selectIntoParquetCodecs[s.compressType]
+		s.parquetWriter = pw
+	}
+	return nil
+}
+
+func (s *SelectIntoExec) closeFile(ctx context.Context) error {
+	var err error
+	if s.parquetWriter != nil {
+		err = s.parquetWriter.WriteStop()
+		s.parquetWriter = nil
+	}
+	if s.writer != nil {
+		if err2 := s.writer.Close(ctx); err == nil {
+			err = err2
+		}
+		s.writer = nil
+	}
+	return errors.Trace(err)
+}
+
+// rotateFile starts a new file if the current one exceeds maxFileSize.
+func (s *SelectIntoExec) rotateFile(ctx context.Context) error {
+	if s.maxFileSize <= 0 || s.fileSize < s.maxFileSize {
+		return nil
+	}
+	if err := s.closeFile(ctx); err != nil {
+		return err
+	}
+	s.fileIdx++
+	return s.openFile(ctx)
+}
+
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	for {
@@ -79,7 +344,13 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		var err error
+		if s.format == selectIntoFormatParquet {
+			err = s.dumpToParquet(ctx)
+		} else {
+			err = s.dumpToOutfile(ctx)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -121,7 +392,7 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
 	encloseFlag := false
 	var encloseByte byte
 	encloseOpt := false
@@ -157,39 +428,7 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			} else {
 				s.enclosed = false
 			}
-			s.fieldBuf = s.fieldBuf[:0]
-			switch col.GetType().GetType() {
-			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
-				s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-			case mysql.TypeLonglong:
-				if mysql.HasUnsignedFlag(col.GetType().GetFlag()) {
-					s.fieldBuf = strconv.AppendUint(s.fieldBuf, row.GetUint64(j), 10)
-				} else {
-					s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-				}
-			case mysql.TypeFloat:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, float64(row.GetFloat32(j)), col.RetType)
-			case mysql.TypeDouble:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, row.GetFloat64(j), col.RetType)
-			case mysql.TypeNewDecimal:
-				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
-			case mysql.TypeBit:
-				// bit value won't be escaped anyway (verified on MySQL, test case added)
-				s.lineBuf = append(s.lineBuf, row.GetBytes(j)...)
-			case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
-				s.fieldBuf = append(s.fieldBuf, row.GetTime(j).String()...)
-			case mysql.TypeDuration:
-				s.fieldBuf = append(s.fieldBuf, row.GetDuration(j, col.GetType().GetDecimal()).String()...)
-			case mysql.TypeEnum:
-				s.fieldBuf = append(s.fieldBuf, row.GetEnum(j).String()...)
-			case mysql.TypeSet:
-				s.fieldBuf = append(s.fieldBuf, row.GetSet(j).String()...)
-			case mysql.TypeJSON:
-				s.fieldBuf = append(s.fieldBuf, row.GetJSON(j).String()...)
-			}
+			s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, col.RetType)
 
 			switch col.GetType().EvalType() {
 			case types.ETString, types.ETJson:
@@ -203,9 +442,133 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, s.LinesTerminatedBy...)
-		if _, err := s.writer.Write(s.lineBuf); err != nil {
+		if err := s.rotateFile(ctx); err != nil {
+			return err
+		}
+		if _, err := s.writer.Write(ctx, s.lineBuf); err != nil {
+			return errors.Trace(err)
+		}
+		s.fileSize += int64(len(s.lineBuf))
+	}
+	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
+
+func (s *SelectIntoExec) appendField(buf []byte, row chunk.Row, j int, tp *types.FieldType) []byte {
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(tp.GetFlag()) {
+			buf = strconv.AppendUint(buf, row.GetUint64(j), 10)
+		} else {
+			buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+		}
+	case mysql.TypeFloat:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, float64(row.GetFloat32(j)), tp)
+	case mysql.TypeDouble:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, row.GetFloat64(j), tp)
+	case mysql.TypeNewDecimal:
+		buf = append(buf, row.GetMyDecimal(j).String()...)
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		buf = append(buf, row.GetBytes(j)...)
+	case mysql.TypeBit:
+		// bit value won't be escaped anyway (verified on MySQL, test case added)
+		buf = append(buf, row.GetBytes(j)...)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		buf = append(buf, row.GetTime(j).String()...)
+	case mysql.TypeDuration:
+		buf = append(buf, row.GetDuration(j, tp.GetDecimal()).String()...)
+	case mysql.TypeEnum:
+		buf = append(buf, row.GetEnum(j).String()...)
+	case mysql.TypeSet:
+		buf = append(buf, row.GetSet(j).String()...)
+	case mysql.TypeJSON:
+		buf = append(buf, row.GetJSON(j).String()...)
+	}
+	return buf
+}
+
+// parquetType returns the parquet type used to store a column, types other
+// than integer and float are written as string. Unsigned BIGINT doesn't fit
+// into INT64 and is written as string too.
+func parquetType(tp *types.FieldType) string {
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		return "INT64"
+	case mysql.TypeLonglong:
+		if !mysql.HasUnsignedFlag(tp.GetFlag()) {
+			return "INT64"
+		}
+	case mysql.TypeFloat, mysql.TypeDouble:
+		return "DOUBLE"
+	}
+	return "UTF8"
+}
+
+func (s *SelectIntoExec) initParquetSchema() {
+	cols := s.Children(0).Schema().Columns
+	s.parquetMeta = make([]string, 0, len(cols))
+	used := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		name := fmt.Sprintf("col_%d", i)
+		if i < len(s.outputNames) {
+			// the metadata string of parquet-go can't contain ',' or '='
+			name = strings.Map(func(r rune) rune {
+				if r == ',' || r == '=' || r == ' ' {
+					return '_'
+				}
+				return r
+			}, s.outputNames[i].ColName.O)
+		}
+		if _, ok := used[strings.ToLower(name)]; ok || name == "" {
+			name = fmt.Sprintf("%s_%d", name, i)
+		}
+		used[strings.ToLower(name)] = struct{}{}
+		s.parquetMeta = append(s.parquetMeta,
+			fmt.Sprintf("name=%s, type=%s, repetitiontype=OPTIONAL", name, parquetType(col.RetType)))
+	}
+}
+
+func (s *SelectIntoExec) dumpToParquet(ctx context.Context) error {
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		// the size of the encoded row is unknown before the row group is
+		// flushed, use the size of the raw values instead.
+		rowSize := int64(0)
+		// parquet-go buffers the rows until the row group is flushed, so the
+		// slice can't be reused.
+		rec := make([]interface{}, len(cols))
+		for j, col := range cols {
+			if row.IsNull(j) {
+				continue
+			}
+			switch parquetType(col.RetType) {
+			case "INT64":
+				rec[j] = row.GetInt64(j)
+				rowSize += 8
+			case "DOUBLE":
+				if col.RetType.GetType() == mysql.TypeFloat {
+					rec[j] = float64(row.GetFloat32(j))
+				} else {
+					rec[j] = row.GetFloat64(j)
+				}
+				rowSize += 8
+			default:
+				s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, col.RetType)
+				rec[j] = string(s.fieldBuf)
+				rowSize += int64(len(s.fieldBuf))
+			}
+		}
+		if err := s.rotateFile(ctx); err != nil {
+			return err
+		}
+		if err := s.parquetWriter.Write(rec); err != nil {
 			return errors.Trace(err)
 		}
+		s.fileSize += rowSize
 	}
 	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
 	return nil
@@ -216,15 +579,12 @@ func (s *SelectIntoExec) Close() error {
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
-	err3 := s.BaseExecutor.Close()
+	err1 := s.closeFile(context.Background())
+	err2 := s.BaseExecutor.Close()
 	if err1 != nil {
-		return errors.Trace(err1)
-	} else if err2 != nil {
-		return errors.Trace(err2)
+		return err1
 	}
-	return err3
+	return err2
 }
 
 const (
@@ -15,18 +15,29 @@
 package executor_test
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func cmpAndRm(expected, outfile string, t *testing.T) {
@@ -290,3 +301,120 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoOutfileOptions(t *testing.T) {
+	outfile := randomSelectFilePath("TestSelectIntoOutfileOptions")
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c')")
+
+	err := tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q with xx=1", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrUnknownOption)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q with max_file_size=1, max_file_size=2", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrDuplicateOption)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q with compress='lz4'", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q with max_file_size='abc'", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q with max_file_size=0", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q format 'json'", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrLoadDataUnsupportedFormat)
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile %q format 'parquet' fields terminated by ','", outfile))
+	require.ErrorIs(t, err, exeerrors.ErrLoadDataUnsupportedOption)
+	err = tk.ExecToErr("select * from t into outfile 'xx://bucket/result.csv'")
+	require.ErrorIs(t, err, exeerrors.ErrLoadDataInvalidURI)
+	_, err = os.Stat(outfile)
+	require.True(t, os.IsNotExist(err))
+
+	// compression
+	tk.MustExec(fmt.Sprintf("select * from t into outfile %q with compress='gzip'", outfile))
+	f, err := os.Open(outfile)
+	require.NoError(t, err)
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "1\ta\n2\tb\n3\tc\n", string(content))
+	require.NoError(t, os.Remove(outfile))
+
+	// split by file size
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile %q format 'csv' fields terminated by ',' with max_file_size=5", outfile))
+	require.Equal(t, uint64(3), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	ext := filepath.Ext(outfile)
+	prefix := strings.TrimSuffix(outfile, ext)
+	cmpAndRm("1,a\n2,b\n", fmt.Sprintf("%s.0%s", prefix, ext), t)
+	cmpAndRm("3,c\n", fmt.Sprintf("%s.1%s", prefix, ext), t)
+	_, err = os.Stat(fmt.Sprintf("%s.2%s", prefix, ext))
+	require.True(t, os.IsNotExist(err))
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile %q with max_file_size='1KiB'", outfile))
+	cmpAndRm("1\ta\n2\tb\n3\tc\n", fmt.Sprintf("%s.0%s", prefix, ext), t)
+}
+
+func TestSelectIntoOutfileParquet(t *testing.T) {
+	outfile := randomSelectFilePath("TestSelectIntoOutfileParquet")
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b double, c varchar(10), d decimal(10, 2), e datetime)")
+	tk.MustExec("insert into t values (1, 1.5, 'a', 1.23, '2000-01-01 00:00:00'), (2, null, 'b', null, null), (null, 3.5, null, 3.45, '2000-03-03 00:00:00')")
+
+	tk.MustExec(fmt.Sprintf("select * from t order by c into outfile %q format 'parquet' with compress='zstd'", outfile))
+	defer func() {
+		require.NoError(t, os.Remove(outfile))
+	}()
+	fr, err := local.NewLocalFileReader(outfile)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(fr, nil, 1)
+	require.NoError(t, err)
+	defer func() {
+		pr.ReadStop()
+		require.NoError(t, fr.Close())
+	}()
+	require.Equal(t, int64(3), pr.GetNumRows())
+	schema := make([]string, 0, 5)
+	for i, elem := range pr.SchemaHandler.SchemaElements[1:] {
+		schema = append(schema, fmt.Sprintf("%s:%s", pr.SchemaHandler.Infos[i+1].ExName, elem.Type))
+	}
+	require.Equal(t, []string{"a:INT64", "b:DOUBLE", "c:BYTE_ARRAY", "d:BYTE_ARRAY", "e:BYTE_ARRAY"}, schema)
+	rows, err := pr.ReadByNumber(3)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	content, err := json.Marshal(rows)
+	require.NoError(t, err)
+	require.Equal(t, `[{"A":null,"B":3.5,"C":null,"D":"3.45","E":"2000-03-03 00:00:00"},`+
+		`{"A":1,"B":1.5,"C":"a","D":"1.23","E":"2000-01-01 00:00:00"},`+
+		`{"A":2,"B":null,"C":"b","D":null,"E":null}]`, string(content))
+}
+
+func TestSelectIntoOutfileExternalStorage(t *testing.T) {
+	backend := s3mem.New()
+	faker := gofakes3.New(backend)
+	ts := httptest.NewServer(faker.Server())
+	defer ts.Close()
+	require.NoError(t, backend.CreateBucket("test-bucket"))
+	query := fmt.Sprintf("region=us-east-1&endpoint=%s&access-key=xxxxxx&secret-access-key=xxxxxx&force-path-style=true", ts.URL)
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b')")
+
+	uri := fmt.Sprintf("s3://test-bucket/path/result.csv.gz?%s", query)
+	tk.MustExec(fmt.Sprintf("select * from t order by a into outfile '%s' fields terminated by ',' with compress='gzip'", uri))
+	err := tk.ExecToErr(fmt.Sprintf("select * from t order by a into outfile '%s'", uri))
+	require.ErrorContains(t, err, "already exists")
+
+	b, err := storage.ParseBackend(fmt.Sprintf("s3://test-bucket?%s", query), nil)
+	require.NoError(t, err)
+	s, err := storage.NewWithDefaultOpt(context.Background(), b)
+	require.NoError(t, err)
+	s = storage.WithCompression(s, storage.Gzip, storage.DecompressConfig{})
+	content, err := s.ReadFile(context.Background(), "path/result.csv.gz")
+	require.NoError(t, err)
+	require.Equal(t, "1,a\n2,b\n", string(content))
+}
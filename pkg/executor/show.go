@@ -189,6 +189,8 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateUser(ctx)
 	case ast.ShowCreateView:
 		return e.fetchShowCreateView()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure(ctx)
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
 	case ast.ShowCreatePlacementPolicy:
@@ -206,7 +208,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(ctx)
 	case ast.ShowPumpStatus:
 		return e.fetchShowPumpOrDrainerStatus(node.PumpNode)
 	case ast.ShowStatus:
@@ -228,7 +230,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -524,6 +526,9 @@ func (e *ShowExec) fetchShowTables() error {
 			continue
 		} else if fieldPatternsLike != nil && !fieldPatternsLike.DoMatch(v.Meta().Name.L) {
 			continue
+		} else if v.Meta().IsMaterializedViewLog {
+			// The change logs of the materialized views are hidden.
+			continue
 		}
 		tableNames = append(tableNames, v.Meta().Name.O)
 		if v.Meta().IsView() {
@@ -752,6 +757,14 @@ func (e *ShowExec) fetchShowIndex() error {
 		if tb.Meta().IsCommonHandle && idxInfo.Primary {
 			isClustered = "YES"
 		}
+		if idxInfo.FullText != nil {
+			e.appendIndexRowsOnColumns(tb.Meta(), idxInfo, idxInfo.FullText.Columns)
+			continue
+		}
+		if idxInfo.Spatial != nil {
+			e.appendIndexRowsOnColumns(tb.Meta(), idxInfo, []model.CIStr{idxInfo.Spatial.Column})
+			continue
+		}
 		for i, col := range idxInfo.Columns {
 			nonUniq := 1
 			if idx.Meta().Unique {
@@ -810,6 +823,39 @@ func (e *ShowExec) fetchShowIndex() error {
 	return nil
 }
 
+// appendIndexRowsOnColumns appends the rows of the columns of a FULLTEXT or SPATIAL index instead
+// of the hidden column the index is built on.
+func (e *ShowExec) appendIndexRowsOnColumns(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, colNames []model.CIStr) {
+	visible := "YES"
+	if idxInfo.Invisible {
+		visible = "NO"
+	}
+	for i, colName := range colNames {
+		nullVal := "YES"
+		if col := model.FindColumnInfo(tblInfo.Columns, colName.L); col != nil && mysql.HasNotNullFlag(col.GetFlag()) {
+			nullVal = ""
+		}
+		e.appendRow([]interface{}{
+			tblInfo.Name.O,      // Table
+			1,                   // Non_unique
+			idxInfo.Name.O,      // Key_name
+			i + 1,               // Seq_in_index
+			colName.O,           // Column_name
+			nil,                 // Collation
+			0,                   // Cardinality
+			nil,                 // Sub_part
+			nil,                 // Packed
+			nullVal,             // Null
+			idxInfo.Tp.String(), // Index_type
+			"",                  // Comment
+			idxInfo.Comment,     // Index_comment
+			visible,             // Index_visible
+			nil,                 // Expression
+			"NO",                // Clustered
+		})
+	}
+}
+
 // fetchShowCharset gets all charset information and fill them into e.rows.
 // See http://dev.mysql.com/doc/refman/5.7/en/show-character-set.html
 func (e *ShowExec) fetchShowCharset() error {
@@ -955,6 +1001,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		fetchShowCreateTable4View(ctx, tableInfo, buf)
 		return nil
 	}
+	if tableInfo.IsMaterializedView() {
+		fetchShowCreateTable4MaterializedView(ctx, tableInfo, buf)
+		return nil
+	}
 	if tableInfo.IsSequence() {
 		ConstructResultOfShowCreateSequence(ctx, tableInfo, buf)
 		return nil
@@ -1123,6 +1173,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.FullText != nil {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.Spatial != nil {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1140,7 +1194,21 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			}
 			cols = append(cols, colInfo)
 		}
+		if idxInfo.FullText != nil {
+			// The FULLTEXT index is shown on its columns instead of the hidden column of the tokens.
+			cols = cols[:0]
+			for _, c := range idxInfo.FullText.Columns {
+				cols = append(cols, stringutil.Escape(c.O, sqlMode))
+			}
+		}
+		if idxInfo.Spatial != nil {
+			// The SPATIAL index is shown on its column instead of the hidden column of the cells.
+			cols = []string{stringutil.Escape(idxInfo.Spatial.Column.O, sqlMode)}
+		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.FullText != nil && idxInfo.FullText.Parser.L != "" {
+			fmt.Fprintf(buf, " /*!50100 WITH PARSER %s */", stringutil.Escape(idxInfo.FullText.Parser.O, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1475,6 +1543,22 @@ func fetchShowCreateTable4View(ctx sessionctx.Context, tb *model.TableInfo, buf
 	fmt.Fprintf(buf, ") AS %s", tb.View.SelectStmt)
 }
 
+func fetchShowCreateTable4MaterializedView(ctx sessionctx.Context, tb *model.TableInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	fmt.Fprintf(buf, "CREATE MATERIALIZED VIEW %s (", stringutil.Escape(tb.Name.O, sqlMode))
+	for i, col := range tb.Cols() {
+		if i > 0 {
+			fmt.Fprintf(buf, ", ")
+		}
+		fmt.Fprintf(buf, "%s", stringutil.Escape(col.Name.O, sqlMode))
+	}
+	fmt.Fprintf(buf, ") REFRESH %s", tb.MaterializedView.RefreshMethod.String())
+	if tb.MaterializedView.RefreshInterval > 0 {
+		fmt.Fprintf(buf, " EVERY %d SECOND", tb.MaterializedView.RefreshInterval)
+	}
+	fmt.Fprintf(buf, " AS %s", tb.MaterializedView.SelectStmt)
+}
+
 // ConstructResultOfShowCreateDatabase constructs the result for show create database.
 func ConstructResultOfShowCreateDatabase(ctx sessionctx.Context, dbInfo *model.DBInfo, ifNotExists bool, buf *bytes.Buffer) (err error) {
 	sqlMode := ctx.GetSessionVars().SQLMode
@@ -1843,10 +1927,6 @@ func (*ShowExec) fetchShowTriggers() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
-	return nil
-}
-
 func (e *ShowExec) fetchShowPlugins() error {
 	tiPlugins := plugin.GetAll()
 	for _, ps := range tiPlugins {
@@ -91,6 +91,12 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
 			statsVal = item.StringVals
+		case ast.StatsTypeMultiHistogram:
+			statsType = "multi_histogram"
+			// The encoded samples are too long to display, so we only show the number of them.
+			if item.Sketch != nil {
+				statsVal = fmt.Sprintf("%d samples", item.Sketch.NumRows())
+			}
 		}
 		e.appendRow([]interface{}{
 			dbName,
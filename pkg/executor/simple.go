@@ -198,6 +198,18 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2720,6 +2732,15 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Statements that implicitly use or modify tables in the mysql database.
 	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
 		return true
+	// Statements that define or modify the events.
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
+	// Statements that define or drop the stored procedures.
+	case *ast.ProcedureInfo, *ast.DropProcedureStmt:
+		return true
+	// Statements that refresh the materialized views.
+	case *ast.RefreshMaterializedViewStmt:
+		return true
 	// Transaction-control and locking statements.  BEGIN, LOCK TABLES, SET autocommit = 1 (if the value is not already 1), START TRANSACTION, UNLOCK TABLES.
 	// (handled in other place)
 	// Data loading statements. LOAD DATA
@@ -2823,6 +2844,10 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
 		domain.GetDomain(e.Ctx()).SetExpiredTimeStamp4PC(now)
+		// The plans shared by all sessions are flushed directly.
+		if pc := domain.GetDomain(e.Ctx()).InstancePlanCache(); pc != nil {
+			pc.DeleteAll()
+		}
 	}
 	return nil
 }
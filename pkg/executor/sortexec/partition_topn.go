@@ -0,0 +1,384 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sortexec
+
+import (
+	"container/heap"
+	"context"
+	"slices"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/executor/aggregate"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// PartitionTopNExec implements a Top-N algorithm for each partition. It is built from a TopN or a Limit
+// with partition by, which is derived from a row_number filter over a window function, e.g.
+// `row_number() over (partition by a order by b) <= N`.
+// Instead of sorting all the rows fetched from the child, it keeps the Top-N elements of every partition
+// in a heap, so the memory usage is bounded by N * NDV(partition by).
+// The output rows are sorted by PartitionBy and then ByItems, so that the window function above can
+// consume them without another sort.
+// If the memory quota is exceeded, which happens when the NDV of PartitionBy is high, it falls back to
+// sort all the rows by PartitionBy and ByItems like SortExec, which can spill to disk. The window
+// function and the row_number filter above still produce the right result.
+type PartitionTopNExec struct {
+	SortExec
+	// PartitionBy is the partition by columns. Rows with equal values of them belong to one partition.
+	PartitionBy []*expression.Column
+	// Count is the number of rows kept for each partition.
+	Count uint64
+	// ColumnIdxsUsedByChild is the column indexes of the child used by the output, nil means all
+	// the columns are used. It is only set when the executor is built from a Limit.
+	ColumnIdxsUsedByChild []int
+
+	// rowChunks is the chunks to store row values of all the partitions.
+	rowChunks *chunk.List
+	// partitions maps the encoded partition key to the rows kept for it.
+	partitions map[string]*topNPartitionHeap
+	// keptRows is the number of the rows kept in all the partitions.
+	keptRows       int
+	groupKey       [][]byte
+	partitionExprs []expression.Expression
+	// partitionCmpFuncs is used to compare each partition by column.
+	partitionCmpFuncs []chunk.CompareFunc
+	// partitionsMemUsage is the memory consumed by the partition keys and the row pointers of the heaps.
+	partitionsMemUsage int64
+
+	// fallbackAction is triggered when the memory quota is exceeded before all the rows are fetched.
+	fallbackAction    *partitionTopNFallbackAction
+	fallbackTriggered atomic.Bool
+	// sorted indicates the executor has fallen back to sort, the rows are output by the sort partitions
+	// and ByItems is prefixed by PartitionBy.
+	sorted bool
+	// sortedChk is used to prune the columns of the sorted rows when ColumnIdxsUsedByChild is set.
+	sortedChk *chunk.Chunk
+
+	// rowPtrs is the result rows sorted by PartitionBy and ByItems.
+	rowPtrs []chunk.RowPtr
+	Idx     int
+}
+
+// topNPartitionHeap implements heap.Interface. It maintains a max heap of the rows of one partition.
+type topNPartitionHeap struct {
+	e       *PartitionTopNExec
+	rowPtrs []chunk.RowPtr
+}
+
+// Less implement heap.Interface, but since we mantains a max heap,
+// this function returns true if row i is greater than row j.
+func (h *topNPartitionHeap) Less(i, j int) bool {
+	rowI := h.e.rowChunks.GetRow(h.rowPtrs[i])
+	rowJ := h.e.rowChunks.GetRow(h.rowPtrs[j])
+	return h.e.compressRow(rowI, rowJ) > 0
+}
+
+func (h *topNPartitionHeap) Len() int {
+	return len(h.rowPtrs)
+}
+
+func (*topNPartitionHeap) Push(interface{}) {
+	// Should never be called.
+}
+
+func (h *topNPartitionHeap) Pop() interface{} {
+	h.rowPtrs = h.rowPtrs[:len(h.rowPtrs)-1]
+	// We don't need the popped value, return nil to avoid memory allocation.
+	return nil
+}
+
+func (h *topNPartitionHeap) Swap(i, j int) {
+	h.rowPtrs[i], h.rowPtrs[j] = h.rowPtrs[j], h.rowPtrs[i]
+}
+
+// partitionTopNFallbackAction implements memory.ActionOnExceed for PartitionTopNExec. If the memory
+// quota of a query is exceeded, the executor falls back to sort the rows before fetching the next chunk.
+type partitionTopNFallbackAction struct {
+	memory.BaseOOMAction
+	e *PartitionTopNExec
+}
+
+// GetPriority get the priority of the Action.
+func (*partitionTopNFallbackAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+func (a *partitionTopNFallbackAction) Action(*memory.Tracker) {
+	a.e.fallbackTriggered.Store(true)
+}
+
+// Open implements the Executor Open interface.
+func (e *PartitionTopNExec) Open(ctx context.Context) error {
+	vars := e.Ctx().GetSessionVars()
+	e.memTracker = memory.NewTracker(e.ID(), -1)
+	e.memTracker.AttachTo(vars.StmtCtx.MemTracker)
+	e.diskTracker = memory.NewTracker(e.ID(), -1)
+	e.diskTracker.AttachTo(vars.StmtCtx.DiskTracker)
+	e.spillLimit = vars.MemTracker.GetBytesLimit() / 10
+	e.enableTmpStorageOnOOM = variable.EnableTmpStorageOnOOM.Load()
+
+	e.fetched = false
+	e.Idx = 0
+	e.sortPartitions = e.sortPartitions[:0]
+	e.fallbackTriggered.Store(false)
+	// Sorting the rows doesn't use less memory unless it can spill.
+	if e.enableTmpStorageOnOOM {
+		e.fallbackAction = &partitionTopNFallbackAction{e: e}
+		vars.MemTracker.FallbackOldAndSetNewAction(e.fallbackAction)
+	}
+
+	return exec.Open(ctx, e.Children(0))
+}
+
+// Close implements the Executor Close interface.
+func (e *PartitionTopNExec) Close() error {
+	e.rowChunks = nil
+	e.partitions = nil
+	e.rowPtrs = nil
+	e.groupKey = nil
+	e.partitionExprs = nil
+	e.sortedChk = nil
+	if e.fallbackAction != nil {
+		e.fallbackAction.SetFinished()
+		e.fallbackAction = nil
+	}
+	if e.sorted {
+		e.ByItems = e.ByItems[len(e.PartitionBy):]
+		e.sorted = false
+	}
+	return e.SortExec.Close()
+}
+
+// Next implements the Executor Next interface.
+func (e *PartitionTopNExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.fetched {
+		err := e.executePartitionTopN(ctx)
+		if err != nil {
+			return err
+		}
+		e.fetched = true
+	}
+	if e.sorted {
+		return e.appendSortedRowsByColIdxs(req)
+	}
+	for !req.IsFull() && e.Idx < len(e.rowPtrs) {
+		req.AppendRowByColIdxs(e.rowChunks.GetRow(e.rowPtrs[e.Idx]), e.ColumnIdxsUsedByChild)
+		e.Idx++
+	}
+	return nil
+}
+
+func (e *PartitionTopNExec) executePartitionTopN(ctx context.Context) error {
+	e.rowChunks = chunk.NewList(exec.RetTypes(e.Children(0)), e.InitCap(), e.MaxChunkSize())
+	e.rowChunks.GetMemTracker().AttachTo(e.memTracker)
+	e.rowChunks.GetMemTracker().SetLabel(memory.LabelForRowChunks)
+	e.partitions = make(map[string]*topNPartitionHeap)
+	e.keptRows = 0
+	e.partitionsMemUsage = 0
+	e.initCompareFuncs()
+	e.buildKeyColumns()
+	e.partitionExprs = make([]expression.Expression, 0, len(e.PartitionBy))
+	e.partitionCmpFuncs = make([]chunk.CompareFunc, 0, len(e.PartitionBy))
+	for _, col := range e.PartitionBy {
+		e.partitionExprs = append(e.partitionExprs, col)
+		e.partitionCmpFuncs = append(e.partitionCmpFuncs, chunk.GetCompareFunc(col.RetType))
+	}
+
+	childRowChk := exec.TryNewCacheChunk(e.Children(0))
+	for {
+		err := exec.Next(ctx, e.Children(0), childRowChk)
+		if err != nil {
+			return err
+		}
+		if childRowChk.NumRows() == 0 {
+			break
+		}
+		err = e.processChildChk(childRowChk)
+		if err != nil {
+			return err
+		}
+		if e.fallbackTriggered.Load() {
+			return e.fallbackToSort(ctx)
+		}
+		if e.rowChunks.Len() > e.keptRows*topNCompactionFactor {
+			e.doCompaction()
+		}
+	}
+	// The memory usage doesn't grow any more, so there is no need to fall back.
+	if e.fallbackAction != nil {
+		e.fallbackAction.SetFinished()
+	}
+
+	e.rowPtrs = make([]chunk.RowPtr, 0, e.keptRows)
+	for _, h := range e.partitions {
+		e.rowPtrs = append(e.rowPtrs, h.rowPtrs...)
+	}
+	// Rows with the same sort keys are output in the order they were fetched, so that the order of
+	// the child is kept when the executor works as a Limit.
+	slices.SortFunc(e.rowPtrs, e.keyColumnsCompare)
+	return nil
+}
+
+func (e *PartitionTopNExec) keyColumnsCompare(i, j chunk.RowPtr) int {
+	rowI, rowJ := e.rowChunks.GetRow(i), e.rowChunks.GetRow(j)
+	for k, col := range e.PartitionBy {
+		if cmp := e.partitionCmpFuncs[k](rowI, col.Index, rowJ, col.Index); cmp != 0 {
+			return cmp
+		}
+	}
+	if cmp := e.compressRow(rowI, rowJ); cmp != 0 {
+		return cmp
+	}
+	if i.ChkIdx != j.ChkIdx {
+		return int(i.ChkIdx) - int(j.ChkIdx)
+	}
+	return int(i.RowIdx) - int(j.RowIdx)
+}
+
+func (e *PartitionTopNExec) processChildChk(childRowChk *chunk.Chunk) (err error) {
+	e.groupKey, err = aggregate.GetGroupKey(e.Ctx(), childRowChk, e.groupKey, e.partitionExprs)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < childRowChk.NumRows(); i++ {
+		h, ok := e.partitions[string(e.groupKey[i])]
+		if !ok {
+			h = &topNPartitionHeap{e: e}
+			e.partitions[string(e.groupKey[i])] = h
+			e.consumePartitionsMem(int64(len(e.groupKey[i])))
+		}
+		row := childRowChk.GetRow(i)
+		if uint64(h.Len()) < e.Count {
+			h.rowPtrs = append(h.rowPtrs, e.rowChunks.AppendRow(row))
+			e.keptRows++
+			e.consumePartitionsMem(8)
+			if uint64(h.Len()) == e.Count && len(e.ByItems) > 0 {
+				heap.Init(h)
+			}
+			continue
+		}
+		// The partition is full. Without ByItems the first Count rows are kept.
+		if e.Count == 0 || len(e.ByItems) == 0 {
+			continue
+		}
+		if e.compressRow(e.rowChunks.GetRow(h.rowPtrs[0]), row) > 0 {
+			// Evict heap max, keep the next row.
+			h.rowPtrs[0] = e.rowChunks.AppendRow(row)
+			heap.Fix(h, 0)
+		}
+	}
+	return nil
+}
+
+// doCompaction rebuild the chunks and row pointers to release the memory of the evicted rows.
+// The relative order of the kept rows is not changed.
+func (e *PartitionTopNExec) doCompaction() {
+	livePtrs := make([]*chunk.RowPtr, 0, e.keptRows)
+	for _, h := range e.partitions {
+		for i := range h.rowPtrs {
+			livePtrs = append(livePtrs, &h.rowPtrs[i])
+		}
+	}
+	slices.SortFunc(livePtrs, func(i, j *chunk.RowPtr) int {
+		if i.ChkIdx != j.ChkIdx {
+			return int(i.ChkIdx) - int(j.ChkIdx)
+		}
+		return int(i.RowIdx) - int(j.RowIdx)
+	})
+	newRowChunks := chunk.NewList(exec.RetTypes(e.Children(0)), e.InitCap(), e.MaxChunkSize())
+	for _, ptr := range livePtrs {
+		*ptr = newRowChunks.AppendRow(e.rowChunks.GetRow(*ptr))
+	}
+	newRowChunks.GetMemTracker().SetLabel(memory.LabelForRowChunks)
+	e.memTracker.ReplaceChild(e.rowChunks.GetMemTracker(), newRowChunks.GetMemTracker())
+	e.rowChunks = newRowChunks
+}
+
+func (e *PartitionTopNExec) consumePartitionsMem(bytes int64) {
+	e.partitionsMemUsage += bytes
+	e.memTracker.Consume(bytes)
+}
+
+// fallbackToSort moves the kept rows to the sort partitions, releases the heaps and stores all the
+// remaining rows of the child, the rows are sorted by PartitionBy and then ByItems.
+func (e *PartitionTopNExec) fallbackToSort(ctx context.Context) error {
+	e.fallbackAction.SetFinished()
+	e.sorted = true
+	byItems := make([]*util.ByItems, 0, len(e.PartitionBy)+len(e.ByItems))
+	for _, col := range e.PartitionBy {
+		byItems = append(byItems, &util.ByItems{Expr: col})
+	}
+	e.ByItems = append(byItems, e.ByItems...)
+	e.initCompareFuncs()
+	e.buildKeyColumns()
+
+	fields := exec.RetTypes(e.Children(0))
+	byItemsDesc := make([]bool, len(e.ByItems))
+	for i, byItem := range e.ByItems {
+		byItemsDesc[i] = byItem.Desc
+	}
+	err := e.switchToNewSortPartition(fields, byItemsDesc, false)
+	if err != nil {
+		return err
+	}
+	chk := chunk.NewChunkWithCapacity(fields, e.MaxChunkSize())
+	for _, h := range e.partitions {
+		for _, ptr := range h.rowPtrs {
+			chk.AppendRow(e.rowChunks.GetRow(ptr))
+			if chk.IsFull() {
+				if err = e.storeChunk(chk, fields, byItemsDesc); err != nil {
+					return err
+				}
+				chk = chunk.NewChunkWithCapacity(fields, e.MaxChunkSize())
+			}
+		}
+	}
+	if chk.NumRows() > 0 {
+		if err = e.storeChunk(chk, fields, byItemsDesc); err != nil {
+			return err
+		}
+	}
+	e.partitions = nil
+	e.rowChunks.GetMemTracker().Detach()
+	e.rowChunks = nil
+	e.memTracker.Consume(-e.partitionsMemUsage)
+	e.partitionsMemUsage = 0
+	return e.storeChildChunks(ctx, fields, byItemsDesc)
+}
+
+// appendSortedRowsByColIdxs appends the sorted rows to req after falling back to sort.
+func (e *PartitionTopNExec) appendSortedRowsByColIdxs(req *chunk.Chunk) error {
+	if e.ColumnIdxsUsedByChild == nil {
+		return e.appendSortedRows(req)
+	}
+	if e.sortedChk == nil {
+		e.sortedChk = exec.TryNewCacheChunk(e.Children(0))
+	}
+	e.sortedChk.SetRequiredRows(req.RequiredRows(), e.MaxChunkSize())
+	e.sortedChk.Reset()
+	if err := e.appendSortedRows(e.sortedChk); err != nil {
+		return err
+	}
+	for i := 0; i < e.sortedChk.NumRows(); i++ {
+		req.AppendRowByColIdxs(e.sortedChk.GetRow(i), e.ColumnIdxsUsedByChild)
+	}
+	return nil
+}
@@ -120,7 +120,11 @@ func (e *SortExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		}
 		e.fetched = true
 	}
+	return e.appendSortedRows(req)
+}
 
+// appendSortedRows appends the sorted rows of the sort partitions to req.
+func (e *SortExec) appendSortedRows(req *chunk.Chunk) error {
 	sortPartitionListLen := len(e.sortPartitions)
 	if sortPartitionListLen == 0 {
 		return nil
@@ -292,7 +296,11 @@ func (e *SortExec) fetchRowChunks(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return e.storeChildChunks(ctx, fields, byItemsDesc)
+}
 
+// storeChildChunks stores the remaining rows of the child to the sort partitions and sorts the last one.
+func (e *SortExec) storeChildChunks(ctx context.Context, fields []*types.FieldType, byItemsDesc []bool) error {
 	for {
 		chk := exec.TryNewCacheChunk(e.Children(0))
 		err := exec.Next(ctx, e.Children(0), chk)
@@ -331,7 +339,7 @@ func (e *SortExec) fetchRowChunks(ctx context.Context) error {
 		}
 	})
 
-	err = e.handleCurrentPartitionBeforeExit()
+	err := e.handleCurrentPartitionBeforeExit()
 	if err != nil {
 		return err
 	}
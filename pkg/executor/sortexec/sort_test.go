@@ -136,3 +136,90 @@ func TestIssue16696(t *testing.T) {
 		}
 	}
 }
+
+func TestPartitionTopN(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int)")
+	var buf bytes.Buffer
+	buf.WriteString("insert into t values ")
+	for i := 0; i < 2000; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		// (a, b) is unique so that the result of row_number() is deterministic.
+		buf.WriteString(fmt.Sprintf("(%d, %d, %d)", i%7, (i*37)%2000, i))
+	}
+	tk.MustExec(buf.String())
+	tk.MustExec("insert into t values (null, 1, 1), (null, 2, 2), (null, null, 3)")
+	tk.MustExec("set @@tidb_max_chunk_size=32")
+
+	queries := []string{
+		"select * from (select a, b, c, row_number() over (partition by a order by b) as rn from t) tt where rn <= 3",
+		"select * from (select a, b, c, row_number() over (partition by a order by b desc, c) as rn from t) tt where rn < 5",
+		"select * from (select a, b, c, row_number() over (partition by b % 5, a order by c desc) as rn from t) tt where rn <= 2",
+		"select * from (select a, b, c, row_number() over (partition by a order by b) as rn from t) tt where rn <= 0",
+	}
+	for _, query := range queries {
+		tk.MustExec("set @@tidb_opt_derive_topn = off")
+		expected := tk.MustQuery(query).Sort().Rows()
+		tk.MustExec("set @@tidb_opt_derive_topn = on")
+		tk.MustQuery(query).Sort().Check(expected)
+	}
+
+	// Without order by, any rows of a partition satisfy the filter, so only check the number of rows.
+	tk.MustExec("set @@tidb_opt_derive_topn = on")
+	tk.MustQuery("select a, count(*) from (select a, row_number() over (partition by a) as rn from t) tt where rn <= 4 group by a").
+		Sort().Check(testkit.Rows("0 4", "1 4", "2 4", "3 4", "4 4", "5 4", "6 4", "<nil> 3"))
+}
+
+func TestPartitionTopNFallbackToSort(t *testing.T) {
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.TempStoragePath = t.TempDir()
+	})
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer tk.MustExec("SET GLOBAL tidb_mem_oom_action = DEFAULT")
+	tk.MustExec("SET GLOBAL tidb_mem_oom_action='LOG'")
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int)")
+	var buf bytes.Buffer
+	buf.WriteString("insert into t values ")
+	for i := 0; i < 3000; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		// The NDV of a is high, and (a, b) is unique so that the result of row_number() is deterministic.
+		buf.WriteString(fmt.Sprintf("(%d, %d, %d)", i%1000, (i*37)%3000, i))
+	}
+	tk.MustExec(buf.String())
+	tk.MustExec("set @@tidb_max_chunk_size=32")
+
+	queries := []string{
+		"select * from (select a, b, c, row_number() over (partition by a order by b) as rn from t) tt where rn <= 2",
+		"select a, c from (select a, c, row_number() over (partition by a order by c desc) as rn from t) tt where rn < 2",
+	}
+	for _, query := range queries {
+		tk.MustExec("set @@tidb_mem_quota_query = default")
+		tk.MustExec("set @@tidb_opt_derive_topn = off")
+		expected := tk.MustQuery(query).Sort().Rows()
+		tk.MustExec("set @@tidb_opt_derive_topn = on")
+		tk.MustExec("set @@tidb_mem_quota_query = 1")
+		tk.MustQuery(query).Sort().Check(expected)
+
+		// The rows are sorted and spilled to disk after falling back.
+		rows := tk.MustQuery("explain analyze " + query).Rows()
+		found := false
+		for _, row := range rows {
+			if strings.Contains(row[0].(string), "TopN") && strings.Contains(row[len(row)-3].(string), "partition by") {
+				found = true
+				require.NotEqual(t, "N/A", row[len(row)-1])
+			}
+		}
+		require.True(t, found)
+	}
+}
@@ -2795,3 +2795,142 @@ func TestIssue38756(t *testing.T) {
 	tk.MustQuery("(SELECT DISTINCT SQRT(1) FROM t)").Check(testkit.Rows("1"))
 	tk.MustQuery("SELECT DISTINCT cast(1 as double) FROM t").Check(testkit.Rows("1"))
 }
+
+func TestIntersectAllAndExceptAll(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b varchar(20))")
+	tk.MustExec("create table t3(a int, b int)")
+	tk.MustExec("insert into t1 values (1,1),(1,1),(1,1),(2,2),(2,2),(3,3),(null,null),(null,null)")
+	tk.MustExec("insert into t2 values (1,'1'),(1,'1'),(2,'2'),(null,null),(4,'4')")
+	tk.MustExec("insert into t3 values (1,1),(3,3),(3,3)")
+
+	tk.MustQuery("select * from t1 intersect all select * from t2").Sort().Check(testkit.Rows(
+		"1 1", "1 1", "2 2", "<nil> <nil>"))
+	tk.MustQuery("select * from t2 intersect all select * from t1").Sort().Check(testkit.Rows(
+		"1 1", "1 1", "2 2", "<nil> <nil>"))
+	tk.MustQuery("select * from t1 except all select * from t2").Sort().Check(testkit.Rows(
+		"1 1", "2 2", "3 3", "<nil> <nil>"))
+	tk.MustQuery("select * from t2 except all select * from t1").Sort().Check(testkit.Rows("4 4"))
+	tk.MustQuery("select * from t1 intersect all select * from t2 intersect all select * from t3").Sort().Check(testkit.Rows("1 1"))
+	tk.MustQuery("select * from t1 except all select * from t2 except all select * from t3").Sort().Check(testkit.Rows(
+		"2 2", "<nil> <nil>"))
+	// INTERSECT ALL has higher precedence than EXCEPT ALL.
+	tk.MustQuery("select * from t1 except all select * from t2 intersect all select * from t3").Sort().Check(testkit.Rows(
+		"1 1", "1 1", "2 2", "2 2", "3 3", "<nil> <nil>", "<nil> <nil>"))
+	tk.MustQuery("select * from t3 union all select * from t3 except all select * from t1").Sort().Check(testkit.Rows(
+		"3 3", "3 3", "3 3"))
+	tk.MustQuery("select * from t1 intersect all select * from t2 except select * from t3").Sort().Check(testkit.Rows(
+		"2 2", "<nil> <nil>"))
+	tk.MustQuery("select * from t1 except all (select * from t1 intersect select * from t3) order by a limit 2").Check(testkit.Rows(
+		"<nil> <nil>", "<nil> <nil>"))
+	tk.MustQuery("select count(*) from (select a from t1 except all select a from t3) tt").Check(testkit.Rows("6"))
+
+	// The duplicated rows are distinguished by a row_number() window on each side.
+	rows := tk.MustQuery("explain format = 'brief' select * from t1 intersect all select * from t3").Rows()
+	plan := fmt.Sprintf("%v", rows)
+	require.Contains(t, plan, "semi join")
+	require.Contains(t, plan, "row_number()")
+	rows = tk.MustQuery("explain format = 'brief' select * from t1 except all select * from t3").Rows()
+	plan = fmt.Sprintf("%v", rows)
+	require.Contains(t, plan, "anti semi join")
+	require.Contains(t, plan, "row_number()")
+}
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustQuery(`select * from json_table('[{"a":1,"b":"x"},{"a":2,"b":"y"},{"c":3}]', '$[*]' columns (
+		id for ordinality, a int path '$.a', b varchar(10) path '$.b', c int exists path '$.c')) as jt`).Check(testkit.Rows(
+		"1 1 x 0", "2 2 y 0", "3 <nil> <nil> 1"))
+	tk.MustQuery(`select * from json_table('{"a":[1,2]}', '$' columns (a json path '$.a')) as jt`).Check(testkit.Rows("[1, 2]"))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a":1},{}]', '$[*]' columns (a int path '$.a' default '100' on empty)) as jt`).Check(testkit.Rows(
+		"1", "100"))
+	tk.MustQuery(`select * from json_table('[{"a":{"x":1}},{"a":"abc"}]', '$[*]' columns (a int path '$.a')) as jt`).Check(testkit.Rows(
+		"<nil>", "<nil>"))
+	tk.MustQuery(`select * from json_table('[{"a":{"x":1}}]', '$[*]' columns (a int path '$.a' default '2' on error)) as jt`).Check(testkit.Rows("2"))
+	tk.MustGetDBError(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`, exeerrors.ErrMissingJSONTableValue)
+	tk.MustGetDBError(`select * from json_table('[{"a":{"x":1}}]', '$[*]' columns (a int path '$.a' error on error)) as jt`, exeerrors.ErrWrongJSONTableValue)
+	tk.MustGetDBError(`select * from json_table('[{"a":1000}]', '$[*]' columns (a tinyint path '$.a' error on error)) as jt`, exeerrors.ErrJSONTableValueOutOfRange)
+
+	// NESTED PATH.
+	tk.MustQuery(`select * from json_table('[{"a":1,"b":[11,111]},{"a":2,"b":[22]},{"a":3}]', '$[*]' columns (
+		a int path '$.a', nested path '$.b[*]' columns (b int path '$'))) as jt`).Check(testkit.Rows(
+		"1 11", "1 111", "2 22", "3 <nil>"))
+	tk.MustQuery(`select * from json_table('[{"a":1,"b":[11,111],"c":[5]}]', '$[*]' columns (
+		a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested path '$.c[*]' columns (c int path '$'))) as jt`).Check(testkit.Rows(
+		"1 11 <nil>", "1 111 <nil>", "1 <nil> 5"))
+	tk.MustQuery(`select * from json_table('[{"b":[10,20]},{"b":[30]}]', '$[*]' columns (
+		id for ordinality, nested path '$.b[*]' columns (nid for ordinality, b int path '$'))) as jt`).Check(testkit.Rows(
+		"1 1 10", "1 2 20", "2 1 30"))
+
+	// JSON_TABLE references the preceding tables.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1,2]'), (2, '[3]'), (3, '[]'), (4, null)`)
+	tk.MustQuery(`select t.id, jt.v from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1", "1 2", "2 3"))
+	tk.MustQuery(`select t.id, jt.v from t left join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1", "1 2", "2 3", "3 <nil>", "4 <nil>"))
+	tk.MustQuery(`select t.id, jt.v from t join json_table(t.j, '$[*]' columns (v int path '$')) as jt where jt.v > 1 order by t.id, jt.v`).Check(testkit.Rows(
+		"1 2", "2 3"))
+	tk.MustQuery(`select id, (select sum(v) from json_table(t.j, '$[*]' columns (v int path '$')) as jt) from t order by id`).Check(testkit.Rows(
+		"1 3", "2 3", "3 <nil>", "4 <nil>"))
+	rows := tk.MustQuery(`explain format = 'brief' select * from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt`).Rows()
+	plan := fmt.Sprintf("%v", rows)
+	require.Contains(t, plan, "Apply")
+	require.Contains(t, plan, "JSONTable")
+
+	tk.MustGetDBError(`select * from json_table('[]', '$[*]' columns (a int path '$'))`, plannercore.ErrTableFunctionMustHaveAlias)
+	tk.MustGetDBError(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) as jt`, plannercore.ErrDupFieldName)
+	tk.MustGetDBError(`select * from json_table('[]', '$[*' columns (a int path '$')) as jt`, types.ErrInvalidJSONPath)
+	tk.MustGetDBError(`select * from t right join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true`, plannercore.ErrTableFunctionForbiddenJoinType)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t1")
+	tk.MustExec("create table t(a int primary key, b int)")
+	tk.MustExec("create table t1(a int, c int)")
+	tk.MustExec("insert into t values (1, 10), (2, 20), (3, 30)")
+	tk.MustExec("insert into t1 values (1, 1), (1, 2), (1, 3), (1, 4), (2, 5), (2, 6)")
+
+	tk.MustQuery("select t.a, dt.c from t, lateral (select c from t1 where t1.a = t.a order by c desc limit 2) dt order by t.a, dt.c").Check(testkit.Rows(
+		"1 3", "1 4", "2 5", "2 6"))
+	tk.MustQuery("select t.a, dt.c from t join lateral (select c from t1 where t1.a = t.a order by c limit 1) dt on dt.c > 1 order by t.a").Check(testkit.Rows(
+		"2 5"))
+	tk.MustQuery("select t.a, dt.c from t left join lateral (select c from t1 where t1.a = t.a order by c limit 1) dt on true order by t.a").Check(testkit.Rows(
+		"1 1", "2 5", "3 <nil>"))
+	tk.MustQuery("select t.a, dt.cnt from t, lateral (select count(*) cnt from t1 where t1.a = t.a) dt order by t.a").Check(testkit.Rows(
+		"1 4", "2 2", "3 0"))
+	tk.MustQuery("select t.a, dt.s from t, lateral (select sum(c) s from t1 where t1.a = t.a) dt order by t.a").Check(testkit.Rows(
+		"1 10", "2 11", "3 <nil>"))
+	tk.MustQuery("select t.a, dt.x from t, t1, lateral (select t.b + t1.c as x) dt where t.a = t1.a and t1.c = 1").Check(testkit.Rows(
+		"1 11"))
+	tk.MustQuery("select t.a, dt.c from t, lateral (select c from t1 where t1.a = t.a union all select t.b) dt where t.a = 2 order by dt.c").Check(testkit.Rows(
+		"2 5", "2 6", "2 20"))
+	// A lateral derived table without outer references is a normal derived table.
+	tk.MustQuery("select count(*) from t, lateral (select * from t1) dt").Check(testkit.Rows("18"))
+
+	// The top-N per group query stays as an Apply, while the aggregation is decorrelated.
+	rows := tk.MustQuery("explain format = 'brief' select * from t, lateral (select c from t1 where t1.a = t.a order by c limit 2) dt").Rows()
+	require.Contains(t, fmt.Sprintf("%v", rows), "Apply")
+	rows = tk.MustQuery("explain format = 'brief' select * from t, lateral (select sum(c) s from t1 where t1.a = t.a) dt").Rows()
+	require.NotContains(t, fmt.Sprintf("%v", rows), "Apply")
+
+	tk.MustGetErrCode("select * from t, lateral (select c from t1 where t1.a = t.a)", mysql.ErrDerivedMustHaveAlias)
+	tk.MustGetErrCode("select * from lateral (select t.a) dt, t", mysql.ErrBadField)
+	tk.MustGetDBError("select * from t right join lateral (select c from t1 where t1.a = t.a) dt on true", plannercore.ErrTableFunctionForbiddenJoinType)
+}
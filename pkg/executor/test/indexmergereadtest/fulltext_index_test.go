@@ -0,0 +1,95 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexmergereadtest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestFullTextIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft (title, body))")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustExec(`insert into t values
+		(1, 'MySQL Tutorial', 'DBMS stands for DataBase ...'),
+		(2, 'How To Use MySQL Well', 'After you went through a MySQL tutorial ...'),
+		(3, 'Optimizing MySQL', 'In this tutorial, we show how to optimize the database'),
+		(4, '1001 MySQL Tricks', '1. Never run mysqld as root. 2. ...'),
+		(5, 'MySQL vs. YourSQL', 'In the following database comparison ...'),
+		(6, 'MySQL Security', 'When configured properly, MySQL ...')`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `title` varchar(100) DEFAULT NULL,\n" +
+		"  `body` text DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `ft` (`title`,`body`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t where key_name = 'ft'").Check(testkit.Rows(
+		"t 1 ft 1 title <nil> 0 <nil> <nil> YES FULLTEXT   YES <nil> NO",
+		"t 1 ft 2 body <nil> 0 <nil> <nil> YES FULLTEXT   YES <nil> NO"))
+
+	// The natural language search returns the rows by relevance.
+	sql := "select id from t where match (title, body) against ('database tutorial')"
+	tk.MustQuery("explain format = 'brief' " + sql).CheckContain("IndexMerge")
+	tk.MustQuery("explain format = 'brief' " + sql).CheckContain("index:ft")
+	tk.MustQuery(sql + " order by id").Check(testkit.Rows("1", "2", "3", "5"))
+	tk.MustQuery("select id, round(match (title, body) against ('database tutorial'), 4) as score from t " +
+		"where match (body, title) against ('database tutorial') order by score desc, id").
+		Check(testkit.Rows("1 2", "3 2", "2 1", "5 1"))
+	tk.MustQuery("select id from t where match (title, body) against ('the of')").Check(testkit.Rows())
+
+	// The boolean search.
+	tk.MustQuery("select id from t where match (title, body) against ('+mysql -yoursql' in boolean mode) order by id").
+		Check(testkit.Rows("1", "2", "3", "4", "6"))
+	tk.MustQuery("select id from t where match (title, body) against ('optimiz* secur*' in boolean mode) order by id").
+		Check(testkit.Rows("3", "6"))
+	tk.MustQuery("select id from t where match (title, body) against ('+\"mysql tutorial\"' in boolean mode) order by id").
+		Check(testkit.Rows("1", "2"))
+	tk.MustQuery("explain format = 'brief' select id from t where match (title, body) against ('+mysql +tutorial' in boolean mode)").
+		CheckContain("IndexMerge")
+
+	// The index is maintained by the DML.
+	tk.MustExec("update t set body = 'A database tutorial' where id = 4")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("insert into t values (7, 'Tutorial', NULL)")
+	tk.MustQuery(sql + " order by id").Check(testkit.Rows("2", "3", "4", "5", "7"))
+	tk.MustExec("admin check table t")
+
+	// The index is built on the existing rows.
+	tk.MustExec("create table t2 (id int primary key, content text)")
+	tk.MustExec("insert into t2 values (1, '分布式数据库'), (2, '数据仓库'), (3, '关系型数据库系统')")
+	tk.MustExec("alter table t2 add fulltext index ft (content) with parser ngram")
+	tk.MustQuery("show create table t2").CheckContain("FULLTEXT KEY `ft` (`content`) /*!50100 WITH PARSER `ngram` */")
+	tk.MustQuery("select id from t2 where match (content) against ('数据库') order by id").
+		Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select id from t2 where match (content) against ('+数据库' in boolean mode) order by id").
+		Check(testkit.Rows("1", "3"))
+	tk.MustExec("admin check table t2")
+
+	tk.MustGetErrCode("select id from t where match (title) against ('database')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select id from t where match (title, body) against (title)", errno.ErrWrongArguments)
+	tk.MustGetErrCode("select id from t where match (title, body) against ('database' with query expansion)", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("alter table t add fulltext index ft2 (id)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t add fulltext index ft2 (title(10))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t add fulltext index ft2 (title) with parser mecab", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create spatial index sp on t (title)", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustExec("alter table t drop index ft")
+	tk.MustGetErrCode(sql, errno.ErrFtMatchingKeyNotFound)
+}
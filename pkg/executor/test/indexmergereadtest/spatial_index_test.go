@@ -0,0 +1,94 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexmergereadtest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestSpatialIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry not null, spatial index sp (g))")
+	tk.MustExec(`insert into t values
+		(1, ST_GeomFromText('POINT(1 1)')),
+		(2, ST_GeomFromText('POINT(5 5)')),
+		(3, ST_GeomFromText('POINT(20 20)')),
+		(4, ST_GeomFromText('LINESTRING(-5 -5,2 2)')),
+		(5, ST_GeomFromText('POLYGON((8 8,30 8,30 30,8 30,8 8))')),
+		(6, Point(-120.5, 35.25))`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry NOT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  SPATIAL KEY `sp` (`g`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t where key_name = 'sp'").Check(testkit.Rows(
+		"t 1 sp 1 g <nil> 0 <nil> <nil>  SPATIAL   YES <nil> NO"))
+	tk.MustQuery("select id, ST_AsText(g), ST_GeometryType(g), ST_SRID(g) from t where id in (4, 6) order by id").Check(testkit.Rows(
+		"4 LINESTRING(-5 -5,2 2) LINESTRING 0",
+		"6 POINT(-120.5 35.25) POINT 0"))
+
+	// The filters on the spatial relation with a constant geometry use the index.
+	sql := "select id from t where ST_Contains(ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0))'), g)"
+	tk.MustQuery("explain format = 'brief' " + sql).CheckContain("IndexMerge")
+	tk.MustQuery("explain format = 'brief' " + sql).CheckContain("index:sp")
+	tk.MustQuery(sql + " order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where ST_Intersects(g, ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0))')) order by id").
+		Check(testkit.Rows("1", "2", "4", "5"))
+	tk.MustQuery("select id from t where MBRWithin(g, ST_GeomFromText('POLYGON((-10 -10,10 -10,10 10,-10 10,-10 -10))')) order by id").
+		Check(testkit.Rows("1", "2", "4"))
+	tk.MustQuery("select id from t where MBRContains(g, Point(10, 10)) order by id").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from t where ST_Within(g, ST_GeomFromText('POLYGON((-180 -90,180 -90,180 90,-180 90,-180 -90))')) order by id").
+		Check(testkit.Rows("1", "2", "3", "4", "5", "6"))
+
+	// The index is maintained by the DML.
+	tk.MustExec("update t set g = Point(3, 3) where id = 3")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("insert into t values (7, ST_GeomFromWKB(ST_AsBinary(Point(9, 1))))")
+	tk.MustQuery(sql + " order by id").Check(testkit.Rows("2", "3", "7"))
+	tk.MustExec("admin check table t")
+
+	// The index is built on the existing rows.
+	tk.MustExec("create table t2 (id int primary key, g geometry not null)")
+	tk.MustExec(`insert into t2 values
+		(1, ST_GeomFromText('POINT(116.4 39.9)', 4326)),
+		(2, ST_GeomFromText('POINT(121.47 31.23)', 4326)),
+		(3, ST_GeomFromGeoJSON('{"type": "Point", "coordinates": [-0.13, 51.51]}'))`)
+	tk.MustExec("alter table t2 add spatial index sp (g)")
+	tk.MustQuery("show create table t2").CheckContain("SPATIAL KEY `sp` (`g`)")
+	tk.MustQuery("select id from t2 where ST_Intersects(g, ST_GeomFromText('POLYGON((100 20,130 20,130 45,100 45,100 20))', 4326)) order by id").
+		Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select round(ST_Distance_Sphere(a.g, b.g)) from t2 a, t2 b where a.id = 1 and b.id = 2").Check(testkit.Rows("1067075"))
+	tk.MustQuery("select ST_AsGeoJSON(g, 1) from t2 where id = 3").Check(testkit.Rows(`{"coordinates": [-0.1, 51.5], "type": "Point"}`))
+	tk.MustExec("admin check table t2")
+
+	tk.MustGetErrCode("insert into t values (8, 'abc')", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("do ST_GeomFromText('POINT(1)')", errno.ErrGISInvalidData)
+	tk.MustGetErrCode("do ST_Contains(ST_GeomFromText('POINT(1 1)', 4326), Point(1, 1))", errno.ErrGISDifferentSRIDs)
+	tk.MustGetErrCode("do ST_Distance_Sphere(Point(190, 0), Point(0, 0))", errno.ErrLongitudeOutOfRange)
+	tk.MustGetErrCode("do ST_Distance_Sphere(Point(0, 100), Point(0, 0))", errno.ErrLatitudeOutOfRange)
+	tk.MustGetErrCode("create table t3 (g geometry, spatial index sp (g))", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t3 (id int not null, spatial index sp (id))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("alter table t add index idx (g)", errno.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("alter table t add spatial index sp2 (id, g)", errno.ErrTooManyKeyParts)
+	tk.MustExec("alter table t drop index sp")
+	tk.MustQuery("explain format = 'brief' " + sql).CheckNotContain("IndexMerge")
+	tk.MustQuery(sql + " order by id").Check(testkit.Rows("2", "3", "7"))
+}
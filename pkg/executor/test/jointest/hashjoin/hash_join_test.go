@@ -461,3 +461,36 @@ func TestIssue31129(t *testing.T) {
 	require.NoError(t, failpoint.Disable(fpName1))
 	require.NoError(t, failpoint.Disable(fpName2))
 }
+
+func TestParallelBuildHashJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	values := make([]string, 0, 500)
+	for i := 0; i < 500; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i%100, i))
+	}
+	tk.MustExec("insert into t1 values " + strings.Join(values, ","))
+	tk.MustExec("insert into t2 values " + strings.Join(values[:300], ",") + ", (null, 1000)")
+	tk.MustExec("set @@tidb_max_chunk_size=32")
+
+	queries := []string{
+		"select /*+ HASH_JOIN_BUILD(t2) */ * from t1 join t2 on t1.a = t2.a",
+		"select /*+ HASH_JOIN_BUILD(t1) */ * from t1 left join t2 on t1.a = t2.a and t1.b < 100",
+		"select /*+ HASH_JOIN_BUILD(t2) */ * from t1 where t1.a not in (select a from t2 where t2.b > 10)",
+		"select /*+ HASH_JOIN_BUILD(t2) */ * from t1 where exists (select 1 from t2 where t1.a = t2.a and t1.b > t2.b)",
+	}
+	for _, query := range queries {
+		tk.MustExec("set @@tidb_hash_join_concurrency=1")
+		expected := tk.MustQuery(query).Sort().Rows()
+		tk.MustExec("set @@tidb_hash_join_concurrency=5")
+		tk.MustQuery(query).Sort().Check(expected)
+		// The build side spills to disk.
+		tk.MustExec("set @@tidb_mem_quota_query=10240")
+		tk.MustQuery(query).Sort().Check(expected)
+		tk.MustExec("set @@tidb_mem_quota_query=default")
+	}
+}
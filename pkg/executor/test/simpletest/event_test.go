@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2035-01-01 00:00:00' comment 'hourly' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 day do select 1", mysql.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 day do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustQuery("select event_schema, event_name, definer, time_zone, event_definition, event_type, execute_at, interval_value, interval_field, " +
+		"starts, ends, status, on_completion, event_comment from information_schema.events").Check(testkit.Rows(
+		"test e1 root@% +00:00 insert into t values (1) RECURRING <nil> 1 HOUR 2035-01-01 00:00:00 <nil> ENABLED NOT PRESERVE hourly"))
+	tk.MustQuery("show events").Check(testkit.Rows(
+		"test e1 +00:00 root@% RECURRING <nil> 1 HOUR 2035-01-01 00:00:00 <nil> ENABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("show events like 'e%'").Check(testkit.Rows(
+		"test e1 +00:00 root@% RECURRING <nil> 1 HOUR 2035-01-01 00:00:00 <nil> ENABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("show events like 'x%'").Check(testkit.Rows())
+	tk.MustQuery("show events from mysql").Check(testkit.Rows())
+
+	// alter the event
+	tk.MustExec("alter event e1 on schedule at '2035-01-01 00:00:00' on completion preserve disable")
+	tk.MustQuery("select event_type, execute_at, interval_value, starts, status, on_completion, event_definition from information_schema.events").Check(testkit.Rows(
+		"ONE TIME 2035-01-01 00:00:00 <nil> <nil> DISABLED PRESERVE insert into t values (1)"))
+	tk.MustExec("alter event e1 enable comment '' do insert into t values (2)")
+	tk.MustQuery("select event_name, status, event_comment, event_definition from information_schema.events").Check(testkit.Rows(
+		"e1 ENABLED  insert into t values (2)"))
+	tk.MustGetErrCode("alter event e1 rename to e1", mysql.ErrEventSameName)
+	tk.MustGetErrCode("alter event e1 rename to no_such_db.e1", mysql.ErrBadDB)
+	tk.MustExec("alter event e1 rename to e2")
+	tk.MustQuery("select event_name, execute_at, status from information_schema.events").Check(testkit.Rows("e2 2035-01-01 00:00:00 ENABLED"))
+	tk.MustGetErrCode("alter event e1 enable", mysql.ErrEventDoesNotExist)
+
+	// the schedule is validated
+	tk.MustGetErrCode("create event e3 on schedule every 0 day do select 1", mysql.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e3 on schedule every 1 day starts '2035-01-02' ends '2035-01-01' do select 1", mysql.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e3 on schedule every 5 month do select 1", mysql.ErrNotSupportedYet)
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' on completion preserve do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+	tk.MustQuery("select event_name, status from information_schema.events").Check(testkit.Rows("e2 ENABLED", "e3 DISABLED"))
+
+	// drop the events
+	tk.MustExec("drop event e3")
+	tk.MustExec("drop event test.e2")
+	tk.MustGetErrCode("drop event e2", mysql.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+	tk.MustQuery("select * from information_schema.events").Check(testkit.Rows())
+
+	// the event name requires a database
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustGetErrCode("create event e1 on schedule every 1 day do select 1", mysql.ErrNoDB)
+	tk1.MustGetErrCode("show events", mysql.ErrNoDB)
+
+	// the EVENT privilege is required
+	tk.MustExec("create user u1")
+	tk.MustExec("create event e1 on schedule every 1 day do select 1")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrCode("create event test.e4 on schedule every 1 day do select 1", mysql.ErrDBaccessDenied)
+	tk2.MustQuery("select event_name from information_schema.events").Check(testkit.Rows())
+	tk.MustExec("grant event on test.* to u1")
+	tk2.MustGetErrCode("create definer = root event test.e4 on schedule every 1 day do select 1", mysql.ErrSpecificAccessDenied)
+	tk2.MustExec("create event test.e4 on schedule every 1 day do select 1")
+	tk2.MustQuery("select event_name, definer from information_schema.events").Check(testkit.Rows("e1 root@%", "e4 u1@%"))
+}
+
+func TestEventExecution(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+
+	tk.MustExec("create event e1 on schedule at now() + interval 2 second on completion preserve do insert into t values (1)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t").Rows()) > 0
+	}, time.Minute, 100*time.Millisecond)
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select status, last_executed is not null from information_schema.events where event_name = 'e1'").Rows()
+		return len(rows) == 1 && rows[0][0] == "DISABLED" && rows[0][1] == "1"
+	}, time.Minute, 100*time.Millisecond)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+
+	// the event is dropped after running without ON COMPLETION PRESERVE
+	tk.MustExec("create event e2 on schedule at now() + interval 2 second do insert into t values (2)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from information_schema.events where event_name = 'e2'").Rows()) == 0
+	}, time.Minute, 100*time.Millisecond)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1", "2"))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3), (3, NULL)")
+
+	tk.MustExec("create materialized view mv1 (a, cnt, cnt_b, sum_b) refresh fast as select a, count(*), count(b), sum(b) from t group by a")
+	tk.MustExec("create materialized view if not exists mv1 refresh fast as select a, count(*) from t group by a")
+	tk.MustGetErrCode("create materialized view mv2 refresh fast as select a, max(b) from t group by a", errno.ErrMaterializedViewNoFastRefresh)
+	tk.MustExec("create materialized view mv2 refresh complete as select a, max(b) from t group by a")
+	tk.MustQuery("select * from mv1 order by a").Check(testkit.Rows("1 2 2 3", "2 1 1 3", "3 1 0 <nil>"))
+	tk.MustQuery("select * from mv2 order by a").Check(testkit.Rows("1 2", "2 3", "3 <nil>"))
+	tk.MustQuery("show create table mv1").Check(testkit.Rows("mv1 CREATE MATERIALIZED VIEW `mv1` (`a`, `cnt`, `cnt_b`, `sum_b`) " +
+		"REFRESH FAST AS SELECT `a`,COUNT(1),COUNT(`b`),SUM(`b`) FROM `test`.`t` GROUP BY `a`"))
+	// The change log is hidden.
+	tk.MustQuery("show tables").Sort().Check(testkit.Rows("mv1", "mv2", "t"))
+
+	// The views are only changed by refreshing.
+	tk.MustGetErrCode("insert into mv1 values (4, 1, 1, 1)", errno.ErrOptOnMaterializedView)
+	tk.MustGetErrCode("update mv1 set cnt = 0", errno.ErrOptOnMaterializedView)
+	tk.MustGetErrCode("delete from mv2", errno.ErrOptOnMaterializedView)
+	tk.MustGetErrCode("drop table mv1", errno.ErrOptOnMaterializedView)
+	tk.MustGetErrCode("truncate table mv1", errno.ErrOptOnMaterializedView)
+	tk.MustGetErrCode("drop table t", errno.ErrOptOnMaterializedViewBase)
+	tk.MustGetErrCode("truncate table t", errno.ErrOptOnMaterializedViewBase)
+	tk.MustGetErrCode("alter table t add column c int", errno.ErrOptOnMaterializedViewBase)
+
+	tk.MustExec("insert into t values (1, 10), (4, 4)")
+	tk.MustExec("update t set b = 5 where a = 2")
+	tk.MustExec("delete from t where a = 3")
+	tk.MustQuery("select * from mv1 order by a").Check(testkit.Rows("1 2 2 3", "2 1 1 3", "3 1 0 <nil>"))
+	tk.MustExec("refresh materialized view mv1")
+	tk.MustQuery("select * from mv1 order by a").Check(testkit.Rows("1 3 3 13", "2 1 1 5", "4 1 1 4"))
+	tk.MustExec("refresh materialized view mv1")
+	tk.MustQuery("select * from mv1 order by a").Check(testkit.Rows("1 3 3 13", "2 1 1 5", "4 1 1 4"))
+	tk.MustExec("refresh materialized view mv2")
+	tk.MustQuery("select * from mv2 order by a").Check(testkit.Rows("1 10", "2 5", "4 4"))
+	tk.MustExec("delete from t where a = 1")
+	tk.MustExec("refresh materialized view mv1 complete")
+	tk.MustQuery("select * from mv1 order by a").Check(testkit.Rows("2 1 1 5", "4 1 1 4"))
+	tk.MustGetErrCode("refresh materialized view t", mysql.ErrWrongObject)
+
+	// The optimizer answers the matching queries by the view.
+	tk.MustExec("insert into t values (2, 1)")
+	tk.MustExec("refresh materialized view mv1")
+	sql := "select a, sum(b) from t group by a"
+	tk.MustQuery("explain " + sql).CheckNotContain("table:_mv")
+	tk.MustExec("set @@tidb_opt_enable_materialized_view_rewrite = on")
+	tk.MustQuery("explain " + sql).CheckContain("table:_mv")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("2 6", "4 4"))
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (5, 5)")
+	tk.MustQuery("explain " + sql).CheckNotContain("table:_mv")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("2 6", "4 4", "5 5"))
+	tk.MustExec("rollback")
+
+	tk.MustExec("drop materialized view mv1")
+	tk.MustGetErrCode("drop materialized view mv1", mysql.ErrBadTable)
+	tk.MustExec("drop materialized view if exists mv1")
+	tk.MustGetErrCode("drop materialized view t", mysql.ErrWrongObject)
+	tk.MustExec("drop materialized view mv2")
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+	tk.MustExec("drop table t")
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create procedure p1(in a int, out b int, inout c varchar(10)) begin set b = a * 2; set c = concat(c, a); end")
+	tk.MustGetErrCode("create procedure p1() select 1", mysql.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition, definer from information_schema.routines").Check(testkit.Rows(
+		"test p1 PROCEDURE begin set b = a * 2; set c = concat(c, a); end root@%"))
+	tk.MustQuery("show procedure status where db = 'test'").CheckAt([]int{0, 1, 2, 3}, testkit.Rows("test p1 PROCEDURE root@%"))
+	require.Len(t, tk.MustQuery("show create procedure p1").Rows(), 1)
+
+	// parameters
+	tk.MustExec("set @b = 1, @c = 'x'")
+	tk.MustExec("call p1(3, @b, @c)")
+	tk.MustQuery("select @b, @c").Check(testkit.Rows("6 x3"))
+	tk.MustGetErrCode("call p1(1, @b)", mysql.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call p1(1, 2, @c)", mysql.ErrSpNotVarArg)
+	tk.MustGetErrCode("call p2()", mysql.ErrSpDoesNotExist)
+
+	// local variables, loops and labels
+	tk.MustExec(`create procedure p2(n int)
+begin
+  declare i int default 0;
+  l1: while i < n do
+    set i = i + 1;
+    if i = 2 then iterate l1; end if;
+    if i > 4 then leave l1; end if;
+    insert into t values (i);
+  end while l1;
+  repeat set i = i - 1; until i <= 3 end repeat;
+  insert into t values (i * 10);
+end`)
+	tk.MustExec("call p2(10)")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "3", "4", "30"))
+
+	// the result set of the procedure
+	tk.MustExec("create procedure p3(x int) select a from t where a > x order by a")
+	tk.MustQuery("call p3(3)").Check(testkit.Rows("4", "30"))
+
+	// cursors and handlers
+	tk.MustExec(`create procedure p4(out total int)
+begin
+  declare done int default 0;
+  declare v int;
+  declare cur cursor for select a from t;
+  declare continue handler for not found set done = 1;
+  set total = 0;
+  open cur;
+  fetch_loop: repeat
+    fetch cur into v;
+    if not done then set total = total + v; end if;
+  until done end repeat fetch_loop;
+  close cur;
+end`)
+	tk.MustExec("call p4(@total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("38"))
+	tk.MustExec(`create procedure p5(out r varchar(20))
+begin
+  declare exit handler for 1146 set r = 'no table';
+  set r = 'start';
+  select * from no_such_table;
+  set r = 'end';
+end`)
+	tk.MustExec("call p5(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("no table"))
+	tk.MustExec("create procedure p6() begin declare c cursor for select 1; close c; end")
+	tk.MustGetErrCode("call p6()", mysql.ErrSpCursorNotOpen)
+	tk.MustExec("create procedure p7(x int) case x when 1 then select 1; end case")
+	tk.MustGetErrCode("call p7(2)", mysql.ErrSpCaseNotFound)
+
+	// invalid definitions
+	tk.MustGetErrCode("create procedure p8(a int, a int) select 1", mysql.ErrSpDupParam)
+	tk.MustGetErrCode("create procedure p8() begin declare a int; declare a int; end", mysql.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure p8() begin declare c cursor for select 1; open d; end", mysql.ErrSpCursorMismatch)
+
+	// drop
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", mysql.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+	tk.MustExec("create database db1")
+	tk.MustExec("create procedure db1.p() select 1")
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from mysql.routines where routine_schema = 'db1'").Check(testkit.Rows("0"))
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+	tk.MustExec("create procedure p_add(n int) insert into t values (n)")
+	tk.MustExec("create procedure p_sum(out s int) set s = (select sum(a) from t)")
+
+	// nested, prepared and internal CALL
+	tk.MustExec("create procedure p_nested(n int) begin declare i int default 0; while i < n do call p_add(i + 10); set i = i + 1; end while; end")
+	tk.MustExec("call p_nested(3)")
+	tk.MustQuery("select a from t where a >= 10 order by a").Check(testkit.Rows("10", "11", "12"))
+	tk.MustExec("prepare stmt from 'call p_add(? * 10)'")
+	tk.MustExec("set @n = 10")
+	tk.MustExec("execute stmt using @n")
+	tk.MustExec("set @n = 20")
+	tk.MustExec("execute stmt using @n")
+	tk.MustQuery("select a from t where a >= 100 order by a").Check(testkit.Rows("100", "200"))
+	tk.MustExec("prepare stmt from 'call p_sum(?)'")
+	tk.MustGetErrCode("execute stmt using @n", mysql.ErrSpNotVarArg)
+	rs, err := tk.Session().ExecuteInternal(kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers), "call p_sum(@s)")
+	require.NoError(t, err)
+	require.Nil(t, rs)
+	tk.MustQuery("select @s").Check(testkit.Rows("339"))
+
+	// the recursive calls are limited by max_sp_recursion_depth
+	tk.MustExec("create procedure p_recursive(n int) begin if n > 0 then call p_recursive(n - 1); insert into t values (n + 1000); end if; end")
+	tk.MustExec("use mysql")
+	tk.MustGetErrCode("call test.p_recursive(3)", mysql.ErrSpRecursionLimit)
+	tk.MustQuery("select database()").Check(testkit.Rows("mysql"))
+	tk.MustExec("use test")
+	tk.MustExec("set @@max_sp_recursion_depth = 2")
+	tk.MustGetErrCode("call p_recursive(3)", mysql.ErrSpRecursionLimit)
+	tk.MustExec("set @@max_sp_recursion_depth = 3")
+	tk.MustExec("call p_recursive(3)")
+	tk.MustQuery("select a from t where a > 1000 order by a").Check(testkit.Rows("1001", "1002", "1003"))
+	tk.MustExec("delete from t where a > 1000")
+	tk.MustExec("set @@max_sp_recursion_depth = default")
+
+	// the memory of the result sets is limited by tidb_mem_quota_query
+	tk.MustExec("create procedure p_select() begin select * from t; select * from t; end")
+	tk.MustQuery("call p_select()").Check(testkit.Rows("1", "2", "3", "10", "11", "12", "100", "200"))
+	tk.MustExec("set @@tidb_mem_quota_query = 1")
+	tk.MustGetErrCode("call p_select()", errno.ErrMemoryExceedForQuery)
+	tk.MustExec("set @@tidb_mem_quota_query = default")
+
+	// the procedure is executed with the privileges of the definer
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+	tk.MustExec("create procedure p_user() select user(), current_user()")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "localhost"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("insert into t values (1000)", mysql.ErrTableaccessDenied)
+	tk1.MustExec("call p_add(1000)")
+	tk1.MustQuery("call p_user()").Check(testkit.Rows("u1@localhost root@%"))
+	tk1.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+	tk.MustQuery("select count(*) from t where a = 1000").Check(testkit.Rows("1"))
+	tk.MustExec("revoke execute on test.* from 'u1'@'%'")
+	tk1.MustGetErrCode("call p_add(1000)", mysql.ErrProcaccessDenied)
+}
@@ -37,162 +37,157 @@ type WindowExec struct {
 	childResult *chunk.Chunk
 	// executed indicates the child executor is drained or something unexpected happened.
 	executed bool
-	// resultChunks stores the chunks to return
-	resultChunks []*chunk.Chunk
-	// remainingRowsInChunk indicates how many rows the resultChunks[i] is not prepared.
-	remainingRowsInChunk []int
+	// partition buffers the rows of the partition being processed.
+	partition *windowPartition
+	// resultIdx is the offset of the next row in partition to be returned.
+	resultIdx uint64
+	// childColIdxs are the offsets of the child columns in the output.
+	childColIdxs []int
+	// batchSizes are the rows of the child chunks whose results are not
+	// returned yet. The results of each child chunk are returned in one chunk,
+	// so the order and batching of the child rows are kept in the output.
+	batchSizes []int
 
 	numWindowFuncs int
 	processor      windowProcessor
 }
 
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.executed = false
+	e.resultIdx = 0
+	e.batchSizes = e.batchSizes[:0]
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	if e.partition == nil {
+		e.partition = newWindowPartition(e.Ctx(), e.ID(), exec.RetTypes(e.Children(0)), e.MaxChunkSize())
+	}
+	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
+	e.childColIdxs = make([]int, 0, len(columns))
+	for _, col := range columns {
+		e.childColIdxs = append(e.childColIdxs, col.Index)
+	}
+	return nil
+}
+
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
+	if e.partition != nil {
+		e.partition.close()
+		e.partition = nil
+	}
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
 // Next implements the Executor Next interface.
 func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 	chk.Reset()
-	for !e.executed && !e.preparedChunkAvailable() {
-		err := e.consumeOneGroup(ctx)
-		if err != nil {
+	for {
+		if len(e.batchSizes) > 0 && chk.NumRows() == e.batchSizes[0] {
+			e.batchSizes = e.batchSizes[1:]
+			break
+		}
+		if e.resultIdx == e.partition.numRows {
+			if e.executed {
+				break
+			}
+			if err := e.consumeOnePartition(ctx); err != nil {
+				e.executed = true
+				return err
+			}
+			continue
+		}
+		numRows := min(e.partition.numRows-e.resultIdx, uint64(e.batchSizes[0]-chk.NumRows()))
+		if err := e.appendResult2Chunk(chk, numRows); err != nil {
 			e.executed = true
 			return err
 		}
 	}
-	if len(e.resultChunks) > 0 {
-		chk.SwapColumns(e.resultChunks[0])
-		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
-		e.resultChunks = e.resultChunks[1:]
-		e.remainingRowsInChunk = e.remainingRowsInChunk[1:]
-	}
 	return nil
 }
 
-func (e *WindowExec) preparedChunkAvailable() bool {
-	return len(e.resultChunks) > 0 && e.remainingRowsInChunk[0] == 0
-}
-
-func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
-	if e.groupChecker.IsExhausted() {
-		eof, err := e.fetchChild(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		if eof {
-			e.executed = true
-			return e.consumeGroupRows(groupRows)
-		}
-		_, err = e.groupChecker.SplitIntoGroups(e.childResult)
-		if err != nil {
-			return errors.Trace(err)
-		}
+// consumeOnePartition buffers the rows of the next partition and feeds them
+// to the window functions.
+func (e *WindowExec) consumeOnePartition(ctx context.Context) error {
+	if e.partition.numRows > 0 {
+		e.processor.resetPartialResult()
+		e.partition.reset()
+		e.resultIdx = 0
 	}
-	begin, end := e.groupChecker.GetNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
+	if err := e.fetchPartition(ctx); err != nil {
+		return err
 	}
-
-	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
-		meetLastGroup = false
-		eof, err := e.fetchChild(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		if eof {
-			e.executed = true
-			return e.consumeGroupRows(groupRows)
-		}
-
-		isFirstGroupSameAsPrev, err := e.groupChecker.SplitIntoGroups(e.childResult)
-		if err != nil {
-			return errors.Trace(err)
-		}
-
-		if isFirstGroupSameAsPrev {
-			begin, end = e.groupChecker.GetNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
-			}
-			meetLastGroup = end == e.childResult.NumRows()
-		}
+	if e.partition.numRows == 0 {
+		return nil
 	}
-	return e.consumeGroupRows(groupRows)
+	return e.processor.consumeGroupRows(e.Ctx(), e.partition)
 }
 
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
-	if remainingRowsInGroup == 0 {
-		return nil
-	}
-	for i := 0; i < len(e.resultChunks); i++ {
-		remained := min(e.remainingRowsInChunk[i], remainingRowsInGroup)
-		e.remainingRowsInChunk[i] -= remained
-		remainingRowsInGroup -= remained
-
-		// TODO: Combine these three methods.
-		// The old implementation needs the processor has these three methods
-		// but now it does not have to.
-		groupRows, err = e.processor.consumeGroupRows(e.Ctx(), groupRows)
-		if err != nil {
-			return errors.Trace(err)
+func (e *WindowExec) fetchPartition(ctx context.Context) error {
+	for {
+		if e.groupChecker.IsExhausted() {
+			eof, err := e.fetchChild(ctx)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if eof {
+				e.executed = true
+				return nil
+			}
+			isFirstGroupSameAsPrev, err := e.groupChecker.SplitIntoGroups(e.childResult)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !isFirstGroupSameAsPrev && e.partition.numRows > 0 {
+				return nil
+			}
 		}
-		_, err = e.processor.appendResult2Chunk(e.Ctx(), groupRows, e.resultChunks[i], remained)
-		if err != nil {
-			return errors.Trace(err)
+		begin, end := e.groupChecker.GetNextGroup()
+		if err := e.partition.appendRows(e.childResult, begin, end); err != nil {
+			return err
 		}
-		if remainingRowsInGroup == 0 {
-			e.processor.resetPartialResult()
-			break
+		if end != e.childResult.NumRows() {
+			return nil
 		}
 	}
-	return nil
 }
 
 func (e *WindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
-	childResult := exec.TryNewCacheChunk(e.Children(0))
-	err = exec.Next(ctx, e.Children(0), childResult)
+	err = exec.Next(ctx, e.Children(0), e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
+	if e.childResult.NumRows() == 0 {
 		return true, nil
 	}
-
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
-	e.resultChunks = append(e.resultChunks, resultChk)
-	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
-
-	e.childResult = childResult
+	e.batchSizes = append(e.batchSizes, e.childResult.NumRows())
 	return false, nil
 }
 
-func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
+// appendResult2Chunk appends the next numRows rows in partition to chk.
+func (e *WindowExec) appendResult2Chunk(chk *chunk.Chunk, numRows uint64) error {
+	for i := e.resultIdx; i < e.resultIdx+numRows; i++ {
+		row, err := e.partition.getRow(i)
+		if err != nil {
 			return err
 		}
+		chk.AppendPartialRowByColIdxs(0, row, e.childColIdxs)
 	}
-	return nil
+	e.resultIdx += numRows
+	return e.processor.appendResult2Chunk(e.Ctx(), e.partition, chk, int(numRows))
 }
 
 // windowProcessor is the interface for processing different kinds of windows.
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
-	// appendResult2Chunk appends the final results to chunk.
-	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	consumeGroupRows(ctx sessionctx.Context, rows *windowPartition) error
+	// appendResult2Chunk appends the final results of the next remained rows to chunk.
+	// It is called when all the rows in current partition are consumed.
+	appendResult2Chunk(ctx sessionctx.Context, rows *windowPartition, chk *chunk.Chunk, remained int) error
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -202,30 +197,29 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowPartition) error {
 	for i, windowFunc := range p.windowFuncs {
 		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx, rows, p.partialResults[i])
+		err := rows.updatePartialResult(ctx, windowFunc, p.partialResults[i], 0, rows.numRows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-	rows = rows[:0]
-	return rows, nil
+	return nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, _ *windowPartition, chk *chunk.Chunk, remained int) error {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
 			err := windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 		remained--
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *aggWindowProcessor) resetPartialResult() {
@@ -288,12 +282,12 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (*rowFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rowFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowPartition) error {
+	return nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowPartition, chk *chunk.Chunk, remained int) error {
+	numRows := rows.numRows
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -321,16 +315,14 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = rows.slide(ctx, slidingWindowAggFunc, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -339,24 +331,16 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = rows.slide(ctx, slidingWindowAggFunc, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
-				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
-				// whether elements inside deque are out of current window.
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
-				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+				err = rows.updatePartialResult(ctx, windowFunc, p.partialResults[i], start, end)
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -369,7 +353,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -389,16 +373,22 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows *windowPartition) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
-	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
+	for ; p.lastStartOffset < rows.numRows; p.lastStartOffset++ {
+		row, err := rows.getRow(p.lastStartOffset)
+		if err != nil {
+			return 0, err
+		}
 		var res int64
-		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx, p.start.CompareCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx, p.start.CompareCols[i], p.start.CalcFuncs[i], row, curRow)
 			if err != nil {
 				return 0, err
 			}
@@ -415,16 +405,22 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows *windowPartition) (uint64, error) {
 	if p.end.UnBounded {
-		return numRows, nil
+		return rows.numRows, nil
 	}
-	for ; p.lastEndOffset < numRows; p.lastEndOffset++ {
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
+	for ; p.lastEndOffset < rows.numRows; p.lastEndOffset++ {
+		row, err := rows.getRow(p.lastEndOffset)
+		if err != nil {
+			return 0, err
+		}
 		var res int64
-		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.end.CompareCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.end.CompareCols[i], curRow, row)
 			if err != nil {
 				return 0, err
 			}
@@ -441,7 +437,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowPartition, chk *chunk.Chunk, remained int) error {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -461,11 +457,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		p.curRowIdx++
 		remained--
@@ -475,16 +471,14 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = rows.slide(ctx, slidingWindowAggFunc, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -493,21 +487,16 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = rows.slide(ctx, slidingWindowAggFunc, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
-				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+				err = rows.updatePartialResult(ctx, windowFunc, p.partialResults[i], start, end)
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -520,11 +509,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
-func (*rangeFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rangeFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowPartition) error {
+	return nil
 }
 
 func (p *rangeFrameWindowProcessor) resetPartialResult() {
@@ -0,0 +1,311 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/executor/aggfuncs"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+// windowPartition buffers the rows of the partition being processed by the
+// window executors. The rows are addressed by their offsets in the partition
+// and stored in chunks of chunkSize rows.
+//
+// If tidb_enable_tmp_storage_on_oom is on and the memory quota of the query is
+// exceeded, windowSpillDiskAction puts the partition in spill mode, and the
+// full chunks are written into a chunk.DataInDiskByChunks since then. The
+// spilled chunks are read back when their rows are accessed again, e.g. by the
+// frames that need random access.
+type windowPartition struct {
+	fieldTypes []*types.FieldType
+	chunkSize  int
+
+	// chunks[i] is nil if the i-th chunk has been spilled or dropped.
+	chunks []*chunk.Chunk
+	// diskChunkIdx[i] is the index of the i-th chunk in dataInDisk, or -1 if
+	// it's not spilled.
+	diskChunkIdx []int
+	numRows      uint64
+	// spillCursor and dropCursor indicate the chunks before them have been
+	// handled by spillFullChunks and dropBefore.
+	spillCursor int
+	dropCursor  int
+
+	dataInDisk *chunk.DataInDiskByChunks
+	// inSpillMode is set by windowSpillDiskAction. Once it's set, the full
+	// chunks of the following partitions are spilled as well.
+	inSpillMode uint32
+
+	// cachedChks keeps the chunks recently read back from disk.
+	cachedChks    [2]windowCachedChunk
+	nextCacheSlot int
+
+	rowBuf []chunk.Row
+	// nullRow is returned by getRowForSlide when it fails to read the row.
+	nullRow chunk.Row
+	// slideErr is the error met by getRowForSlide.
+	slideErr error
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	spillAction *windowSpillDiskAction
+}
+
+type windowCachedChunk struct {
+	chkIdx int
+	chk    *chunk.Chunk
+}
+
+func newWindowPartition(sctx sessionctx.Context, id int, fieldTypes []*types.FieldType, chunkSize int) *windowPartition {
+	p := &windowPartition{
+		fieldTypes:  fieldTypes,
+		chunkSize:   chunkSize,
+		memTracker:  memory.NewTracker(id, -1),
+		diskTracker: disk.NewTracker(id, -1),
+	}
+	for i := range p.cachedChks {
+		p.cachedChks[i].chkIdx = -1
+	}
+	p.memTracker.AttachTo(sctx.GetSessionVars().StmtCtx.MemTracker)
+	p.diskTracker.AttachTo(sctx.GetSessionVars().StmtCtx.DiskTracker)
+	if variable.EnableTmpStorageOnOOM.Load() {
+		p.spillAction = &windowSpillDiskAction{partition: p}
+		sctx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(p.spillAction)
+		failpoint.Inject("testWindowSpill", func(val failpoint.Value) {
+			if val.(bool) {
+				atomic.StoreUint32(&p.inSpillMode, 1)
+			}
+		})
+	}
+	return p
+}
+
+// appendRows appends the rows in [begin, end) of chk to the partition.
+func (p *windowPartition) appendRows(chk *chunk.Chunk, begin, end int) error {
+	for begin < end {
+		if p.numRows%uint64(p.chunkSize) == 0 {
+			p.chunks = append(p.chunks, chunk.NewChunkWithCapacity(p.fieldTypes, p.chunkSize))
+			p.diskChunkIdx = append(p.diskChunkIdx, -1)
+		}
+		tail := p.chunks[len(p.chunks)-1]
+		n := min(end-begin, p.chunkSize-tail.NumRows())
+		oldMemUsage := tail.MemoryUsage()
+		tail.Append(chk, begin, begin+n)
+		p.memTracker.Consume(tail.MemoryUsage() - oldMemUsage)
+		p.numRows += uint64(n)
+		begin += n
+	}
+	if atomic.LoadUint32(&p.inSpillMode) == 1 {
+		return p.spillFullChunks()
+	}
+	return nil
+}
+
+// spillFullChunks writes the full chunks kept in memory into disk.
+func (p *windowPartition) spillFullChunks() error {
+	numFullChunks := int(p.numRows / uint64(p.chunkSize))
+	for ; p.spillCursor < numFullChunks; p.spillCursor++ {
+		chk := p.chunks[p.spillCursor]
+		if chk == nil {
+			continue
+		}
+		if p.dataInDisk == nil {
+			p.dataInDisk = chunk.NewDataInDiskByChunks(p.fieldTypes)
+			p.dataInDisk.GetDiskTracker().AttachTo(p.diskTracker)
+		}
+		if err := p.dataInDisk.Add(chk); err != nil {
+			return err
+		}
+		p.diskChunkIdx[p.spillCursor] = p.dataInDisk.NumChunks() - 1
+		p.chunks[p.spillCursor] = nil
+		p.memTracker.Consume(-chk.MemoryUsage())
+	}
+	return nil
+}
+
+func (p *windowPartition) getChunk(chkIdx int) (*chunk.Chunk, error) {
+	if chk := p.chunks[chkIdx]; chk != nil {
+		return chk, nil
+	}
+	diskIdx := p.diskChunkIdx[chkIdx]
+	if diskIdx < 0 {
+		return nil, errors.Errorf("chunk %d of the window partition has been dropped", chkIdx)
+	}
+	for _, cached := range p.cachedChks {
+		if cached.chkIdx == chkIdx {
+			return cached.chk, nil
+		}
+	}
+	chk, err := p.dataInDisk.GetChunk(diskIdx)
+	if err != nil {
+		return nil, err
+	}
+	slot := &p.cachedChks[p.nextCacheSlot]
+	p.nextCacheSlot = (p.nextCacheSlot + 1) % len(p.cachedChks)
+	if slot.chk != nil {
+		p.memTracker.Consume(-slot.chk.MemoryUsage())
+	}
+	slot.chkIdx, slot.chk = chkIdx, chk
+	p.memTracker.Consume(chk.MemoryUsage())
+	return chk, nil
+}
+
+func (p *windowPartition) getRow(idx uint64) (chunk.Row, error) {
+	chk, err := p.getChunk(int(idx / uint64(p.chunkSize)))
+	if err != nil {
+		return chunk.Row{}, err
+	}
+	return chk.GetRow(int(idx % uint64(p.chunkSize))), nil
+}
+
+// getRowForSlide is passed to SlidingWindowAggFunc.Slide, which can't handle
+// errors. The error is kept in slideErr and a row of NULLs is returned instead,
+// the caller should check slideErr after sliding.
+func (p *windowPartition) getRowForSlide(idx uint64) chunk.Row {
+	row, err := p.getRow(idx)
+	if err == nil {
+		return row
+	}
+	if p.slideErr == nil {
+		p.slideErr = err
+	}
+	if p.nullRow.Chunk() == nil {
+		chk := chunk.NewChunkWithCapacity(p.fieldTypes, 1)
+		for i := range p.fieldTypes {
+			chk.AppendNull(i)
+		}
+		p.nullRow = chk.GetRow(0)
+	}
+	return p.nullRow
+}
+
+// slide calls SlidingWindowAggFunc.Slide with the rows of the partition.
+func (p *windowPartition) slide(ctx sessionctx.Context, windowFunc aggfuncs.SlidingWindowAggFunc, lastStart, lastEnd, shiftStart, shiftEnd uint64, pr aggfuncs.PartialResult) error {
+	p.slideErr = nil
+	if err := windowFunc.Slide(ctx, p.getRowForSlide, lastStart, lastEnd, shiftStart, shiftEnd, pr); err != nil {
+		return err
+	}
+	return p.slideErr
+}
+
+// updatePartialResult updates the partial result with the rows in
+// [start, end), which are passed to the window function chunk by chunk.
+func (p *windowPartition) updatePartialResult(ctx sessionctx.Context, windowFunc aggfuncs.AggFunc, pr aggfuncs.PartialResult, start, end uint64) error {
+	for start < end {
+		chk, err := p.getChunk(int(start / uint64(p.chunkSize)))
+		if err != nil {
+			return err
+		}
+		rowIdx := int(start % uint64(p.chunkSize))
+		n := min(end-start, uint64(chk.NumRows()-rowIdx))
+		p.rowBuf = p.rowBuf[:0]
+		for i := 0; i < int(n); i++ {
+			p.rowBuf = append(p.rowBuf, chk.GetRow(rowIdx+i))
+		}
+		// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
+		// whether elements inside deque are out of current window.
+		if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+			minMaxSlidingWindowAggFunc.SetWindowStart(start)
+		}
+		if _, err = windowFunc.UpdatePartialResult(ctx, p.rowBuf, pr); err != nil {
+			return err
+		}
+		start += n
+	}
+	return nil
+}
+
+// dropBefore releases the full chunks whose rows are all before offset, they
+// are not accessed any more.
+func (p *windowPartition) dropBefore(offset uint64) {
+	numDroppable := int(min(offset, p.numRows) / uint64(p.chunkSize))
+	for ; p.dropCursor < numDroppable; p.dropCursor++ {
+		if chk := p.chunks[p.dropCursor]; chk != nil {
+			p.memTracker.Consume(-chk.MemoryUsage())
+			p.chunks[p.dropCursor] = nil
+		}
+		p.diskChunkIdx[p.dropCursor] = -1
+	}
+}
+
+// reset clears the partition to buffer the rows of the next partition.
+func (p *windowPartition) reset() {
+	p.chunks = p.chunks[:0]
+	p.diskChunkIdx = p.diskChunkIdx[:0]
+	p.numRows = 0
+	p.spillCursor, p.dropCursor = 0, 0
+	for i := range p.cachedChks {
+		p.cachedChks[i] = windowCachedChunk{chkIdx: -1}
+	}
+	p.nextCacheSlot = 0
+	p.rowBuf = p.rowBuf[:0]
+	p.memTracker.Consume(-p.memTracker.BytesConsumed())
+	if p.dataInDisk != nil {
+		p.dataInDisk.Close()
+		p.dataInDisk.GetDiskTracker().Detach()
+		p.dataInDisk = nil
+	}
+}
+
+// close releases the partition, detaches the trackers and finishes the spill
+// action registered by newWindowPartition.
+func (p *windowPartition) close() {
+	p.reset()
+	p.memTracker.Detach()
+	p.diskTracker.Detach()
+	if p.spillAction != nil {
+		p.spillAction.SetFinished()
+	}
+}
+
+// windowSpillDiskAction implements memory.ActionOnExceed for the window
+// executors. If the memory quota of a query is exceeded,
+// windowSpillDiskAction.Action puts the windowPartition in spill mode.
+type windowSpillDiskAction struct {
+	memory.BaseOOMAction
+	partition *windowPartition
+}
+
+// Action sets the windowPartition in spill mode.
+func (a *windowSpillDiskAction) Action(t *memory.Tracker) {
+	// Guarantee that the buffered rows are at least 20% of the threshold, to avoid spilling in vain.
+	if atomic.LoadUint32(&a.partition.inSpillMode) == 0 && a.partition.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
+		logutil.BgLogger().Info("memory exceeds quota, set window partition to spill-mode",
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		atomic.StoreUint32(&a.partition.inSpillMode, 1)
+		memory.QueryForceDisk.Add(1)
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (*windowSpillDiskAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
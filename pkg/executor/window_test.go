@@ -17,10 +17,13 @@ package executor_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWindowFunctions(t *testing.T) {
@@ -470,3 +473,44 @@ func TestIssue45964And46050(t *testing.T) {
 	testReturnColumnNullableAttribute(tk, "cume_dist()", false)
 	testReturnColumnNullableAttribute(tk, "percent_rank()", false)
 }
+
+func TestWindowSpillToDisk(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("SET GLOBAL tidb_enable_tmp_storage_on_oom = 1")
+	defer tk.MustExec("SET GLOBAL tidb_enable_tmp_storage_on_oom = 0")
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int)")
+	values := make([]string, 0, 500)
+	for i := 0; i < 500; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i%3, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+
+	queries := []string{
+		"select a, b, row_number() over (partition by a order by b) from t",
+		"select a, b, sum(b) over (partition by a), count(*) over () from t",
+		"select a, b, sum(b) over (partition by a order by b rows between 10 preceding and 10 following) from t",
+		"select a, b, max(b) over (partition by a order by b rows between 50 preceding and 3 preceding) from t",
+		"select a, b, count(b) over (partition by a order by b range between 20 preceding and 5 following) from t",
+		"select a, b, first_value(b) over (partition by a order by b rows between 100 following and unbounded following) from t",
+		"select a, b, lead(b, 40) over (partition by a order by b), rank() over (order by a) from t",
+	}
+	for _, pipelined := range []int{0, 1} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %d", pipelined))
+		expected := make([][][]any, 0, len(queries))
+		for _, q := range queries {
+			expected = append(expected, tk.MustQuery(q).Sort().Rows())
+		}
+
+		require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/testWindowSpill", "return(true)"))
+		for i, q := range queries {
+			tk.MustQuery(q).Sort().Check(expected[i])
+			require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0), q)
+		}
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/executor/testWindowSpill"))
+	}
+}
@@ -813,6 +813,10 @@ var funcs = map[string]functionClass{
 	ast.GetLock:     &lockFunctionClass{baseFunctionClass{ast.GetLock, 2, 2}},
 	ast.ReleaseLock: &releaseLockFunctionClass{baseFunctionClass{ast.ReleaseLock, 1, 1}},
 
+	// full-text search functions
+	ast.TiDBFullTextTokenize: &fullTextTokenizeFunctionClass{baseFunctionClass{ast.TiDBFullTextTokenize, 2, -1}},
+	ast.TiDBFullTextMatch:    &fullTextMatchFunctionClass{baseFunctionClass{ast.TiDBFullTextMatch, 4, -1}},
+
 	ast.LogicAnd:           &logicAndFunctionClass{baseFunctionClass{ast.LogicAnd, 2, 2}},
 	ast.LogicOr:            &logicOrFunctionClass{baseFunctionClass{ast.LogicOr, 2, 2}},
 	ast.LogicXor:           &logicXorFunctionClass{baseFunctionClass{ast.LogicXor, 2, 2}},
@@ -904,6 +908,36 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// spatial functions
+	ast.STGeomFromText:     &geomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STGeometryFromText: &geomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}},
+	ast.STGeomFromWKB:      &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 2}},
+	ast.STGeometryFromWKB:  &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 2}},
+	ast.STGeomFromGeoJSON:  &geomFromGeoJSONFunctionClass{baseFunctionClass{ast.STGeomFromGeoJSON, 1, 3}},
+	ast.STAsText:           &asTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKT:            &asTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STAsBinary:         &asBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 1}},
+	ast.STAsWKB:            &asBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 1}},
+	ast.STAsGeoJSON:        &asGeoJSONFunctionClass{baseFunctionClass{ast.STAsGeoJSON, 1, 2}},
+	ast.Point:              &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.STX:                &pointCoordFunctionClass{baseFunctionClass{ast.STX, 1, 1}},
+	ast.STY:                &pointCoordFunctionClass{baseFunctionClass{ast.STY, 1, 1}},
+	ast.STSRID:             &sridFunctionClass{baseFunctionClass{ast.STSRID, 1, 1}},
+	ast.STGeometryType:     &geometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STIsEmpty:          &isEmptyFunctionClass{baseFunctionClass{ast.STIsEmpty, 1, 1}},
+	ast.STArea:             &areaFunctionClass{baseFunctionClass{ast.STArea, 1, 1}},
+	ast.STContains:         &spatialRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STWithin:           &spatialRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STIntersects:       &spatialRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+	ast.STDisjoint:         &spatialRelationFunctionClass{baseFunctionClass{ast.STDisjoint, 2, 2}},
+	ast.MBRContains:        &spatialRelationFunctionClass{baseFunctionClass{ast.MBRContains, 2, 2}},
+	ast.MBRWithin:          &spatialRelationFunctionClass{baseFunctionClass{ast.MBRWithin, 2, 2}},
+	ast.MBRIntersects:      &spatialRelationFunctionClass{baseFunctionClass{ast.MBRIntersects, 2, 2}},
+	ast.MBRDisjoint:        &spatialRelationFunctionClass{baseFunctionClass{ast.MBRDisjoint, 2, 2}},
+	ast.STDistance:         &distanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STDistanceSphere:   &distanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
+	ast.TiDBSpatialCells:   &spatialCellsFunctionClass{baseFunctionClass{ast.TiDBSpatialCells, 1, 1}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
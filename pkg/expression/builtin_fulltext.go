@@ -0,0 +1,177 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+)
+
+var (
+	_ functionClass = &fullTextTokenizeFunctionClass{}
+	_ functionClass = &fullTextMatchFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinFullTextTokenizeSig{}
+	_ builtinFunc = &builtinFullTextMatchSig{}
+)
+
+// getFullTextParser returns the parser named by the constant argument.
+func getFullTextParser(ctx sessionctx.Context, arg Expression) (fulltext.Parser, error) {
+	c, ok := arg.(*Constant)
+	if !ok {
+		return nil, ErrIncorrectType.GenWithStackByArgs("parser", "full-text function")
+	}
+	name, isNull, err := c.EvalString(ctx, chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+	p, ok := fulltext.GetParser(name)
+	if isNull || !ok {
+		return nil, errors.Errorf("full-text parser %s is not supported", name)
+	}
+	return p, nil
+}
+
+// fullTextTokenizeFunctionClass is the class of `tidb_fulltext_tokenize(parser, str...)`, which
+// returns the JSON array of the distinct tokens of the strings. It is the expression of the
+// hidden column indexed by a FULLTEXT index.
+type fullTextTokenizeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fullTextTokenizeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	p, err := getFullTextParser(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	for i := range argTps {
+		argTps[i] = types.ETString
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinFullTextTokenizeSig{bf, p}
+	return sig, nil
+}
+
+type builtinFullTextTokenizeSig struct {
+	baseBuiltinFunc
+	parser fulltext.Parser
+}
+
+func (b *builtinFullTextTokenizeSig) Clone() builtinFunc {
+	newSig := &builtinFullTextTokenizeSig{parser: b.parser}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals tidb_fulltext_tokenize(parser, str...). The NULL strings are ignored.
+func (b *builtinFullTextTokenizeSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	texts := make([]string, 0, len(b.args)-1)
+	for _, arg := range b.args[1:] {
+		text, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return types.BinaryJSON{}, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	tokens := fulltext.DistinctTokens(b.parser, texts...)
+	vals := make([]any, 0, len(tokens))
+	for _, token := range tokens {
+		vals = append(vals, token)
+	}
+	return types.CreateBinaryJSON(vals), false, nil
+}
+
+// fullTextMatchFunctionClass is the class of `tidb_fulltext_match(mode, parser, against, str...)`,
+// which `MATCH (col...) AGAINST (against mode)` is rewritten to. It returns the relevance of the
+// strings, and 0 if they don't match the query.
+type fullTextMatchFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fullTextMatchFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	p, err := getFullTextParser(ctx, args[1])
+	if err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	argTps[0] = types.ETInt
+	for i := 1; i < len(argTps); i++ {
+		argTps[i] = types.ETString
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	sig := &builtinFullTextMatchSig{bf, p}
+	return sig, nil
+}
+
+type builtinFullTextMatchSig struct {
+	baseBuiltinFunc
+	parser fulltext.Parser
+}
+
+func (b *builtinFullTextMatchSig) Clone() builtinFunc {
+	newSig := &builtinFullTextMatchSig{parser: b.parser}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals tidb_fulltext_match(mode, parser, against, str...).
+func (b *builtinFullTextMatchSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	mode, isNull, err := b.args[0].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	against, isNull, err := b.args[2].EvalString(ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull {
+		return 0, false, nil
+	}
+	query := fulltext.ParseQuery(b.parser, against, fulltext.Mode(mode))
+	texts := make([]string, 0, len(b.args)-3)
+	for _, arg := range b.args[3:] {
+		text, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	return query.Score(fulltext.NewDocument(b.parser, texts...)), false, nil
+}
@@ -0,0 +1,823 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/geo"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+var (
+	_ functionClass = &geomFromTextFunctionClass{}
+	_ functionClass = &geomFromWKBFunctionClass{}
+	_ functionClass = &geomFromGeoJSONFunctionClass{}
+	_ functionClass = &asTextFunctionClass{}
+	_ functionClass = &asBinaryFunctionClass{}
+	_ functionClass = &asGeoJSONFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &pointCoordFunctionClass{}
+	_ functionClass = &sridFunctionClass{}
+	_ functionClass = &geometryTypeFunctionClass{}
+	_ functionClass = &isEmptyFunctionClass{}
+	_ functionClass = &areaFunctionClass{}
+	_ functionClass = &spatialRelationFunctionClass{}
+	_ functionClass = &distanceFunctionClass{}
+	_ functionClass = &distanceSphereFunctionClass{}
+	_ functionClass = &spatialCellsFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinGeomFromSig{}
+	_ builtinFunc = &builtinAsTextSig{}
+	_ builtinFunc = &builtinAsBinarySig{}
+	_ builtinFunc = &builtinAsGeoJSONSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinPointCoordSig{}
+	_ builtinFunc = &builtinSRIDSig{}
+	_ builtinFunc = &builtinGeometryTypeSig{}
+	_ builtinFunc = &builtinIsEmptySig{}
+	_ builtinFunc = &builtinAreaSig{}
+	_ builtinFunc = &builtinSpatialRelationSig{}
+	_ builtinFunc = &builtinDistanceSig{}
+	_ builtinFunc = &builtinDistanceSphereSig{}
+	_ builtinFunc = &builtinSpatialCellsSig{}
+)
+
+// geoJSONDefaultSRID is the SRID of the geometries parsed from GeoJSON, which is WGS 84.
+const geoJSONDefaultSRID = 4326
+
+// setGeometryRetTp sets the return type of a function returning a geometry.
+func setGeometryRetTp(bf *baseBuiltinFunc) {
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetFlen(types.UnspecifiedLength)
+	types.SetBinChsClnFlag(bf.tp)
+}
+
+// evalGeometry evaluates the argument as a geometry of the function.
+func evalGeometry(ctx EvalContext, funcName string, arg Expression, row chunk.Row) (*geo.Geometry, bool, error) {
+	data, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return nil, true, err
+	}
+	g, err := geo.Decode(hack.Slice(data))
+	if err != nil {
+		return nil, true, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalGeometryPair evaluates the first two arguments as the geometries of a binary function,
+// which must have the same SRID.
+func evalGeometryPair(ctx EvalContext, funcName string, args []Expression, row chunk.Row) (a, b *geo.Geometry, isNull bool, err error) {
+	a, isNull, err = evalGeometry(ctx, funcName, args[0], row)
+	if isNull || err != nil {
+		return nil, nil, true, err
+	}
+	b, isNull, err = evalGeometry(ctx, funcName, args[1], row)
+	if isNull || err != nil {
+		return nil, nil, true, err
+	}
+	if a.SRID != b.SRID {
+		return nil, nil, true, errGISDifferentSRIDs.GenWithStackByArgs(funcName, a.SRID, b.SRID)
+	}
+	return a, b, false, nil
+}
+
+// geomFromTextFunctionClass is the class of `ST_GeomFromText(wkt[, srid])`.
+type geomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	return newGeomFromSig(ctx, &c.baseFunctionClass, args, 1, 0, func(data string) (*geo.Geometry, error) {
+		return geo.ParseWKT(data)
+	})
+}
+
+// geomFromWKBFunctionClass is the class of `ST_GeomFromWKB(wkb[, srid])`.
+type geomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	return newGeomFromSig(ctx, &c.baseFunctionClass, args, 1, 0, func(data string) (*geo.Geometry, error) {
+		return geo.ParseWKB(hack.Slice(data))
+	})
+}
+
+// geomFromGeoJSONFunctionClass is the class of `ST_GeomFromGeoJSON(str[, options[, srid]])`. The
+// coordinates of higher dimensions are always stripped, so the options are ignored.
+type geomFromGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	return newGeomFromSig(ctx, &c.baseFunctionClass, args, 2, geoJSONDefaultSRID, func(data string) (*geo.Geometry, error) {
+		return geo.ParseGeoJSON(hack.Slice(data))
+	})
+}
+
+// newGeomFromSig creates the signature of a function parsing the first argument to a geometry,
+// the optional SRID is the argument at sridIdx.
+func newGeomFromSig(ctx sessionctx.Context, c *baseFunctionClass, args []Expression, sridIdx int, defaultSRID uint32,
+	parse func(string) (*geo.Geometry, error)) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	argTps[0] = types.ETString
+	for i := 1; i < len(args); i++ {
+		argTps[i] = types.ETInt
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf)
+	sig := &builtinGeomFromSig{bf, c.funcName, parse, sridIdx, defaultSRID}
+	return sig, nil
+}
+
+type builtinGeomFromSig struct {
+	baseBuiltinFunc
+	funcName    string
+	parse       func(string) (*geo.Geometry, error)
+	sridIdx     int
+	defaultSRID uint32
+}
+
+func (b *builtinGeomFromSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromSig{funcName: b.funcName, parse: b.parse, sridIdx: b.sridIdx, defaultSRID: b.defaultSRID}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals the functions constructing a geometry from WKT, WKB or GeoJSON.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html
+func (b *builtinGeomFromSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	data, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	srid := int64(b.defaultSRID)
+	if len(b.args) > b.sridIdx {
+		srid, isNull, err = b.args[b.sridIdx].EvalInt(ctx, row)
+		if isNull || err != nil {
+			return "", true, err
+		}
+		if srid < 0 || srid > math.MaxUint32 {
+			return "", true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+	}
+	g, err := b.parse(data)
+	if err != nil {
+		return "", true, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	g.SetSRID(uint32(srid))
+	return string(g.Encode()), false, nil
+}
+
+// asTextFunctionClass is the class of `ST_AsText(g)`.
+type asTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinAsTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsTextSig) Clone() builtinFunc {
+	newSig := &builtinAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return g.WKT(), false, nil
+}
+
+// asBinaryFunctionClass is the class of `ST_AsBinary(g)`.
+type asBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	types.SetBinChsClnFlag(bf.tp)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinAsBinarySig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsBinary(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinAsBinarySig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return string(g.WKB()), false, nil
+}
+
+// asGeoJSONFunctionClass is the class of `ST_AsGeoJSON(g[, max_dec_digits])`.
+type asGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) > 1 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinAsGeoJSONSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsGeoJSONSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinAsGeoJSONSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals ST_AsGeoJSON(g[, max_dec_digits]).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html#function_st-asgeojson
+func (b *builtinAsGeoJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, true, err
+	}
+	maxDecimals := int64(-1)
+	if len(b.args) > 1 {
+		maxDecimals, isNull, err = b.args[1].EvalInt(ctx, row)
+		if isNull || err != nil {
+			return types.BinaryJSON{}, true, err
+		}
+		if maxDecimals < 0 {
+			return types.BinaryJSON{}, true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+	}
+	return types.CreateBinaryJSON(g.GeoJSON(int(maxDecimals))), false, nil
+}
+
+// pointFunctionClass is the class of `Point(x, y)`.
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf)
+	sig := &builtinPointSig{bf}
+	return sig, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals Point(x, y).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	y, isNull, err := b.args[1].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return string(geo.NewPoint(x, y, 0).Encode()), false, nil
+}
+
+// pointCoordFunctionClass is the class of `ST_X(p)` and `ST_Y(p)`.
+type pointCoordFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointCoordFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	sig := &builtinPointCoordSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinPointCoordSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinPointCoordSig) Clone() builtinFunc {
+	newSig := &builtinPointCoordSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_X(p) and ST_Y(p).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html
+func (b *builtinPointCoordSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if g.Kind != geo.KindPoint {
+		return 0, true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+	}
+	if b.funcName == ast.STX {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+// sridFunctionClass is the class of `ST_SRID(g)`.
+type sridFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *sridFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(10)
+	bf.tp.AddFlag(mysql.UnsignedFlag)
+	sig := &builtinSRIDSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSRIDSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+// geometryTypeFunctionClass is the class of `ST_GeometryType(g)`.
+type geometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geometryTypeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(len(geo.KindGeometryCollection.String()))
+	sig := &builtinGeometryTypeSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinGeometryTypeSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinGeometryTypeSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeometryType(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-geometrytype
+func (b *builtinGeometryTypeSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return g.Kind.String(), false, nil
+}
+
+// isEmptyFunctionClass is the class of `ST_IsEmpty(g)`.
+type isEmptyFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *isEmptyFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	sig := &builtinIsEmptySig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinIsEmptySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinIsEmptySig) Clone() builtinFunc {
+	newSig := &builtinIsEmptySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_IsEmpty(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-isempty
+func (b *builtinIsEmptySig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if g.IsEmpty() {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+// areaFunctionClass is the class of `ST_Area(g)`.
+type areaFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *areaFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	sig := &builtinAreaSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAreaSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAreaSig) Clone() builtinFunc {
+	newSig := &builtinAreaSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Area(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-polygon-property-functions.html#function_st-area
+func (b *builtinAreaSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return g.Area(), false, nil
+}
+
+// spatialRelationFunctionClass is the class of the functions testing the spatial relation of two
+// geometries, which are `ST_Contains`, `ST_Within`, `ST_Intersects`, `ST_Disjoint` and their MBR
+// versions.
+type spatialRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *spatialRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	sig := &builtinSpatialRelationSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSpatialRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSpatialRelationSig) Clone() builtinFunc {
+	newSig := &builtinSpatialRelationSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals the spatial relation functions.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+// and https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-mbr.html
+func (b *builtinSpatialRelationSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	var res bool
+	switch b.funcName {
+	case ast.STContains:
+		res = geo.Contains(g1, g2)
+	case ast.STWithin:
+		res = geo.Contains(g2, g1)
+	case ast.STIntersects:
+		res = geo.Intersects(g1, g2)
+	case ast.STDisjoint:
+		res = !geo.Intersects(g1, g2)
+	default:
+		r1, ok1 := g1.Bound()
+		r2, ok2 := g2.Bound()
+		ok := ok1 && ok2
+		switch b.funcName {
+		case ast.MBRContains:
+			res = ok && r1.Contains(r2)
+		case ast.MBRWithin:
+			res = ok && r2.Contains(r1)
+		case ast.MBRIntersects:
+			res = ok && r1.Intersects(r2)
+		case ast.MBRDisjoint:
+			res = !ok || !r1.Intersects(r2)
+		}
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+// distanceFunctionClass is the class of `ST_Distance(g1, g2)`.
+type distanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *distanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	sig := &builtinDistanceSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinDistanceSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinDistanceSig) Clone() builtinFunc {
+	newSig := &builtinDistanceSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance(g1, g2). It returns NULL if any geometry is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	dist, ok := geo.Distance(g1, g2)
+	return dist, !ok, nil
+}
+
+// distanceSphereFunctionClass is the class of `ST_Distance_Sphere(g1, g2[, radius])`.
+type distanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *distanceSphereFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}
+	if len(args) > 2 {
+		argTps = append(argTps, types.ETReal)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	sig := &builtinDistanceSphereSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinDistanceSphereSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinDistanceSphereSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// checkSpherePoints checks the geometry is a Point or a MultiPoint, and the coordinates of the
+// points are valid longitudes and latitudes.
+func checkSpherePoints(funcName string, g *geo.Geometry) error {
+	points := g.Points
+	switch g.Kind {
+	case geo.KindPoint:
+	case geo.KindMultiPoint:
+		points = make([]geo.Point, 0, len(g.Geoms))
+		for _, m := range g.Geoms {
+			points = append(points, m.Points[0])
+		}
+	default:
+		return errIncorrectArgs.GenWithStackByArgs(funcName)
+	}
+	for _, p := range points {
+		if p.X <= -180 || p.X > 180 {
+			return errLongitudeOutOfRange.GenWithStackByArgs(p.X, funcName)
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return errLatitudeOutOfRange.GenWithStackByArgs(p.Y, funcName)
+		}
+	}
+	return nil
+}
+
+// evalReal evals ST_Distance_Sphere(g1, g2[, radius]), the geometries must be Points or
+// MultiPoints, whose X and Y are the longitudes and the latitudes.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-convenience-functions.html#function_st-distance-sphere
+func (b *builtinDistanceSphereSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	radius := float64(geo.EarthRadius)
+	if len(b.args) > 2 {
+		radius, isNull, err = b.args[2].EvalReal(ctx, row)
+		if isNull || err != nil {
+			return 0, true, err
+		}
+		if radius <= 0 {
+			return 0, true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+	}
+	for _, g := range []*geo.Geometry{g1, g2} {
+		if err := checkSpherePoints(b.funcName, g); err != nil {
+			return 0, true, err
+		}
+	}
+	return geo.DistanceSphere(g1, g2, radius), false, nil
+}
+
+// spatialCellsFunctionClass is the class of `tidb_spatial_cells(g)`, which returns the JSON
+// array of the cells covering the MBR of the geometry. It is the expression of the hidden column
+// indexed by a SPATIAL index.
+type spatialCellsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *spatialCellsFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSpatialCellsSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSpatialCellsSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSpatialCellsSig) Clone() builtinFunc {
+	newSig := &builtinSpatialCellsSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals tidb_spatial_cells(g).
+func (b *builtinSpatialCellsSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	g, isNull, err := evalGeometry(ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, true, err
+	}
+	cells := g.IndexCells()
+	vals := make([]any, 0, len(cells))
+	for _, cell := range cells {
+		vals = append(vals, uint64(cell))
+	}
+	return types.CreateBinaryJSON(vals), false, nil
+}
@@ -140,6 +140,25 @@ func (d *ParamMarker) GetUserVar() types.Datum {
 	return sessionVars.PlanCacheParams.GetParamValue(d.order)
 }
 
+// BindParamMarkers binds the param markers in the cloned expression to the session, so the expression
+// reads the parameters of the session. It's used to share the cached plans between sessions.
+func BindParamMarkers(ctx sessionctx.Context, expr Expression) {
+	switch x := expr.(type) {
+	case *ScalarFunction:
+		for _, arg := range x.GetArgs() {
+			BindParamMarkers(ctx, arg)
+		}
+	case *Constant:
+		if x.ParamMarker != nil {
+			x.ParamMarker = &ParamMarker{ctx: ctx, order: x.ParamMarker.order}
+		}
+		if x.DeferredExpr != nil {
+			x.DeferredExpr = x.DeferredExpr.Clone()
+			BindParamMarkers(ctx, x.DeferredExpr)
+		}
+	}
+}
+
 // String implements fmt.Stringer interface.
 func (c *Constant) String() string {
 	if c.ParamMarker != nil {
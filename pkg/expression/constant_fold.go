@@ -154,7 +154,7 @@ func caseWhenHandler(ctx sessionctx.Context, expr *ScalarFunction) (Expression,
 func foldConstant(ctx sessionctx.Context, expr Expression) (Expression, bool) {
 	switch x := expr.(type) {
 	case *ScalarFunction:
-		if _, ok := unFoldableFunctions[x.FuncName.L]; ok {
+		if isUnFoldableFunction(x) {
 			return expr, false
 		}
 		if function := specialFoldHandler[x.FuncName.L]; function != nil && !MaybeOverOptimized4PlanCache(ctx, []Expression{expr}) {
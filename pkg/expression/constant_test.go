@@ -380,6 +380,23 @@ func TestDeferredParamNotNull(t *testing.T) {
 	require.NotNil(t, evalJSON)
 }
 
+func TestBindParamMarkers(t *testing.T) {
+	ctx1, ctx2 := mock.NewContext(), mock.NewContext()
+	ctx1.GetSessionVars().PlanCacheParams.Append(types.NewIntDatum(1))
+	ctx2.GetSessionVars().PlanCacheParams.Append(types.NewIntDatum(2))
+	param := &Constant{ParamMarker: &ParamMarker{ctx: ctx1, order: 0}, RetType: newIntFieldType()}
+	expr := newFunction(ctx1, ast.Plus, newColumn(0), param)
+
+	cloned := expr.Clone()
+	BindParamMarkers(ctx2, cloned)
+	v, _, err := expr.(*ScalarFunction).GetArgs()[1].EvalInt(ctx1, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v)
+	v, _, err = cloned.(*ScalarFunction).GetArgs()[1].EvalInt(ctx2, chunk.Row{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), v)
+}
+
 func TestDeferredExprNotNull(t *testing.T) {
 	m := &MockExpr{}
 	ctx := mock.NewContext()
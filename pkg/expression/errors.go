@@ -67,6 +67,12 @@ var (
 	errSequenceAccessDenied      = dbterror.ClassExpression.NewStd(mysql.ErrTableaccessDenied)
 	errUnsupportedJSONComparison = dbterror.ClassExpression.NewStdErr(mysql.ErrNotSupportedYet,
 		pmysql.Message("comparison of JSON in the LEAST and GREATEST operators", nil))
+
+	// Spatial functions.
+	errGISDifferentSRIDs   = dbterror.ClassExpression.NewStd(mysql.ErrGISDifferentSRIDs)
+	errGISInvalidData      = dbterror.ClassExpression.NewStd(mysql.ErrGISInvalidData)
+	errLongitudeOutOfRange = dbterror.ClassExpression.NewStd(mysql.ErrLongitudeOutOfRange)
+	errLatitudeOutOfRange  = dbterror.ClassExpression.NewStd(mysql.ErrLatitudeOutOfRange)
 )
 
 // handleInvalidTimeError reports error or warning depend on the context.
@@ -64,6 +64,24 @@ func removeExtensionFunc(name string) {
 	extensionFuncs.Delete(name)
 }
 
+// HasNoPushDownExtensionFunc checks whether an expression contains an extension function
+// whose predicates should not be pushed down.
+func HasNoPushDownExtensionFunc(expr Expression) bool {
+	scalaFunc, ok := expr.(*ScalarFunction)
+	if !ok {
+		return false
+	}
+	if sig, ok := scalaFunc.Function.(*extensionFuncSig); ok && sig.DisablePushDown {
+		return true
+	}
+	for _, arg := range scalaFunc.GetArgs() {
+		if HasNoPushDownExtensionFunc(arg) {
+			return true
+		}
+	}
+	return false
+}
+
 type extensionFuncClass struct {
 	baseFunctionClass
 	funcDef extension.FunctionDef
@@ -72,21 +90,37 @@ type extensionFuncClass struct {
 
 func newExtensionFuncClass(def *extension.FunctionDef) (*extensionFuncClass, error) {
 	var flen int
+	var hasEvalFunc bool
 	switch def.EvalTp {
 	case types.ETString:
 		flen = mysql.MaxFieldVarCharLength
-		if def.EvalStringFunc == nil {
-			return nil, errors.New("eval function is nil")
-		}
+		hasEvalFunc = def.EvalStringFunc != nil
 	case types.ETInt:
 		flen = mysql.MaxIntWidth
-		if def.EvalIntFunc == nil {
-			return nil, errors.New("eval function is nil")
-		}
+		hasEvalFunc = def.EvalIntFunc != nil
+	case types.ETReal:
+		flen = mysql.MaxRealWidth
+		hasEvalFunc = def.EvalRealFunc != nil
+	case types.ETDecimal:
+		flen = mysql.MaxDecimalWidth
+		hasEvalFunc = def.EvalDecimalFunc != nil
+	case types.ETDatetime, types.ETTimestamp:
+		flen = mysql.MaxDatetimeWidthWithFsp
+		hasEvalFunc = def.EvalTimeFunc != nil
+	case types.ETDuration:
+		flen = mysql.MaxDurationWidthWithFsp
+		hasEvalFunc = def.EvalDurationFunc != nil
+	case types.ETJson:
+		flen = mysql.MaxBlobWidth
+		hasEvalFunc = def.EvalJSONFunc != nil
 	default:
 		return nil, errors.Errorf("unsupported extension function ret type: '%v'", def.EvalTp)
 	}
 
+	if !hasEvalFunc {
+		return nil, errors.New("eval function is nil")
+	}
+
 	maxArgs := len(def.ArgTps)
 	minArgs := maxArgs - def.OptionalArgsLen
 	return &extensionFuncClass{
@@ -109,6 +143,10 @@ func (c *extensionFuncClass) getFunction(ctx sessionctx.Context, args []Expressi
 		return nil, err
 	}
 	bf.tp.SetFlen(c.flen)
+	if c.funcDef.EvalTp == types.ETDecimal {
+		// the scale of the result is decided by the function itself
+		bf.tp.SetDecimal(types.UnspecifiedLength)
+	}
 	sig := &extensionFuncSig{bf, c.funcDef}
 	return sig, nil
 }
@@ -171,6 +209,110 @@ func (b *extensionFuncSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool,
 	return b.baseBuiltinFunc.evalInt(ctx, row)
 }
 
+func (b *extensionFuncSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	if b.EvalTp == types.ETReal {
+		fnCtx := newExtensionFnContext(ctx, b)
+		return b.EvalRealFunc(fnCtx, row)
+	}
+	return b.baseBuiltinFunc.evalReal(ctx, row)
+}
+
+func (b *extensionFuncSig) evalDecimal(ctx EvalContext, row chunk.Row) (*types.MyDecimal, bool, error) {
+	if b.EvalTp == types.ETDecimal {
+		fnCtx := newExtensionFnContext(ctx, b)
+		return b.EvalDecimalFunc(fnCtx, row)
+	}
+	return b.baseBuiltinFunc.evalDecimal(ctx, row)
+}
+
+func (b *extensionFuncSig) evalTime(ctx EvalContext, row chunk.Row) (types.Time, bool, error) {
+	if b.EvalTp == types.ETDatetime || b.EvalTp == types.ETTimestamp {
+		fnCtx := newExtensionFnContext(ctx, b)
+		return b.EvalTimeFunc(fnCtx, row)
+	}
+	return b.baseBuiltinFunc.evalTime(ctx, row)
+}
+
+func (b *extensionFuncSig) evalDuration(ctx EvalContext, row chunk.Row) (types.Duration, bool, error) {
+	if b.EvalTp == types.ETDuration {
+		fnCtx := newExtensionFnContext(ctx, b)
+		return b.EvalDurationFunc(fnCtx, row)
+	}
+	return b.baseBuiltinFunc.evalDuration(ctx, row)
+}
+
+func (b *extensionFuncSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	if b.EvalTp == types.ETJson {
+		fnCtx := newExtensionFnContext(ctx, b)
+		return b.EvalJSONFunc(fnCtx, row)
+	}
+	return b.baseBuiltinFunc.evalJSON(ctx, row)
+}
+
+func (b *extensionFuncSig) vectorized() bool {
+	return b.VecEvalFunc != nil
+}
+
+// vecEval evaluates the function in a vectorized manner if its return type is one of `evalTps`.
+func (b *extensionFuncSig) vecEval(ctx EvalContext, input *chunk.Chunk, result *chunk.Column, evalTps ...types.EvalType) (bool, error) {
+	for _, tp := range evalTps {
+		if b.EvalTp == tp {
+			fnCtx := newExtensionFnContext(ctx, b)
+			return true, b.VecEvalFunc(fnCtx, input, result)
+		}
+	}
+	return false, nil
+}
+
+func (b *extensionFuncSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETString); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalString(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalInt(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETInt); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalInt(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETReal); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalReal(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalDecimal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETDecimal); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalDecimal(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalTime(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETDatetime, types.ETTimestamp); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalTime(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalDuration(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETDuration); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalDuration(ctx, input, result)
+}
+
+func (b *extensionFuncSig) vecEvalJSON(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	if ok, err := b.vecEval(ctx, input, result, types.ETJson); ok {
+		return err
+	}
+	return b.baseBuiltinFunc.vecEvalJSON(ctx, input, result)
+}
+
 type extensionFnContext struct {
 	context.Context
 	ctx EvalContext
@@ -198,6 +340,23 @@ func (b extensionFnContext) EvalArgs(row chunk.Row) ([]types.Datum, error) {
 	return result, nil
 }
 
+func (b extensionFnContext) VecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	if len(b.sig.args) == 0 {
+		return nil, nil
+	}
+
+	result := make([]*chunk.Column, 0, len(b.sig.args))
+	for _, arg := range b.sig.args {
+		col := chunk.NewColumn(arg.GetType(), input.NumRows())
+		if err := EvalExpr(b.ctx, arg, arg.GetType().EvalType(), input, col); err != nil {
+			return nil, err
+		}
+		result = append(result, col)
+	}
+
+	return result, nil
+}
+
 func (b extensionFnContext) ConnectionInfo() *variable.ConnectionInfo {
 	return b.ctx.GetSessionVars().ConnectionInfo
 }
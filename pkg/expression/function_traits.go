@@ -65,6 +65,16 @@ var unFoldableFunctions = map[string]struct{}{
 	ast.AnyValue:  {},
 }
 
+// isUnFoldableFunction checks whether the function can not be folded, including the extension functions
+// which are not deterministic.
+func isUnFoldableFunction(sf *ScalarFunction) bool {
+	if _, ok := unFoldableFunctions[sf.FuncName.L]; ok {
+		return true
+	}
+	sig, ok := sf.Function.(*extensionFuncSig)
+	return ok && sig.NonDeterministic
+}
+
 // DisableFoldFunctions stores functions which prevent child scope functions from being constant folded.
 // Typically, these functions shall also exist in unFoldableFunctions, to stop from being folded when they themselves
 // are in child scope of an outer function, and the outer function is recursively folding its children.
@@ -355,7 +355,7 @@ func (sf *ScalarFunction) IsCorrelated() bool {
 // ConstLevel returns the const level for the expression
 func (sf *ScalarFunction) ConstLevel() ConstLevel {
 	// Note: some unfoldable functions are deterministic, we use unFoldableFunctions here for simplification.
-	if _, ok := unFoldableFunctions[sf.FuncName.L]; ok {
+	if isUnFoldableFunction(sf) {
 		return ConstNone
 	}
 
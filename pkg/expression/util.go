@@ -1273,7 +1273,7 @@ func BuildNotNullExpr(ctx sessionctx.Context, expr Expression) Expression {
 func IsRuntimeConstExpr(expr Expression) bool {
 	switch x := expr.(type) {
 	case *ScalarFunction:
-		if _, ok := unFoldableFunctions[x.FuncName.L]; ok {
+		if isUnFoldableFunction(x) {
 			return false
 		}
 		for _, arg := range x.GetArgs() {
@@ -1296,7 +1296,7 @@ func CheckNonDeterministic(e Expression) bool {
 	case *Constant, *Column, *CorrelatedColumn:
 		return false
 	case *ScalarFunction:
-		if _, ok := unFoldableFunctions[x.FuncName.L]; ok {
+		if isUnFoldableFunction(x) {
 			return true
 		}
 		for _, arg := range x.GetArgs() {
@@ -1352,7 +1352,7 @@ func IsMutableEffectsExpr(expr Expression) bool {
 func IsInmutableExpr(expr Expression) bool {
 	switch x := expr.(type) {
 	case *ScalarFunction:
-		if _, ok := unFoldableFunctions[x.FuncName.L]; ok {
+		if isUnFoldableFunction(x) {
 			return false
 		}
 		if _, ok := mutableEffectsFunctions[x.FuncName.L]; ok {
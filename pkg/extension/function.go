@@ -32,6 +32,7 @@ type FunctionContext interface {
 	CurrentDB() string
 	ConnectionInfo() *variable.ConnectionInfo
 	EvalArgs(row chunk.Row) ([]types.Datum, error)
+	VecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error)
 }
 
 // FunctionDef is the definition for the custom function
@@ -48,6 +49,25 @@ type FunctionDef struct {
 	EvalStringFunc func(ctx FunctionContext, row chunk.Row) (string, bool, error)
 	// EvalIntFunc is the eval function when `EvalTp` is `types.ETInt`
 	EvalIntFunc func(ctx FunctionContext, row chunk.Row) (int64, bool, error)
+	// EvalRealFunc is the eval function when `EvalTp` is `types.ETReal`
+	EvalRealFunc func(ctx FunctionContext, row chunk.Row) (float64, bool, error)
+	// EvalDecimalFunc is the eval function when `EvalTp` is `types.ETDecimal`
+	EvalDecimalFunc func(ctx FunctionContext, row chunk.Row) (*types.MyDecimal, bool, error)
+	// EvalTimeFunc is the eval function when `EvalTp` is `types.ETDatetime` or `types.ETTimestamp`
+	EvalTimeFunc func(ctx FunctionContext, row chunk.Row) (types.Time, bool, error)
+	// EvalDurationFunc is the eval function when `EvalTp` is `types.ETDuration`
+	EvalDurationFunc func(ctx FunctionContext, row chunk.Row) (types.Duration, bool, error)
+	// EvalJSONFunc is the eval function when `EvalTp` is `types.ETJson`
+	EvalJSONFunc func(ctx FunctionContext, row chunk.Row) (types.BinaryJSON, bool, error)
+	// VecEvalFunc is the optional vectorized eval function. It evaluates all the rows of `input`
+	// and writes the results of type `EvalTp` to `result`.
+	VecEvalFunc func(ctx FunctionContext, input *chunk.Chunk, result *chunk.Column) error
+	// NonDeterministic indicates the function may return different results for the same arguments,
+	// e.g. it depends on the session or the time. The non-deterministic functions are not constant folded.
+	NonDeterministic bool
+	// DisablePushDown indicates the predicates using the function should not be pushed down through
+	// the operators by the optimizer, e.g. the function has side effects.
+	DisablePushDown bool
 	// RequireDynamicPrivileges is a function to return a list of dynamic privileges to check.
 	RequireDynamicPrivileges func(sem bool) []string
 }
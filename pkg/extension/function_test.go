@@ -19,11 +19,13 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/extension"
 	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
@@ -197,6 +199,205 @@ func TestExtensionFuncDynamicArgLen(t *testing.T) {
 	require.EqualError(t, tk.ExecToErr("select dynamic_arg_func(1, 2)"), expectedErrMsg)
 }
 
+func TestExtensionFuncRetTypes(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{
+		{
+			Name:   "custom_real_func",
+			EvalTp: types.ETReal,
+			ArgTps: []types.EvalType{types.ETReal},
+			EvalRealFunc: func(ctx extension.FunctionContext, row chunk.Row) (float64, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() {
+					return 0, true, err
+				}
+				return args[0].GetFloat64() * 2, false, nil
+			},
+		},
+		{
+			Name:   "custom_decimal_func",
+			EvalTp: types.ETDecimal,
+			ArgTps: []types.EvalType{types.ETDecimal},
+			EvalDecimalFunc: func(ctx extension.FunctionContext, row chunk.Row) (*types.MyDecimal, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() {
+					return nil, true, err
+				}
+				result := new(types.MyDecimal)
+				err = args[0].GetMysqlDecimal().Round(result, 2, types.ModeHalfUp)
+				return result, false, err
+			},
+		},
+		{
+			Name:   "custom_time_func",
+			EvalTp: types.ETDatetime,
+			EvalTimeFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.Time, bool, error) {
+				return types.NewTime(types.FromDate(2024, 1, 2, 3, 4, 5, 0), mysql.TypeDatetime, 0), false, nil
+			},
+		},
+		{
+			Name:   "custom_duration_func",
+			EvalTp: types.ETDuration,
+			EvalDurationFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.Duration, bool, error) {
+				return types.Duration{Duration: 90 * time.Minute}, false, nil
+			},
+		},
+		{
+			Name:   "custom_json_func",
+			EvalTp: types.ETJson,
+			ArgTps: []types.EvalType{types.ETString},
+			EvalJSONFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() {
+					return types.BinaryJSON{}, true, err
+				}
+				return types.CreateBinaryJSON(map[string]any{"name": args[0].GetString()}), false, nil
+			},
+		},
+	})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select custom_real_func(1.25), custom_real_func(null)").Check(testkit.Rows("2.5 <nil>"))
+	tk.MustQuery("select custom_decimal_func(1.235), custom_decimal_func('3.1')").Check(testkit.Rows("1.24 3.10"))
+	tk.MustQuery("select custom_time_func(), custom_duration_func()").Check(testkit.Rows("2024-01-02 03:04:05 01:30:00.000000"))
+	tk.MustQuery("select custom_json_func('a'), json_extract(custom_json_func('b'), '$.name')").Check(testkit.Rows(`{"name": "a"} "b"`))
+
+	tk.MustExec("create table t(a double, b decimal(10, 3))")
+	tk.MustExec("insert into t values (1, 1.001), (2.5, 2.005)")
+	tk.MustQuery("select custom_real_func(a), custom_decimal_func(b) from t order by a").Check(testkit.Rows("2 1.00", "5 2.01"))
+	tk.MustExec("create table t2(b decimal(10, 2), c datetime, d time)")
+	tk.MustExec("insert into t2 select custom_decimal_func(b), custom_time_func(), custom_duration_func() from t")
+	tk.MustQuery("select * from t2 order by b").Check(testkit.Rows(
+		"1.00 2024-01-02 03:04:05 01:30:00",
+		"2.01 2024-01-02 03:04:05 01:30:00",
+	))
+
+	// the eval function of the ret type is required
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{
+		{
+			Name:   "custom_no_eval_func",
+			EvalTp: types.ETReal,
+			EvalIntFunc: func(ctx extension.FunctionContext, row chunk.Row) (int64, bool, error) {
+				return 1, false, nil
+			},
+		},
+	})))
+	require.EqualError(t, extension.Setup(), "eval function is nil")
+}
+
+func TestExtensionFuncVectorized(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	vecInvoked := 0
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{
+		{
+			Name:   "custom_vec_func",
+			EvalTp: types.ETInt,
+			ArgTps: []types.EvalType{types.ETInt, types.ETInt},
+			EvalIntFunc: func(ctx extension.FunctionContext, row chunk.Row) (int64, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() || args[1].IsNull() {
+					return 0, true, err
+				}
+				return args[0].GetInt64() + args[1].GetInt64(), false, nil
+			},
+			VecEvalFunc: func(ctx extension.FunctionContext, input *chunk.Chunk, result *chunk.Column) error {
+				vecInvoked++
+				args, err := ctx.VecEvalArgs(input)
+				if err != nil {
+					return err
+				}
+				n := input.NumRows()
+				result.ResizeInt64(n, false)
+				result.MergeNulls(args...)
+				i64s := result.Int64s()
+				for i := 0; i < n; i++ {
+					if !result.IsNull(i) {
+						i64s[i] = args[0].GetInt64(i) + args[1].GetInt64(i)
+					}
+				}
+				return nil
+			},
+		},
+	})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t values (1, 10), (2, null), (3, 30)")
+	tk.MustQuery("select custom_vec_func(a, b) from t order by a").Check(testkit.Rows("11", "<nil>", "33"))
+	require.Greater(t, vecInvoked, 0)
+
+	vecInvoked = 0
+	tk.MustExec("set @@tidb_enable_vectorized_expression = 0")
+	tk.MustQuery("select custom_vec_func(a, b) from t order by a").Check(testkit.Rows("11", "<nil>", "33"))
+	require.Equal(t, 0, vecInvoked)
+}
+
+func TestExtensionFuncFlags(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	invoked := make(map[string]int)
+	newFunc := func(name string, nonDeterministic, disablePushDown bool) *extension.FunctionDef {
+		return &extension.FunctionDef{
+			Name:             name,
+			EvalTp:           types.ETInt,
+			ArgTps:           []types.EvalType{types.ETInt},
+			NonDeterministic: nonDeterministic,
+			DisablePushDown:  disablePushDown,
+			EvalIntFunc: func(ctx extension.FunctionContext, row chunk.Row) (int64, bool, error) {
+				invoked[name]++
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() {
+					return 0, true, err
+				}
+				return args[0].GetInt64() * 10, false, nil
+			},
+		}
+	}
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{
+		newFunc("custom_deterministic_func", false, false),
+		newFunc("custom_non_deterministic_func", true, false),
+		newFunc("custom_no_push_down_func", false, true),
+	})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+
+	// only the deterministic functions are constant folded
+	tk.MustQuery("select custom_deterministic_func(1), custom_non_deterministic_func(1) from t").Check(testkit.Rows("10 10", "10 10", "10 10"))
+	require.Equal(t, 1, invoked["custom_deterministic_func"])
+	require.Equal(t, 3, invoked["custom_non_deterministic_func"])
+
+	// the predicates using the function are not pushed down if the push down is disabled
+	hasSelectionOverProjection := func(sql string) bool {
+		rows := tk.MustQuery("explain format = 'brief' " + sql).Rows()
+		for i := 0; i+1 < len(rows); i++ {
+			if strings.Contains(rows[i][0].(string), "Selection") && strings.Contains(rows[i+1][0].(string), "Projection") {
+				return true
+			}
+		}
+		return false
+	}
+	require.False(t, hasSelectionOverProjection("select * from (select a, custom_deterministic_func(a) as b from t) s where b > 10"))
+	require.True(t, hasSelectionOverProjection("select * from (select a, custom_no_push_down_func(a) as b from t) s where b > 10"))
+	tk.MustQuery("select * from (select a, custom_no_push_down_func(a) as b from t) s where b > 10 order by a").Check(testkit.Rows("2 20", "3 30"))
+}
+
 func TestRegisterExtensionFunc(t *testing.T) {
 	defer extension.Reset()
 
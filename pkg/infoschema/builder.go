@@ -491,7 +491,9 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 		oldTableID = diff.TableID
 	case model.ActionTruncateTable, model.ActionCreateView,
 		model.ActionExchangeTablePartition, model.ActionAlterTablePartitioning,
-		model.ActionRemovePartitioning:
+		model.ActionRemovePartitioning, model.ActionAddClusteredPrimaryKey,
+		model.ActionDropClusteredPrimaryKey, model.ActionCreateMaterializedView,
+		model.ActionDropMaterializedView:
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
 	default:
@@ -527,7 +529,11 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 			// So here skip to reserve the allocators when repairing table.
 			diff.Type != model.ActionRepairTable &&
 			// Alter sequence will change the sequence info in the allocator, so the old allocator is not valid any more.
-			diff.Type != model.ActionAlterSequence {
+			diff.Type != model.ActionAlterSequence &&
+			// Rewriting the handle may add or remove the row ID allocator of the rewritten table.
+			diff.Type != model.ActionAddClusteredPrimaryKey && diff.Type != model.ActionDropClusteredPrimaryKey &&
+			// The allocators of the change logs are added or removed with the materialized views on the table.
+			diff.Type != model.ActionCreateMaterializedView && diff.Type != model.ActionDropMaterializedView {
 			// TODO: Check how this would work with ADD/REMOVE Partitioning,
 			// which may have AutoID not connected to tableID
 			// TODO: can there be _tidb_rowid AutoID per partition?
@@ -104,10 +104,12 @@ const (
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews           = "VIEWS"
-	tableRoutines        = "ROUTINES"
-	tableParameters      = "PARAMETERS"
-	tableEvents          = "EVENTS"
+	TableViews = "VIEWS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents          = "EVENTS"
 	tableGlobalStatus    = "GLOBAL_STATUS"
 	tableGlobalVariables = "GLOBAL_VARIABLES"
 	tableSessionStatus   = "SESSION_STATUS"
@@ -213,6 +215,12 @@ const (
 	TableTiDBCheckConstraints = "TIDB_CHECK_CONSTRAINTS"
 	// TableKeywords is the list of keywords.
 	TableKeywords = "KEYWORDS"
+	// TableTiDBAutoAnalyzeQueue is the list of tables waiting in the auto-analyze priority queue.
+	TableTiDBAutoAnalyzeQueue = "TIDB_AUTO_ANALYZE_QUEUE"
+	// TableInstancePlanCache is the list of plans in the instance plan cache.
+	TableInstancePlanCache = "INSTANCE_PLAN_CACHE"
+	// TableTiDBIndexUsage is the usage of the indexes collected by the tidb instance.
+	TableTiDBIndexUsage = "TIDB_INDEX_USAGE"
 )
 
 const (
@@ -254,9 +262,9 @@ var tableIDMap = map[string]int64{
 	tableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
 	TableEngines:                            autoid.InformationSchemaDBID + 22,
 	TableViews:                              autoid.InformationSchemaDBID + 23,
-	tableRoutines:                           autoid.InformationSchemaDBID + 24,
+	TableRoutines:                           autoid.InformationSchemaDBID + 24,
 	tableParameters:                         autoid.InformationSchemaDBID + 25,
-	tableEvents:                             autoid.InformationSchemaDBID + 26,
+	TableEvents:                             autoid.InformationSchemaDBID + 26,
 	tableGlobalStatus:                       autoid.InformationSchemaDBID + 27,
 	tableGlobalVariables:                    autoid.InformationSchemaDBID + 28,
 	tableSessionStatus:                      autoid.InformationSchemaDBID + 29,
@@ -324,6 +332,10 @@ var tableIDMap = map[string]int64{
 	TableCheckConstraints:                autoid.InformationSchemaDBID + 90,
 	TableTiDBCheckConstraints:            autoid.InformationSchemaDBID + 91,
 	TableKeywords:                        autoid.InformationSchemaDBID + 92,
+	TableTiDBAutoAnalyzeQueue:            autoid.InformationSchemaDBID + 93,
+	TableInstancePlanCache:               autoid.InformationSchemaDBID + 94,
+	TableTiDBIndexUsage:                  autoid.InformationSchemaDBID + 95,
+	ClusterTableTiDBIndexUsage:           autoid.InformationSchemaDBID + 96,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -1670,6 +1682,50 @@ var tableKeywords = []columnInfo{
 	{name: "RESERVED", tp: mysql.TypeLong, size: 11},
 }
 
+// information_schema.TIDB_AUTO_ANALYZE_QUEUE
+var tableTiDBAutoAnalyzeQueueCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "PARTITION_NAMES", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "INDEX_NAMES", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "CHANGE_PERCENTAGE", tp: mysql.TypeDouble, size: 22},
+	{name: "TABLE_SIZE", tp: mysql.TypeDouble, size: 22},
+	{name: "LAST_ANALYSIS_DURATION", tp: mysql.TypeLonglong, size: 21, comment: "The seconds elapsed since the last analysis"},
+	{name: "WEIGHT", tp: mysql.TypeDouble, size: 22},
+}
+
+// information_schema.INSTANCE_PLAN_CACHE
+var tableInstancePlanCacheCols = []columnInfo{
+	{name: "SQL_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "SQL_TEXT", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "SCHEMA_VERSION", tp: mysql.TypeLonglong, size: 21},
+	{name: "PLAN_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "BINDING", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "MEMORY_USAGE", tp: mysql.TypeLonglong, size: 21},
+	{name: "HITS", tp: mysql.TypeLonglong, size: 21},
+	{name: "CREATE_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6},
+	{name: "LAST_ACCESS_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6},
+}
+
+// information_schema.TIDB_INDEX_USAGE
+var tableTiDBIndexUsageCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "INDEX_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "QUERY_TOTAL", tp: mysql.TypeLonglong, size: 21},
+	{name: "KV_REQ_TOTAL", tp: mysql.TypeLonglong, size: 21},
+	{name: "ROWS_ACCESS_TOTAL", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_0", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_0_1", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_1_10", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_10_20", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_20_50", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_50_100", tp: mysql.TypeLonglong, size: 21},
+	{name: "PERCENTAGE_ACCESS_100", tp: mysql.TypeLonglong, size: 21},
+	{name: "LAST_ACCESS_TIME", tp: mysql.TypeDatetime, size: 21},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //   - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -2152,9 +2208,9 @@ var tableNameToColumns = map[string][]columnInfo{
 	tableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableGlobalStatus:                       tableGlobalStatusCols,
 	tableGlobalVariables:                    tableGlobalVariablesCols,
 	tableSessionStatus:                      tableSessionStatusCols,
@@ -2210,6 +2266,9 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableCheckConstraints:                   tableCheckConstraintsCols,
 	TableTiDBCheckConstraints:               tableTiDBCheckConstraintsCols,
 	TableKeywords:                           tableKeywords,
+	TableTiDBAutoAnalyzeQueue:               tableTiDBAutoAnalyzeQueueCols,
+	TableInstancePlanCache:                  tableInstancePlanCacheCols,
+	TableTiDBIndexUsage:                     tableTiDBIndexUsageCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
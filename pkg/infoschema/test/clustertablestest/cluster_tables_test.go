@@ -40,9 +40,11 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/server"
+	"github.com/pingcap/tidb/pkg/statistics/handle/usage/indexusage"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/store/mockstore/mockstorage"
 	"github.com/pingcap/tidb/pkg/store/mockstore/unistore"
@@ -287,6 +289,10 @@ func TestSelectClusterTable(t *testing.T) {
 	instanceAddr, err := infoschema.GetInstanceAddr(tk.Session())
 	require.NoError(t, err)
 	tk.MustQuery("select instance from `CLUSTER_SLOW_QUERY` where time='2019-02-12 19:33:56.571953'").Check(testkit.Rows(instanceAddr))
+
+	tk.MustExec("create table test.t_index_usage (a int, key idx_a(a))")
+	tk.MustQuery("select instance, table_name, index_name, query_total from `CLUSTER_TIDB_INDEX_USAGE` where table_schema = 'test'").
+		Check(testkit.Rows(instanceAddr + " t_index_usage idx_a 0"))
 }
 
 func SubTestSelectClusterTablePrivilege(t *testing.T) {
@@ -1548,3 +1554,48 @@ func TestCreateBindingForPrepareToken(t *testing.T) {
 		tk.MustExec(fmt.Sprintf("create binding from history using plan digest '%s'", planDigest[0][0]))
 	}
 }
+
+func TestRecommendIndexDropUnused(t *testing.T) {
+	s := new(clusterTablesSuite)
+	s.store, s.dom = testkit.CreateMockStoreAndDomain(t)
+	s.rpcserver, s.listenAddr = s.setUpRPCService(t, "127.0.0.1:0", nil)
+	s.httpServer, s.mockAddr = s.setUpMockPDHTTPServer()
+	s.startTime = time.Now()
+	defer s.httpServer.Close()
+	defer s.rpcserver.Stop()
+	tk := s.newTestKitWithRoot(t)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, key idx_c(c))")
+	tk.MustExec("create table p(id int primary key)")
+	tk.MustExec("create table child(id int primary key, pid int, key idx_pid(pid), foreign key (pid) references p(id))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec("insert into t values (?, ?, ?)", i, i, i)
+	}
+	tk.MustExec("analyze table t")
+	tk.MustExec("set global tidb_enable_stmt_summary = 1")
+	tk.MustExec("select a, b from t where a = 1 and b > 10")
+	tk.MustExec("select a, b from t where a = 2 and b > 20")
+	tk.MustExec("select * from child where id = 1")
+
+	// The index supporting the foreign key is never suggested to be dropped.
+	rows := tk.MustQuery("recommend index run").Sort().Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []any{"test", "t", "idx_a_b", "a,b"}, rows[0][:4])
+	require.Equal(t, []any{"test", "t", "idx_c", "c"}, rows[1][:4])
+	require.Regexp(t, "^the index is not used by the workload and has not been read in the cluster in the last [0-9hms]+$", rows[1][4])
+	require.Equal(t, "DROP INDEX `idx_c` ON `test`.`t`", rows[1][6])
+
+	// The index is no longer suggested to be dropped once it is read in the cluster.
+	tbl, err := s.dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	c := s.dom.StatsHandle().NewSessionIndexUsageCollector()
+	c.Update(tbl.Meta().ID, tbl.Meta().FindIndexByName("idx_c").ID, indexusage.NewSample(1, 1, 1, 100))
+	c.Flush()
+	// Close it to wait for the updates to be merged.
+	s.dom.StatsHandle().StatsUsage.Close()
+	rows = tk.MustQuery("recommend index run").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "idx_a_b", rows[0][2])
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/session/txninfo"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics/handle/usage/indexusage"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
@@ -1372,6 +1373,33 @@ func TestMemoryUsageAndOpsHistory(t *testing.T) {
 	require.Equal(t, row[11], "explain analyze select * from t t1 join t t2 join t t3 on t1.a=t2.a and t1.a=t3.a order by t1.a") // SQL_TEXT
 }
 
+func TestTiDBIndexUsage(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := newTestKitWithRoot(t, store)
+	tk.MustExec("create table t (a int, b int, key idx_a(a), key idx_b(b))")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+
+	c := dom.StatsHandle().NewSessionIndexUsageCollector()
+	c.Update(tbl.Meta().ID, tbl.Meta().FindIndexByName("idx_a").ID, indexusage.NewSample(1, 2, 3, 4))
+	c.Flush()
+	// Close it to wait for the updates to be merged.
+	dom.StatsHandle().StatsUsage.Close()
+
+	tk.MustQuery("select table_schema, table_name, index_name, query_total, kv_req_total, rows_access_total, " +
+		"percentage_access_50_100, last_access_time is null from information_schema.tidb_index_usage " +
+		"where table_schema = 'test' order by index_name").Check(testkit.Rows(
+		"test t idx_a 1 2 3 1 0",
+		"test t idx_b 0 0 0 0 1",
+	))
+
+	// The index usage of the tables without any privilege is not shown.
+	tk.MustExec("create user index_usage_user")
+	user := testkit.NewTestKit(t, store)
+	require.NoError(t, user.Session().Auth(&auth.UserIdentity{Username: "index_usage_user", Hostname: "%"}, nil, nil, nil))
+	user.MustQuery("select count(*) from information_schema.tidb_index_usage where table_schema = 'test'").Check(testkit.Rows("0"))
+}
+
 func TestAddFieldsForBinding(t *testing.T) {
 	s := new(clusterTablesSuite)
 	s.store, s.dom = testkit.CreateMockStoreAndDomain(t)
@@ -675,6 +675,21 @@ func NewAllocatorsFromTblInfo(r Requirement, schemaID int64, tblInfo *model.Tabl
 	if tblInfo.IsSequence() {
 		allocs = append(allocs, NewSequenceAllocator(r.Store(), dbID, tblInfo.ID, tblInfo.Sequence))
 	}
+	if rw := tblInfo.HandleRewrite; rw != nil && !rw.PKIsHandle && !rw.IsCommonHandle {
+		// The table is being rewritten with _tidb_rowid as the handle. The row ID allocator
+		// of the rewritten table is appended after the ones of the table itself.
+		alloc := NewAllocator(r, dbID, rw.TableID, false, RowIDAllocType, idCacheOpt, tblVer)
+		allocs = append(allocs, alloc)
+	}
+	for _, ref := range tblInfo.MaterializedViews {
+		if ref.LogTableID == 0 {
+			continue
+		}
+		// The row ID allocators of the change logs kept for the materialized views are
+		// appended after the ones of the table itself. The change logs are in the same schema.
+		alloc := NewAllocator(r, dbID, ref.LogTableID, false, RowIDAllocType, idCacheOpt, tblVer)
+		allocs = append(allocs, alloc)
+	}
 	return NewAllocators(tblInfo.SepAutoInc(), allocs...)
 }
 
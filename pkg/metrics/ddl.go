@@ -185,6 +185,7 @@ const (
 	LblModifyColumn  = "modify_column"
 
 	LblReorgPartition = "reorganize_partition"
+	LblRewriteHandle  = "rewrite_handle"
 )
 
 // GenerateReorgLabel returns the label with schema name and table name.
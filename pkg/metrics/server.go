@@ -49,6 +49,7 @@ var (
 	PlanCacheMissCounter            *prometheus.CounterVec
 	PlanCacheInstanceMemoryUsage    *prometheus.GaugeVec
 	PlanCacheInstancePlanNumCounter *prometheus.GaugeVec
+	PlanCacheInstanceEvictCounter   *prometheus.CounterVec
 	ReadFromTableCacheCounter       prometheus.Counter
 	HandShakeErrorCounter           prometheus.Counter
 	GetTokenDurationHistogram       prometheus.Histogram
@@ -194,6 +195,14 @@ func InitServerMetrics() {
 			Help:      "Counter of plan of all prepared plan cache in a instance",
 		}, []string{LblType})
 
+	PlanCacheInstanceEvictCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "plan_cache_instance_evict_total",
+			Help:      "Counter of plan evicted from the instance plan cache",
+		}, []string{LblType})
+
 	ReadFromTableCacheCounter = NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "tidb",
@@ -19,6 +19,7 @@ import (
 )
 
 var _ StmtNode = &IndexAdviseStmt{}
+var _ StmtNode = &RecommendIndexStmt{}
 
 // IndexAdviseStmt is used to advise indexes
 type IndexAdviseStmt struct {
@@ -83,3 +84,47 @@ func (n *MaxIndexNumClause) Restore(ctx *format.RestoreCtx) error {
 	}
 	return nil
 }
+
+// RecommendIndexStmt is a statement to recommend indexes for the workload.
+// If SQL is empty, the top queries in the statement summary are used as the workload.
+type RecommendIndexStmt struct {
+	stmtNode
+
+	SQL     string
+	Options []*RecommendIndexOption
+}
+
+// RecommendIndexOption is the option of the RECOMMEND INDEX statement.
+type RecommendIndexOption struct {
+	Name  string
+	Value uint64
+}
+
+// Restore implements Node interface.
+func (n *RecommendIndexStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RECOMMEND INDEX RUN")
+	if n.SQL != "" {
+		ctx.WriteKeyWord(" FOR ")
+		ctx.WriteString(n.SQL)
+	}
+	for i, opt := range n.Options {
+		if i == 0 {
+			ctx.WriteKeyWord(" WITH ")
+		} else {
+			ctx.WritePlain(", ")
+		}
+		ctx.WritePlain(opt.Name)
+		ctx.WritePlainf(" = %d", opt.Value)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RecommendIndexStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RecommendIndexStmt)
+	return v.Leave(n)
+}
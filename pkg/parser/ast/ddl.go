@@ -801,6 +801,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -855,6 +856,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1878,7 +1881,11 @@ type DropIndexStmt struct {
 
 // Restore implements Node interface.
 func (n *DropIndexStmt) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("DROP INDEX ")
+	ctx.WriteKeyWord("DROP ")
+	if n.IsHypo {
+		ctx.WriteKeyWord("HYPO ")
+	}
+	ctx.WriteKeyWord("INDEX ")
 	if n.IfExists {
 		_ = ctx.WriteWithSpecialComments("", func() error {
 			ctx.WriteKeyWord("IF EXISTS ")
@@ -3129,6 +3136,8 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord(" DEPENDENCY(")
 		case StatsTypeCorrelation:
 			ctx.WriteKeyWord(" CORRELATION(")
+		case StatsTypeMultiHistogram:
+			ctx.WriteKeyWord(" MULTI_HISTOGRAM(")
 		}
 		for i, col := range n.Statistics.Columns {
 			if i != 0 {
@@ -546,6 +546,8 @@ func TestAlterTableSpecRestore(t *testing.T) {
 		{"add stats_extended if not exists s1 correlation(a,b)", "ADD STATS_EXTENDED IF NOT EXISTS `s1` CORRELATION(`a`, `b`)"},
 		{"add stats_extended s1 dependency(a,b)", "ADD STATS_EXTENDED `s1` DEPENDENCY(`a`, `b`)"},
 		{"add stats_extended if not exists s1 dependency(a,b)", "ADD STATS_EXTENDED IF NOT EXISTS `s1` DEPENDENCY(`a`, `b`)"},
+		{"add stats_extended s1 multi_histogram(a,b,c)", "ADD STATS_EXTENDED `s1` MULTI_HISTOGRAM(`a`, `b`, `c`)"},
+		{"add stats_extended if not exists s1 multi_histogram(a,b)", "ADD STATS_EXTENDED IF NOT EXISTS `s1` MULTI_HISTOGRAM(`a`, `b`)"},
 		{"drop stats_extended s1", "DROP STATS_EXTENDED `s1`"},
 		{"drop stats_extended if exists s1", "DROP STATS_EXTENDED IF EXISTS `s1`"},
 		{"placement policy p1", "PLACEMENT POLICY = `p1`"},
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
 var (
@@ -531,6 +532,10 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates the derived table is a LATERAL derived table, which
+	// can reference the columns of the preceding tables in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -576,6 +581,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -609,6 +617,177 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTable represents the JSON_TABLE table function, which extracts data
+// from a JSON document and returns it as a relational table.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	// Expr is the JSON document to be parsed.
+	Expr ExprNode
+	// Path is the row path that selects the rows of the table.
+	Path string
+	// Columns is the column list of the table.
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WritePlain(" ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WriteKeyWord("COLUMNS ")
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTableColumnType is the type of a JSON_TABLE column.
+type JSONTableColumnType int
+
+// JSON_TABLE column types.
+const (
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality JSONTableColumnType = iota
+	// JSONTableColumnPath is `name type PATH path [on_empty] [on_error]`.
+	JSONTableColumnPath
+	// JSONTableColumnExistsPath is `name type EXISTS PATH path`.
+	JSONTableColumnExistsPath
+	// JSONTableColumnNested is `NESTED [PATH] path COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableColumn represents a column definition in JSON_TABLE.
+type JSONTableColumn struct {
+	node
+
+	Tp JSONTableColumnType
+	// Name is the column name, it is empty for NESTED PATH.
+	Name model.CIStr
+	// FieldType is the column type of PATH and EXISTS PATH columns.
+	FieldType *types.FieldType
+	// Path is the column path of PATH, EXISTS PATH and NESTED PATH columns.
+	Path string
+	// OnEmpty and OnError are the behaviors of PATH columns when the path
+	// matches nothing or fails to convert. Nil means the default, NULL ON EMPTY
+	// and NULL ON ERROR.
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+	// NestedColumns is the column list of NESTED PATH.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case JSONTableColumnOrdinality:
+		ctx.WriteName(n.Name.O)
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+	case JSONTableColumnPath, JSONTableColumnExistsPath:
+		ctx.WriteName(n.Name.O)
+		ctx.WritePlain(" ")
+		if err := n.FieldType.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+		}
+		if n.Tp == JSONTableColumnExistsPath {
+			ctx.WriteKeyWord(" EXISTS")
+		}
+		ctx.WriteKeyWord(" PATH ")
+		ctx.WriteString(n.Path)
+		if n.OnEmpty != nil {
+			ctx.WritePlain(" ")
+			n.OnEmpty.Restore(ctx)
+			ctx.WriteKeyWord(" ON EMPTY")
+		}
+		if n.OnError != nil {
+			ctx.WritePlain(" ")
+			n.OnError.Restore(ctx)
+			ctx.WriteKeyWord(" ON ERROR")
+		}
+	case JSONTableColumnNested:
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WritePlain(" ")
+		return restoreJSONTableColumns(ctx, n.NestedColumns)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTableColumn) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// JSONTableOnResponseType is the behavior of ON EMPTY and ON ERROR clauses.
+type JSONTableOnResponseType int
+
+// JSON_TABLE ON EMPTY and ON ERROR behaviors.
+const (
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	JSONTableOnResponseError
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse represents the `NULL | ERROR | DEFAULT json_string`
+// part of the ON EMPTY and ON ERROR clauses.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the JSON string of `DEFAULT json_string`.
+	Default string
+}
+
+// Restore writes the response to ctx.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
@@ -3596,8 +3775,10 @@ type SelectIntoOption struct {
 
 	Tp         SelectIntoType
 	FileName   string
+	Format     *string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	Options    []*LoadDataOpt
 }
 
 // Restore implements Node interface.
@@ -3609,6 +3790,10 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 
 	ctx.WriteKeyWord("INTO OUTFILE ")
 	ctx.WriteString(n.FileName)
+	if n.Format != nil {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(*n.Format)
+	}
 	if n.FieldsInfo != nil {
 		if err := n.FieldsInfo.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.FieldsInfo")
@@ -3619,6 +3804,18 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if len(n.Options) > 0 {
+		ctx.WriteKeyWord(" WITH")
+		for i, option := range n.Options {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WritePlain(" ")
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Options")
+			}
+		}
+	}
 	return nil
 }
 
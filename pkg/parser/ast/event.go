@@ -0,0 +1,364 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+)
+
+var (
+	_ StmtNode = &CreateEventStmt{}
+	_ StmtNode = &AlterEventStmt{}
+	_ StmtNode = &DropEventStmt{}
+
+	_ Node = &EventSchedule{}
+)
+
+// EventScheduleType is the type of the event schedule.
+type EventScheduleType int
+
+// Event schedule types.
+const (
+	// EventScheduleAt runs the event once at the specified time.
+	EventScheduleAt EventScheduleType = iota
+	// EventScheduleEvery runs the event repeatedly at the specified interval.
+	EventScheduleEvery
+)
+
+// EventOnCompletion indicates whether the event is kept after it expires.
+type EventOnCompletion int
+
+// Event on completion types.
+const (
+	EventOnCompletionUnspecified EventOnCompletion = iota
+	EventOnCompletionNotPreserve
+	EventOnCompletionPreserve
+)
+
+// String implements fmt.Stringer interface.
+func (c EventOnCompletion) String() string {
+	switch c {
+	case EventOnCompletionPreserve:
+		return "PRESERVE"
+	case EventOnCompletionNotPreserve:
+		return "NOT PRESERVE"
+	}
+	return ""
+}
+
+// EventStatus is the status of the event.
+type EventStatus int
+
+// Event status types.
+const (
+	EventStatusUnspecified EventStatus = iota
+	EventStatusEnable
+	EventStatusDisable
+	EventStatusSlavesideDisabled
+)
+
+// String implements fmt.Stringer interface.
+func (s EventStatus) String() string {
+	switch s {
+	case EventStatusEnable:
+		return "ENABLED"
+	case EventStatusDisable:
+		return "DISABLED"
+	case EventStatusSlavesideDisabled:
+		return "SLAVESIDE_DISABLED"
+	}
+	return ""
+}
+
+func (s EventStatus) restore(ctx *format.RestoreCtx) {
+	switch s {
+	case EventStatusEnable:
+		ctx.WriteKeyWord(" ENABLE")
+	case EventStatusDisable:
+		ctx.WriteKeyWord(" DISABLE")
+	case EventStatusSlavesideDisabled:
+		ctx.WriteKeyWord(" DISABLE ON SLAVE")
+	}
+}
+
+// EventSchedule is the schedule of an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type EventSchedule struct {
+	node
+
+	Tp EventScheduleType
+	// At is the time to run the event, only used by EventScheduleAt.
+	At ExprNode
+	// Interval and Unit is the interval to run the event, Starts and Ends
+	// limits the time range of it, they are only used by EventScheduleEvery.
+	Interval ExprNode
+	Unit     TimeUnitType
+	Starts   ExprNode
+	Ends     ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == EventScheduleAt {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Interval.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Interval")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	exprs := []*ExprNode{&n.At, &n.Interval, &n.Starts, &n.Ends}
+	for _, expr := range exprs {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// CreateEventStmt is a statement to create an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	stmtNode
+
+	Definer      *auth.UserIdentity
+	IfNotExists  bool
+	EventName    *TableName
+	Schedule     *EventSchedule
+	OnCompletion EventOnCompletion
+	Status       EventStatus
+	Comment      string
+	Body         StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if err := n.Definer.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Definer")
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord("EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	if n.OnCompletion != EventOnCompletionUnspecified {
+		ctx.WriteKeyWord(" ON COMPLETION ")
+		ctx.WriteKeyWord(n.OnCompletion.String())
+	}
+	n.Status.restore(ctx)
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	node, ok = n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to alter an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	stmtNode
+
+	EventName *TableName
+	// The following fields are nil or unspecified if they are not changed.
+	Schedule     *EventSchedule
+	OnCompletion EventOnCompletion
+	NewName      *TableName
+	Status       EventStatus
+	Comment      *string
+	Body         StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WriteKeyWord(" ON SCHEDULE ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	if n.OnCompletion != EventOnCompletionUnspecified {
+		ctx.WriteKeyWord(" ON COMPLETION ")
+		ctx.WriteKeyWord(n.OnCompletion.String())
+	}
+	if n.NewName != nil {
+		ctx.WriteKeyWord(" RENAME TO ")
+		if err := n.NewName.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.NewName")
+		}
+	}
+	n.Status.restore(ctx)
+	if n.Comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*n.Comment)
+	}
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	if n.Schedule != nil {
+		node, ok = n.Schedule.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Schedule = node.(*EventSchedule)
+	}
+	if n.NewName != nil {
+		node, ok = n.NewName.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.NewName = node.(*TableName)
+	}
+	if n.Body != nil {
+		node, ok = n.Body.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+type DropEventStmt struct {
+	stmtNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	return v.Leave(n)
+}
@@ -305,6 +305,10 @@ const (
 	ReleaseLock     = "release_lock"
 	Grouping        = "grouping"
 
+	// full-text search functions
+	TiDBFullTextTokenize = "tidb_fulltext_tokenize"
+	TiDBFullTextMatch    = "tidb_fulltext_match"
+
 	// encryption and compression functions
 	AesDecrypt               = "aes_decrypt"
 	AesEncrypt               = "aes_encrypt"
@@ -355,6 +359,36 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// spatial functions
+	STGeomFromText       = "st_geomfromtext"
+	STGeometryFromText   = "st_geometryfromtext"
+	STGeomFromWKB        = "st_geomfromwkb"
+	STGeometryFromWKB    = "st_geometryfromwkb"
+	STGeomFromGeoJSON    = "st_geomfromgeojson"
+	STAsText             = "st_astext"
+	STAsWKT              = "st_aswkt"
+	STAsBinary           = "st_asbinary"
+	STAsWKB              = "st_aswkb"
+	STAsGeoJSON          = "st_asgeojson"
+	Point                = "point"
+	STX                  = "st_x"
+	STY                  = "st_y"
+	STSRID               = "st_srid"
+	STGeometryType       = "st_geometrytype"
+	STIsEmpty            = "st_isempty"
+	STArea               = "st_area"
+	STContains           = "st_contains"
+	STWithin             = "st_within"
+	STIntersects         = "st_intersects"
+	STDisjoint           = "st_disjoint"
+	MBRContains          = "mbrcontains"
+	MBRWithin            = "mbrwithin"
+	MBRIntersects        = "mbrintersects"
+	MBRDisjoint          = "mbrdisjoint"
+	STDistance           = "st_distance"
+	STDistanceSphere     = "st_distance_sphere"
+	TiDBSpatialCells     = "tidb_spatial_cells"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
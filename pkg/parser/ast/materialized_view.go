@@ -0,0 +1,187 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+var (
+	_ DDLNode  = &CreateMaterializedViewStmt{}
+	_ DDLNode  = &DropMaterializedViewStmt{}
+	_ StmtNode = &RefreshMaterializedViewStmt{}
+)
+
+// MaterializedViewRefresh is the REFRESH clause of a materialized view.
+type MaterializedViewRefresh struct {
+	Method model.MaterializedViewRefreshMethod
+	// Interval and Unit is the interval to refresh the view automatically, Interval
+	// is nil if the view is only refreshed manually.
+	Interval ExprNode
+	Unit     TimeUnitType
+}
+
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	ViewName    *TableName
+	Cols        []model.CIStr
+	Refresh     MaterializedViewRefresh
+	Select      StmtNode
+
+	// ColTypes are the types of the columns of the view, they are filled by the
+	// planner along with Cols.
+	ColTypes []*types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	ctx.WriteKeyWord(" REFRESH ")
+	ctx.WriteKeyWord(n.Refresh.Method.String())
+	if n.Refresh.Interval != nil {
+		ctx.WriteKeyWord(" EVERY ")
+		if err := n.Refresh.Interval.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Refresh.Interval")
+		}
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Refresh.Unit.String())
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	if n.Refresh.Interval != nil {
+		node, ok = n.Refresh.Interval.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Refresh.Interval = node.(ExprNode)
+	}
+	node, ok = n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropMaterializedViewStmt is a statement to drop a materialized view.
+type DropMaterializedViewStmt struct {
+	ddlNode
+
+	IfExists bool
+	ViewName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropMaterializedViewStmt.ViewName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh a materialized view.
+type RefreshMaterializedViewStmt struct {
+	stmtNode
+
+	ViewName *TableName
+	// Complete indicates the view is refreshed completely even if it can be refreshed fast.
+	Complete bool
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Complete {
+		ctx.WriteKeyWord(" COMPLETE")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
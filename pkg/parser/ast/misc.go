@@ -2155,6 +2155,7 @@ const (
 	StatsTypeCardinality uint8 = iota
 	StatsTypeDependency
 	StatsTypeCorrelation
+	StatsTypeMultiHistogram
 )
 
 // StatisticsSpec is the specification for ADD /DROP STATISTICS.
@@ -2170,6 +2171,7 @@ type StatisticsSpec struct {
 //	CREATE STATISTICS stats1 (cardinality) ON t(a, b, c);
 //	CREATE STATISTICS stats2 (dependency) ON t(a, b);
 //	CREATE STATISTICS stats3 (correlation) ON t(a, b);
+//	CREATE STATISTICS stats4 (multi_histogram) ON t(a, b, c);
 type CreateStatisticsStmt struct {
 	stmtNode
 
@@ -2194,6 +2196,8 @@ func (n *CreateStatisticsStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord(" (dependency) ")
 	case StatsTypeCorrelation:
 		ctx.WriteKeyWord(" (correlation) ")
+	case StatsTypeMultiHistogram:
+		ctx.WriteKeyWord(" (multi_histogram) ")
 	}
 	ctx.WriteKeyWord("ON ")
 	if err := n.Table.Restore(ctx); err != nil {
@@ -2335,6 +2339,7 @@ const (
 	AdminSetBDRRole
 	AdminShowBDRRole
 	AdminUnsetBDRRole
+	AdminAlterDDLJobs
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2474,6 +2479,11 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+
+	// Schedule and ScheduleDuration are the maintenance window of the reorg jobs set by
+	// ADMIN ALTER DDL JOBS, both of them are empty for SCHEDULE DEFAULT.
+	Schedule         string
+	ScheduleDuration string
 }
 
 // Restore implements Node interface.
@@ -2571,6 +2581,17 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 	case AdminResumeDDLJobs:
 		ctx.WriteKeyWord("RESUME DDL JOBS ")
 		restoreJobIDs()
+	case AdminAlterDDLJobs:
+		ctx.WriteKeyWord("ALTER DDL JOBS ")
+		restoreJobIDs()
+		if n.Schedule == "" {
+			ctx.WriteKeyWord(" SCHEDULE DEFAULT")
+		} else {
+			ctx.WriteKeyWord(" SCHEDULE ")
+			ctx.WriteString(n.Schedule)
+			ctx.WriteKeyWord(" DURATION ")
+			ctx.WriteString(n.ScheduleDuration)
+		}
 	case AdminShowDDLJobQueries:
 		ctx.WriteKeyWord("SHOW DDL JOB QUERIES ")
 		restoreJobIDs()
@@ -73,6 +73,7 @@ func TestProcedure(t *testing.T) {
 			"select a.id,a.username,a.password,a.age,a.sex,ad.score from user a left join user_score ad on a.id = ad.user_id where a.id in (select user_id from user_score where score > 90 and score < 99 ) " +
 			"union select a.id,a.username,a.password,a.age,a.sex,ad.score from user a left join user_score ad on a.id = ad.user_id where a.id in (select user_id from user_score where score > 30 and score < 70 ); end;",
 		`create procedure proc_2() begin select * from t1; if i > 1 then select 2 ; end if ;end`,
+		`create procedure proc_2(in id int) begin call proc_1(id + 1); call test.proc_1(); end`,
 		`create procedure proc_2() begin select * from t1; if i > 1 then select 2; else select id from t2  ; end if ; end`,
 		`create procedure proc_2() begin select * from t1; if i > 1 then select 2; elseif i = 3 then select 4; else select 5 ; end if; end`,
 		`create procedure proc_2(id int) begin while id < 10 do set id = id + 1; select 1; end while; end`,
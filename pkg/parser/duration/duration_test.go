@@ -53,6 +53,14 @@ func TestParseDuration(t *testing.T) {
 			"1d3.555h",
 			24*time.Hour + time.Duration(3.555*float64(time.Hour)),
 		},
+		{
+			"90s",
+			90 * time.Second,
+		},
+		{
+			"1h30m15s",
+			time.Hour + 30*time.Minute + 15*time.Second,
+		},
 	}
 
 	for _, c := range cases {
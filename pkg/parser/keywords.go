@@ -128,11 +128,13 @@ var Keywords = []KeywordsType{
 	{"IS", true, "reserved"},
 	{"ITERATE", true, "reserved"},
 	{"JOIN", true, "reserved"},
+	{"JSON_TABLE", true, "reserved"},
 	{"KEY", true, "reserved"},
 	{"KEYS", true, "reserved"},
 	{"KILL", true, "reserved"},
 	{"LAG", true, "reserved"},
 	{"LAST_VALUE", true, "reserved"},
+	{"LATERAL", true, "reserved"},
 	{"LEAD", true, "reserved"},
 	{"LEADING", true, "reserved"},
 	{"LEAVE", true, "reserved"},
@@ -267,6 +269,7 @@ var Keywords = []KeywordsType{
 	{"ALWAYS", false, "unreserved"},
 	{"ANY", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -315,6 +318,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"CONCURRENCY", false, "unreserved"},
@@ -350,10 +355,12 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EMPTY", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -363,6 +370,7 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
@@ -371,6 +379,7 @@ var Keywords = []KeywordsType{
 	{"EXPIRE", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
+	{"FAST", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
 	{"FILE", false, "unreserved"},
@@ -383,6 +392,9 @@ var Keywords = []KeywordsType{
 	{"FULL", false, "unreserved"},
 	{"FUNCTION", false, "unreserved"},
 	{"GENERAL", false, "unreserved"},
+	{"GEOMCOLLECTION", false, "unreserved"},
+	{"GEOMETRY", false, "unreserved"},
+	{"GEOMETRYCOLLECTION", false, "unreserved"},
 	{"GLOBAL", false, "unreserved"},
 	{"GRANTS", false, "unreserved"},
 	{"HANDLER", false, "unreserved"},
@@ -416,12 +428,14 @@ var Keywords = []KeywordsType{
 	{"LAST_BACKUP", false, "unreserved"},
 	{"LESS", false, "unreserved"},
 	{"LEVEL", false, "unreserved"},
+	{"LINESTRING", false, "unreserved"},
 	{"LIST", false, "unreserved"},
 	{"LOCAL", false, "unreserved"},
 	{"LOCATION", false, "unreserved"},
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
@@ -440,9 +454,13 @@ var Keywords = []KeywordsType{
 	{"MODE", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
+	{"MULTILINESTRING", false, "unreserved"},
+	{"MULTIPOINT", false, "unreserved"},
+	{"MULTIPOLYGON", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
+	{"NESTED", false, "unreserved"},
 	{"NEVER", false, "unreserved"},
 	{"NEXT", false, "unreserved"},
 	{"NEXTVAL", false, "unreserved"},
@@ -467,6 +485,7 @@ var Keywords = []KeywordsType{
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"ORDINALITY", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
@@ -475,6 +494,7 @@ var Keywords = []KeywordsType{
 	{"PARTITIONS", false, "unreserved"},
 	{"PASSWORD", false, "unreserved"},
 	{"PASSWORD_LOCK_TIME", false, "unreserved"},
+	{"PATH", false, "unreserved"},
 	{"PAUSE", false, "unreserved"},
 	{"PERCENT", false, "unreserved"},
 	{"PER_DB", false, "unreserved"},
@@ -482,6 +502,7 @@ var Keywords = []KeywordsType{
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"POLYGON", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
@@ -499,8 +520,10 @@ var Keywords = []KeywordsType{
 	{"QUICK", false, "unreserved"},
 	{"RATE_LIMIT", false, "unreserved"},
 	{"REBUILD", false, "unreserved"},
+	{"RECOMMEND", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -565,6 +588,7 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -643,6 +667,7 @@ var Keywords = []KeywordsType{
 	{"HISTOGRAMS_IN_FLIGHT", false, "tidb"},
 	{"JOB", false, "tidb"},
 	{"JOBS", false, "tidb"},
+	{"MULTI_HISTOGRAM", false, "tidb"},
 	{"NODE_ID", false, "tidb"},
 	{"NODE_STATE", false, "tidb"},
 	{"OPTIMISTIC", false, "tidb"},
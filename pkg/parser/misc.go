@@ -178,6 +178,7 @@ var tokenMap = map[string]int{
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
+	"AT":                       at,
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
@@ -261,6 +262,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETE":                 complete,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -346,11 +349,13 @@ var tokenMap = map[string]int{
 	"DYNAMIC":                  dynamic,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
@@ -362,6 +367,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -378,6 +384,7 @@ var tokenMap = map[string]int{
 	"EXTENDED":                 extended,
 	"EXTRACT":                  extract,
 	"FALSE":                    falseKwd,
+	"FAST":                     fast,
 	"FAULTS":                   faultsSym,
 	"FETCH":                    fetch,
 	"FIELDS":                   fields,
@@ -406,6 +413,9 @@ var tokenMap = map[string]int{
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
@@ -472,6 +482,7 @@ var tokenMap = map[string]int{
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
@@ -484,6 +495,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -498,6 +510,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -514,6 +527,7 @@ var tokenMap = map[string]int{
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
 	"MATCH":                    match,
+	"MATERIALIZED":             materialized,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
 	"MAX_MINUTES":              max_minutes,
@@ -543,10 +557,15 @@ var tokenMap = map[string]int{
 	"MODE":                     mode,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
+	"MULTI_HISTOGRAM":          multiHistogram,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -586,6 +605,7 @@ var tokenMap = map[string]int{
 	"OPTIMIZE":                 optimize,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"ORDINALITY":               ordinality,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -611,6 +631,7 @@ var tokenMap = map[string]int{
 	"PLUGINS":                  plugins,
 	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygon,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -640,10 +661,12 @@ var tokenMap = map[string]int{
 	"REAL":                     realType,
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
+	"RECOMMEND":                recommend,
 	"RECOVER":                  recover,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
 	"REFERENCES":               references,
+	"REFRESH":                  refresh,
 	"REGEXP":                   regexpKwd,
 	"REGION":                   region,
 	"REGIONS":                  regions,
@@ -751,6 +774,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -908,6 +932,7 @@ var tokenMap = map[string]int{
 	"WAIT":                     wait,
 	"FAILED_LOGIN_ATTEMPTS":    failedLoginAttempts,
 	"PASSWORD_LOCK_TIME":       passwordLockTime,
+	"PATH":                     path,
 	"REUSE":                    reuse,
 }
 
@@ -91,20 +91,24 @@ const (
 	ActionAlterTablePlacement           ActionType = 56
 	ActionAlterCacheTable               ActionType = 57
 	// not used
-	ActionAlterTableStatsOptions ActionType = 58
-	ActionAlterNoCacheTable      ActionType = 59
-	ActionCreateTables           ActionType = 60
-	ActionMultiSchemaChange      ActionType = 61
-	ActionFlashbackCluster       ActionType = 62
-	ActionRecoverSchema          ActionType = 63
-	ActionReorganizePartition    ActionType = 64
-	ActionAlterTTLInfo           ActionType = 65
-	ActionAlterTTLRemove         ActionType = 67
-	ActionCreateResourceGroup    ActionType = 68
-	ActionAlterResourceGroup     ActionType = 69
-	ActionDropResourceGroup      ActionType = 70
-	ActionAlterTablePartitioning ActionType = 71
-	ActionRemovePartitioning     ActionType = 72
+	ActionAlterTableStatsOptions  ActionType = 58
+	ActionAlterNoCacheTable       ActionType = 59
+	ActionCreateTables            ActionType = 60
+	ActionMultiSchemaChange       ActionType = 61
+	ActionFlashbackCluster        ActionType = 62
+	ActionRecoverSchema           ActionType = 63
+	ActionReorganizePartition     ActionType = 64
+	ActionAlterTTLInfo            ActionType = 65
+	ActionAlterTTLRemove          ActionType = 67
+	ActionCreateResourceGroup     ActionType = 68
+	ActionAlterResourceGroup      ActionType = 69
+	ActionDropResourceGroup       ActionType = 70
+	ActionAlterTablePartitioning  ActionType = 71
+	ActionRemovePartitioning      ActionType = 72
+	ActionAddClusteredPrimaryKey  ActionType = 73
+	ActionDropClusteredPrimaryKey ActionType = 74
+	ActionCreateMaterializedView  ActionType = 75
+	ActionDropMaterializedView    ActionType = 76
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -176,6 +180,10 @@ var ActionMap = map[ActionType]string{
 	ActionDropResourceGroup:             "drop resource group",
 	ActionAlterTablePartitioning:        "alter table partition by",
 	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionAddClusteredPrimaryKey:        "add clustered primary key",
+	ActionDropClusteredPrimaryKey:       "drop clustered primary key",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionDropMaterializedView:          "drop materialized view",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -265,6 +273,10 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionReorganizePartition,
 		ActionAlterTablePartitioning,
 		ActionRemovePartitioning,
+		ActionAddClusteredPrimaryKey,
+		ActionDropClusteredPrimaryKey,
+		ActionCreateMaterializedView,
+		ActionDropMaterializedView,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
@@ -945,9 +957,10 @@ func (job *Job) NotStarted() bool {
 func (job *Job) MayNeedReorg() bool {
 	switch job.Type {
 	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition,
-		ActionRemovePartitioning, ActionAlterTablePartitioning:
+		ActionRemovePartitioning, ActionAlterTablePartitioning,
+		ActionAddClusteredPrimaryKey, ActionDropClusteredPrimaryKey:
 		return true
-	case ActionModifyColumn:
+	case ActionModifyColumn, ActionAddColumn:
 		if len(job.CtxVars) > 0 {
 			needReorg, ok := job.CtxVars[0].(bool)
 			return ok && needReorg
@@ -80,6 +80,8 @@ func TestMayNeedReorg(t *testing.T) {
 		model.ActionAlterTablePartitioning,
 		model.ActionAddIndex,
 		model.ActionAddPrimaryKey,
+		model.ActionAddClusteredPrimaryKey,
+		model.ActionDropClusteredPrimaryKey,
 	}
 	generalJobTypes := []model.ActionType{
 		model.ActionCreateTable,
@@ -541,6 +541,16 @@ type TableInfo struct {
 	ExchangePartitionInfo *ExchangePartitionInfo `json:"exchange_partition_info"`
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
+
+	// HandleRewrite is set when the table is being rewritten under a new handle encoding.
+	HandleRewrite *HandleRewriteInfo `json:"handle_rewrite,omitempty"`
+
+	// MaterializedView is set when the table stores the rows of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MaterializedViews are the materialized views aggregating only the table.
+	MaterializedViews []*MaterializedViewRef `json:"materialized_views,omitempty"`
+	// IsMaterializedViewLog indicates the table is the hidden change log of a table.
+	IsMaterializedViewLog bool `json:"is_materialized_view_log,omitempty"`
 }
 
 // TableNameInfo provides meta data describing a table name info.
@@ -757,6 +767,20 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.HandleRewrite != nil {
+		nt.HandleRewrite = t.HandleRewrite.Clone()
+	}
+	if t.MaterializedView != nil {
+		mv := *t.MaterializedView
+		nt.MaterializedView = &mv
+	}
+	if t.MaterializedViews != nil {
+		nt.MaterializedViews = make([]*MaterializedViewRef, len(t.MaterializedViews))
+		for i, ref := range t.MaterializedViews {
+			r := *ref
+			nt.MaterializedViews[i] = &r
+		}
+	}
 
 	return &nt
 }
@@ -1028,6 +1052,22 @@ func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
 }
 
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
+// HasMaterializedViewLogs checks whether the changes of the table are logged for
+// the fast refreshed materialized views.
+func (t *TableInfo) HasMaterializedViewLogs() bool {
+	for _, ref := range t.MaterializedViews {
+		if ref.LogTableID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // ViewAlgorithm is VIEW's SQL ALGORITHM characteristic.
 // See https://dev.mysql.com/doc/refman/5.7/en/view-algorithms.html
 type ViewAlgorithm int
@@ -1175,6 +1215,154 @@ func (p PartitionType) String() string {
 	}
 }
 
+// HandleRewriteInfo provides the information of rewriting a table under a new handle encoding,
+// which happens when a clustered primary key is added or dropped. During the rewriting,
+// the rows are double written into another physical table encoded with the other handle.
+type HandleRewriteInfo struct {
+	// TableID is the physical table ID of the other table.
+	TableID             int64         `json:"table_id"`
+	PKIsHandle          bool          `json:"pk_is_handle"`
+	IsCommonHandle      bool          `json:"is_common_handle"`
+	CommonHandleVersion uint16        `json:"common_handle_version"`
+	Columns             []*ColumnInfo `json:"cols"`
+	Indices             []*IndexInfo  `json:"index_info"`
+	// OriginIndexID is the ID of the internal index in the other table, which maps
+	// the handle of a row in the table to the handle of the row in the other table.
+	// It tells whether a row has been written into the other table.
+	OriginIndexID int64 `json:"origin_index_id"`
+	// DDLState is the state of the rewriting. The other table only receives the
+	// deletions in StateDeleteOnly, and all the writes in the later states.
+	DDLState SchemaState `json:"ddl_state"`
+}
+
+// Clone clones HandleRewriteInfo.
+func (h *HandleRewriteInfo) Clone() *HandleRewriteInfo {
+	nh := *h
+	nh.Columns = make([]*ColumnInfo, len(h.Columns))
+	for i := range h.Columns {
+		nh.Columns[i] = h.Columns[i].Clone()
+	}
+	nh.Indices = make([]*IndexInfo, len(h.Indices))
+	for i := range h.Indices {
+		nh.Indices[i] = h.Indices[i].Clone()
+	}
+	return &nh
+}
+
+// HandleRewriteTableInfo returns the table info of the other table during rewriting the handle.
+// The returned table info is not public, it is only used to double write the rows.
+func (t *TableInfo) HandleRewriteTableInfo() *TableInfo {
+	r := t.HandleRewrite
+	if r == nil {
+		return nil
+	}
+	nt := t.Clone()
+	nt.ID = r.TableID
+	nt.PKIsHandle = r.PKIsHandle
+	nt.IsCommonHandle = r.IsCommonHandle
+	nt.CommonHandleVersion = r.CommonHandleVersion
+	nt.Columns = nt.HandleRewrite.Columns
+	nt.Indices = nt.HandleRewrite.Indices
+	nt.State = r.DDLState
+	nt.HandleRewrite = nil
+	return nt
+}
+
+// MaterializedViewRefreshMethod is the method to refresh a materialized view.
+type MaterializedViewRefreshMethod int
+
+// Materialized view refresh methods.
+const (
+	// RefreshComplete recomputes all the rows of the view.
+	RefreshComplete MaterializedViewRefreshMethod = iota
+	// RefreshFast applies the changes logged for the base table to the view.
+	RefreshFast
+)
+
+// String implements fmt.Stringer interface.
+func (m MaterializedViewRefreshMethod) String() string {
+	if m == RefreshFast {
+		return "FAST"
+	}
+	return "COMPLETE"
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view, whose rows
+// are stored in the table itself.
+type MaterializedViewInfo struct {
+	SelectStmt    string                        `json:"select"`
+	RefreshMethod MaterializedViewRefreshMethod `json:"refresh_method"`
+	// RefreshInterval is the interval in seconds to refresh the view automatically,
+	// 0 means the view is only refreshed by REFRESH MATERIALIZED VIEW.
+	RefreshInterval int64 `json:"refresh_interval,omitempty"`
+	// BaseSchemaID and BaseTableID are the table read by the view, they are 0 if
+	// the view is not an aggregation on a single table.
+	BaseSchemaID int64 `json:"base_schema_id,omitempty"`
+	BaseTableID  int64 `json:"base_table_id,omitempty"`
+	// LogTableID is the change log of the base table for the fast refresh.
+	LogTableID int64 `json:"log_table_id,omitempty"`
+}
+
+// MaterializedViewRef is a materialized view aggregating only the table.
+type MaterializedViewRef struct {
+	ViewID int64 `json:"view_id"`
+	// LogTableID is the change log of the table written for the view, it is 0
+	// if the view is not refreshed fast.
+	LogTableID int64 `json:"log_table_id,omitempty"`
+}
+
+// MaterializedViewLogOpName is the name of the column in the change log of a table,
+// which is 1 for an inserted row and -1 for a deleted row.
+var MaterializedViewLogOpName = NewCIStr("_tidb_mlog_op")
+
+// MaterializedViewLogTableName returns the name of the change log kept for the view.
+func MaterializedViewLogTableName(viewID int64) CIStr {
+	return NewCIStr(fmt.Sprintf("_tidb_mlog$%d", viewID))
+}
+
+// MaterializedViewLogTableInfo returns the table info of the change log of the table
+// kept for the materialized view. The change log has the public columns of the table
+// without any constraints, and the op column at last.
+func (t *TableInfo) MaterializedViewLogTableInfo(ref *MaterializedViewRef) *TableInfo {
+	cols := t.Cols()
+	logInfo := &TableInfo{
+		ID:                    ref.LogTableID,
+		Name:                  MaterializedViewLogTableName(ref.ViewID),
+		Charset:               t.Charset,
+		Collate:               t.Collate,
+		Columns:               make([]*ColumnInfo, 0, len(cols)+1),
+		State:                 StatePublic,
+		Version:               CurrLatestTableInfoVersion,
+		MaxColumnID:           t.MaxColumnID + 1,
+		IsMaterializedViewLog: true,
+	}
+	for i, col := range cols {
+		c := &ColumnInfo{
+			ID:        col.ID,
+			Name:      col.Name,
+			Offset:    i,
+			FieldType: *col.FieldType.Clone(),
+			State:     StatePublic,
+			Hidden:    col.Hidden,
+			Version:   col.Version,
+		}
+		c.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag | mysql.AutoIncrementFlag |
+			mysql.NotNullFlag | mysql.OnUpdateNowFlag | mysql.NoDefaultValueFlag)
+		logInfo.Columns = append(logInfo.Columns, c)
+	}
+	op := &ColumnInfo{
+		ID:        logInfo.MaxColumnID,
+		Name:      MaterializedViewLogOpName,
+		Offset:    len(cols),
+		FieldType: *types.NewFieldType(mysql.TypeLonglong),
+		State:     StatePublic,
+		Version:   CurrLatestColumnInfoVersion,
+	}
+	op.AddFlag(mysql.NotNullFlag)
+	logInfo.Columns = append(logInfo.Columns, op)
+	return logInfo
+}
+
 // ExchangePartitionInfo provides exchange partition info.
 type ExchangePartitionInfo struct {
 	// It is nt tableID when table which has the info is a partition table, else pt tableID.
@@ -1217,6 +1405,11 @@ type PartitionInfo struct {
 	DDLType    PartitionType `json:"ddl_type"`
 	DDLExpr    string        `json:"ddl_expr"`
 	DDLColumns []CIStr       `json:"ddl_columns"`
+	// Set during MODIFY COLUMN of a partitioning column, which reorganizes
+	// all partitions. First as the new column definitions used by the
+	// AddingDefinitions, then in StateDeleteReorg as the old ones used by
+	// the DroppingDefinitions.
+	DDLChangedColumns []*ColumnInfo `json:"ddl_changed_columns,omitempty"`
 }
 
 // Clone clones itself.
@@ -1240,9 +1433,36 @@ func (pi *PartitionInfo) Clone() *PartitionInfo {
 		newPi.DroppingDefinitions[i] = pi.DroppingDefinitions[i].Clone()
 	}
 
+	if pi.DDLChangedColumns != nil {
+		newPi.DDLChangedColumns = make([]*ColumnInfo, len(pi.DDLChangedColumns))
+		for i := range pi.DDLChangedColumns {
+			newPi.DDLChangedColumns[i] = pi.DDLChangedColumns[i].Clone()
+		}
+	}
+
 	return &newPi
 }
 
+// ReplaceDDLChangedColumns returns a copy of cols, where the columns changed
+// by the ongoing reorganization are replaced by their DDLChangedColumns
+// definitions. It returns cols directly if no columns are changed.
+func (pi *PartitionInfo) ReplaceDDLChangedColumns(cols []*ColumnInfo) []*ColumnInfo {
+	if len(pi.DDLChangedColumns) == 0 {
+		return cols
+	}
+	newCols := make([]*ColumnInfo, len(cols))
+	copy(newCols, cols)
+	for _, changed := range pi.DDLChangedColumns {
+		for i, col := range newCols {
+			if col.ID == changed.ID {
+				newCols[i] = changed
+				break
+			}
+		}
+	}
+	return newCols
+}
+
 // GetNameByID gets the partition name by ID.
 func (pi *PartitionInfo) GetNameByID(id int64) string {
 	definitions := pi.Definitions
@@ -1437,6 +1657,10 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
+	case IndexTypeSpatial:
+		return "SPATIAL"
 	default:
 		return ""
 	}
@@ -1449,6 +1673,8 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFulltext
+	IndexTypeSpatial
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1468,6 +1694,25 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	// FullText is not nil for a FULLTEXT index, which is a multi-valued index on the tokens of its columns.
+	FullText *FullTextIndexInfo `json:"full_text,omitempty"`
+	// Spatial is not nil for a SPATIAL index, which is a multi-valued index on the cells covering its column.
+	Spatial *SpatialIndexInfo `json:"spatial,omitempty"`
+}
+
+// FullTextIndexInfo provides meta data describing a FULLTEXT index. It's a multi-valued index
+// on the tokens rather than an inverted index, see ddl.BuildFullTextIndexParts for its limits.
+type FullTextIndexInfo struct {
+	// Columns are the full-text columns. The index is on the hidden generated column of their tokens.
+	Columns []CIStr `json:"columns"`
+	// Parser is the name of the parser splitting the text into tokens, it's empty for the built-in parser.
+	Parser CIStr `json:"parser"`
+}
+
+// SpatialIndexInfo provides meta data describing a SPATIAL index.
+type SpatialIndexInfo struct {
+	// Column is the geometry column. The index is on the hidden generated column of the cells covering it.
+	Column CIStr `json:"column"`
 }
 
 // Clone clones IndexInfo.
@@ -1480,6 +1725,15 @@ func (index *IndexInfo) Clone() *IndexInfo {
 	for i := range index.Columns {
 		ni.Columns[i] = index.Columns[i].Clone()
 	}
+	if index.FullText != nil {
+		fullText := *index.FullText
+		fullText.Columns = append([]CIStr(nil), index.FullText.Columns...)
+		ni.FullText = &fullText
+	}
+	if index.Spatial != nil {
+		spatial := *index.Spatial
+		ni.Spatial = &spatial
+	}
 	return &ni
 }
 
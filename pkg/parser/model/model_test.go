@@ -806,6 +806,38 @@ func TestTTLInfoClone(t *testing.T) {
 	require.Equal(t, true, ttlInfo.Enable)
 }
 
+func TestHandleRewriteTableInfo(t *testing.T) {
+	tblInfo := &TableInfo{
+		ID:         1,
+		PKIsHandle: true,
+		State:      StatePublic,
+		Columns:    []*ColumnInfo{{ID: 1, Name: NewCIStr("a")}},
+		HandleRewrite: &HandleRewriteInfo{
+			TableID:             2,
+			IsCommonHandle:      true,
+			CommonHandleVersion: 1,
+			Columns:             []*ColumnInfo{{ID: 1, Name: NewCIStr("a")}},
+			Indices:             []*IndexInfo{{ID: 1, Name: NewCIStr("primary"), Primary: true}},
+			DDLState:            StateWriteOnly,
+		},
+	}
+
+	cloned := tblInfo.Clone()
+	cloned.HandleRewrite.Indices[0].Name = NewCIStr("changed")
+	require.Equal(t, "primary", tblInfo.HandleRewrite.Indices[0].Name.L)
+
+	other := tblInfo.HandleRewriteTableInfo()
+	require.Equal(t, int64(2), other.ID)
+	require.False(t, other.PKIsHandle)
+	require.True(t, other.IsCommonHandle)
+	require.Equal(t, uint16(1), other.CommonHandleVersion)
+	require.Equal(t, StateWriteOnly, other.State)
+	require.Len(t, other.Indices, 1)
+	require.Nil(t, other.HandleRewrite)
+	require.Equal(t, int64(1), tblInfo.ID)
+	require.NotNil(t, tblInfo.HandleRewrite)
+}
+
 func TestTTLJobInterval(t *testing.T) {
 	ttlInfo := &TTLInfo{}
 
@@ -33,6 +33,10 @@ type DDLReorgMeta struct {
 	UseCloudStorage   bool                             `json:"use_cloud_storage"`
 	ResourceGroupName string                           `json:"resource_group_name"`
 	Version           int64                            `json:"version"`
+	// Schedule is the cron expression of the maintenance window in which the reorganization runs,
+	// it lasts ScheduleDuration from each scheduled time. The global window is used if it's empty.
+	Schedule         string `json:"schedule,omitempty"`
+	ScheduleDuration string `json:"schedule_duration,omitempty"`
 }
 
 const (
@@ -181,11 +181,13 @@ import (
 	is                "IS"
 	iterate           "ITERATE"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -322,6 +324,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -370,6 +373,8 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	concurrency           "CONCURRENCY"
@@ -405,10 +410,12 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -418,6 +425,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -426,6 +434,7 @@ import (
 	expire                "EXPIRE"
 	extended              "EXTENDED"
 	failedLoginAttempts   "FAILED_LOGIN_ATTEMPTS"
+	fast                  "FAST"
 	faultsSym             "FAULTS"
 	fields                "FIELDS"
 	file                  "FILE"
@@ -438,6 +447,9 @@ import (
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometryType          "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -471,12 +483,14 @@ import (
 	lastBackup            "LAST_BACKUP"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	local                 "LOCAL"
 	location              "LOCATION"
 	locked                "LOCKED"
 	logs                  "LOGS"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
@@ -495,9 +509,13 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -522,6 +540,7 @@ import (
 	onDuplicate           "ON_DUPLICATE"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -530,6 +549,7 @@ import (
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
 	passwordLockTime      "PASSWORD_LOCK_TIME"
+	path                  "PATH"
 	pause                 "PAUSE"
 	percent               "PERCENT"
 	per_db                "PER_DB"
@@ -538,6 +558,7 @@ import (
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
@@ -555,8 +576,10 @@ import (
 	quick                 "QUICK"
 	rateLimit             "RATE_LIMIT"
 	rebuild               "REBUILD"
+	recommend             "RECOMMEND"
 	recover               "RECOVER"
 	redundant             "REDUNDANT"
+	refresh               "REFRESH"
 	reload                "RELOAD"
 	remove                "REMOVE"
 	reorganize            "REORGANIZE"
@@ -621,6 +644,7 @@ import (
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -847,6 +871,7 @@ import (
 	histogramsInFlight         "HISTOGRAMS_IN_FLIGHT"
 	job                        "JOB"
 	jobs                       "JOBS"
+	multiHistogram             "MULTI_HISTOGRAM"
 	nodeID                     "NODE_ID"
 	nodeState                  "NODE_STATE"
 	optimistic                 "OPTIMISTIC"
@@ -943,6 +968,7 @@ import (
 %type	<statement>
 	AdminStmt                  "Check table statement or show ddl statement"
 	AlterDatabaseStmt          "Alter database statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AlterTableStmt             "Alter table statement"
 	AlterUserStmt              "Alter user statement"
 	AlterInstanceStmt          "Alter instance statement"
@@ -965,6 +991,8 @@ import (
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateEventStmt            "CREATE EVENT statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	AddQueryWatchStmt          "ADD QUERY WATCH statement"
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
@@ -973,6 +1001,8 @@ import (
 	DropDatabaseStmt           "DROP DATABASE statement"
 	DropIndexStmt              "DROP INDEX statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropEventStmt              "DROP EVENT statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropQueryWatchStmt         "DROP QUERY WATCH statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
@@ -1021,7 +1051,9 @@ import (
 	RenameTableStmt            "rename table statement"
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
+	RecommendIndexStmt         "RECOMMEND INDEX statement"
 	RecoverTableStmt           "recover table statement"
+	RefreshMatViewStmt         "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1171,6 +1203,7 @@ import (
 	AsOfClauseOpt                          "AS OF clause optional"
 	HandleRange                            "handle range"
 	HandleRangeList                        "handle range list"
+	HypoOpt                                "HYPO or empty"
 	IfExists                               "If Exists"
 	IfNotExists                            "If Not Exists"
 	IgnoreOptional                         "IGNORE or empty"
@@ -1196,6 +1229,10 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR behavior"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1261,6 +1298,10 @@ import (
 	PrivElem                               "Privilege element"
 	PrivLevel                              "Privilege scope"
 	PrivType                               "Privilege type"
+	RecommendIndexForOpt                   "optional FOR clause of RECOMMEND INDEX"
+	RecommendIndexOption                   "RECOMMEND INDEX option"
+	RecommendIndexOptionList               "RECOMMEND INDEX option list"
+	RecommendIndexOptionListOpt            "optional RECOMMEND INDEX option list"
 	ReferDef                               "Reference definition"
 	OnDelete                               "ON DELETE clause"
 	OnUpdate                               "ON UPDATE clause"
@@ -1295,6 +1336,7 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoOptionListOpt                "SELECT INTO OUTFILE optional option list"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1430,6 +1472,7 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1491,6 +1534,20 @@ import (
 	OptionalShardColumn                    "Optional shard column"
 	SpOptInout                             "Optional procedure param type"
 	OptSpPdparams                          "Optional procedure param list"
+	EventSchedule                          "Event schedule"
+	MaterializedViewRefreshOpt             "Optional materialized view REFRESH clause"
+	MaterializedViewRefreshMethod          "Materialized view refresh method"
+	MaterializedViewRefreshEveryOpt        "Optional materialized view EVERY clause"
+	MaterializedViewRefreshCompleteOpt     "Optional COMPLETE of REFRESH MATERIALIZED VIEW"
+	EventStartsOpt                         "Optional event STARTS clause"
+	EventEndsOpt                           "Optional event ENDS clause"
+	EventOnCompletion                      "Event ON COMPLETION clause"
+	EventOnCompletionOpt                   "Optional event ON COMPLETION clause"
+	EventOnClausesOpt                      "Optional event ON SCHEDULE and ON COMPLETION clauses of ALTER EVENT"
+	EventStatusOpt                         "Optional event status"
+	EventCommentOpt                        "Optional event comment"
+	EventRenameOpt                         "Optional event RENAME TO clause"
+	EventBodyOpt                           "Optional event body of ALTER EVENT"
 	SpPdparams                             "Procedure params"
 	SpPdparam                              "Procedure param"
 	ProcedureOptDefault                    "Optional procedure variable default value"
@@ -1561,6 +1618,7 @@ import (
 	FunctionNameDateArithMultiForms "Date arith function call names (adddate or subdate)"
 	VariableName                    "A simple Identifier like xx or the xx.xx form"
 	ConfigItemName                  "A config item like aa or aa.bb or aa.bb-cc.dd"
+	SpatialTypeName                 "Spatial type name"
 	AuthString                      "Password string value"
 	AuthPlugin                      "Authentication plugin name"
 	CharsetName                     "Character set name"
@@ -3768,6 +3826,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4062,6 +4133,10 @@ StatsType:
 	{
 		$$ = ast.StatsTypeCorrelation
 	}
+|	"MULTI_HISTOGRAM"
+	{
+		$$ = ast.StatsTypeMultiHistogram
+	}
 
 BindingStatusType:
 	"ENABLED"
@@ -4096,7 +4171,7 @@ DropStatisticsStmt:
  *
  * TYPE type_name is recognized as a synonym for USING type_name. However, USING is the preferred form.
  *
- * CREATE [UNIQUE | FULLTEXT | SPATIAL] INDEX index_name
+ * CREATE [UNIQUE | FULLTEXT | SPATIAL] [HYPO] INDEX index_name
  *     [index_type]
  *     ON tbl_name (key_part,...)
  *     [index_option]
@@ -4112,7 +4187,7 @@ DropStatisticsStmt:
  *   | {VISIBLE | INVISIBLE}
  *
  * index_type:
- *     USING {BTREE | HASH}
+ *     USING {BTREE | HASH | HYPO}
  *
  * algorithm_option:
  *     ALGORITHM [=] {DEFAULT | INPLACE | COPY}
@@ -4121,34 +4196,37 @@ DropStatisticsStmt:
  *     LOCK [=] {DEFAULT | NONE | SHARED | EXCLUSIVE}
  *******************************************************************************************/
 CreateIndexStmt:
-	"CREATE" IndexKeyTypeOpt "INDEX" IfNotExists Identifier IndexTypeOpt "ON" TableName '(' IndexPartSpecificationList ')' IndexOptionList IndexLockAndAlgorithmOpt
+	"CREATE" IndexKeyTypeOpt HypoOpt "INDEX" IfNotExists Identifier IndexTypeOpt "ON" TableName '(' IndexPartSpecificationList ')' IndexOptionList IndexLockAndAlgorithmOpt
 	{
 		var indexOption *ast.IndexOption
-		if $12 != nil {
-			indexOption = $12.(*ast.IndexOption)
+		if $13 != nil {
+			indexOption = $13.(*ast.IndexOption)
 			if indexOption.Tp == model.IndexTypeInvalid {
-				if $6 != nil {
-					indexOption.Tp = $6.(model.IndexType)
+				if $7 != nil {
+					indexOption.Tp = $7.(model.IndexType)
 				}
 			}
 		} else {
 			indexOption = &ast.IndexOption{}
-			if $6 != nil {
-				indexOption.Tp = $6.(model.IndexType)
+			if $7 != nil {
+				indexOption.Tp = $7.(model.IndexType)
 			}
 		}
+		if $3.(bool) {
+			indexOption.Tp = model.IndexTypeHypo
+		}
 		var indexLockAndAlgorithm *ast.IndexLockAndAlgorithm
-		if $13 != nil {
-			indexLockAndAlgorithm = $13.(*ast.IndexLockAndAlgorithm)
+		if $14 != nil {
+			indexLockAndAlgorithm = $14.(*ast.IndexLockAndAlgorithm)
 			if indexLockAndAlgorithm.LockTp == ast.LockTypeDefault && indexLockAndAlgorithm.AlgorithmTp == ast.AlgorithmTypeDefault {
 				indexLockAndAlgorithm = nil
 			}
 		}
 		$$ = &ast.CreateIndexStmt{
-			IfNotExists:             $4.(bool),
-			IndexName:               $5,
-			Table:                   $8.(*ast.TableName),
-			IndexPartSpecifications: $10.([]*ast.IndexPartSpecification),
+			IfNotExists:             $5.(bool),
+			IndexName:               $6,
+			Table:                   $9.(*ast.TableName),
+			IndexPartSpecifications: $11.([]*ast.IndexPartSpecification),
 			IndexOption:             indexOption,
 			KeyType:                 $2.(ast.IndexKeyType),
 			LockAlg:                 indexLockAndAlgorithm,
@@ -4234,6 +4312,15 @@ IndexKeyTypeOpt:
 		$$ = ast.IndexKeyTypeFullText
 	}
 
+HypoOpt:
+	{
+		$$ = false
+	}
+|	"HYPO"
+	{
+		$$ = true
+	}
+
 /**************************************AlterDatabaseStmt***************************************
  * See https://dev.mysql.com/doc/refman/5.7/en/alter-database.html
  * 'ALTER DATABASE ... UPGRADE DATA DIRECTORY NAME' is not supported yet.
@@ -6537,6 +6624,7 @@ UnReservedKeyword:
 	"ACTION"
 |	"ADVISE"
 |	"ASCII"
+|	"AT"
 |	"ATTRIBUTE"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
@@ -6567,6 +6655,8 @@ UnReservedKeyword:
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETE"
+|	"COMPLETION"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6581,6 +6671,7 @@ UnReservedKeyword:
 |	"DYNAMIC"
 |	"ENCRYPTION"
 |	"END"
+|	"ENDS"
 |	"ENFORCED"
 |	"ENGINE"
 |	"ENGINES"
@@ -6632,9 +6723,18 @@ UnReservedKeyword:
 |	"SHUTDOWN"
 |	"SNAPSHOT"
 |	"START"
+|	"STARTS"
 |	"STATUS"
 |	"OPEN"
 |	"POINT"
+|	"GEOMETRY"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"LINESTRING"
+|	"MULTILINESTRING"
+|	"MULTIPOINT"
+|	"MULTIPOLYGON"
+|	"POLYGON"
 |	"SUBPARTITIONS"
 |	"SUBPARTITION"
 |	"TABLES"
@@ -6706,6 +6806,10 @@ UnReservedKeyword:
 |	"BINDINGS"
 |	"MODIFY"
 |	"EVENTS"
+|	"EVERY"
+|	"FAST"
+|	"MATERIALIZED"
+|	"REFRESH"
 |	"PARTITIONS"
 |	"NONE"
 |	"NULLS"
@@ -6751,6 +6855,7 @@ UnReservedKeyword:
 |	"SECURITY"
 |	"CASCADED"
 |	"RECOVER"
+|	"RECOMMEND"
 |	"CIPHER"
 |	"SUBJECT"
 |	"ISSUER"
@@ -6903,6 +7008,10 @@ UnReservedKeyword:
 |	"OLTP_READ_ONLY"
 |	"OLTP_WRITE_ONLY"
 |	"TPCH_10"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6920,6 +7029,7 @@ TiDBKeyword:
 |	"DRAINER"
 |	"JOBS"
 |	"JOB"
+|	"MULTI_HISTOGRAM"
 |	"NODE_ID"
 |	"NODE_STATE"
 |	"PUMP"
@@ -9643,12 +9753,99 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsNameOpt
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExistsPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $5.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $6.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10045,22 +10242,34 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines SelectIntoOptionListOpt
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
 			FileName: $3,
-		}
-		if $4 != nil {
-			x.FieldsInfo = $4.(*ast.FieldsClause)
+			Format:   $4.(*string),
+			Options:  $7.([]*ast.LoadDataOpt),
 		}
 		if $5 != nil {
-			x.LinesInfo = $5.(*ast.LinesClause)
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
 		}
 
 		$$ = x
 	}
 
+SelectIntoOptionListOpt:
+	%prec lowerThanWith
+	{
+		$$ = []*ast.LoadDataOpt{}
+	}
+|	"WITH" LoadDataOptionList
+	{
+		$$ = $2.([]*ast.LoadDataOpt)
+	}
+
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
 	'(' SelectStmt ')'
@@ -11034,6 +11243,22 @@ AdminStmt:
 			JobIDs: $5.([]int64),
 		}
 	}
+|	"ADMIN" "ALTER" "DDL" "JOBS" NumList "SCHEDULE" EqOpt stringLit "DURATION" EqOpt StringName
+	{
+		$$ = &ast.AdminStmt{
+			Tp:               ast.AdminAlterDDLJobs,
+			JobIDs:           $5.([]int64),
+			Schedule:         $8,
+			ScheduleDuration: $11,
+		}
+	}
+|	"ADMIN" "ALTER" "DDL" "JOBS" NumList "SCHEDULE" EqOpt "DEFAULT"
+	{
+		$$ = &ast.AdminStmt{
+			Tp:     ast.AdminAlterDDLJobs,
+			JobIDs: $5.([]int64),
+		}
+	}
 |	"ADMIN" "SHOW" "DDL" "JOB" "QUERIES" NumList
 	{
 		$$ = &ast.AdminStmt{
@@ -11986,6 +12211,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterDatabaseStmt
+|	AlterEventStmt
 |	AlterTableStmt
 |	AlterUserStmt
 |	AlterInstanceStmt
@@ -12013,6 +12239,8 @@ Statement:
 |	CreateBindingStmt
 |	CreatePolicyStmt
 |	CreateProcedureStmt
+|	CreateEventStmt
+|	CreateMaterializedViewStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12022,6 +12250,8 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropEventStmt
+|	DropMaterializedViewStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
@@ -12054,7 +12284,9 @@ Statement:
 |	RenameTableStmt
 |	RenameUserStmt
 |	ReplaceIntoStmt
+|	RecommendIndexStmt
 |	RecoverTableStmt
+|	RefreshMatViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12557,6 +12789,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -13088,6 +13321,28 @@ DateAndTimeType:
 		$$ = tp
 	}
 
+SpatialType:
+	SpatialTypeName
+	{
+		// All the spatial types are stored as GEOMETRY.
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+SpatialTypeName:
+	"GEOMETRY"
+|	"POINT"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+
 FieldLen:
 	'(' LengthNum ')'
 	{
@@ -15176,6 +15431,61 @@ IndexAdviseStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Recommend Index Statement
+ *
+ *  Example:
+ *	RECOMMEND INDEX RUN
+ *	[FOR 'select_stmt']
+ *	[WITH option_name = N [, option_name = N] ...]
+ *******************************************************************/
+RecommendIndexStmt:
+	"RECOMMEND" "INDEX" "RUN" RecommendIndexForOpt RecommendIndexOptionListOpt
+	{
+		$$ = &ast.RecommendIndexStmt{
+			SQL:     $4.(string),
+			Options: $5.([]*ast.RecommendIndexOption),
+		}
+	}
+
+RecommendIndexForOpt:
+	{
+		$$ = ""
+	}
+|	"FOR" stringLit
+	{
+		$$ = $2
+	}
+
+RecommendIndexOptionListOpt:
+	{
+		$$ = []*ast.RecommendIndexOption(nil)
+	}
+|	"WITH" RecommendIndexOptionList
+	{
+		$$ = $2.([]*ast.RecommendIndexOption)
+	}
+
+RecommendIndexOptionList:
+	RecommendIndexOption
+	{
+		$$ = []*ast.RecommendIndexOption{$1.(*ast.RecommendIndexOption)}
+	}
+|	RecommendIndexOptionList ',' RecommendIndexOption
+	{
+		$$ = append($1.([]*ast.RecommendIndexOption), $3.(*ast.RecommendIndexOption))
+	}
+
+RecommendIndexOption:
+	Identifier EqOpt NUM
+	{
+		$$ = &ast.RecommendIndexOption{
+			Name:  strings.ToLower($1),
+			Value: getUint64FromNUM($3),
+		}
+	}
+
 MaxMinutesOpt:
 	{
 		$$ = uint64(ast.UnspecifiedSize)
@@ -15509,6 +15819,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -16021,6 +16332,297 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+*  CREATE
+*      [DEFINER = user]
+*      EVENT
+*      [IF NOT EXISTS]
+*      event_name
+*      ON SCHEDULE schedule
+*      [ON COMPLETION [NOT] PRESERVE]
+*      [ENABLE | DISABLE | DISABLE ON SLAVE]
+*      [COMMENT 'string']
+*      DO event_body;
+*
+*  The prefix is shared with CREATE VIEW to avoid the conflicts of DEFINER, the
+*  view only options are rejected.
+********************************************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventOnCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureStatementStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(yylex.Errorf("OR REPLACE, ALGORITHM and SQL SECURITY are not supported by CREATE EVENT"))
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $16
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateEventStmt{
+			Definer:      $4.(*auth.UserIdentity),
+			IfNotExists:  $7.(bool),
+			EventName:    $8.(*ast.TableName),
+			Schedule:     $11.(*ast.EventSchedule),
+			OnCompletion: $12.(ast.EventOnCompletion),
+			Status:       $13.(ast.EventStatus),
+			Body:         body,
+		}
+		if $14 != nil {
+			x.Comment = $14.(string)
+		}
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{
+			Tp: ast.EventScheduleAt,
+			At: $2,
+		}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Tp:       ast.EventScheduleEvery,
+			Interval: $2,
+			Unit:     $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventOnCompletion:
+	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventOnCompletionPreserve
+	}
+|	"ON" "COMPLETION" NotSym "PRESERVE"
+	{
+		$$ = ast.EventOnCompletionNotPreserve
+	}
+
+EventOnCompletionOpt:
+	{
+		$$ = ast.EventOnCompletionUnspecified
+	}
+|	EventOnCompletion
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusSlavesideDisabled
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+*  ALTER EVENT event_name
+*      [ON SCHEDULE schedule]
+*      [ON COMPLETION [NOT] PRESERVE]
+*      [RENAME TO new_event_name]
+*      [ENABLE | DISABLE | DISABLE ON SLAVE]
+*      [COMMENT 'string']
+*      [DO event_body]
+********************************************************************************************/
+AlterEventStmt:
+	"ALTER" "EVENT" TableName EventOnClausesOpt EventRenameOpt EventStatusOpt EventCommentOpt EventBodyOpt
+	{
+		x := $4.(*ast.AlterEventStmt)
+		x.EventName = $3.(*ast.TableName)
+		if $5 != nil {
+			x.NewName = $5.(*ast.TableName)
+		}
+		x.Status = $6.(ast.EventStatus)
+		if $7 != nil {
+			comment := $7.(string)
+			x.Comment = &comment
+		}
+		if $8 != nil {
+			x.Body = $8.(ast.StmtNode)
+		}
+		if x.Schedule == nil && x.OnCompletion == ast.EventOnCompletionUnspecified && x.NewName == nil &&
+			x.Status == ast.EventStatusUnspecified && x.Comment == nil && x.Body == nil {
+			yylex.AppendError(yylex.Errorf("ALTER EVENT requires at least one clause"))
+			return 1
+		}
+		$$ = x
+	}
+
+EventOnClausesOpt:
+	{
+		$$ = &ast.AlterEventStmt{}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventOnCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:     $3.(*ast.EventSchedule),
+			OnCompletion: $4.(ast.EventOnCompletion),
+		}
+	}
+|	EventOnCompletion
+	{
+		$$ = &ast.AlterEventStmt{
+			OnCompletion: $1.(ast.EventOnCompletion),
+		}
+	}
+
+EventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+EventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureStatementStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $2
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = body
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  CREATE MATERIALIZED VIEW [IF NOT EXISTS] view_name [(column_list)]
+*      [REFRESH {COMPLETE | FAST} [EVERY interval unit]]
+*      AS select_statement
+********************************************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists TableName ViewFieldList MaterializedViewRefreshOpt "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $9.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists: $4.(bool),
+			ViewName:    $5.(*ast.TableName),
+			Refresh:     $7.(ast.MaterializedViewRefresh),
+			Select:      selStmt,
+		}
+		if $6 != nil {
+			x.Cols = $6.([]model.CIStr)
+		}
+		$$ = x
+	}
+
+MaterializedViewRefreshOpt:
+	{
+		$$ = ast.MaterializedViewRefresh{Method: model.RefreshComplete}
+	}
+|	"REFRESH" MaterializedViewRefreshMethod MaterializedViewRefreshEveryOpt
+	{
+		x := $3.(ast.MaterializedViewRefresh)
+		x.Method = $2.(model.MaterializedViewRefreshMethod)
+		$$ = x
+	}
+
+MaterializedViewRefreshMethod:
+	"COMPLETE"
+	{
+		$$ = model.RefreshComplete
+	}
+|	"FAST"
+	{
+		$$ = model.RefreshFast
+	}
+
+MaterializedViewRefreshEveryOpt:
+	{
+		$$ = ast.MaterializedViewRefresh{}
+	}
+|	"EVERY" Expression TimeUnit
+	{
+		$$ = ast.MaterializedViewRefresh{
+			Interval: $2,
+			Unit:     $3.(ast.TimeUnitType),
+		}
+	}
+
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{
+			IfExists: $4.(bool),
+			ViewName: $5.(*ast.TableName),
+		}
+	}
+
+RefreshMatViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName MaterializedViewRefreshCompleteOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Complete: $5.(bool),
+		}
+	}
+
+MaterializedViewRefreshCompleteOpt:
+	{
+		$$ = false
+	}
+|	"COMPLETE"
+	{
+		$$ = true
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -99,7 +99,7 @@ func TestSimple(t *testing.T) {
 		"following", "preceding", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
 		"chain", "error", "general", "nvarchar", "pack_keys", "p", "shard_row_id_bits", "pre_split_regions",
 		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement", "attributes", "attribute", "resource",
-		"burstable", "calibrate", "rollup",
+		"burstable", "calibrate", "rollup", "nested", "ordinality", "path", "empty",
 	}
 	for _, kw := range unreservedKws {
 		src := fmt.Sprintf("SELECT %s FROM tbl;", kw)
@@ -491,6 +491,11 @@ func TestAdminStmt(t *testing.T) {
 		{"admin resume ddl jobs 3", true, "ADMIN RESUME DDL JOBS 3"},
 		{"admin resume ddl jobs", false, "ADMIN RESUME DDL JOBS"},
 		{"admin resume ddl jobs str_not_num", false, "ADMIN RESUME DDL JOBS str_not_num"},
+		{"admin alter ddl jobs 1, 2 schedule '0 1 * * *' duration '4h'", true, "ADMIN ALTER DDL JOBS 1, 2 SCHEDULE '0 1 * * *' DURATION '4h'"},
+		{"admin alter ddl jobs 3 schedule = '0 1 * * *' duration = 4h", true, "ADMIN ALTER DDL JOBS 3 SCHEDULE '0 1 * * *' DURATION '4h'"},
+		{"admin alter ddl jobs 3 schedule default", true, "ADMIN ALTER DDL JOBS 3 SCHEDULE DEFAULT"},
+		{"admin alter ddl jobs 3 schedule '0 1 * * *'", false, ""},
+		{"admin alter ddl jobs schedule default", false, ""},
 		{"admin recover index t1 idx_a", true, "ADMIN RECOVER INDEX `t1` idx_a"},
 		{"admin cleanup index t1 idx_a", true, "ADMIN CLEANUP INDEX `t1` idx_a"},
 		{"admin show slow top 3", true, "ADMIN SHOW SLOW TOP 3"},
@@ -792,6 +797,11 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select `a` from `t` into outfile 's3://bucket/result.csv' with max_file_size=268435456", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/result.csv' WITH max_file_size=268435456"},
+		{"select a from t into outfile 'gcs://bucket/result.parquet' format 'parquet'", true, "SELECT `a` FROM `t` INTO OUTFILE 'gcs://bucket/result.parquet' FORMAT 'parquet'"},
+		{"select `a` from `t` into outfile '/tmp/result.txt' format 'csv' fields terminated BY ',' lines terminated BY '\n' with max_file_size=1024", true, "SELECT `a` FROM `t` INTO OUTFILE '/tmp/result.txt' FORMAT 'csv' FIELDS TERMINATED BY ',' LINES TERMINATED BY '\n' WITH max_file_size=1024"},
+		{"select a from t into outfile '/tmp/result.txt' with", false, ""},
+		{"select a from t into outfile '/tmp/result.txt' with compress='gzip' fields terminated by ','", false, ""},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -3288,6 +3298,14 @@ func TestDDL(t *testing.T) {
 		{"CREATE INDEX idx ON t ( a ) USING HASH VISIBLE", true, "CREATE INDEX `idx` ON `t` (`a`) USING HASH VISIBLE"},
 		{"CREATE INDEX idx ON t ( a ) USING HASH INVISIBLE", true, "CREATE INDEX `idx` ON `t` (`a`) USING HASH INVISIBLE"},
 
+		// For create hypo index
+		{"CREATE INDEX idx TYPE HYPO ON t (a)", true, "CREATE INDEX `idx` ON `t` (`a`) USING HYPO"},
+		{"CREATE HYPO INDEX idx ON t (a, b)", true, "CREATE INDEX `idx` ON `t` (`a`, `b`) USING HYPO"},
+		{"CREATE UNIQUE HYPO INDEX idx ON t (a)", true, "CREATE UNIQUE INDEX `idx` ON `t` (`a`) USING HYPO"},
+		{"CREATE HYPO INDEX IF NOT EXISTS idx ON t (a) USING BTREE", true, "CREATE INDEX IF NOT EXISTS `idx` ON `t` (`a`) USING HYPO"},
+		{"CREATE HYPO UNIQUE INDEX idx ON t (a)", false, ""},
+		{"DROP HYPO INDEX idx ON t", true, "DROP HYPO INDEX `idx` ON `t`"},
+
 		// For create index with algorithm
 		{"CREATE INDEX idx ON t ( a ) ALGORITHM = DEFAULT", true, "CREATE INDEX `idx` ON `t` (`a`)"},
 		{"CREATE INDEX idx ON t ( a ) ALGORITHM DEFAULT", true, "CREATE INDEX `idx` ON `t` (`a`)"},
@@ -4787,6 +4805,13 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{"create table t (g geometry not null, p point, l linestring, pg polygon)", true, "CREATE TABLE `t` (`g` GEOMETRY NOT NULL,`p` GEOMETRY,`l` GEOMETRY,`pg` GEOMETRY)"},
+		{"create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection)", true, "CREATE TABLE `t` (`a` GEOMETRY,`b` GEOMETRY,`c` GEOMETRY,`d` GEOMETRY,`e` GEOMETRY)"},
+		{"create table t (g geometry, spatial index idx (g))", true, "CREATE TABLE `t` (`g` GEOMETRY,SPATIAL `idx`(`g`))"},
+		{"create table polygon (point int, geometry int)", true, "CREATE TABLE `polygon` (`point` INT,`geometry` INT)"},
+		{"create table t (g geometry(10))", false, ""},
 	}
 	RunTest(t, table, false)
 }
@@ -5038,6 +5063,14 @@ func TestSubquery(t *testing.T) {
 		{"select * from ((SELECT 1 a,3 b) UNION (SELECT 2,1) ORDER BY (SELECT 2)) t order by a,b", true, "SELECT * FROM ((SELECT 1 AS `a`,3 AS `b`) UNION (SELECT 2,1) ORDER BY (SELECT 2)) AS `t` ORDER BY `a`,`b`"},
 		{"select (select * from t1 where a != t.a union all (select * from t2 where a != t.a) order by a limit 1) from t1 t", true, "SELECT (SELECT * FROM `t1` WHERE `a`!=`t`.`a` UNION ALL (SELECT * FROM `t2` WHERE `a`!=`t`.`a`) ORDER BY `a` LIMIT 1) FROM `t1` AS `t`"},
 		{"(WITH v0 AS (SELECT TRUE) (SELECT 'abc' EXCEPT (SELECT TRUE)))", true, "WITH `v0` AS (SELECT TRUE) (SELECT _UTF8MB4'abc' EXCEPT (SELECT TRUE))"},
+
+		// for lateral derived table
+		{"select * from t, lateral (select * from t1 where t1.a = t.a limit 3) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a` LIMIT 3) AS `dt`"},
+		{"select * from t join lateral (select a from t1 where t1.a = t.a union select 1) dt on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT `a` FROM `t1` WHERE `t1`.`a`=`t`.`a` UNION SELECT 1) AS `dt` ON TRUE"},
+		{"select * from t left join lateral (select count(*) c from t1 where t1.a = t.a) dt on true", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT COUNT(1) AS `c` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{"select * from t, lateral t1", false, ""},
+		{"select * from t, lateral (t1)", false, ""},
+		{"select lateral from t", false, ""},
 	}
 	RunTest(t, table, false)
 
@@ -5668,6 +5701,105 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		// for create event
+		{"create event e on schedule at '2024-01-01 00:00:00' do insert into t values (1)", true, "CREATE EVENT `e` ON SCHEDULE AT _UTF8MB4'2024-01-01 00:00:00' DO INSERT INTO `t` VALUES (1)"},
+		{"create event if not exists test.e on schedule at current_timestamp + interval 1 hour do delete from t", true, "CREATE EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 HOUR) DO DELETE FROM `t`"},
+		{"create event e on schedule every 1 hour do update t set a = a + 1", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 HOUR DO UPDATE `t` SET `a`=`a`+1"},
+		{"create event e on schedule every 10 minute starts '2024-01-01 00:00:00' ends '2024-02-01 00:00:00' do analyze table t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 10 MINUTE STARTS _UTF8MB4'2024-01-01 00:00:00' ENDS _UTF8MB4'2024-02-01 00:00:00' DO ANALYZE TABLE `t`"},
+		{"create event e on schedule every 1 day on completion preserve disable comment 'daily' do truncate table t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY ON COMPLETION PRESERVE DISABLE COMMENT 'daily' DO TRUNCATE TABLE `t`"},
+		{"create event e on schedule every 1 day on completion not preserve enable do select 1", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY ON COMPLETION NOT PRESERVE ENABLE DO SELECT 1"},
+		{"create event e on schedule every 1 day disable on slave do select 1", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY DISABLE ON SLAVE DO SELECT 1"},
+		{"create definer = 'root'@'localhost' event e on schedule every 1 day do select 1", true, "CREATE DEFINER = `root`@`localhost` EVENT `e` ON SCHEDULE EVERY 1 DAY DO SELECT 1"},
+		{"create definer = current_user event e on schedule every 1 day do select 1", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY DO SELECT 1"},
+		{"create or replace event e on schedule every 1 day do select 1", false, ""},
+		{"create algorithm = merge event e on schedule every 1 day do select 1", false, ""},
+		{"create sql security invoker event e on schedule every 1 day do select 1", false, ""},
+		{"create event e on schedule every 1 day", false, ""},
+		{"create event e do select 1", false, ""},
+
+		// for alter event
+		{"alter event e on schedule every 2 hour", true, "ALTER EVENT `e` ON SCHEDULE EVERY 2 HOUR"},
+		{"alter event test.e on schedule at '2024-01-01 00:00:00' on completion preserve", true, "ALTER EVENT `test`.`e` ON SCHEDULE AT _UTF8MB4'2024-01-01 00:00:00' ON COMPLETION PRESERVE"},
+		{"alter event e on completion not preserve", true, "ALTER EVENT `e` ON COMPLETION NOT PRESERVE"},
+		{"alter event e rename to e1", true, "ALTER EVENT `e` RENAME TO `e1`"},
+		{"alter event e disable", true, "ALTER EVENT `e` DISABLE"},
+		{"alter event e comment 'abc' do select 1", true, "ALTER EVENT `e` COMMENT 'abc' DO SELECT 1"},
+		{"alter event e rename to e1 enable comment '' do insert into t values (1)", true, "ALTER EVENT `e` RENAME TO `e1` ENABLE COMMENT '' DO INSERT INTO `t` VALUES (1)"},
+		{"alter event e", false, ""},
+
+		// for drop event
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+
+		// the new keywords are unreserved
+		{"create table at (every int, starts int, ends int, completion int)", true, "CREATE TABLE `at` (`every` INT,`starts` INT,`ends` INT,`completion` INT)"},
+	}
+	RunTest(t, table, false)
+
+	// Test case for the text of the event body.
+	p := parser.New()
+	st, err := p.ParseOneStmt("create definer = root@localhost event e on schedule every 1 hour starts '2024-01-01 00:00:00' do insert into t select * from s;", "", "")
+	require.NoError(t, err)
+	create, ok := st.(*ast.CreateEventStmt)
+	require.True(t, ok)
+	require.Equal(t, "root", create.Definer.Username)
+	require.Equal(t, "localhost", create.Definer.Hostname)
+	require.Equal(t, ast.EventScheduleEvery, create.Schedule.Tp)
+	require.Equal(t, ast.TimeUnitHour, create.Schedule.Unit)
+	require.NotNil(t, create.Schedule.Starts)
+	require.Nil(t, create.Schedule.Ends)
+	require.Equal(t, ast.EventOnCompletionUnspecified, create.OnCompletion)
+	require.Equal(t, ast.EventStatusUnspecified, create.Status)
+	require.Equal(t, "insert into t select * from s", create.Body.Text())
+
+	st, err = p.ParseOneStmt("alter event e comment '' do delete from t", "", "")
+	require.NoError(t, err)
+	alter, ok := st.(*ast.AlterEventStmt)
+	require.True(t, ok)
+	require.Nil(t, alter.Schedule)
+	require.Equal(t, "", *alter.Comment)
+	require.Equal(t, "delete from t", alter.Body.Text())
+}
+
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		// for create materialized view
+		{"create materialized view v as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `v` REFRESH COMPLETE AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.v (a, c) refresh fast as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`v` (`a`,`c`) REFRESH FAST AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view v refresh complete every 1 minute as select * from t", true, "CREATE MATERIALIZED VIEW `v` REFRESH COMPLETE EVERY 1 MINUTE AS SELECT * FROM `t`"},
+		{"create materialized view v refresh fast every 10 second as select a, sum(b), count(b), count(*) from t where c > 1 group by a", true, "CREATE MATERIALIZED VIEW `v` REFRESH FAST EVERY 10 SECOND AS SELECT `a`,SUM(`b`),COUNT(`b`),COUNT(1) FROM `t` WHERE `c`>1 GROUP BY `a`"},
+		{"create materialized view v refresh every 1 minute as select * from t", false, ""},
+		{"create materialized view v refresh fast", false, ""},
+		{"create or replace materialized view v as select * from t", false, ""},
+
+		// for drop materialized view
+		{"drop materialized view v", true, "DROP MATERIALIZED VIEW `v`"},
+		{"drop materialized view if exists test.v", true, "DROP MATERIALIZED VIEW IF EXISTS `test`.`v`"},
+		{"drop materialized view v1, v2", false, ""},
+
+		// for refresh materialized view
+		{"refresh materialized view v", true, "REFRESH MATERIALIZED VIEW `v`"},
+		{"refresh materialized view test.v complete", true, "REFRESH MATERIALIZED VIEW `test`.`v` COMPLETE"},
+		{"refresh materialized view v fast", false, ""},
+
+		// the new keywords are unreserved
+		{"create table materialized (refresh int, fast int, complete int)", true, "CREATE TABLE `materialized` (`refresh` INT,`fast` INT,`complete` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create materialized view v refresh fast every 1 hour as select a, count(*) from t group by a;", "", "")
+	require.NoError(t, err)
+	create, ok := st.(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, model.RefreshFast, create.Refresh.Method)
+	require.Equal(t, ast.TimeUnitHour, create.Refresh.Unit)
+	require.NotNil(t, create.Refresh.Interval)
+	require.Equal(t, "select a, count(*) from t group by a", create.Select.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -5967,6 +6099,34 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1,2]', '$[*]' columns (id for ordinality, a int exists path '$.a')) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` INT EXISTS PATH '$.a')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a varchar(10) path '$.a' default '\"x\"' on empty null on error)) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` VARCHAR(10) PATH '$.a' DEFAULT '\"x\"' ON EMPTY NULL ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' error on empty)) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON EMPTY)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c[*]' columns (c int path '$', nested path '$.d' columns (d json path '$')))) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c[*]' COLUMNS (`c` INT PATH '$', NESTED PATH '$.d' COLUMNS (`d` JSON PATH '$')))) AS `jt`"},
+		{"select * from t, json_table(t.j, '$[*]' columns (a int path '$')) as jt;", true, "SELECT * FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from t left join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true;", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{"select * from json_table('[]', '$[*]' columns (nested int path '$', path int path '$', ordinality int path '$', empty int path '$')) as jt;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`nested` INT PATH '$', `path` INT PATH '$', `ordinality` INT PATH '$', `empty` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$')) as jt where jt.a > 1;", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` WHERE `jt`.`a`>1"},
+
+		// negative test cases
+		{"select * from json_table('[]', '$[*]') as jt;", false, ""},
+		{"select * from json_table('[]', '$[*]' columns ()) as jt;", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int)) as jt;", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' null on error null on empty)) as jt;", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int exists path '$' null on empty)) as jt;", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a for ordinality path '$')) as jt;", false, ""},
+		{"select * from json_table('[]', concat('$', '[*]') columns (a int path '$')) as jt;", false, ""},
+		{"select * from json_table;", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -6843,6 +7003,22 @@ func TestIndexAdviseStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestRecommendIndexStmt(t *testing.T) {
+	table := []testCase{
+		{"RECOMMEND INDEX RUN", true, "RECOMMEND INDEX RUN"},
+		{"recommend index run for 'select a from t where b = 1'", true, "RECOMMEND INDEX RUN FOR 'select a from t where b = 1'"},
+		{"RECOMMEND INDEX RUN WITH max_num_index = 3", true, "RECOMMEND INDEX RUN WITH max_num_index = 3"},
+		{"RECOMMEND INDEX RUN WITH MAX_NUM_INDEX 3, max_index_columns = 2", true, "RECOMMEND INDEX RUN WITH max_num_index = 3, max_index_columns = 2"},
+		{"RECOMMEND INDEX RUN FOR 'select * from t' WITH max_num_query = 10", true, "RECOMMEND INDEX RUN FOR 'select * from t' WITH max_num_query = 10"},
+		{"RECOMMEND INDEX RUN WITH max_num_index = -1", false, ""},
+		{"RECOMMEND INDEX RUN WITH", false, ""},
+		{"RECOMMEND INDEX", false, ""},
+		{"create table recommend (recommend int)", true, "CREATE TABLE `recommend` (`recommend` INT)"},
+	}
+
+	RunTest(t, table, false)
+}
+
 // For BRIE
 func TestBRIE(t *testing.T) {
 	table := []testCase{
@@ -6912,6 +7088,7 @@ func TestStatisticsOps(t *testing.T) {
 		{"create statistics stats1 (cardinality) on t(a,b,c)", true, "CREATE STATISTICS `stats1` (CARDINALITY) ON `t`(`a`, `b`, `c`)"},
 		{"create statistics stats2 (dependency) on t(a,b)", true, "CREATE STATISTICS `stats2` (DEPENDENCY) ON `t`(`a`, `b`)"},
 		{"create statistics stats3 (correlation) on t(a,b)", true, "CREATE STATISTICS `stats3` (CORRELATION) ON `t`(`a`, `b`)"},
+		{"create statistics stats4 (multi_histogram) on t(a,b,c)", true, "CREATE STATISTICS `stats4` (MULTI_HISTOGRAM) ON `t`(`a`, `b`, `c`)"},
 		{"create statistics stats3 on t(a,b)", false, ""},
 		{"create statistics if not exists stats1 (cardinality) on t(a,b,c)", true, "CREATE STATISTICS IF NOT EXISTS `stats1` (CARDINALITY) ON `t`(`a`, `b`, `c`)"},
 		{"create statistics if not exists stats2 (dependency) on t(a,b)", true, "CREATE STATISTICS IF NOT EXISTS `stats2` (DEPENDENCY) ON `t`(`a`, `b`)"},
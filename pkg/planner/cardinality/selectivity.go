@@ -206,6 +206,9 @@ func Selectivity(
 			})
 		}
 	}
+	if ctx.GetSessionVars().EnableExtendedStats {
+		nodes = append(nodes, getMultiColStatsNodes(ctx, coll, remainedExprs)...)
+	}
 	usedSets := GetUsableSetsByGreedy(nodes)
 	// Initialize the mask with the full set.
 	mask := (int64(1) << uint(len(remainedExprs))) - 1
@@ -542,6 +545,7 @@ const (
 	IndexType = iota
 	PkType
 	ColType
+	MultiColType
 )
 
 func compareType(l, r int) int {
@@ -557,7 +561,10 @@ func compareType(l, r int) int {
 	if r == ColType {
 		return 1
 	}
-	return -1
+	if r == PkType || l == MultiColType {
+		return -1
+	}
+	return 1
 }
 
 const unknownColumnID = math.MinInt64
@@ -904,6 +911,100 @@ func GetSelectivityByFilter(sctx sessionctx.Context, coll *statistics.HistColl,
 	return true, res, err
 }
 
+// getMultiColStatsNodes builds the StatsNodes of the MULTI_HISTOGRAM statistics. A node covers the expressions which
+// only refer to the columns of the statistics, and it's built only if the covered expressions refer to at least 2 columns,
+// otherwise the statistics of single columns are good enough.
+func getMultiColStatsNodes(sctx sessionctx.Context, coll *statistics.HistColl, exprs []expression.Expression) []*StatsNode {
+	if len(coll.MultiColStats) == 0 {
+		return nil
+	}
+	exprCols := make([][]*expression.Column, len(exprs))
+	for i, expr := range exprs {
+		if expression.IsMutableEffectsExpr(expr) || expression.ContainCorrelatedColumn([]expression.Expression{expr}) {
+			continue
+		}
+		exprCols[i] = expression.ExtractColumns(expr)
+	}
+	var nodes []*StatsNode
+	for id, item := range coll.MultiColStats {
+		var (
+			mask    int64
+			filters []expression.Expression
+			cols    []*expression.Column
+			offsets []int
+		)
+		for i, expr := range exprs {
+			if len(exprCols[i]) == 0 || slices.ContainsFunc(exprCols[i], func(col *expression.Column) bool {
+				return !slices.Contains(item.ColIDs, col.ID)
+			}) {
+				continue
+			}
+			mask |= 1 << uint64(i)
+			filters = append(filters, expr)
+			for _, col := range exprCols[i] {
+				if !slices.ContainsFunc(cols, func(c *expression.Column) bool { return c.UniqueID == col.UniqueID }) {
+					cols = append(cols, col)
+					offsets = append(offsets, slices.Index(item.ColIDs, col.ID))
+				}
+			}
+		}
+		if len(cols) < 2 {
+			continue
+		}
+		ok, sel, err := getSelectivityByMultiColSketch(sctx, item.Sketch, cols, offsets, filters)
+		if err != nil {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("Error when using multi-column statistics estimation: " + err.Error()))
+		}
+		if !ok {
+			continue
+		}
+		nodes = append(nodes, &StatsNode{
+			Tp:          MultiColType,
+			ID:          int64(id),
+			mask:        mask,
+			numCols:     len(item.ColIDs),
+			Selectivity: sel,
+		})
+	}
+	return nodes
+}
+
+// getSelectivityByMultiColSketch estimates the selectivity of the filters by evaluating them on the sampled rows of the
+// MULTI_HISTOGRAM statistics. cols are the columns referred by the filters and offsets are their positions in the sketch.
+func getSelectivityByMultiColSketch(sctx sessionctx.Context, sketch *statistics.MultiColumnSketch,
+	cols []*expression.Column, offsets []int, filters []expression.Expression) (ok bool, selectivity float64, err error) {
+	c, err := sketch.Chunk(offsets, sctx.GetSessionVars().StmtCtx.TimeZone())
+	if err != nil {
+		return false, 0, err
+	}
+	// For execution, we use Column.Index instead of Column.UniqueID to locate a column, so we resolve the
+	// indices of the columns by their positions in the chunk.
+	schema := expression.NewSchema(cols...)
+	resolved := make([]expression.Expression, 0, len(filters))
+	for _, filter := range filters {
+		expr, err := filter.ResolveIndices(schema)
+		if err != nil {
+			return false, 0, err
+		}
+		resolved = append(resolved, expr)
+	}
+	selected, err := expression.VectorizedFilter(sctx, resolved, chunk.NewIterator4Chunk(c), nil)
+	if err != nil {
+		return false, 0, err
+	}
+	matched := 0
+	for _, isTrue := range selected {
+		if isTrue {
+			matched++
+		}
+	}
+	// If no sampled row satisfies the filters, the selectivity is too small to be estimated by the samples.
+	if matched == 0 {
+		return false, 0, nil
+	}
+	return true, float64(matched) / float64(c.NumRows()), nil
+}
+
 func findAvailableStatsForCol(sctx sessionctx.Context, coll *statistics.HistColl, uniqueID int64) (isIndex bool, idx int64) {
 	// try to find available stats in column stats
 	if colStats, ok := coll.Columns[uniqueID]; ok && colStats != nil && !colStats.IsInvalid(sctx, coll.Pseudo) && colStats.IsFullLoad() {
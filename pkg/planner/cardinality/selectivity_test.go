@@ -757,6 +757,48 @@ func TestSelectivityGreedyAlgo(t *testing.T) {
 	require.Equal(t, int64(1), usedSets[0].ID)
 }
 
+func TestSelectivityWithMultiColumnStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_analyze_version = 2")
+	tk.MustExec("set @@tidb_enable_extended_stats = on")
+	tk.MustExec("create table t(a int, b int, c int)")
+	tk.MustGetErrMsg("alter table t add stats_extended s1 multi_histogram(a)", "Only support MultiHistogram statistics type on 2 to 4 columns")
+	tk.MustExec("alter table t add stats_extended s1 multi_histogram(a, b)")
+	// The values of column a and b are totally correlated.
+	vals := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		vals = append(vals, fmt.Sprintf("(%d, %d, %d)", i%10, i%10, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ", "))
+	tk.MustExec("analyze table t")
+	rows := tk.MustQuery("show stats_extended where db_name = 'test' and table_name = 't'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"test", "t", "s1", "[a,b]", "multi_histogram", "100 samples"}, rows[0][:6])
+
+	// 10 rows satisfy both conditions, instead of 1 row under the independence assumption.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"TableReader 10.00 root  data:Selection",
+		"└─Selection 10.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 1)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false"))
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1 and c < 50").Check(testkit.Rows(
+		"TableReader 5.00 root  data:Selection",
+		"└─Selection 5.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 1), lt(test.t.c, 50)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false"))
+	// No sampled row satisfies the conditions, so the independence assumption is used.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 2").Check(testkit.Rows(
+		"TableReader 1.00 root  data:Selection",
+		"└─Selection 1.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 2)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false"))
+
+	tk.MustExec("set @@tidb_enable_extended_stats = off")
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"TableReader 1.00 root  data:Selection",
+		"└─Selection 1.00 cop[tikv]  eq(test.t.a, 1), eq(test.t.b, 1)",
+		"  └─TableFullScan 100.00 cop[tikv] table:t keep order:false"))
+}
+
 func TestTopNAssistedEstimationWithoutNewCollation(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	collate.SetNewCollationEnabledForTest(false)
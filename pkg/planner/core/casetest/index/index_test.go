@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testdata"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNullConditionForPrefixIndex(t *testing.T) {
@@ -78,3 +79,26 @@ func TestNullConditionForPrefixIndex(t *testing.T) {
 		"  └─StreamAgg_9 1.00 cop[tikv]  funcs:count(1)->Column#7",
 		"    └─IndexRangeScan_16 99.90 cop[tikv] table:t1, index:idx2(c1, c2) range:[\"0xfff\" -inf,\"0xfff\" +inf], keep order:false, stats:pseudo"))
 }
+
+func TestHypoIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int)")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d, %d)", i, i, i))
+	}
+	tk.MustExec("analyze table t")
+
+	tk.MustExec("create hypo index hypo_ab on t (a, b)")
+	tk.MustQuery("explain format='brief' select a, b from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexReader 0.01 root  index:IndexRangeScan",
+		"└─IndexRangeScan 0.01 cop[tikv] table:t, index:hypo_ab(a, b) range:[1 1,1 1], keep order:false"))
+	// hypo indexes are only considered by EXPLAIN.
+	tk.MustQuery("select * from t where a = 1 and b = 1").Check(testkit.Rows("1 1 1"))
+	require.NotContains(t, fmt.Sprint(tk.MustQuery("explain analyze select * from t where a = 1 and b = 1").Rows()), "hypo_ab")
+
+	tk.MustGetErrMsg("create hypo index hypo_ab on t (c)", "conflict hypo index name hypo_ab")
+	tk.MustExec("drop hypo index hypo_ab on t")
+	require.NotContains(t, fmt.Sprint(tk.MustQuery("explain select * from t where a = 1 and b = 1").Rows()), "hypo_ab")
+}
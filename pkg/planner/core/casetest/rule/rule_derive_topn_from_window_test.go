@@ -25,7 +25,7 @@ import (
 
 type Input []string
 
-// TiFlash cases. TopN pushed down to storage only when no partition by.
+// TiFlash cases. TopN is derived for TiFlash only when there is partition by, it keeps N rows for each partition in TiFlash.
 func TestPushDerivedTopnFlash(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
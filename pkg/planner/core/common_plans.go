@@ -141,6 +141,15 @@ type ResumeDDLJobs struct {
 	JobIDs []int64
 }
 
+// AlterDDLJobs indicates a plan to alter the maintenance window of the DDL reorg jobs.
+type AlterDDLJobs struct {
+	baseSchemaProducer
+
+	JobIDs           []int64
+	Schedule         string
+	ScheduleDuration string
+}
+
 // ReloadExprPushdownBlacklist reloads the data from expr_pushdown_blacklist table.
 type ReloadExprPushdownBlacklist struct {
 	baseSchemaProducer
@@ -687,8 +696,10 @@ type DDL struct {
 type SelectInto struct {
 	baseSchemaProducer
 
-	TargetPlan Plan
-	IntoOpt    *ast.SelectIntoOption
+	TargetPlan  Plan
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
+	Options     []*LoadDataOpt
 	LineFieldsInfo
 }
 
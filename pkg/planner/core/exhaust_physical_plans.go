@@ -2707,7 +2707,8 @@ func (lt *LogicalTopN) getPhysTopN(prop *property.PhysicalProperty) []PhysicalPl
 	if !pushLimitOrTopNForcibly(lt) {
 		allTaskTypes = append(allTaskTypes, property.RootTaskType)
 	}
-	if lt.SCtx().GetSessionVars().IsMPPAllowed() {
+	// The TopN with partition by is finished in TiFlash for mpp task, so it can't provide any order.
+	if lt.SCtx().GetSessionVars().IsMPPAllowed() && (len(lt.PartitionBy) == 0 || prop.IsSortItemEmpty()) {
 		allTaskTypes = append(allTaskTypes, property.MppTaskType)
 	}
 	ret := make([]PhysicalPlan, 0, len(allTaskTypes))
@@ -2734,9 +2735,14 @@ func (lt *LogicalTopN) getPhysLimits(prop *property.PhysicalProperty) []Physical
 	if !pushLimitOrTopNForcibly(lt) {
 		allTaskTypes = append(allTaskTypes, property.RootTaskType)
 	}
+	expectedCnt := float64(lt.Count + lt.Offset)
+	if len(lt.PartitionBy) > 0 {
+		// All the rows are needed to find the first N rows of each partition.
+		expectedCnt = math.MaxFloat64
+	}
 	ret := make([]PhysicalPlan, 0, len(allTaskTypes))
 	for _, tp := range allTaskTypes {
-		resultProp := &property.PhysicalProperty{TaskTp: tp, ExpectedCnt: float64(lt.Count + lt.Offset), SortItems: p.SortItems, CTEProducerStatus: prop.CTEProducerStatus}
+		resultProp := &property.PhysicalProperty{TaskTp: tp, ExpectedCnt: expectedCnt, SortItems: p.SortItems, CTEProducerStatus: prop.CTEProducerStatus}
 		limit := PhysicalLimit{
 			Count:       lt.Count,
 			Offset:      lt.Offset,
@@ -2762,8 +2768,22 @@ func MatchItems(p *property.PhysicalProperty, items []*util.ByItems) bool {
 	return true
 }
 
+// partitionByItemsWithOrder returns the items that the output of a TopN or Limit with partition by is sorted by.
+// It is finished by PartitionTopNExec at root, which outputs rows sorted by the partition by items first.
+func partitionByItemsWithOrder(partitionBy []property.SortItem, byItems []*util.ByItems) []*util.ByItems {
+	items := make([]*util.ByItems, 0, len(partitionBy)+len(byItems))
+	for _, item := range partitionBy {
+		items = append(items, &util.ByItems{Expr: item.Col, Desc: item.Desc})
+	}
+	return append(items, byItems...)
+}
+
 func (lt *LogicalTopN) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
-	if MatchItems(prop, lt.ByItems) {
+	byItems := lt.ByItems
+	if len(lt.PartitionBy) > 0 {
+		byItems = partitionByItemsWithOrder(lt.PartitionBy, lt.ByItems)
+	}
+	if MatchItems(prop, byItems) {
 		return append(lt.getPhysTopN(prop), lt.getPhysLimits(prop)...), true, nil
 	}
 	return nil, true, nil
@@ -3053,8 +3073,18 @@ func (p *baseLogicalPlan) canPushToCopImpl(storeTp kv.StoreType, considerDual bo
 			}
 			ret = ret && c.canPushToCop(storeTp)
 		// These operators can be partially push down to TiFlash, so we don't raise warning for them.
-		case *LogicalLimit, *LogicalTopN:
-			return false
+		// The ones with partition by are derived from a row_number filter, and the window function above
+		// takes care of the final result, so they can be finished in TiFlash.
+		case *LogicalLimit:
+			if storeTp != kv.TiFlash || len(c.PartitionBy) == 0 {
+				return false
+			}
+			ret = ret && c.canPushToCop(storeTp)
+		case *LogicalTopN:
+			if storeTp != kv.TiFlash || len(c.PartitionBy) == 0 {
+				return false
+			}
+			ret = ret && c.canPushToCop(storeTp)
 		case *LogicalSequence:
 			return storeTp == kv.TiFlash
 		case *LogicalCTE:
@@ -3510,7 +3540,7 @@ func (p *LogicalSelection) canPushDown(storeTp kv.StoreType) bool {
 }
 
 func (p *LogicalLimit) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
-	if !prop.IsSortItemEmpty() {
+	if !prop.IsSortItemEmpty() && (len(p.PartitionBy) == 0 || !MatchItems(prop, partitionByItemsWithOrder(p.PartitionBy, nil))) {
 		return nil, true, nil
 	}
 
@@ -3518,12 +3548,17 @@ func (p *LogicalLimit) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]
 	if !pushLimitOrTopNForcibly(p) {
 		allTaskTypes = append(allTaskTypes, property.RootTaskType)
 	}
-	if p.canPushToCop(kv.TiFlash) && p.SCtx().GetSessionVars().IsMPPAllowed() {
+	if p.canPushToCop(kv.TiFlash) && p.SCtx().GetSessionVars().IsMPPAllowed() && prop.IsSortItemEmpty() {
 		allTaskTypes = append(allTaskTypes, property.MppTaskType)
 	}
+	expectedCnt := float64(p.Count + p.Offset)
+	if len(p.PartitionBy) > 0 {
+		// All the rows are needed to find the first N rows of each partition.
+		expectedCnt = math.MaxFloat64
+	}
 	ret := make([]PhysicalPlan, 0, len(allTaskTypes))
 	for _, tp := range allTaskTypes {
-		resultProp := &property.PhysicalProperty{TaskTp: tp, ExpectedCnt: float64(p.Count + p.Offset), CTEProducerStatus: prop.CTEProducerStatus}
+		resultProp := &property.PhysicalProperty{TaskTp: tp, ExpectedCnt: expectedCnt, CTEProducerStatus: prop.CTEProducerStatus}
 		limit := PhysicalLimit{
 			Offset:      p.Offset,
 			Count:       p.Count,
@@ -422,6 +422,16 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return fmt.Sprintf("json:%s, path:%s", p.Expr.ExplainInfo(p.SCtx()), p.Path.Path)
+}
+
+// ExplainNormalizedInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainNormalizedInfo() string {
+	return fmt.Sprintf("json:%s, path:%s", p.Expr.ExplainNormalizedInfo(), p.Path.Path)
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
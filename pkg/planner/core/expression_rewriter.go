@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/sem"
@@ -1296,6 +1298,8 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.toTable(v)
 	case *ast.ColumnName:
 		er.toColumn(v)
+	case *ast.MatchAgainst:
+		er.matchAgainstToExpression(v)
 	case *ast.UnaryOperationExpr:
 		er.unaryOpToExpression(v)
 	case *ast.BinaryOperationExpr:
@@ -2243,6 +2247,80 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 	er.err = ErrUnknownColumn.GenWithStackByArgs(v.String(), clauseMsg[planCtx.builder.curClause])
 }
 
+// matchAgainstToExpression rewrites `MATCH (col...) AGAINST (expr modifier)` to
+// `tidb_fulltext_match(mode, parser, expr, col...)`. The columns must be the columns of a FULLTEXT
+// index, whose parser tokenizes both the columns and the query.
+func (er *expressionRewriter) matchAgainstToExpression(v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("MATCH ... AGAINST WITH QUERY EXPANSION")
+		return
+	}
+	stkLen := er.ctxStackLen()
+	colLen := len(v.ColumnNames)
+	against := er.ctxStack[stkLen-1]
+	if _, ok := against.(*expression.Constant); !ok {
+		er.err = ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	cols := er.ctxStack[stkLen-colLen-1 : stkLen-1]
+	idxInfo := er.findFullTextIndex(cols, er.ctxNameStk[stkLen-colLen-1:stkLen-1])
+	if idxInfo == nil {
+		er.err = ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+		return
+	}
+
+	mode := fulltext.NaturalLanguageMode
+	if v.Modifier.IsBooleanMode() {
+		mode = fulltext.BooleanMode
+	}
+	args := make([]expression.Expression, 0, colLen+3)
+	args = append(args, expression.NewInt64Const(int64(mode)), expression.NewStrConst(idxInfo.FullText.Parser.L), against)
+	args = append(args, cols...)
+	function, err := er.newFunction(ast.TiDBFullTextMatch, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colLen + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFullTextIndex returns the public FULLTEXT index on exactly the columns, or nil if there is
+// no such index.
+func (er *expressionRewriter) findFullTextIndex(cols []expression.Expression, names []*types.FieldName) *model.IndexInfo {
+	if er.planCtx == nil {
+		return nil
+	}
+	for i, col := range cols {
+		if _, ok := col.(*expression.Column); !ok || names[i] == nil || names[i].OrigTblName.L == "" {
+			return nil
+		}
+		if names[i].DBName.L != names[0].DBName.L || names[i].OrigTblName.L != names[0].OrigTblName.L {
+			return nil
+		}
+	}
+	tbl, err := er.planCtx.builder.is.TableByName(names[0].DBName, names[0].OrigTblName)
+	if err != nil {
+		return nil
+	}
+	for _, idxInfo := range tbl.Meta().Indices {
+		if idxInfo.FullText == nil || idxInfo.State != model.StatePublic || len(idxInfo.FullText.Columns) != len(cols) {
+			continue
+		}
+		matched := true
+		for _, name := range names {
+			if !slices.ContainsFunc(idxInfo.FullText.Columns, func(col model.CIStr) bool { return col.L == name.OrigColName.L }) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idxInfo
+		}
+	}
+	return nil
+}
+
 func findFieldNameFromNaturalUsingJoin(p LogicalPlan, v *ast.ColumnName) (col *expression.Column, name *types.FieldName, err error) {
 	switch x := p.(type) {
 	case *LogicalLimit, *LogicalSelection, *LogicalTopN, *LogicalSort, *LogicalMaxOneRow:
@@ -157,6 +157,21 @@ func (p *LogicalTableDual) findBestTask(prop *property.PhysicalProperty, planCou
 	return &rootTask{p: dual, isEmpty: p.RowCount == 0}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		Expr: p.Expr,
+		Path: p.Path,
+		Name: p.Name,
+	}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	opt.appendCandidate(p, jt, prop)
+	return &rootTask{p: jt}, 1, nil
+}
+
 func (p *LogicalShow) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, _ *physicalOptimizeOp) (task, int64, error) {
 	if !prop.IsSortItemEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
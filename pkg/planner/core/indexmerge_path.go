@@ -34,6 +34,8 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+	"github.com/pingcap/tidb/pkg/util/geo"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"go.uber.org/zap"
@@ -90,6 +92,12 @@ func (ds *DataSource) generateIndexMergePath() error {
 	if err := ds.generateIndexMerge4MVIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	if err := ds.generateIndexMerge4FullTextIndex(regularPathCount, indexMergeConds); err != nil {
+		return err
+	}
+	if err := ds.generateIndexMerge4SpatialIndex(regularPathCount, indexMergeConds); err != nil {
+		return err
+	}
 	oldIndexMergeCount := len(ds.possibleAccessPaths)
 	if err := ds.generateIndexMerge4ComposedIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
@@ -1216,6 +1224,278 @@ func (ds *DataSource) generateIndexMerge4MVIndex(normalPathCnt int, filters []ex
 	return nil
 }
 
+// generateIndexMerge4FullTextIndex generates the index merge paths on the FULLTEXT indexes for the
+// `MATCH ... AGAINST` filters. Each term of the query is looked up in the multi-valued index on the
+// tokens, and the handles of the terms are unioned or intersected. All the filters are kept as the
+// table filters because the index only finds the rows which may match the query.
+func (ds *DataSource) generateIndexMerge4FullTextIndex(normalPathCnt int, filters []expression.Expression) error {
+	for idx := 0; idx < normalPathCnt; idx++ {
+		path := ds.possibleAccessPaths[idx]
+		if !isMVIndexPath(path) || path.Index.FullText == nil || !ds.isInIndexMergeHints(path.Index.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareCols4MVIndex(ds.table.Meta(), path.Index, ds.TblCols)
+		if !ok || len(idxCols) != 1 {
+			continue
+		}
+		for _, filter := range filters {
+			query, ok := ds.extractFullTextQuery(filter, path.Index)
+			if !ok {
+				continue
+			}
+			terms, isIntersection, ok := query.IndexTerms()
+			if !ok {
+				continue
+			}
+			partialPaths, ok, err := buildPartialPaths4FullTextIndex(ds.SCtx(), terms, idxCols[0], path.Index, ds.tableStats.HistColl)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, ds.buildPartialPathUp4MVIndex(
+				partialPaths,
+				isIntersection,
+				filters,
+				ds.tableStats.HistColl,
+			))
+			break
+		}
+	}
+	return nil
+}
+
+// extractFullTextQuery returns the query of the filter if the rows satisfying the filter must match
+// the query on the columns of the FULLTEXT index. The filter is `MATCH ... AGAINST` or
+// `MATCH ... AGAINST > c` where c is a non-negative constant.
+func (ds *DataSource) extractFullTextQuery(filter expression.Expression, idxInfo *model.IndexInfo) (*fulltext.Query, bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	if sf.FuncName.L == ast.GT {
+		c, ok := sf.GetArgs()[1].(*expression.Constant)
+		if !ok || c.ParamMarker != nil || c.DeferredExpr != nil {
+			return nil, false
+		}
+		val, isNull, err := c.EvalReal(ds.SCtx(), chunk.Row{})
+		if err != nil || isNull || val < 0 {
+			return nil, false
+		}
+		if sf, ok = sf.GetArgs()[0].(*expression.ScalarFunction); !ok {
+			return nil, false
+		}
+	}
+	args := sf.GetArgs()
+	if sf.FuncName.L != ast.TiDBFullTextMatch || len(args)-3 != len(idxInfo.FullText.Columns) {
+		return nil, false
+	}
+	for _, arg := range args[3:] {
+		col, ok := arg.(*expression.Column)
+		if !ok {
+			return nil, false
+		}
+		colInfo := model.FindColumnInfoByID(ds.tableInfo.Columns, col.ID)
+		if colInfo == nil || !slices.ContainsFunc(idxInfo.FullText.Columns, func(name model.CIStr) bool {
+			return name.L == colInfo.Name.L
+		}) {
+			return nil, false
+		}
+	}
+	// The query is decided by the plan, so it can't be a parameter of a cached plan.
+	vals := make([]types.Datum, 3)
+	for i := range vals {
+		c, ok := args[i].(*expression.Constant)
+		if !ok || c.ParamMarker != nil || c.DeferredExpr != nil {
+			return nil, false
+		}
+		vals[i] = c.Value
+	}
+	parserName := vals[1].GetString()
+	p, ok := fulltext.GetParser(parserName)
+	if !ok || parserName != idxInfo.FullText.Parser.L {
+		return nil, false
+	}
+	return fulltext.ParseQuery(p, vals[2].GetString(), fulltext.Mode(vals[0].GetInt64())), true
+}
+
+// buildPartialPaths4FullTextIndex builds a partial path on the FULLTEXT index for each term. The
+// term is looked up by `token = term`, or by `term <= token < PrefixNext(term)` for a prefix term.
+func buildPartialPaths4FullTextIndex(
+	sctx sessionctx.Context,
+	terms []fulltext.IndexTerm,
+	virCol *expression.Column,
+	idxInfo *model.IndexInfo,
+	histColl *statistics.HistColl,
+) ([]*util.AccessPath, bool, error) {
+	partialPaths := make([]*util.AccessPath, 0, len(terms))
+	for _, term := range terms {
+		var accessFilters []expression.Expression
+		if term.Prefix {
+			ge, err := expression.NewFunction(sctx, ast.GE, types.NewFieldType(mysql.TypeTiny), virCol, newFullTextTokenConst(term.Token))
+			if err != nil {
+				return nil, false, err
+			}
+			upper := string(kv.Key(term.Token).PrefixNext())
+			lt, err := expression.NewFunction(sctx, ast.LT, types.NewFieldType(mysql.TypeTiny), virCol, newFullTextTokenConst(upper))
+			if err != nil {
+				return nil, false, err
+			}
+			accessFilters = append(accessFilters, ge, lt)
+		} else {
+			eq, err := expression.NewFunction(sctx, ast.EQ, types.NewFieldType(mysql.TypeTiny), virCol, newFullTextTokenConst(term.Token))
+			if err != nil {
+				return nil, false, err
+			}
+			accessFilters = append(accessFilters, eq)
+		}
+		partialPath, ok, err := buildPartialPath4MVIndex(sctx, accessFilters, []*expression.Column{virCol}, idxInfo, histColl)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		partialPaths = append(partialPaths, partialPath)
+	}
+	return partialPaths, true, nil
+}
+
+// newFullTextTokenConst returns the binary string constant of the token, which is compared with the
+// tokens in the FULLTEXT index.
+func newFullTextTokenConst(token string) *expression.Constant {
+	tp := types.NewFieldType(mysql.TypeVarString)
+	tp.SetFlen(len(token))
+	tp.SetCharset(charset.CharsetBin)
+	tp.SetCollate(charset.CollationBin)
+	tp.AddFlag(mysql.BinaryFlag)
+	return &expression.Constant{Value: types.NewStringDatum(token), RetType: tp}
+}
+
+// generateIndexMerge4SpatialIndex generates the index merge paths on the SPATIAL indexes for the
+// filters testing the spatial relation between the indexed column and a constant geometry. The
+// rows whose MBRs intersect the MBR of the constant are looked up in the multi-valued index on the
+// cells by a single partial path. All the filters are kept as the table filters because the index
+// only finds the rows which may satisfy the filter.
+func (ds *DataSource) generateIndexMerge4SpatialIndex(normalPathCnt int, filters []expression.Expression) error {
+	for idx := 0; idx < normalPathCnt; idx++ {
+		path := ds.possibleAccessPaths[idx]
+		if !isMVIndexPath(path) || path.Index.Spatial == nil || !ds.isInIndexMergeHints(path.Index.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareCols4MVIndex(ds.table.Meta(), path.Index, ds.TblCols)
+		if !ok || len(idxCols) != 1 {
+			continue
+		}
+		for _, filter := range filters {
+			rect, ok := ds.extractSpatialQueryRect(filter, path.Index)
+			if !ok {
+				continue
+			}
+			partialPath, ok, err := buildPartialPath4SpatialIndex(ds.SCtx(), rect, idxCols[0], path.Index, ds.tableStats.HistColl)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, ds.buildPartialPathUp4MVIndex(
+				[]*util.AccessPath{partialPath},
+				false,
+				filters,
+				ds.tableStats.HistColl,
+			))
+			break
+		}
+	}
+	return nil
+}
+
+// extractSpatialQueryRect returns the MBR of the constant geometry if the rows satisfying the
+// filter must have the MBRs of the column of the SPATIAL index intersecting it. The filter is
+// ST_Contains, ST_Within, ST_Intersects or their MBR versions on the column and a constant.
+func (ds *DataSource) extractSpatialQueryRect(filter expression.Expression, idxInfo *model.IndexInfo) (geo.Rect, bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return geo.Rect{}, false
+	}
+	switch sf.FuncName.L {
+	case ast.STContains, ast.STWithin, ast.STIntersects, ast.MBRContains, ast.MBRWithin, ast.MBRIntersects:
+	default:
+		return geo.Rect{}, false
+	}
+	args := sf.GetArgs()
+	col, ok := args[0].(*expression.Column)
+	arg := args[1]
+	if !ok {
+		col, ok = args[1].(*expression.Column)
+		arg = args[0]
+	}
+	if !ok {
+		return geo.Rect{}, false
+	}
+	colInfo := model.FindColumnInfoByID(ds.tableInfo.Columns, col.ID)
+	if colInfo == nil || colInfo.Name.L != idxInfo.Spatial.Column.L {
+		return geo.Rect{}, false
+	}
+	// The ranges are decided by the plan, so the geometry can't be a parameter of a cached plan.
+	c, ok := arg.(*expression.Constant)
+	if !ok || c.ParamMarker != nil || c.DeferredExpr != nil || c.Value.IsNull() {
+		return geo.Rect{}, false
+	}
+	g, err := geo.Decode(c.Value.GetBytes())
+	if err != nil {
+		return geo.Rect{}, false
+	}
+	// No geometry satisfies the filter with an empty geometry, it's left to the table filter.
+	return g.Bound()
+}
+
+// buildPartialPath4SpatialIndex builds the partial path on the SPATIAL index to look up the cells
+// returned by geo.QueryCells, which are `min <= cell <= max` for each range and `cell in (...)` for
+// the ancestors.
+func buildPartialPath4SpatialIndex(
+	sctx sessionctx.Context,
+	rect geo.Rect,
+	virCol *expression.Column,
+	idxInfo *model.IndexInfo,
+	histColl *statistics.HistColl,
+) (*util.AccessPath, bool, error) {
+	ranges, ancestors := geo.QueryCells(rect)
+	items := make([]expression.Expression, 0, len(ranges)+1)
+	for _, r := range ranges {
+		ge, err := expression.NewFunction(sctx, ast.GE, types.NewFieldType(mysql.TypeTiny), virCol, newSpatialCellConst(r.Min))
+		if err != nil {
+			return nil, false, err
+		}
+		le, err := expression.NewFunction(sctx, ast.LE, types.NewFieldType(mysql.TypeTiny), virCol, newSpatialCellConst(r.Max))
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, expression.ComposeCNFCondition(sctx, ge, le))
+	}
+	if len(ancestors) > 0 {
+		args := make([]expression.Expression, 0, len(ancestors)+1)
+		args = append(args, virCol)
+		for _, cell := range ancestors {
+			args = append(args, newSpatialCellConst(cell))
+		}
+		in, err := expression.NewFunction(sctx, ast.In, types.NewFieldType(mysql.TypeTiny), args...)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, in)
+	}
+	accessFilters := []expression.Expression{expression.ComposeDNFCondition(sctx, items...)}
+	return buildPartialPath4MVIndex(sctx, accessFilters, []*expression.Column{virCol}, idxInfo, histColl)
+}
+
+// newSpatialCellConst returns the unsigned integer constant of the cell, which is compared with the
+// cells in the SPATIAL index.
+func newSpatialCellConst(cell geo.CellID) *expression.Constant {
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	tp.AddFlag(mysql.UnsignedFlag)
+	return &expression.Constant{Value: types.NewUintDatum(uint64(cell)), RetType: tp}
+}
+
 // buildPartialPathUp4MVIndex builds these partial paths up to a complete index merge path.
 func (*DataSource) buildPartialPathUp4MVIndex(
 	partialPaths []*util.AccessPath,
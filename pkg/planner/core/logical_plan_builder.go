@@ -440,6 +440,10 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			// `JSON_TABLE` is not a select block either.
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -941,7 +945,18 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// A lateral table source can reference the columns of the tables preceding
+	// it in the FROM clause, so the left side is visible to it as an outer schema.
+	lateral := isLateralTableSource(joinNode.Right)
+	if lateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if lateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -978,6 +993,21 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.JoinType = InnerJoin
 	}
 
+	// If the lateral table source really references the left side, the join is
+	// evaluated as an Apply, which may be decorrelated later.
+	var resultPlan LogicalPlan = joinPlan
+	if lateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0 {
+		if joinNode.Tp == ast.RightJoin {
+			return nil, ErrTableFunctionForbiddenJoinType.GenWithStackByArgs(joinNode.Right.(*ast.TableSource).AsName.O)
+		}
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		setIsInApplyForCTE(rightPlan, joinPlan.Schema())
+		ap := &LogicalApply{LogicalJoin: *joinPlan}
+		ap.SetTP(plancodec.TypeApply)
+		ap.self = ap
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	}
+
 	// Merge sub-plan's fullSchema into this join plan.
 	// Please read the comment of LogicalJoin.fullSchema for the details.
 	var (
@@ -1055,7 +1085,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1065,7 +1095,116 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// isLateralTableSource checks whether the table source is able to reference
+// the columns of the tables preceding it in the FROM clause.
+func isLateralTableSource(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
+}
+
+// buildJSONTable builds the JSON_TABLE table function. The JSON document is
+// rewritten against an empty schema, so all the column references in it are
+// resolved as correlated columns of the outer schemas.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	expr, _, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	p := LogicalJSONTable{
+		Expr: expression.WrapWithCastAsJSON(b.ctx, expr),
+		Name: asName,
+	}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Path, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, tblName model.CIStr,
+	schema *expression.Schema, names *types.NameSlice) (*JSONTablePath, error) {
+	if _, err := types.ParseJSONPathExpr(path); err != nil {
+		return nil, err
+	}
+	jtPath := &JSONTablePath{Path: path}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, tblName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			jtPath.Nested = append(jtPath.Nested, nested)
+			continue
+		}
+		var tp *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			tp = types.NewFieldType(mysql.TypeLong)
+			tp.AddFlag(mysql.UnsignedFlag)
+		} else {
+			if _, err := types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			for _, resp := range []*ast.JSONTableOnResponse{col.OnEmpty, col.OnError} {
+				if resp != nil && resp.Tp == ast.JSONTableOnResponseDefault {
+					if _, err := types.ParseBinaryJSONFromString(resp.Default); err != nil {
+						return nil, err
+					}
+				}
+			}
+			tp = jsonTableColumnFieldType(col.FieldType)
+		}
+		schema.Append(&expression.Column{
+			RetType:  tp,
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     tblName,
+			OrigTblName: tblName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+		jtPath.Columns = append(jtPath.Columns, &JSONTableColumn{JSONTableColumn: col, Offset: schema.Len() - 1})
+	}
+	return jtPath, nil
+}
+
+// jsonTableColumnFieldType fills the unspecified length, decimal, charset
+// and collation of the JSON_TABLE column type.
+func jsonTableColumnFieldType(colTp *types.FieldType) *types.FieldType {
+	tp := colTp.Clone()
+	flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(flen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(decimal)
+	}
+	if tp.GetCharset() == "" {
+		if tp.EvalType() == types.ETString {
+			tp.SetCharset(charset.CharsetUTF8MB4)
+			tp.SetCollate(charset.CollationUTF8MB4)
+		} else {
+			types.SetBinChsClnFlag(tp)
+		}
+	}
+	return tp
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -2054,6 +2193,74 @@ func (b *PlanBuilder) buildSemiJoinForSetOperator(
 	if err != nil {
 		return nil, err
 	}
+	return b.buildJoinForSetOperator(leftPlan, rightPlan, joinType)
+}
+
+// buildSemiJoinForSetOperatorAll builds the plan for 'intersect all' and 'except all'. Both sides are numbered by
+// `row_number() over (partition by all columns)` first, so that the k-th duplicate of a row on the left side can
+// only be matched by the k-th duplicate of the same row on the right side. Then:
+//   - 'intersect all' keeps min(m, n) duplicates of a row by a semi join on all columns plus the row number;
+//   - 'except all' keeps max(m-n, 0) duplicates of a row by an anti semi join on all columns plus the row number.
+//
+// The extra row number column is removed by a projection at last.
+func (b *PlanBuilder) buildSemiJoinForSetOperatorAll(
+	leftOriginPlan LogicalPlan,
+	rightOriginPlan LogicalPlan,
+	joinType JoinType) (LogicalPlan, error) {
+	leftPlan, err := b.buildRowNumberForSetOperator(leftOriginPlan)
+	if err != nil {
+		return nil, err
+	}
+	rightPlan, err := b.buildRowNumberForSetOperator(rightOriginPlan)
+	if err != nil {
+		return nil, err
+	}
+	joinPlan, err := b.buildJoinForSetOperator(leftPlan, rightPlan, joinType)
+	if err != nil {
+		return nil, err
+	}
+	colLen := leftOriginPlan.Schema().Len()
+	proj := LogicalProjection{Exprs: expression.Column2Exprs(joinPlan.Schema().Columns[:colLen])}.Init(b.ctx, b.getSelectOffset())
+	proj.SetChildren(joinPlan)
+	schema := expression.NewSchema(joinPlan.Schema().Clone().Columns[:colLen]...)
+	for _, col := range schema.Columns {
+		col.UniqueID = b.ctx.GetSessionVars().AllocPlanColumnID()
+	}
+	proj.names = joinPlan.OutputNames()[:colLen]
+	proj.SetSchema(schema)
+	return proj, nil
+}
+
+// buildRowNumberForSetOperator appends a `row_number() over (partition by all columns)` column to the plan,
+// which is used to distinguish the duplicated rows for 'intersect all' and 'except all'.
+func (b *PlanBuilder) buildRowNumberForSetOperator(p LogicalPlan) (LogicalPlan, error) {
+	desc, err := aggregation.NewWindowFuncDesc(b.ctx, ast.WindowFuncRowNumber, []expression.Expression{}, false)
+	if err != nil {
+		return nil, err
+	}
+	partitionBy := make([]property.SortItem, 0, p.Schema().Len())
+	for _, col := range p.Schema().Columns {
+		partitionBy = append(partitionBy, property.SortItem{Col: col})
+	}
+	window := LogicalWindow{
+		WindowFuncDescs: []*aggregation.WindowFuncDesc{desc},
+		PartitionBy:     partitionBy,
+	}.Init(b.ctx, b.getSelectOffset())
+	schema := p.Schema().Clone()
+	schema.Append(&expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  desc.RetTp,
+	})
+	window.names = make([]*types.FieldName, p.Schema().Len(), p.Schema().Len()+1)
+	copy(window.names, p.OutputNames())
+	window.names = append(window.names, types.EmptyName)
+	window.SetChildren(p)
+	window.SetSchema(schema)
+	return window, nil
+}
+
+// buildJoinForSetOperator builds a semi join or an anti semi join on all the columns of the two plans.
+func (b *PlanBuilder) buildJoinForSetOperator(leftPlan LogicalPlan, rightPlan LogicalPlan, joinType JoinType) (LogicalPlan, error) {
 	joinPlan := LogicalJoin{JoinType: joinType}.Init(b.ctx, b.getSelectOffset())
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(leftPlan.Schema())
@@ -2100,18 +2307,13 @@ func (b *PlanBuilder) buildIntersect(ctx context.Context, selects []ast.Node) (L
 	columnNums := leftPlan.Schema().Len()
 	for i := 1; i < len(selects); i++ {
 		var rightPlan LogicalPlan
+		var setOprType ast.SetOprType
 		switch x := selects[i].(type) {
 		case *ast.SelectStmt:
-			if *x.AfterSetOperator == ast.IntersectAll {
-				// TODO: support intersect all
-				return nil, nil, errors.Errorf("TiDB do not support intersect all")
-			}
+			setOprType = *x.AfterSetOperator
 			rightPlan, err = b.buildSelect(ctx, x)
 		case *ast.SetOprSelectList:
-			if *x.AfterSetOperator == ast.IntersectAll {
-				// TODO: support intersect all
-				return nil, nil, errors.Errorf("TiDB do not support intersect all")
-			}
+			setOprType = *x.AfterSetOperator
 			rightPlan, err = b.buildSetOpr(ctx, &ast.SetOprStmt{SelectList: x, With: x.With, Limit: x.Limit, OrderBy: x.OrderBy})
 		}
 		if err != nil {
@@ -2120,7 +2322,11 @@ func (b *PlanBuilder) buildIntersect(ctx context.Context, selects []ast.Node) (L
 		if rightPlan.Schema().Len() != columnNums {
 			return nil, nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
 		}
-		leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, SemiJoin)
+		if setOprType == ast.IntersectAll {
+			leftPlan, err = b.buildSemiJoinForSetOperatorAll(leftPlan, rightPlan, SemiJoin)
+		} else {
+			leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, SemiJoin)
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2139,20 +2345,21 @@ func (b *PlanBuilder) buildExcept(ctx context.Context, selects []LogicalPlan, af
 		if rightPlan.Schema().Len() != columnNums {
 			return nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
 		}
-		if *afterSetOpts[i] == ast.Except {
+		if *afterSetOpts[i] == ast.Except || *afterSetOpts[i] == ast.ExceptAll {
 			leftPlan, err := b.buildUnion(ctx, unionPlans, tmpAfterSetOpts)
 			if err != nil {
 				return nil, err
 			}
-			leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, AntiSemiJoin)
+			if *afterSetOpts[i] == ast.ExceptAll {
+				leftPlan, err = b.buildSemiJoinForSetOperatorAll(leftPlan, rightPlan, AntiSemiJoin)
+			} else {
+				leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, AntiSemiJoin)
+			}
 			if err != nil {
 				return nil, err
 			}
 			unionPlans = []LogicalPlan{leftPlan}
 			tmpAfterSetOpts = []*ast.SetOprType{nil}
-		} else if *afterSetOpts[i] == ast.ExceptAll {
-			// TODO: support except all.
-			return nil, errors.Errorf("TiDB do not support except all")
 		} else {
 			unionPlans = append(unionPlans, rightPlan)
 			tmpAfterSetOpts = append(tmpAfterSetOpts, afterSetOpts[i])
@@ -4325,7 +4532,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	}
 	l := sel.LockInfo
 	if l != nil && l.LockType != ast.SelectLockNone {
-		if l.LockType == ast.SelectLockForShare && noopFuncsMode != variable.OnInt {
+		if (l.LockType == ast.SelectLockForShare || l.LockType == ast.SelectLockForShareSkipLocked) && noopFuncsMode != variable.OnInt {
 			err = expression.ErrFunctionsNoopImpl.GenWithStackByArgs("LOCK IN SHARE MODE")
 			if noopFuncsMode == variable.OffInt {
 				return nil, err
@@ -6087,6 +6294,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
 					return nil, nil, false, ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
+				if err := b.checkWriteMaterializedView(tl.TableInfo, "Update"); err != nil {
+					return nil, nil, false, err
+				}
 				foundListItem = true
 			}
 		}
@@ -6406,6 +6616,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if err := b.checkWriteMaterializedView(tn.TableInfo, "Delete"); err != nil {
+				return nil, err
+			}
 			if sessionVars.User != nil {
 				authErr = ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6425,6 +6638,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if err := b.checkWriteMaterializedView(v.TableInfo, "Delete"); err != nil {
+				return nil, err
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
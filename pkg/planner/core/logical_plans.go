@@ -1374,6 +1374,40 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// LogicalJSONTable represents the JSON_TABLE table function.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// Expr is the JSON document. It contains correlated columns when
+	// JSON_TABLE references the columns of the preceding tables.
+	Expr expression.Expression
+	// Path is the row path and the column definitions.
+	Path *JSONTablePath
+	// Name is the alias of JSON_TABLE.
+	Name model.CIStr
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// JSONTablePath is the row path or a NESTED PATH of JSON_TABLE together with
+// the columns defined under it.
+type JSONTablePath struct {
+	Path    string
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// JSONTableColumn is a FOR ORDINALITY, PATH or EXISTS PATH column of JSON_TABLE.
+type JSONTableColumn struct {
+	*ast.JSONTableColumn
+
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset int
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -1639,10 +1673,28 @@ func detachCondAndBuildRangeForPath(
 			path.ConstCols[i] = res.ColumnValues[i] != nil
 		}
 	}
+	if path.Index.Tp == model.IndexTypeHypo && !histColl.Pseudo {
+		path.CountAfterAccess, err = getHypoIndexRowCount(sctx, histColl, path.AccessConds)
+		return err
+	}
 	path.CountAfterAccess, err = cardinality.GetRowCountByIndexRanges(sctx, histColl, path.Index.ID, path.Ranges)
 	return err
 }
 
+// getHypoIndexRowCount estimates the row count of the access conditions of a hypo index.
+// Hypo indexes are metadata only and have no statistics of their own, so the estimation
+// is derived from the column statistics of the table.
+func getHypoIndexRowCount(sctx sessionctx.Context, histColl *statistics.HistColl, accessConds []expression.Expression) (float64, error) {
+	if len(accessConds) == 0 {
+		return float64(histColl.RealtimeCount), nil
+	}
+	selectivity, _, err := cardinality.Selectivity(sctx, histColl, accessConds, nil)
+	if err != nil {
+		return 0, err
+	}
+	return selectivity * float64(histColl.RealtimeCount), nil
+}
+
 func (ds *DataSource) deriveCommonHandleTablePathStats(path *util.AccessPath, conds []expression.Expression, isIm bool) error {
 	path.CountAfterAccess = float64(ds.statisticTable.RealtimeCount)
 	path.Ranges = ranger.FullNotNullRange()
@@ -1295,12 +1295,15 @@ func eliminateUnionScanAndLock(sctx sessionctx.Context, p PhysicalPlan) Physical
 		if !lock {
 			return p
 		}
+		skipLocked := isSkipLockedSelectLock(physLock.Lock)
 		if pointGet != nil {
 			pointGet.Lock = lock
 			pointGet.LockWaitTime = waitTime
+			pointGet.LockSkipLocked = skipLocked
 		} else {
 			batchPointGet.Lock = lock
 			batchPointGet.LockWaitTime = waitTime
+			batchPointGet.LockSkipLocked = skipLocked
 		}
 	}
 	return transformPhysicalPlan(p, func(p PhysicalPlan) PhysicalPlan {
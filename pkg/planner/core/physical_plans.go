@@ -151,6 +151,14 @@ type PhysPlanPartInfo struct {
 
 const emptyPartitionInfoSize = int64(unsafe.Sizeof(PhysPlanPartInfo{}))
 
+// Clone clones the PhysPlanPartInfo.
+func (pi *PhysPlanPartInfo) Clone() PhysPlanPartInfo {
+	cloned := *pi
+	cloned.PruningConds = util.CloneExprs(pi.PruningConds)
+	cloned.Columns = util.CloneCols(pi.Columns)
+	return cloned
+}
+
 // MemoryUsage return the memory usage of PhysPlanPartInfo
 func (pi *PhysPlanPartInfo) MemoryUsage() (sum int64) {
 	if pi == nil {
@@ -259,19 +267,18 @@ func (sg *TiKVSingleGather) GetPhysicalIndexReader(schema *expression.Schema, st
 // Clone implements PhysicalPlan interface.
 func (p *PhysicalTableReader) Clone() (PhysicalPlan, error) {
 	cloned := new(PhysicalTableReader)
+	*cloned = *p
 	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
 	if err != nil {
 		return nil, err
 	}
 	cloned.physicalSchemaProducer = *base
-	cloned.StoreType = p.StoreType
-	cloned.ReadReqType = p.ReadReqType
-	cloned.IsCommonHandle = p.IsCommonHandle
 	if cloned.tablePlan, err = p.tablePlan.Clone(); err != nil {
 		return nil, err
 	}
 	// TablePlans are actually the flattened plans in tablePlan, so can't copy them, just need to extract from tablePlan
 	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
+	cloned.PlanPartInfo = p.PlanPartInfo.Clone()
 	return cloned, nil
 }
 
@@ -321,6 +328,7 @@ type PhysicalIndexReader struct {
 // Clone implements PhysicalPlan interface.
 func (p *PhysicalIndexReader) Clone() (PhysicalPlan, error) {
 	cloned := new(PhysicalIndexReader)
+	*cloned = *p
 	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
 	if err != nil {
 		return nil, err
@@ -329,10 +337,10 @@ func (p *PhysicalIndexReader) Clone() (PhysicalPlan, error) {
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
+	// IndexPlans are the flattened plans in indexPlan, extract them from the cloned indexPlan.
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
 	cloned.OutputColumns = util.CloneCols(p.OutputColumns)
+	cloned.PlanPartInfo = p.PlanPartInfo.Clone()
 	return cloned, err
 }
 
@@ -454,29 +462,29 @@ type PhysicalIndexLookUpReader struct {
 // Clone implements PhysicalPlan interface.
 func (p *PhysicalIndexLookUpReader) Clone() (PhysicalPlan, error) {
 	cloned := new(PhysicalIndexLookUpReader)
+	*cloned = *p
 	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
 	if err != nil {
 		return nil, err
 	}
 	cloned.physicalSchemaProducer = *base
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
-	if cloned.TablePlans, err = clonePhysicalPlan(p.TablePlans); err != nil {
-		return nil, err
-	}
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
 	if cloned.tablePlan, err = p.tablePlan.Clone(); err != nil {
 		return nil, err
 	}
+	// IndexPlans and TablePlans are the flattened plans in indexPlan and tablePlan, extract them from the cloned ones.
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
 	if p.ExtraHandleCol != nil {
 		cloned.ExtraHandleCol = p.ExtraHandleCol.Clone().(*expression.Column)
 	}
 	if p.PushedLimit != nil {
 		cloned.PushedLimit = p.PushedLimit.Clone()
 	}
+	cloned.CommonHandleCols = util.CloneCols(p.CommonHandleCols)
+	cloned.PlanPartInfo = p.PlanPartInfo.Clone()
 	return cloned, nil
 }
 
@@ -2159,6 +2167,7 @@ func (p *PhysicalSelection) Clone() (PhysicalPlan, error) {
 	}
 	cloned.basePhysicalPlan = *base
 	cloned.Conditions = util.CloneExprs(p.Conditions)
+	cloned.fromDataSource = p.fromDataSource
 	return cloned, nil
 }
 
@@ -2243,6 +2252,44 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr expression.Expression
+	Path *JSONTablePath
+	Name model.CIStr
+}
+
+// Clone implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone() (PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.Expr = p.Expr.Clone()
+	cloned.Path = p.Path
+	cloned.Name = p.Name
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + p.Expr.MemoryUsage() + size.SizeOfPointer + p.Name.MemoryUsage()
+	return
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
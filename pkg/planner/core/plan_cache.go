@@ -265,8 +265,16 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	stmtCtx := sessVars.StmtCtx
 
 	candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts)
+	fromInstanceCache := false
 	if !exist {
-		return nil, nil, false, nil
+		// try the plans shared by other sessions
+		if pc := enabledInstancePlanCache(sctx); pc != nil {
+			candidate, exist = pc.Get(sctx, newInstancePlanCacheKey(sessVars, cacheKey), matchOpts)
+			fromInstanceCache = exist
+		}
+		if !exist {
+			return nil, nil, false, nil
+		}
 	}
 	cachedVal := candidate.(*PlanCacheValue)
 	if err := CheckPreparedPriv(sctx, stmt, is); err != nil {
@@ -276,7 +284,10 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
 			// TODO we can inject UnionScan into cached plan to avoid invalidating it, though
 			// rebuilding the filters in UnionScan is pretty trivial.
-			sctx.GetSessionPlanCache().Delete(cacheKey)
+			// The shared plan is still valid for other sessions, so just skip it.
+			if !fromInstanceCache {
+				sctx.GetSessionPlanCache().Delete(cacheKey)
+			}
 			return nil, nil, false, nil
 		}
 	}
@@ -342,7 +353,11 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
 		stmtCtx.SetPlan(p)
 		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
-		sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		// share the plan with other sessions if possible, the shared plan is cloned for each execution
+		// instead of being kept in every session
+		if pc := enabledInstancePlanCache(sctx); pc == nil || !pc.Put(sctx, newInstancePlanCacheKey(sessVars, cacheKey), cached, matchOpts) {
+			sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		}
 	}
 	sessVars.FoundInPlanCache = false
 	return p, names, err
@@ -0,0 +1,384 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	core_metrics "github.com/pingcap/tidb/pkg/planner/core/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/kvcache"
+	utilpc "github.com/pingcap/tidb/pkg/util/plancache"
+	"github.com/pingcap/tidb/pkg/util/syncutil"
+)
+
+func init() {
+	domain.NewInstancePlanCache = func() sessionctx.InstancePlanCache {
+		return NewInstancePlanCache()
+	}
+}
+
+// instancePlanCacheEntry is the entry of the instance plan cache. The cached plan isn't bound to any session,
+// it's cloned and bound to the session when it's got from the cache.
+type instancePlanCacheEntry struct {
+	key        *planCacheKey
+	value      *PlanCacheValue
+	sqlDigest  string
+	planDigest string
+	memory     int64
+	createTime time.Time
+
+	// hits and lastAccessTime are protected by the lock of the cache.
+	hits           int64
+	lastAccessTime time.Time
+}
+
+// InstancePlanCacheEntry is the information of a plan cached in the instance plan cache.
+type InstancePlanCacheEntry struct {
+	SQLDigest      string
+	SQLText        string
+	DB             string
+	SchemaVersion  int64
+	PlanDigest     string
+	Binding        string
+	MemoryUsage    int64
+	Hits           int64
+	CreateTime     time.Time
+	LastAccessTime time.Time
+}
+
+// InstancePlanCache is the least recently used plan cache shared by all sessions of the instance.
+// The memory usage of the cache is limited by tidb_instance_plan_cache_max_size.
+type InstancePlanCache struct {
+	lock syncutil.Mutex
+	// buckets group the entries with the same key, the entries in a bucket differ in the match options.
+	buckets     map[string]map[*list.Element]struct{}
+	lruList     *list.List
+	memoryUsage int64
+	evictions   int64
+}
+
+// NewInstancePlanCache creates an InstancePlanCache.
+func NewInstancePlanCache() *InstancePlanCache {
+	return &InstancePlanCache{
+		buckets: make(map[string]map[*list.Element]struct{}),
+		lruList: list.New(),
+	}
+}
+
+// GetInstancePlanCache returns the instance plan cache of the domain.
+func GetInstancePlanCache(sctx sessionctx.Context) *InstancePlanCache {
+	do := domain.GetDomain(sctx)
+	if do == nil {
+		return nil
+	}
+	pc, _ := do.InstancePlanCache().(*InstancePlanCache)
+	return pc
+}
+
+// enabledInstancePlanCache returns the instance plan cache if it's enabled by tidb_enable_instance_plan_cache.
+func enabledInstancePlanCache(sctx sessionctx.Context) *InstancePlanCache {
+	if !variable.EnableInstancePlanCache.Load() {
+		return nil
+	}
+	return GetInstancePlanCache(sctx)
+}
+
+// Get gets a plan from the cache, the plan is cloned and bound to the session.
+func (c *InstancePlanCache) Get(sctx sessionctx.Context, key kvcache.Key, opts *utilpc.PlanCacheMatchOpts) (kvcache.Value, bool) {
+	c.lock.Lock()
+	var cached *PlanCacheValue
+	if bucket, ok := c.buckets[strHashKey(key, false)]; ok {
+		for element := range bucket {
+			entry := element.Value.(*instancePlanCacheEntry)
+			if matchCachedPlan(sctx.GetSessionVars(), entry.value, opts) {
+				entry.hits++
+				entry.lastAccessTime = time.Now()
+				c.lruList.MoveToFront(element)
+				cached = entry.value
+				break
+			}
+		}
+	}
+	c.lock.Unlock()
+	if cached == nil {
+		return nil, false
+	}
+
+	// The cached plan is never modified, so it can be cloned without the lock.
+	plan, err := cached.Plan.(PhysicalPlan).Clone()
+	if err != nil {
+		return nil, false
+	}
+	bindPlan2Session(sctx, plan)
+	return &PlanCacheValue{
+		Plan:              plan,
+		OutPutNames:       cached.OutPutNames,
+		TblInfo2UnionScan: cached.TblInfo2UnionScan,
+		memoryUsage:       cached.memoryUsage,
+		matchOpts:         cached.matchOpts,
+		stmtHints:         cached.stmtHints,
+	}, true
+}
+
+// Put puts a plan into the cache, it returns false if the plan can't be shared between sessions or
+// it exceeds the memory limit of the cache. The plan is cloned so the session can keep using it.
+func (c *InstancePlanCache) Put(sctx sessionctx.Context, key kvcache.Key, value kvcache.Value, opts *utilpc.PlanCacheMatchOpts) bool {
+	val := value.(*PlanCacheValue)
+	plan, ok := val.Plan.(PhysicalPlan)
+	if !ok || opts.HasSubQuery || !isPlanSharable(plan) {
+		return false
+	}
+	cloned, err := plan.Clone()
+	if err != nil {
+		return false
+	}
+	bindPlan2Session(nil, cloned)
+	pcKey := key.(*planCacheKey)
+	_, sqlDigest := parser.NormalizeDigest(pcKey.stmtText)
+	_, planDigest := sctx.GetSessionVars().StmtCtx.GetPlanDigest()
+	now := time.Now()
+	entry := &instancePlanCacheEntry{
+		key: pcKey,
+		value: &PlanCacheValue{
+			Plan:              cloned,
+			OutPutNames:       val.OutPutNames,
+			TblInfo2UnionScan: val.TblInfo2UnionScan,
+			memoryUsage:       val.MemoryUsage(),
+			matchOpts:         val.matchOpts,
+			stmtHints:         val.stmtHints,
+		},
+		sqlDigest:      sqlDigest.String(),
+		memory:         pcKey.MemoryUsage() + val.MemoryUsage(),
+		createTime:     now,
+		lastAccessTime: now,
+	}
+	if planDigest != nil {
+		entry.planDigest = planDigest.String()
+	}
+	memLimit := int64(variable.InstancePlanCacheMaxMemSize.Load())
+	if entry.memory > memLimit {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	hash := strHashKey(key, true)
+	for element := range c.buckets[hash] {
+		if matchCachedPlan(sctx.GetSessionVars(), element.Value.(*instancePlanCacheEntry).value, opts) {
+			c.removeElement(element)
+			break
+		}
+	}
+	bucket, ok := c.buckets[hash]
+	if !ok {
+		bucket = make(map[*list.Element]struct{}, 1)
+		c.buckets[hash] = bucket
+	}
+	bucket[c.lruList.PushFront(entry)] = struct{}{}
+	c.memoryUsage += entry.memory
+	core_metrics.GetInstancePlanCacheNumCounter().Inc()
+	core_metrics.GetInstancePlanCacheMemoryUsage().Add(float64(entry.memory))
+	for c.memoryUsage > memLimit {
+		c.removeElement(c.lruList.Back())
+		c.evictions++
+		core_metrics.GetInstancePlanCacheEvictCounter().Inc()
+	}
+	return true
+}
+
+// Delete deletes all plans with the key from the cache.
+func (c *InstancePlanCache) Delete(key kvcache.Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for element := range c.buckets[strHashKey(key, false)] {
+		c.removeElement(element)
+	}
+}
+
+// DeleteAll deletes all plans from the cache.
+func (c *InstancePlanCache) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	core_metrics.GetInstancePlanCacheNumCounter().Sub(float64(c.lruList.Len()))
+	core_metrics.GetInstancePlanCacheMemoryUsage().Sub(float64(c.memoryUsage))
+	c.buckets = make(map[string]map[*list.Element]struct{})
+	c.lruList = list.New()
+	c.memoryUsage = 0
+}
+
+// Size returns the number of the cached plans.
+func (c *InstancePlanCache) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lruList.Len()
+}
+
+// MemoryUsage returns the memory usage of the cached plans.
+func (c *InstancePlanCache) MemoryUsage() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.memoryUsage
+}
+
+// Evictions returns the number of the plans evicted by the memory limit.
+func (c *InstancePlanCache) Evictions() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.evictions
+}
+
+// Entries returns the information of the cached plans, the most recently used ones come first.
+func (c *InstancePlanCache) Entries() []InstancePlanCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entries := make([]InstancePlanCacheEntry, 0, c.lruList.Len())
+	for element := c.lruList.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*instancePlanCacheEntry)
+		entries = append(entries, InstancePlanCacheEntry{
+			SQLDigest:      entry.sqlDigest,
+			SQLText:        entry.key.stmtText,
+			DB:             entry.key.database,
+			SchemaVersion:  entry.key.schemaVersion,
+			PlanDigest:     entry.planDigest,
+			Binding:        entry.key.bindSQL,
+			MemoryUsage:    entry.memory,
+			Hits:           entry.hits,
+			CreateTime:     entry.createTime,
+			LastAccessTime: entry.lastAccessTime,
+		})
+	}
+	return entries
+}
+
+func (c *InstancePlanCache) removeElement(element *list.Element) {
+	entry := element.Value.(*instancePlanCacheEntry)
+	hash := strHashKey(entry.key, false)
+	bucket := c.buckets[hash]
+	delete(bucket, element)
+	if len(bucket) == 0 {
+		delete(c.buckets, hash)
+	}
+	c.lruList.Remove(element)
+	c.memoryUsage -= entry.memory
+	core_metrics.GetInstancePlanCacheNumCounter().Dec()
+	core_metrics.GetInstancePlanCacheMemoryUsage().Sub(float64(entry.memory))
+}
+
+// newInstancePlanCacheKey creates the key of the instance plan cache from the key of the session plan cache.
+// The plans are shared between connections, so the key doesn't contain the connection ID but the values
+// of the variables which may affect the plan.
+func newInstancePlanCacheKey(vars *variable.SessionVars, key kvcache.Key) kvcache.Key {
+	instanceKey := *key.(*planCacheKey)
+	instanceKey.connID = 0
+	instanceKey.planAffectedVars = vars.PlanAffectedVarsHash()
+	instanceKey.memoryUsage = 0
+	instanceKey.hash = nil
+	return &instanceKey
+}
+
+// isPlanSharable checks whether the plan can be shared between sessions. Only the plans consisting of the
+// operators whose Clone copies all the fields are sharable, the MPP and TiFlash plans aren't supported.
+func isPlanSharable(p PhysicalPlan) bool {
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		return x.StoreType == kv.TiKV && isPlanSharable(x.tablePlan)
+	case *PhysicalIndexReader:
+		return isPlanSharable(x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		return isPlanSharable(x.indexPlan) && isPlanSharable(x.tablePlan)
+	case *PhysicalTableScan, *PhysicalIndexScan, *PhysicalSelection, *PhysicalProjection,
+		*PhysicalLimit, *PhysicalTopN, *PhysicalSort, *PhysicalHashAgg, *PhysicalStreamAgg:
+	default:
+		return false
+	}
+	for _, child := range p.Children() {
+		if !isPlanSharable(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// sctxSetter is implemented by the plans embedding base.Plan, which are all the sharable plans.
+type sctxSetter interface {
+	SetSCtx(ctx sessionctx.Context)
+}
+
+// bindPlan2Session binds the cloned sharable plan to the session, so it reads the parameters of the session.
+func bindPlan2Session(sctx sessionctx.Context, p PhysicalPlan) {
+	p.(sctxSetter).SetSCtx(sctx)
+	bindExprs := func(exprs []expression.Expression) {
+		for _, expr := range exprs {
+			expression.BindParamMarkers(sctx, expr)
+		}
+	}
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		bindExprs(x.PlanPartInfo.PruningConds)
+		bindPlan2Session(sctx, x.tablePlan)
+	case *PhysicalIndexReader:
+		bindExprs(x.PlanPartInfo.PruningConds)
+		bindPlan2Session(sctx, x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		bindExprs(x.PlanPartInfo.PruningConds)
+		bindPlan2Session(sctx, x.indexPlan)
+		bindPlan2Session(sctx, x.tablePlan)
+	case *PhysicalTableScan:
+		bindExprs(x.AccessCondition)
+		bindExprs(x.filterCondition)
+	case *PhysicalIndexScan:
+		bindExprs(x.AccessCondition)
+	case *PhysicalSelection:
+		bindExprs(x.Conditions)
+	case *PhysicalProjection:
+		bindExprs(x.Exprs)
+	case *PhysicalTopN:
+		for _, item := range x.ByItems {
+			expression.BindParamMarkers(sctx, item.Expr)
+		}
+	case *PhysicalSort:
+		for _, item := range x.ByItems {
+			expression.BindParamMarkers(sctx, item.Expr)
+		}
+	case *PhysicalHashAgg:
+		bindAggExprs(sctx, &x.basePhysicalAgg)
+	case *PhysicalStreamAgg:
+		bindAggExprs(sctx, &x.basePhysicalAgg)
+	}
+	for _, child := range p.Children() {
+		bindPlan2Session(sctx, child)
+	}
+}
+
+func bindAggExprs(sctx sessionctx.Context, agg *basePhysicalAgg) {
+	for _, expr := range agg.GroupByItems {
+		expression.BindParamMarkers(sctx, expr)
+	}
+	for _, aggFunc := range agg.AggFuncs {
+		for _, arg := range aggFunc.Args {
+			expression.BindParamMarkers(sctx, arg)
+		}
+		for _, item := range aggFunc.OrderByItems {
+			expression.BindParamMarkers(sctx, item.Expr)
+		}
+	}
+}
@@ -1288,6 +1288,88 @@ func TestWarningWithDisablePlanCacheStmt(t *testing.T) {
 	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
 }
 
+func TestInstancePlanCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set global tidb_enable_instance_plan_cache = 1")
+	defer func() {
+		tk.MustExec("set global tidb_enable_instance_plan_cache = default")
+		tk.MustExec("set global tidb_instance_plan_cache_max_size = default")
+	}()
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	newSession := func() *testkit.TestKit {
+		tk := testkit.NewTestKit(t, store)
+		tk.MustExec("use test")
+		tk.MustExec("prepare st from 'select b from t where a > ? order by b'")
+		return tk
+	}
+
+	// The plan generated by a session is shared with other sessions, it's cloned for each execution
+	// instead of being kept in the session plan caches.
+	tk1, tk2 := newSession(), newSession()
+	tk1.MustExec("set @a = 1")
+	tk1.MustQuery("execute st using @a").Check(testkit.Rows("2", "3"))
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk2.MustExec("set @a = 2")
+	tk2.MustQuery("execute st using @a").Check(testkit.Rows("3"))
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk2.MustQuery("execute st using @a").Check(testkit.Rows("3"))
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	require.Equal(t, 0, tk1.Session().GetSessionPlanCache().Size())
+	require.Equal(t, 0, tk2.Session().GetSessionPlanCache().Size())
+	tk1.MustExec("set @a = 0")
+	tk1.MustQuery("execute st using @a").Check(testkit.Rows("1", "2", "3"))
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	// The plan isn't shared between sessions with different variables affecting the plan.
+	tk2.MustExec("set tidb_opt_cpu_factor = 10")
+	tk2.MustQuery("execute st using @a").Check(testkit.Rows("3"))
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select sql_text, db, hits from information_schema.instance_plan_cache").Sort().Check(testkit.Rows(
+		"select b from t where a > ? order by b test 0",
+		"select b from t where a > ? order by b test 3"))
+
+	// The sessions can use the shared plan concurrently.
+	tk2.MustExec("set tidb_opt_cpu_factor = default")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		tk := newSession()
+		wg.Add(1)
+		go func(a int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				tk.MustExec(fmt.Sprintf("set @a = %d", a))
+				tk.MustQuery("execute st using @a").Check(testkit.Rows("3"))
+				tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+			}
+		}(2)
+	}
+	wg.Wait()
+
+	// The least recently used plans are evicted when the memory usage exceeds the limit.
+	pc := plannercore.GetInstancePlanCache(tk.Session())
+	require.Equal(t, 2, pc.Size())
+	tk.MustExec("admin flush instance plan_cache")
+	require.Equal(t, 0, pc.Size())
+	tk1.MustQuery("execute st using @a").Check(testkit.Rows("1", "2", "3"))
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec(fmt.Sprintf("set global tidb_instance_plan_cache_max_size = %d", pc.MemoryUsage()*3/2))
+	tk1.MustExec("prepare st2 from 'select b from t where a < ? order by b'")
+	tk1.MustQuery("execute st2 using @a").Check(testkit.Rows())
+	require.Equal(t, 1, pc.Size())
+	require.Equal(t, int64(1), pc.Evictions())
+	tk.MustQuery("select sql_text from information_schema.instance_plan_cache").Check(testkit.Rows(
+		"select b from t where a < ? order by b"))
+	tk.MustExec("set global tidb_instance_plan_cache_max_size = 1")
+	tk1.MustQuery("execute st using @a").Check(testkit.Rows("1", "2", "3"))
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	// The plans exceeding the limit are cached in the session plan cache.
+	tk1.MustQuery("execute st using @a").Check(testkit.Rows("1", "2", "3"))
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	require.Equal(t, 1, pc.Size())
+}
+
 func BenchmarkPlanCacheBindingMatch(b *testing.B) {
 	store := testkit.CreateMockStore(b)
 	tk := testkit.NewTestKit(b, store)
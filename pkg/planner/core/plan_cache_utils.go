@@ -220,6 +220,8 @@ type planCacheKey struct {
 	restrictedReadOnly       bool
 	TiDBSuperReadOnly        bool
 	exprBlacklistTS          int64 // expr-pushdown-blacklist can affect query optimization, so we need to consider it in plan cache.
+	// planAffectedVars is the values of the variables which may affect the plan, it's only set for the instance plan cache.
+	planAffectedVars []byte
 
 	memoryUsage int64 // Do not include in hash
 	hash        []byte
@@ -254,6 +256,7 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
 		key.hash = codec.EncodeInt(key.hash, key.exprBlacklistTS)
+		key.hash = append(key.hash, key.planAffectedVars...)
 	}
 	return key.hash
 }
@@ -269,7 +272,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)+len(key.planAffectedVars)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -554,7 +554,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt, *ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CallStmt,
+		*ast.RefreshMaterializedViewStmt, *ast.RecommendIndexStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1028,7 +1030,17 @@ func isForUpdateReadSelectLock(lock *ast.SelectLockInfo) bool {
 	}
 	return lock.LockType == ast.SelectLockForUpdate ||
 		lock.LockType == ast.SelectLockForUpdateNoWait ||
-		lock.LockType == ast.SelectLockForUpdateWaitN
+		lock.LockType == ast.SelectLockForUpdateWaitN ||
+		lock.LockType == ast.SelectLockForUpdateSkipLocked
+}
+
+// isSkipLockedSelectLock checks if the lock type skips the rows locked by other transactions.
+func isSkipLockedSelectLock(lock *ast.SelectLockInfo) bool {
+	if lock == nil {
+		return false
+	}
+	return lock.LockType == ast.SelectLockForUpdateSkipLocked ||
+		lock.LockType == ast.SelectLockForShareSkipLocked
 }
 
 // getLatestIndexInfo gets the index info of latest schema version from given table id,
@@ -1115,9 +1127,10 @@ func getPossibleAccessPaths(ctx sessionctx.Context, tableHints *hint.TableHintIn
 		}
 	}
 
-	// consider hypo-indexes
+	// consider hypo-indexes, they only exist in metadata and can not be used by EXPLAIN ANALYZE
 	hypoIndexes := ctx.GetSessionVars().HypoIndexes
-	if ctx.GetSessionVars().StmtCtx.InExplainStmt && hypoIndexes != nil {
+	sc := ctx.GetSessionVars().StmtCtx
+	if sc.InExplainStmt && !sc.InExplainAnalyzeStmt && hypoIndexes != nil {
 		originalTableName := tblInfo.Name.L
 		if hypoIndexes[dbName.L] != nil && hypoIndexes[dbName.L][originalTableName] != nil {
 			for _, index := range hypoIndexes[dbName.L][originalTableName] {
@@ -1416,6 +1429,10 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		p := &ResumeDDLJobs{JobIDs: as.JobIDs}
 		p.setSchemaAndNames(buildResumeDDLJobsFields())
 		ret = p
+	case ast.AdminAlterDDLJobs:
+		p := &AlterDDLJobs{JobIDs: as.JobIDs, Schedule: as.Schedule, ScheduleDuration: as.ScheduleDuration}
+		p.setSchemaAndNames(buildAlterDDLJobsFields())
+		ret = p
 	case ast.AdminCheckIndexRange:
 		schema, names, err := b.buildCheckIndexSchema(as.Tables[0], as.Index)
 		if err != nil {
@@ -2992,6 +3009,10 @@ func buildResumeDDLJobsFields() (*expression.Schema, types.NameSlice) {
 	return buildCommandOnDDLJobsFields()
 }
 
+func buildAlterDDLJobsFields() (*expression.Schema, types.NameSlice) {
+	return buildCommandOnDDLJobsFields()
+}
+
 func buildAdminShowBDRRoleFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(1)
 	schema.Append(buildColumnWithName("", "BDR_ROLE", mysql.TypeString, 1))
@@ -3065,6 +3086,18 @@ func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	return cols.col2Schema(), cols.names
 }
 
+func buildRecommendIndexSchema() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "DATABASE", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "TABLE", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "INDEX_NAME", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "INDEX_COLUMNS", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "REASON", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "TOP_IMPACTED_QUERIES", mysql.TypeString, mysql.MaxBlobWidth))
+	schema.Append(buildColumnWithName("", "STATEMENT", mysql.TypeVarchar, 1024))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowTelemetrySchema() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(1)
 	schema.Append(buildColumnWithName("", "TRACKING_ID", mysql.TypeVarchar, 64))
@@ -3188,6 +3221,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			}
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AllPrivMask, show.Table.Schema.L, show.Table.Name.L, "", err)
 		}
+	case ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, ErrNoDB
+		}
+	case ast.ShowCreateProcedure:
+		// The name of the procedure is passed to the executor as the table.
+		p.Table = show.Procedure
 	case ast.ShowConfig:
 		privErr := ErrSpecificAccessDenied.GenWithStackByArgs("CONFIG")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ConfigPriv, "", "", "", privErr)
@@ -3254,8 +3294,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowEvents {
+			// The pattern of SHOW EVENTS matches the event names.
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3338,6 +3383,11 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
 		p.setSchemaAndNames(buildAddQueryWatchSchema())
+	case *ast.RecommendIndexStmt:
+		// The workload is read from the statement summary, which contains the queries of all users.
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ProcessPriv, "", "", "", err)
+		p.setSchemaAndNames(buildRecommendIndexSchema())
 	case *ast.DropQueryWatchStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
@@ -3392,6 +3442,38 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		}
 	case *ast.ShutdownStmt:
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShutdownPriv, "", "", "", nil)
+	case *ast.CreateEventStmt:
+		b.appendEventVisitInfo(raw.EventName)
+		user := b.ctx.GetSessionVars().User
+		if raw.Definer.CurrentUser && user != nil {
+			raw.Definer = user
+		}
+		if user != nil && raw.Definer.String() != user.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		}
+	case *ast.AlterEventStmt:
+		b.appendEventVisitInfo(raw.EventName)
+		if raw.NewName != nil {
+			b.appendEventVisitInfo(raw.NewName)
+		}
+	case *ast.DropEventStmt:
+		b.appendEventVisitInfo(raw.EventName)
+	case *ast.ProcedureInfo:
+		b.appendRoutineVisitInfo(raw.ProcedureName, mysql.CreateRoutinePriv)
+	case *ast.DropProcedureStmt:
+		b.appendRoutineVisitInfo(raw.ProcedureName, mysql.AlterRoutinePriv)
+	case *ast.RefreshMaterializedViewStmt:
+		// Refreshing replaces the rows of the view, which is the same as deleting
+		// and inserting the rows.
+		for _, priv := range []mysql.PrivilegeType{mysql.InsertPriv, mysql.DeletePriv} {
+			var err error
+			if user := b.ctx.GetSessionVars().User; user != nil {
+				err = ErrTableaccessDenied.GenWithStackByArgs(strings.ToUpper(mysql.Priv2Str[priv]), user.AuthUsername,
+					user.AuthHostname, raw.ViewName.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, priv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", err)
+		}
 	case *ast.BeginStmt:
 		readTS := b.ctx.GetSessionVars().TxnReadTS.PeakTxnReadTS()
 		if raw.AsOf != nil {
@@ -3422,6 +3504,24 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	return p, nil
 }
 
+// appendEventVisitInfo requires the EVENT privilege on the schema of the event.
+func (b *PlanBuilder) appendEventVisitInfo(name *ast.TableName) {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, name.Schema.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, name.Schema.L, "", "", authErr)
+}
+
+// appendRoutineVisitInfo requires the privilege on the schema of the stored routine.
+func (b *PlanBuilder) appendRoutineVisitInfo(name *ast.TableName, priv mysql.PrivilegeType) {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, name.Schema.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, priv, name.Schema.L, "", "", authErr)
+}
+
 func collectVisitInfoFromRevokeStmt(sctx sessionctx.Context, vi []visitInfo, stmt *ast.RevokeStmt) ([]visitInfo, error) {
 	// To use REVOKE, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3642,6 +3742,9 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	if err := b.checkWriteMaterializedView(tableInfo, "Insert"); err != nil {
+		return nil, err
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx, tn.Schema, tableInfo)
 	if err != nil {
@@ -4734,6 +4837,40 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		plan, err := b.Build(ctx, v.Select)
+		if err != nil {
+			return nil, err
+		}
+		schema := plan.Schema()
+		names := plan.OutputNames()
+		if v.Cols == nil {
+			adjustOverlongViewColname(plan.(LogicalPlan))
+			v.Cols = make([]model.CIStr, len(schema.Columns))
+			for i, name := range names {
+				v.Cols[i] = name.ColName
+			}
+		}
+		if len(v.Cols) != schema.Len() {
+			return nil, dbterror.ErrViewWrongList
+		}
+		v.ColTypes = make([]*types.FieldType, len(schema.Columns))
+		for i, col := range schema.Columns {
+			v.ColTypes[i] = col.RetType.Clone()
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.DropMaterializedViewStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5009,14 +5146,28 @@ func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt)
 	}
 	selectIntoInfo := sel.SelectIntoOpt
 	sel.SelectIntoOpt = nil
-	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	targetPlan, names, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	options := make([]*LoadDataOpt, 0, len(selectIntoInfo.Options))
+	for _, opt := range selectIntoInfo.Options {
+		selectIntoOpt := LoadDataOpt{Name: opt.Name}
+		if opt.Value != nil {
+			selectIntoOpt.Value, _, err = b.rewrite(ctx, opt.Value, mockTablePlan, nil, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, &selectIntoOpt)
+	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
 		TargetPlan:     targetPlan,
+		TargetNames:    names,
 		IntoOpt:        selectIntoInfo,
+		Options:        options,
 		LineFieldsInfo: NewLineFieldsInfo(selectIntoInfo.FieldsInfo, selectIntoInfo.LinesInfo),
 	}, nil
 }
@@ -5168,6 +5319,8 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreateView:
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowDrainerStatus:
@@ -5362,6 +5515,15 @@ func buildChecksumTableSchema() (*expression.Schema, []*types.FieldName) {
 	return schema.col2Schema(), schema.names
 }
 
+// checkWriteMaterializedView checks whether the table can be written by the statement, the
+// materialized views and the change logs are only written when the views are refreshed.
+func (b *PlanBuilder) checkWriteMaterializedView(tblInfo *model.TableInfo, op string) error {
+	if (tblInfo.IsMaterializedView() || tblInfo.IsMaterializedViewLog) && !b.ctx.GetSessionVars().InRestrictedSQL {
+		return ErrOptOnMaterializedView.GenWithStackByArgs(op)
+	}
+	return nil
+}
+
 // adjustOverlongViewColname adjusts the overlong outputNames of a view to
 // `new_exp_$off` where `$off` is the offset of the output column, $off starts from 1.
 // There is still some MySQL compatible problems.
@@ -87,6 +87,7 @@ type PointGetPlan struct {
 	Lock               bool
 	outputNames        []*types.FieldName
 	LockWaitTime       int64
+	LockSkipLocked     bool
 	partitionColumnPos int
 	Columns            []*model.ColumnInfo
 	cost               float64
@@ -203,6 +204,9 @@ func (p *PointGetPlan) OperatorInfo(normalized bool) string {
 		} else {
 			buffer.WriteString("lock")
 		}
+		if p.LockSkipLocked {
+			buffer.WriteString(" skip locked")
+		}
 	}
 	return buffer.String()
 }
@@ -332,6 +336,7 @@ type BatchPointGetPlan struct {
 	Desc             bool
 	Lock             bool
 	LockWaitTime     int64
+	LockSkipLocked   bool
 	Columns          []*model.ColumnInfo
 	cost             float64
 
@@ -436,6 +441,9 @@ func (p *BatchPointGetPlan) OperatorInfo(normalized bool) string {
 	buffer.WriteString(strconv.FormatBool(p.Desc))
 	if p.Lock {
 		buffer.WriteString(", lock")
+		if p.LockSkipLocked {
+			buffer.WriteString(" skip locked")
+		}
 	}
 	return buffer.String()
 }
@@ -569,6 +577,7 @@ func TryFastPlan(ctx sessionctx.Context, node ast.Node) (p Plan) {
 				return nil
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.LockSkipLocked = fp.Lock && isSkipLockedSelectLock(x.LockInfo)
 			p = fp
 			return
 		}
@@ -587,6 +596,7 @@ func TryFastPlan(ctx sessionctx.Context, node ast.Node) (p Plan) {
 				return
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.LockSkipLocked = fp.Lock && isSkipLockedSelectLock(x.LockInfo)
 			p = fp
 			return
 		}
@@ -603,7 +613,9 @@ func IsSelectForUpdateLockType(lockType ast.SelectLockType) bool {
 	if lockType == ast.SelectLockForUpdate ||
 		lockType == ast.SelectLockForShare ||
 		lockType == ast.SelectLockForUpdateNoWait ||
-		lockType == ast.SelectLockForUpdateWaitN {
+		lockType == ast.SelectLockForUpdateWaitN ||
+		lockType == ast.SelectLockForUpdateSkipLocked ||
+		lockType == ast.SelectLockForShareSkipLocked {
 		return true
 	}
 	return false
@@ -622,7 +634,8 @@ func getLockWaitTime(ctx sessionctx.Context, lockInfo *ast.SelectLockInfo) (lock
 				waitTime = sessVars.LockWaitTimeout
 				if lockInfo.LockType == ast.SelectLockForUpdateWaitN {
 					waitTime = int64(lockInfo.WaitSec * 1000)
-				} else if lockInfo.LockType == ast.SelectLockForUpdateNoWait {
+				} else if lockInfo.LockType == ast.SelectLockForUpdateNoWait || isSkipLockedSelectLock(lockInfo) {
+					// SKIP LOCKED never waits for a lock, the rows locked by others are skipped instead.
 					waitTime = tikvstore.LockNoWait
 				}
 			}
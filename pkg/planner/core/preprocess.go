@@ -263,6 +263,13 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.stmtTp = TypeDrop
+		p.flag |= inCreateOrDropTable
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -320,6 +327,31 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 			}
 		}
 		return in, true
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.handleEventName(node.EventName)
+		// The statement of the event is checked when the event runs.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.handleEventName(node.EventName)
+		if node.NewName != nil {
+			p.handleEventName(node.NewName)
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.handleEventName(node.EventName)
+		return in, true
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.handleEventName(node.ProcedureName)
+		// The statements in the body are checked when the procedure is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.handleEventName(node.ProcedureName)
+		return in, true
 	case *ast.RecoverTableStmt:
 		// The specified table in recover table statement maybe already been dropped.
 		// So skip check table name here, otherwise, recover table [table_name] syntax will return
@@ -370,6 +402,9 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if _, ok := node.Source.(*ast.SelectStmt); ok && !isModeOracle && len(node.AsName.L) == 0 {
 			p.err = dbterror.ErrDerivedMustHaveAlias.GenWithStackByArgs()
 		}
+		if _, ok := node.Source.(*ast.JSONTable); ok && len(node.AsName.L) == 0 {
+			p.err = ErrTableFunctionMustHaveAlias.GenWithStackByArgs()
+		}
 		if v, ok := node.Source.(*ast.TableName); ok && v.TableSample != nil {
 			switch v.TableSample.SampleMethod {
 			case ast.SampleMethodTypeTiDBRegion:
@@ -563,7 +598,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -1010,6 +1045,37 @@ func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	switch s := stmt.Select.(type) {
+	case *ast.SelectStmt:
+		if s.SelectIntoOpt != nil {
+			p.err = dbterror.ErrViewSelectClause.GenWithStackByArgs("INFO")
+			return
+		}
+		if s.LockInfo != nil && s.LockInfo.LockType != ast.SelectLockNone {
+			s.LockInfo.LockType = ast.SelectLockNone
+		}
+	case *ast.SetOprStmt:
+		for _, sel := range s.SelectList.Selects {
+			p.checkCreateViewWithSelect(sel)
+			if p.err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (p *preprocessor) checkDropSequenceGrammar(stmt *ast.DropSequenceStmt) {
 	p.checkDropTableNames(stmt.Sequences)
 }
@@ -1513,6 +1579,20 @@ func (p *preprocessor) stmtType() string {
 	}
 }
 
+// handleEventName fills the schema of the event or stored routine name, these
+// names are not resolved as tables.
+func (p *preprocessor) handleEventName(tn *ast.TableName) {
+	if tn.Schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(ErrNoDB)
+		return
+	}
+	tn.Schema = model.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) handleTableName(tn *ast.TableName) {
 	if tn.Schema.L == "" {
 		for _, cte := range p.preprocessWith.cteCanUsed {
@@ -1617,6 +1697,10 @@ func (p *preprocessor) handleRepairName(tn *ast.TableName) {
 }
 
 func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
+	if node.Procedure != nil {
+		p.handleEventName(node.Procedure)
+		node.DBName = node.Procedure.Schema.O
+	}
 	if node.DBName == "" {
 		if node.Table != nil && node.Table.Schema.L != "" {
 			node.DBName = node.Table.Schema.O
@@ -39,38 +39,14 @@ func appendDerivedTopNTrace(topN LogicalPlan, opt *logicalOptimizeOp) {
 	opt.appendStepToCurrent(topN.ID(), topN.TP(), reason, action)
 }
 
-// checkPartitionBy mainly checks if partition by of window function is a prefix of
-// data order (clustered index) of the data source. TiFlash is allowed only for empty partition by.
-func checkPartitionBy(p *LogicalWindow, d *DataSource) bool {
-	// No window partition by. We are OK.
-	if len(p.PartitionBy) == 0 {
-		return true
-	}
-
-	// Table not clustered and window has partition by. Can not do the TopN push down.
-	if d.handleCols == nil {
-		return false
-	}
-
-	if len(p.PartitionBy) > d.handleCols.NumCols() {
-		return false
-	}
-
-	for i, col := range p.PartitionBy {
-		if !(col.Col.EqualColumn(d.handleCols.GetCol(i))) {
-			return false
-		}
-	}
-	return true
-}
-
 /*
 		Check the following pattern of filter over row number window function:
 	  - Filter is simple condition of row_number < value or row_number <= value
 	  - The window function is a simple row number
 	  - With default frame: rows between current row and current row. Check is not necessary since
 	    current row is only frame applicable to row number
-	  - Child is a data source with no tiflash option.
+	  - Child is a data source with no tiflash option when there is no partition by. With partition by, the
+	    derived TopN keeps N rows for each partition and can be evaluated at root or pushed down to TiFlash.
 */
 func windowIsTopN(p *LogicalSelection) (bool, uint64) {
 	// Check if child is window function.
@@ -101,16 +77,18 @@ func windowIsTopN(p *LogicalSelection) (bool, uint64) {
 		return false, 0
 	}
 
-	// Give up if TiFlash is one possible access path. Pushing down window aggregation is good enough in this case.
-	for _, path := range dataSource.possibleAccessPaths {
-		if path.StoreType == kv.TiFlash {
-			return false, 0
+	// Give up if TiFlash is one possible access path and there is no partition by. Pushing down window
+	// aggregation is good enough in this case, while a TopN without partition by can only be finished at root.
+	if len(child.PartitionBy) == 0 {
+		for _, path := range dataSource.possibleAccessPaths {
+			if path.StoreType == kv.TiFlash {
+				return false, 0
+			}
 		}
 	}
 
 	if len(child.WindowFuncDescs) == 1 && child.WindowFuncDescs[0].Name == "row_number" &&
-		child.Frame.Type == ast.Rows && child.Frame.Start.Type == ast.CurrentRow && child.Frame.End.Type == ast.CurrentRow &&
-		checkPartitionBy(child, dataSource) {
+		child.Frame.Type == ast.Rows && child.Frame.Start.Type == ast.CurrentRow && child.Frame.End.Type == ast.CurrentRow {
 		return true, uint64(limitValue)
 	}
 	return false, 0
@@ -88,7 +88,7 @@ func splitSetGetVarFunc(filters []expression.Expression) ([]expression.Expressio
 	canBePushDown := make([]expression.Expression, 0, len(filters))
 	canNotBePushDown := make([]expression.Expression, 0, len(filters))
 	for _, expr := range filters {
-		if expression.HasGetSetVarFunc(expr) {
+		if expression.HasGetSetVarFunc(expr) || expression.HasNoPushDownExtensionFunc(expr) {
 			canNotBePushDown = append(canNotBePushDown, expr)
 		} else {
 			canBePushDown = append(canBePushDown, expr)
@@ -529,7 +529,7 @@ func (p *LogicalProjection) PredicatePushDown(predicates []expression.Expression
 	ctx := p.SCtx()
 	for _, cond := range predicates {
 		substituted, hasFailed, newFilter := expression.ColumnSubstituteImpl(ctx, cond, p.Schema(), p.Exprs, true)
-		if substituted && !hasFailed && !expression.HasGetSetVarFunc(newFilter) {
+		if substituted && !hasFailed && !expression.HasGetSetVarFunc(newFilter) && !expression.HasNoPushDownExtensionFunc(newFilter) {
 			canBePushed = append(canBePushed, newFilter)
 		} else {
 			canNotBePushed = append(canNotBePushed, cond)
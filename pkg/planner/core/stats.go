@@ -258,6 +258,9 @@ func (ds *DataSource) initStats(colGroups [][]*expression.Column) {
 	if ds.statisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
 	}
+	if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.MultiColStats = ds.statisticTable.ExtendedStats.MultiColumnStats()
+	}
 
 	statsRecord := ds.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(true)
 	name, tblInfo := getTblInfoForUsedStatsByPhysicalID(ds.SCtx(), ds.physicalTableID)
@@ -609,21 +612,35 @@ func deriveLimitStats(childProfile *property.StatsInfo, limitCount float64) *pro
 	return stats
 }
 
+// derivePartitionLimitStats derives the stats of a Limit or TopN with partition by, which keeps
+// limitCount rows for each partition.
+func derivePartitionLimitStats(childProfile *property.StatsInfo, childSchema *expression.Schema, partitionBy []property.SortItem, limitCount float64) *property.StatsInfo {
+	if len(partitionBy) == 0 {
+		return deriveLimitStats(childProfile, limitCount)
+	}
+	cols := make([]*expression.Column, 0, len(partitionBy))
+	for _, item := range partitionBy {
+		cols = append(cols, item.Col)
+	}
+	ndv, _ := cardinality.EstimateColsNDVWithMatchedLen(cols, childSchema, childProfile)
+	return deriveLimitStats(childProfile, limitCount*ndv)
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
-func (p *LogicalLimit) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+func (p *LogicalLimit) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.StatsInfo() != nil {
 		return p.StatsInfo(), nil
 	}
-	p.SetStats(deriveLimitStats(childStats[0], float64(p.Count)))
+	p.SetStats(derivePartitionLimitStats(childStats[0], childSchema[0], p.PartitionBy, float64(p.Count)))
 	return p.StatsInfo(), nil
 }
 
 // DeriveStats implement LogicalPlan DeriveStats interface.
-func (lt *LogicalTopN) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+func (lt *LogicalTopN) DeriveStats(childStats []*property.StatsInfo, _ *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if lt.StatsInfo() != nil {
 		return lt.StatsInfo(), nil
 	}
-	lt.SetStats(deriveLimitStats(childStats[0], float64(lt.Count)))
+	lt.SetStats(derivePartitionLimitStats(childStats[0], childSchema[0], lt.PartitionBy, float64(lt.Count)))
 	return lt.StatsInfo(), nil
 }
 
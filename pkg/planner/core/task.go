@@ -837,9 +837,8 @@ func (t *rootTask) MemoryUsage() (sum int64) {
 // intersection/union case.
 func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
-	newPartitionBy := make([]property.SortItem, 0, len(p.GetPartitionBy()))
-	for _, expr := range p.GetPartitionBy() {
-		newPartitionBy = append(newPartitionBy, expr.Clone())
+	if len(p.GetPartitionBy()) > 0 {
+		return p.attach2TaskWithPartition(t)
 	}
 
 	sunk := false
@@ -849,7 +848,7 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 			childProfile := cop.tablePlan.StatsInfo()
 			// but "regionNum" is unknown since the copTask can be a double read, so we ignore it now.
 			stats := deriveLimitStats(childProfile, float64(newCount))
-			pushedDownLimit := PhysicalLimit{PartitionBy: newPartitionBy, Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+			pushedDownLimit := PhysicalLimit{Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
 			pushedDownLimit.SetChildren(cop.tablePlan)
 			cop.tablePlan = pushedDownLimit
 			// Don't use clone() so that Limit and its children share the same schema. Otherwise, the virtual generated column may not be resolved right.
@@ -866,7 +865,7 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 				// Strictly speaking, for the row count of stats, we should multiply newCount with "regionNum",
 				// but "regionNum" is unknown since the copTask can be a double read, so we ignore it now.
 				stats := deriveLimitStats(childProfile, float64(newCount))
-				pushedDownLimit := PhysicalLimit{PartitionBy: newPartitionBy, Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+				pushedDownLimit := PhysicalLimit{Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
 				cop = attachPlan2Task(pushedDownLimit, cop).(*copTask)
 				// Don't use clone() so that Limit and its children share the same schema. Otherwise the virtual generated column may not be resolved right.
 				pushedDownLimit.SetSchema(pushedDownLimit.children[0].Schema())
@@ -886,7 +885,7 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 					for _, partialScan := range cop.idxMergePartPlans {
 						childProfile := partialScan.StatsInfo()
 						stats := deriveLimitStats(childProfile, float64(newCount))
-						pushedDownLimit := PhysicalLimit{PartitionBy: newPartitionBy, Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+						pushedDownLimit := PhysicalLimit{Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
 						pushedDownLimit.SetChildren(partialScan)
 						pushedDownLimit.SetSchema(pushedDownLimit.children[0].Schema())
 						limitChildren = append(limitChildren, pushedDownLimit)
@@ -926,7 +925,7 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 		newCount := p.Offset + p.Count
 		childProfile := mpp.plan().StatsInfo()
 		stats := deriveLimitStats(childProfile, float64(newCount))
-		pushedDownLimit := PhysicalLimit{Count: newCount, PartitionBy: newPartitionBy}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+		pushedDownLimit := PhysicalLimit{Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
 		mpp = attachPlan2Task(pushedDownLimit, mpp).(*mppTask)
 		pushedDownLimit.SetSchema(pushedDownLimit.children[0].Schema())
 		t = mpp.convertToRootTask(p.SCtx())
@@ -934,11 +933,34 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 	if sunk {
 		return t
 	}
-	// Skip limit with partition on the root. This is a derived topN and window function
-	// will take care of the filter.
-	if len(p.GetPartitionBy()) > 0 {
-		return t
+	return attachPlan2Task(p, t)
+}
+
+// attach2TaskWithPartition attaches the limit with partition by, which is derived from a row_number filter
+// over a window function. It keeps the first N rows for each partition, so it can be evaluated by every
+// storage node in advance and the root one filters the partial results again.
+func (p *PhysicalLimit) attach2TaskWithPartition(t task) task {
+	newPartitionBy := make([]property.SortItem, 0, len(p.GetPartitionBy()))
+	for _, expr := range p.GetPartitionBy() {
+		newPartitionBy = append(newPartitionBy, expr.Clone())
+	}
+	newCount := p.Offset + p.Count
+	if cop, ok := t.(*copTask); ok && len(cop.rootTaskConds) == 0 && cop.partitionByIsHandlePrefix(p.GetPartitionBy()) {
+		stats := derivePartitionLimitStats(cop.plan().StatsInfo(), cop.plan().Schema(), newPartitionBy, float64(newCount))
+		pushedDownLimit := PhysicalLimit{PartitionBy: newPartitionBy, Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+		cop = attachPlan2Task(pushedDownLimit, cop).(*copTask)
+		// Don't use clone() so that Limit and its children share the same schema. Otherwise the virtual generated column may not be resolved right.
+		pushedDownLimit.SetSchema(pushedDownLimit.children[0].Schema())
+	} else if mpp, ok := t.(*mppTask); ok {
+		stats := derivePartitionLimitStats(mpp.plan().StatsInfo(), mpp.plan().Schema(), newPartitionBy, float64(newCount))
+		pushedDownLimit := PhysicalLimit{PartitionBy: newPartitionBy, Count: newCount}.Init(p.SCtx(), stats, p.QueryBlockOffset())
+		mpp = attachPlan2Task(pushedDownLimit, mpp).(*mppTask)
+		pushedDownLimit.SetSchema(pushedDownLimit.children[0].Schema())
+		// The window function and the row_number filter above take care of the final result, so the
+		// limit is not needed at root any more.
+		return mpp
 	}
+	t = t.convertToRootTask(p.SCtx())
 	return attachPlan2Task(p, t)
 }
 
@@ -1075,7 +1097,7 @@ func (p *PhysicalTopN) getPushedDownTopN(childPlan PhysicalPlan) *PhysicalTopN {
 	childProfile := childPlan.StatsInfo()
 	// Strictly speaking, for the row count of pushed down TopN, we should multiply newCount with "regionNum",
 	// but "regionNum" is unknown since the copTask can be a double read, so we ignore it now.
-	stats := deriveLimitStats(childProfile, float64(newCount))
+	stats := derivePartitionLimitStats(childProfile, childPlan.Schema(), newPartitionBy, float64(newCount))
 	topN := PhysicalTopN{
 		ByItems:     newByItems,
 		PartitionBy: newPartitionBy,
@@ -1156,6 +1178,29 @@ func (p *PhysicalTopN) canPushDownToTiKV(copTask *copTask) bool {
 	return true
 }
 
+// partitionByIsHandlePrefix checks whether the partition by items are a prefix of the handle columns,
+// and the copTask is a pure table scan. TiKV evaluates the TopN and Limit with partition by in a streaming
+// way, so the rows of one partition must be adjacent, which is only guaranteed by the data order.
+func (t *copTask) partitionByIsHandlePrefix(partitionBy []property.SortItem) bool {
+	if t.indexPlan != nil || len(t.idxMergePartPlans) > 0 || t.tablePlan == nil {
+		return false
+	}
+	p := t.tablePlan
+	for len(p.Children()) == 1 {
+		p = p.Children()[0]
+	}
+	ts, ok := p.(*PhysicalTableScan)
+	if !ok || ts.HandleCols == nil || len(partitionBy) > ts.HandleCols.NumCols() {
+		return false
+	}
+	for i, item := range partitionBy {
+		if !item.Col.EqualColumn(ts.HandleCols.GetCol(i)) {
+			return false
+		}
+	}
+	return true
+}
+
 // canPushDownToTiFlash checks whether this topN can be pushed down to TiFlash.
 func (p *PhysicalTopN) canPushDownToTiFlash(mppTask *mppTask) bool {
 	if !p.canExpressionConvertedToPB(kv.TiFlash) {
@@ -1169,6 +1214,9 @@ func (p *PhysicalTopN) canPushDownToTiFlash(mppTask *mppTask) bool {
 
 func (p *PhysicalTopN) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
+	if len(p.GetPartitionBy()) > 0 {
+		return p.attach2TaskWithPartition(t)
+	}
 	cols := make([]*expression.Column, 0, len(p.ByItems))
 	for _, item := range p.ByItems {
 		cols = append(cols, expression.ExtractColumns(item.Expr)...)
@@ -1192,11 +1240,22 @@ func (p *PhysicalTopN) attach2Task(tasks ...task) task {
 		mppTask.p = pushedDownTopN
 	}
 	rootTask := t.convertToRootTask(p.SCtx())
-	// Skip TopN with partition on the root. This is a derived topN and window function
-	// will take care of the filter.
-	if len(p.GetPartitionBy()) > 0 {
-		return t
+	return attachPlan2Task(p, rootTask)
+}
+
+// attach2TaskWithPartition attaches the TopN with partition by, which is derived from a row_number filter
+// over a window function. It keeps N rows for each partition, so it can be evaluated by every storage node
+// in advance and the root one filters the partial results again.
+func (p *PhysicalTopN) attach2TaskWithPartition(t task) task {
+	if copTask, ok := t.(*copTask); ok && p.canPushDownToTiKV(copTask) && copTask.partitionByIsHandlePrefix(p.GetPartitionBy()) {
+		copTask.tablePlan = p.getPushedDownTopN(copTask.tablePlan)
+	} else if mppTask, ok := t.(*mppTask); ok && p.canPushDownToTiFlash(mppTask) {
+		// The window function and the row_number filter above take care of the final result, so the
+		// TopN is not needed at root any more.
+		mppTask.p = p.getPushedDownTopN(mppTask.p)
+		return mppTask
 	}
+	rootTask := t.convertToRootTask(p.SCtx())
 	return attachPlan2Task(p, rootTask)
 }
 
@@ -0,0 +1,505 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// minImprovementRatio is the minimal ratio of the estimated cost of the impacted queries that
+	// a candidate index must reduce to be recommended.
+	minImprovementRatio = 0.01
+	// maxImpactedQueries is the maximum number of impacted queries shown for a recommended index.
+	maxImpactedQueries = 3
+)
+
+// Recommendation is an index to create or an unused index to drop recommended by the index advisor.
+type Recommendation struct {
+	Schema    string
+	Table     string
+	IndexName string
+	Columns   []string
+	// IsDrop indicates that the index is an existing index which is suggested to be dropped.
+	IsDrop bool
+	Reason string
+	// TopImpactedQueries are the queries whose estimated costs are reduced the most by the index.
+	TopImpactedQueries []*ImpactedQuery
+}
+
+// ImpactedQuery is a query whose estimated cost is reduced by a recommended index.
+type ImpactedQuery struct {
+	Query string `json:"query"`
+	// Improvement is the ratio of the reduced estimated cost to the original estimated cost.
+	Improvement float64 `json:"improvement"`
+}
+
+// Statement returns the DDL statement to apply the recommendation.
+func (r *Recommendation) Statement() string {
+	if r.IsDrop {
+		return fmt.Sprintf("DROP INDEX %s ON %s.%s", quoteName(r.IndexName), quoteName(r.Schema), quoteName(r.Table))
+	}
+	cols := make([]string, 0, len(r.Columns))
+	for _, col := range r.Columns {
+		cols = append(cols, quoteName(col))
+	}
+	return fmt.Sprintf("CREATE INDEX %s ON %s.%s(%s)", quoteName(r.IndexName), quoteName(r.Schema), quoteName(r.Table), strings.Join(cols, ", "))
+}
+
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// query is a query of the workload.
+type query struct {
+	schema    string
+	text      string
+	frequency int64
+	cols      []*indexableColumns
+	// cost is the estimated cost of the query with the indexes recommended so far.
+	cost float64
+	// usedIndexes are the names of the existing indexes used by the original plan.
+	usedIndexes []string
+}
+
+func (q *query) references(t *tableRef) bool {
+	return slices.ContainsFunc(q.cols, func(c *indexableColumns) bool { return c.table.key() == t.key() })
+}
+
+// AdviseIndexes recommends indexes for the workload. If sql is empty, the top queries in the
+// statement summary are used as the workload and the unused indexes of the tables referenced
+// by the workload are also suggested to be dropped.
+//
+// The candidate indexes are generated from the columns in the predicates and the ordering
+// of the queries, and they are evaluated by the cost model as hypothetical indexes, which
+// means the statement runs EXPLAIN in the current session.
+func AdviseIndexes(ctx context.Context, sctx sessionctx.Context, sql string, opts *Options) ([]*Recommendation, error) {
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	queries, err := loadWorkload(sctx, is, sql, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	o := newWhatIfOptimizer(ctx, sctx)
+	defer o.close()
+	workload := make([]*query, 0, len(queries))
+	totalCost := 0.0
+	for _, q := range queries {
+		cost, usedIndexes, err := o.cost(q, nil)
+		if err != nil {
+			if sql != "" {
+				return nil, err
+			}
+			logutil.BgLogger().Warn("failed to explain the query for index advisor, skip it", zap.String("query", q.text), zap.Error(err))
+			continue
+		}
+		q.cost, q.usedIndexes = cost, usedIndexes
+		totalCost += float64(q.frequency) * cost
+		workload = append(workload, q)
+	}
+
+	recommendations, err := selectIndexes(o, workload, totalCost, opts)
+	if err != nil {
+		return nil, err
+	}
+	if sql == "" {
+		unused, err := findUnusedIndexes(ctx, sctx, is, workload)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, unused...)
+	}
+	return recommendations, nil
+}
+
+// loadWorkload parses the queries of the workload and extracts their indexable columns.
+func loadWorkload(sctx sessionctx.Context, is infoschema.InfoSchema, sql string, opts *Options) ([]*query, error) {
+	var stmts []*query
+	if sql != "" {
+		stmts = append(stmts, &query{schema: sctx.GetSessionVars().CurrentDB, text: sql, frequency: 1})
+	} else {
+		for _, stmt := range stmtsummaryv2.GetTopWorkloadStmts(opts.MaxNumQuery) {
+			stmts = append(stmts, &query{schema: stmt.Schema, text: stmt.Query, frequency: max(stmt.ExecCount, 1)})
+		}
+	}
+
+	p := parser.New()
+	p.SetSQLMode(sctx.GetSessionVars().SQLMode)
+	queries := make([]*query, 0, len(stmts))
+	for _, q := range stmts {
+		stmt, err := p.ParseOneStmt(q.text, "", "")
+		if err == nil {
+			switch stmt.(type) {
+			case *ast.SelectStmt, *ast.SetOprStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+			default:
+				err = errors.New("Recommend Index: only SELECT, UPDATE and DELETE statements are supported")
+			}
+		}
+		if err != nil {
+			if sql != "" {
+				return nil, err
+			}
+			continue
+		}
+		q.cols = extractIndexableColumns(is, q.schema, stmt)
+		if len(q.cols) > 0 {
+			queries = append(queries, q)
+		}
+	}
+	return queries, nil
+}
+
+// selectIndexes greedily picks the candidate index which reduces the estimated cost of the workload the most,
+// until no candidate can improve the workload or the number of the recommended indexes reaches the limit.
+func selectIndexes(o *whatIfOptimizer, workload []*query, totalCost float64, opts *Options) ([]*Recommendation, error) {
+	var candidates []*indexCandidate
+	for _, q := range workload {
+		for _, cols := range q.cols {
+			for _, c := range generateCandidates(cols, opts.MaxIndexColumns) {
+				if !slices.ContainsFunc(candidates, func(e *indexCandidate) bool { return e.key() == c.key() }) {
+					candidates = append(candidates, c)
+				}
+			}
+		}
+	}
+
+	var (
+		chosen          []*indexCandidate
+		recommendations []*Recommendation
+	)
+	for len(chosen) < opts.MaxNumIndex && len(candidates) > 0 {
+		var (
+			best      int
+			bestGain  float64
+			bestCosts map[*query]float64
+		)
+		for i, c := range candidates {
+			gain, impactedCost := 0.0, 0.0
+			costs := make(map[*query]float64)
+			for _, q := range workload {
+				if !q.references(c.table) {
+					continue
+				}
+				cost, _, err := o.cost(q, append(slices.Clone(chosen), c))
+				if err != nil {
+					return nil, err
+				}
+				costs[q] = cost
+				gain += float64(q.frequency) * (q.cost - cost)
+				impactedCost += float64(q.frequency) * q.cost
+			}
+			if gain > bestGain && gain >= impactedCost*minImprovementRatio {
+				best, bestGain, bestCosts = i, gain, costs
+			}
+		}
+		if bestCosts == nil {
+			break
+		}
+
+		c := candidates[best]
+		candidates = slices.Delete(candidates, best, best+1)
+		chosen = append(chosen, c)
+		impacted := make([]*ImpactedQuery, 0, len(bestCosts))
+		for q, cost := range bestCosts {
+			if cost < q.cost {
+				impacted = append(impacted, &ImpactedQuery{Query: q.text, Improvement: (q.cost - cost) / q.cost})
+			}
+			q.cost = cost
+		}
+		slices.SortFunc(impacted, func(a, b *ImpactedQuery) int {
+			if r := cmp.Compare(b.Improvement, a.Improvement); r != 0 {
+				return r
+			}
+			return cmp.Compare(a.Query, b.Query)
+		})
+		if len(impacted) > maxImpactedQueries {
+			impacted = impacted[:maxImpactedQueries]
+		}
+		columns := make([]string, 0, len(c.columns))
+		for _, col := range c.columns {
+			columns = append(columns, col.Name.O)
+		}
+		recommendations = append(recommendations, &Recommendation{
+			Schema:             c.table.schema.O,
+			Table:              c.table.tblInfo.Name.O,
+			IndexName:          o.indexName(c),
+			Columns:            columns,
+			Reason:             fmt.Sprintf("estimated workload cost reduced by %.2f%%", bestGain/totalCost*100),
+			TopImpactedQueries: impacted,
+		})
+	}
+	return recommendations, nil
+}
+
+// findUnusedIndexes finds the secondary indexes of the tables referenced by the workload, which are
+// neither used by the plans of the workload nor read by any query in the cluster since the index usage
+// is collected. The indexes required by the foreign keys are never suggested to be dropped.
+func findUnusedIndexes(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, workload []*query) ([]*Recommendation, error) {
+	var (
+		tables []*tableRef
+		used   = make(map[string]struct{})
+	)
+	for _, q := range workload {
+		for _, cols := range q.cols {
+			if !slices.ContainsFunc(tables, func(t *tableRef) bool { return t.key() == cols.table.key() }) {
+				tables = append(tables, cols.table)
+			}
+			for _, idx := range q.usedIndexes {
+				used[cols.table.key()+"."+strings.ToLower(idx)] = struct{}{}
+			}
+		}
+	}
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	window, err := indexUsageWindow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	read, err := readIndexes(ctx, sctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var recommendations []*Recommendation
+	for _, t := range tables {
+		for _, idx := range t.tblInfo.Indices {
+			if idx.Primary || idx.Unique || idx.State != model.StatePublic || idx.Tp == model.IndexTypeHypo {
+				continue
+			}
+			key := t.key() + "." + idx.Name.L
+			if _, ok := used[key]; ok {
+				continue
+			}
+			if _, ok := read[key]; ok {
+				continue
+			}
+			if isForeignKeyIndex(is, t, idx) {
+				continue
+			}
+			columns := make([]string, 0, len(idx.Columns))
+			for _, col := range idx.Columns {
+				columns = append(columns, col.Name.O)
+			}
+			recommendations = append(recommendations, &Recommendation{
+				Schema:    t.schema.O,
+				Table:     t.tblInfo.Name.O,
+				IndexName: idx.Name.O,
+				Columns:   columns,
+				IsDrop:    true,
+				Reason:    fmt.Sprintf("the index is not used by the workload and has not been read in the cluster in the last %s", window),
+			})
+		}
+	}
+	return recommendations, nil
+}
+
+// indexUsageWindow returns how long the index usage has been collected by all the tidb instances,
+// that is, the uptime of the latest started instance, since the index usage is kept in memory.
+func indexUsageWindow(ctx context.Context) (time.Duration, error) {
+	servers, err := infosync.GetAllServerInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var latestStart int64
+	for _, server := range servers {
+		latestStart = max(latestStart, server.StartTimestamp)
+	}
+	return time.Since(time.Unix(latestStart, 0)).Truncate(time.Second), nil
+}
+
+// readIndexes returns the keys of the indexes which have been read by any tidb instance, the keys
+// are like `schema.table.index` in lower case.
+func readIndexes(ctx context.Context, sctx sessionctx.Context) (map[string]struct{}, error) {
+	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		"select distinct lower(table_schema), lower(table_name), lower(index_name) from information_schema.cluster_tidb_index_usage where query_total > 0")
+	if err != nil {
+		return nil, err
+	}
+	read := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		read[row.GetString(0)+"."+row.GetString(1)+"."+row.GetString(2)] = struct{}{}
+	}
+	return read, nil
+}
+
+// isForeignKeyIndex checks whether the index covers the columns of a foreign key of the table, or the
+// referred columns of a foreign key of another table.
+func isForeignKeyIndex(is infoschema.InfoSchema, t *tableRef, idx *model.IndexInfo) bool {
+	for _, fk := range t.tblInfo.ForeignKeys {
+		if model.IsIndexPrefixCovered(t.tblInfo, idx, fk.Cols...) {
+			return true
+		}
+	}
+	for _, referredFK := range is.GetTableReferredForeignKeys(t.schema.L, t.tblInfo.Name.L) {
+		if model.IsIndexPrefixCovered(t.tblInfo, idx, referredFK.Cols...) {
+			return true
+		}
+	}
+	return false
+}
+
+// whatIfOptimizer estimates the costs of the queries with hypothetical indexes.
+type whatIfOptimizer struct {
+	ctx  context.Context
+	sctx sessionctx.Context
+	exec sqlexec.RestrictedSQLExecutor
+
+	names map[*indexCandidate]string
+
+	originalDB             string
+	originalHypoIndexes    map[string]map[string]map[string]*model.IndexInfo
+	originalNonPreparePlan bool
+}
+
+func newWhatIfOptimizer(ctx context.Context, sctx sessionctx.Context) *whatIfOptimizer {
+	vars := sctx.GetSessionVars()
+	o := &whatIfOptimizer{
+		ctx:                    ctx,
+		sctx:                   sctx,
+		exec:                   sctx.(sqlexec.RestrictedSQLExecutor),
+		names:                  make(map[*indexCandidate]string),
+		originalDB:             vars.CurrentDB,
+		originalHypoIndexes:    vars.HypoIndexes,
+		originalNonPreparePlan: vars.EnableNonPreparedPlanCache,
+	}
+	// The plans with different hypothetical indexes must not be shared by the plan cache.
+	vars.EnableNonPreparedPlanCache = false
+	return o
+}
+
+func (o *whatIfOptimizer) close() {
+	vars := o.sctx.GetSessionVars()
+	vars.CurrentDB = o.originalDB
+	vars.HypoIndexes = o.originalHypoIndexes
+	vars.EnableNonPreparedPlanCache = o.originalNonPreparePlan
+}
+
+// indexName returns the name of the hypothetical index, which doesn't conflict with the existing indexes.
+func (o *whatIfOptimizer) indexName(c *indexCandidate) string {
+	if name, ok := o.names[c]; ok {
+		return name
+	}
+	base := "idx"
+	for _, col := range c.columns {
+		base += "_" + col.Name.L
+	}
+	if len(base) > mysql.MaxIndexIdentifierLen-4 {
+		base = base[:mysql.MaxIndexIdentifierLen-4]
+	}
+	name := base
+	for i := 1; ; i++ {
+		conflict := c.table.tblInfo.FindIndexByName(name) != nil
+		for other, otherName := range o.names {
+			if other.table.key() == c.table.key() && otherName == name {
+				conflict = true
+			}
+		}
+		if !conflict {
+			break
+		}
+		name = base + "_" + strconv.Itoa(i)
+	}
+	o.names[c] = name
+	return name
+}
+
+// cost returns the estimated cost of the query and the existing indexes used by the plan
+// when the hypothetical indexes exist.
+func (o *whatIfOptimizer) cost(q *query, hypoIndexes []*indexCandidate) (float64, []string, error) {
+	vars := o.sctx.GetSessionVars()
+	vars.HypoIndexes = nil
+	for _, c := range hypoIndexes {
+		if vars.HypoIndexes == nil {
+			vars.HypoIndexes = make(map[string]map[string]map[string]*model.IndexInfo)
+		}
+		schema, table := c.table.schema.L, c.table.tblInfo.Name.L
+		if vars.HypoIndexes[schema] == nil {
+			vars.HypoIndexes[schema] = make(map[string]map[string]*model.IndexInfo)
+		}
+		if vars.HypoIndexes[schema][table] == nil {
+			vars.HypoIndexes[schema][table] = make(map[string]*model.IndexInfo)
+		}
+		idxInfo := o.hypoIndexInfo(c)
+		vars.HypoIndexes[schema][table][idxInfo.Name.L] = idxInfo
+	}
+	vars.CurrentDB = q.schema
+
+	rows, _, err := o.exec.ExecRestrictedSQL(o.ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession},
+		"explain format='"+types.ExplainFormatVerbose+"' "+q.text)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, nil, errors.Errorf("Recommend Index: no plan for query %s", q.text)
+	}
+	cost, err := strconv.ParseFloat(rows[0].GetString(2), 64)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	var usedIndexes []string
+	for _, row := range rows {
+		// The access object of the index scans is like `table:t, index:idx(a, b)`.
+		accessObject := row.GetString(4)
+		if start := strings.Index(accessObject, "index:"); start >= 0 {
+			name := accessObject[start+len("index:"):]
+			if end := strings.IndexByte(name, '('); end >= 0 {
+				usedIndexes = append(usedIndexes, name[:end])
+			}
+		}
+	}
+	return cost, usedIndexes, nil
+}
+
+func (o *whatIfOptimizer) hypoIndexInfo(c *indexCandidate) *model.IndexInfo {
+	columns := make([]*model.IndexColumn, 0, len(c.columns))
+	for _, col := range c.columns {
+		columns = append(columns, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return &model.IndexInfo{
+		Name:    model.NewCIStr(o.indexName(c)),
+		Table:   c.table.tblInfo.Name,
+		Columns: columns,
+		State:   model.StatePublic,
+		Tp:      model.IndexTypeHypo,
+	}
+}
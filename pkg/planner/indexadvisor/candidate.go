@@ -0,0 +1,406 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+)
+
+// tableRef is a table referenced by a query.
+type tableRef struct {
+	schema  model.CIStr
+	tblInfo *model.TableInfo
+}
+
+func (t *tableRef) key() string {
+	return t.schema.L + "." + t.tblInfo.Name.L
+}
+
+// indexableColumns are the columns of a table which may benefit from an index in one query.
+type indexableColumns struct {
+	table *tableRef
+	// eq are the columns in equal, IN and IS NULL predicates, including the equal join conditions.
+	eq []*model.ColumnInfo
+	// rng are the columns in range predicates.
+	rng []*model.ColumnInfo
+	// order are the columns in ORDER BY and GROUP BY clauses.
+	order []*model.ColumnInfo
+}
+
+func appendColumn(cols []*model.ColumnInfo, col *model.ColumnInfo) []*model.ColumnInfo {
+	if slices.Contains(cols, col) {
+		return cols
+	}
+	return append(cols, col)
+}
+
+// queryAnalyzer extracts the indexable columns of a query.
+type queryAnalyzer struct {
+	is            infoschema.InfoSchema
+	defaultSchema model.CIStr
+
+	// tables maps the table names and aliases to the referenced tables.
+	tables map[string]*tableRef
+	// refs keeps the referenced tables in order to make the result stable.
+	refs    []*tableRef
+	columns map[string]*indexableColumns
+}
+
+// extractIndexableColumns returns the indexable columns of each table referenced by the query.
+// System tables, memory tables, views and tables that can't be found are ignored.
+func extractIndexableColumns(is infoschema.InfoSchema, defaultSchema string, stmt ast.StmtNode) []*indexableColumns {
+	a := &queryAnalyzer{
+		is:            is,
+		defaultSchema: model.NewCIStr(defaultSchema),
+		tables:        make(map[string]*tableRef),
+		columns:       make(map[string]*indexableColumns),
+	}
+	stmt.Accept(&tableCollector{a: a})
+	if len(a.refs) == 0 {
+		return nil
+	}
+	stmt.Accept(&predicateCollector{a: a})
+	result := make([]*indexableColumns, 0, len(a.columns))
+	for _, ref := range a.refs {
+		if cols, ok := a.columns[ref.key()]; ok {
+			result = append(result, cols)
+		}
+	}
+	return result
+}
+
+func (a *queryAnalyzer) addTable(tn *ast.TableName, alias model.CIStr) {
+	schema := tn.Schema
+	if schema.L == "" {
+		schema = a.defaultSchema
+	}
+	if schema.L == "" || util.IsMemOrSysDB(schema.L) {
+		return
+	}
+	tbl, err := a.is.TableByName(schema, tn.Name)
+	if err != nil {
+		return
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return
+	}
+	ref := &tableRef{schema: schema, tblInfo: tblInfo}
+	if idx := slices.IndexFunc(a.refs, func(r *tableRef) bool { return r.key() == ref.key() }); idx >= 0 {
+		ref = a.refs[idx]
+	} else {
+		a.refs = append(a.refs, ref)
+	}
+	if alias.L == "" {
+		alias = tn.Name
+	}
+	a.tables[alias.L] = ref
+}
+
+// resolveColumn finds the table and the column info of the column name.
+func (a *queryAnalyzer) resolveColumn(name *ast.ColumnName) (*tableRef, *model.ColumnInfo) {
+	if name.Table.L != "" {
+		ref, ok := a.tables[name.Table.L]
+		if !ok {
+			return nil, nil
+		}
+		col := ref.tblInfo.FindPublicColumnByName(name.Name.L)
+		if col == nil {
+			return nil, nil
+		}
+		return ref, col
+	}
+	var (
+		found    *tableRef
+		foundCol *model.ColumnInfo
+	)
+	for _, ref := range a.refs {
+		if col := ref.tblInfo.FindPublicColumnByName(name.Name.L); col != nil {
+			if found != nil {
+				// The column is ambiguous, it may come from a derived table or a subquery.
+				return nil, nil
+			}
+			found, foundCol = ref, col
+		}
+	}
+	return found, foundCol
+}
+
+type columnKind int
+
+const (
+	eqColumn columnKind = iota
+	rangeColumn
+	orderColumn
+)
+
+func (a *queryAnalyzer) addColumn(expr ast.ExprNode, kind columnKind) {
+	colExpr, ok := unwrapParentheses(expr).(*ast.ColumnNameExpr)
+	if !ok {
+		return
+	}
+	ref, col := a.resolveColumn(colExpr.Name)
+	if ref == nil || !isIndexableType(col) {
+		return
+	}
+	cols, ok := a.columns[ref.key()]
+	if !ok {
+		cols = &indexableColumns{table: ref}
+		a.columns[ref.key()] = cols
+	}
+	switch kind {
+	case eqColumn:
+		cols.eq = appendColumn(cols.eq, col)
+	case rangeColumn:
+		cols.rng = appendColumn(cols.rng, col)
+	case orderColumn:
+		cols.order = appendColumn(cols.order, col)
+	}
+}
+
+func unwrapParentheses(expr ast.ExprNode) ast.ExprNode {
+	for {
+		p, ok := expr.(*ast.ParenthesesExpr)
+		if !ok {
+			return expr
+		}
+		expr = p.Expr
+	}
+}
+
+func isColumn(expr ast.ExprNode) bool {
+	_, ok := unwrapParentheses(expr).(*ast.ColumnNameExpr)
+	return ok
+}
+
+func isConstant(expr ast.ExprNode) bool {
+	switch unwrapParentheses(expr).(type) {
+	case ast.ValueExpr, ast.ParamMarkerExpr:
+		return true
+	}
+	return false
+}
+
+// isIndexableType returns whether the column can be indexed without a prefix length.
+func isIndexableType(col *model.ColumnInfo) bool {
+	tp := col.GetType()
+	return !types.IsTypeBlob(tp) && tp != mysql.TypeJSON && tp != mysql.TypeGeometry
+}
+
+// tableCollector collects the tables referenced by the query.
+type tableCollector struct {
+	a *queryAnalyzer
+}
+
+// Enter implements ast.Visitor interface.
+func (c *tableCollector) Enter(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.TableSource); ok {
+		if tn, ok := x.Source.(*ast.TableName); ok {
+			c.a.addTable(tn, x.AsName)
+		}
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*tableCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// predicateCollector collects the indexable columns in the WHERE, ON, GROUP BY and ORDER BY clauses.
+type predicateCollector struct {
+	a *queryAnalyzer
+}
+
+// Enter implements ast.Visitor interface.
+func (c *predicateCollector) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SelectStmt:
+		if x.From != nil {
+			x.From.Accept(c)
+		}
+		c.visit(x.Where)
+		if x.Having != nil {
+			c.visit(x.Having.Expr)
+		}
+		if x.GroupBy != nil {
+			c.addByItems(x.GroupBy.Items)
+		}
+		if x.OrderBy != nil {
+			c.addByItems(x.OrderBy.Items)
+		}
+		// The subqueries in the field list are skipped.
+		return in, true
+	case *ast.UpdateStmt:
+		x.TableRefs.Accept(c)
+		c.visit(x.Where)
+		if x.Order != nil {
+			c.addByItems(x.Order.Items)
+		}
+		return in, true
+	case *ast.DeleteStmt:
+		x.TableRefs.Accept(c)
+		c.visit(x.Where)
+		if x.Order != nil {
+			c.addByItems(x.Order.Items)
+		}
+		return in, true
+	case *ast.BinaryOperationExpr:
+		switch x.Op {
+		case opcode.EQ, opcode.NullEQ:
+			if isColumn(x.L) && (isColumn(x.R) || isConstant(x.R)) {
+				c.a.addColumn(x.L, eqColumn)
+			}
+			if isColumn(x.R) && (isColumn(x.L) || isConstant(x.L)) {
+				c.a.addColumn(x.R, eqColumn)
+			}
+		case opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+			if isConstant(x.R) {
+				c.a.addColumn(x.L, rangeColumn)
+			}
+			if isConstant(x.L) {
+				c.a.addColumn(x.R, rangeColumn)
+			}
+		}
+	case *ast.PatternInExpr:
+		if !x.Not && x.Sel == nil {
+			c.a.addColumn(x.Expr, eqColumn)
+		}
+	case *ast.IsNullExpr:
+		if !x.Not {
+			c.a.addColumn(x.Expr, eqColumn)
+		}
+	case *ast.BetweenExpr:
+		if !x.Not && isConstant(x.Left) && isConstant(x.Right) {
+			c.a.addColumn(x.Expr, rangeColumn)
+		}
+	case *ast.PatternLikeOrIlikeExpr:
+		// Only `LIKE 'prefix%'` can be converted to a range.
+		if v, ok := unwrapParentheses(x.Pattern).(ast.ValueExpr); ok && x.IsLike && !x.Not {
+			pattern := v.GetString()
+			if pattern != "" && pattern[0] != '%' && pattern[0] != '_' {
+				c.a.addColumn(x.Expr, rangeColumn)
+			}
+		}
+	}
+	return in, false
+}
+
+func (c *predicateCollector) visit(expr ast.ExprNode) {
+	if expr != nil {
+		expr.Accept(c)
+	}
+}
+
+func (c *predicateCollector) addByItems(items []*ast.ByItem) {
+	for _, item := range items {
+		c.a.addColumn(item.Expr, orderColumn)
+	}
+}
+
+// Leave implements ast.Visitor interface.
+func (*predicateCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// indexCandidate is a hypothetical index to be evaluated by the cost model.
+type indexCandidate struct {
+	table   *tableRef
+	columns []*model.ColumnInfo
+}
+
+func (c *indexCandidate) key() string {
+	return c.table.key() + "(" + c.columnNames() + ")"
+}
+
+func (c *indexCandidate) columnNames() string {
+	names := make([]string, 0, len(c.columns))
+	for _, col := range c.columns {
+		names = append(names, col.Name.O)
+	}
+	return strings.Join(names, ",")
+}
+
+// generateCandidates generates the candidate indexes from the indexable columns of a query:
+// an index for each single column, and a composite index that starts with the equal columns
+// and ends with a range or an ordering column.
+func generateCandidates(cols *indexableColumns, maxIndexColumns int) []*indexCandidate {
+	candidates := make([]*indexCandidate, 0, len(cols.eq)+len(cols.rng)+len(cols.order)+1)
+	for _, group := range [][]*model.ColumnInfo{cols.eq, cols.rng, cols.order} {
+		for _, col := range group {
+			candidates = append(candidates, &indexCandidate{table: cols.table, columns: []*model.ColumnInfo{col}})
+		}
+	}
+	composite := slices.Clone(cols.eq)
+	for _, group := range [][]*model.ColumnInfo{cols.rng, cols.order} {
+		for _, col := range group {
+			if !slices.Contains(composite, col) {
+				composite = append(composite, col)
+				break
+			}
+		}
+		if len(composite) > len(cols.eq) {
+			break
+		}
+	}
+	if len(composite) > maxIndexColumns {
+		composite = composite[:maxIndexColumns]
+	}
+	if len(composite) > 1 {
+		candidates = append(candidates, &indexCandidate{table: cols.table, columns: composite})
+	}
+
+	result := candidates[:0]
+	for _, c := range candidates {
+		if !isCoveredByExistingIndex(c) && !slices.ContainsFunc(result, func(r *indexCandidate) bool { return r.key() == c.key() }) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// isCoveredByExistingIndex returns whether the candidate is a prefix of an existing index,
+// in which case the existing index can serve the same access paths.
+func isCoveredByExistingIndex(c *indexCandidate) bool {
+	tblInfo := c.table.tblInfo
+	if tblInfo.PKIsHandle && len(c.columns) == 1 && mysql.HasPriKeyFlag(c.columns[0].GetFlag()) {
+		return true
+	}
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic || len(idx.Columns) < len(c.columns) {
+			continue
+		}
+		covered := true
+		for i, col := range c.columns {
+			if idx.Columns[i].Name.L != col.Name.L || idx.Columns[i].Length != types.UnspecifiedLength {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTableInfo(id int64, name string, cols []string, tps []byte, idxCols ...string) *model.TableInfo {
+	tblInfo := &model.TableInfo{ID: id, Name: model.NewCIStr(name), State: model.StatePublic}
+	for i, col := range cols {
+		colInfo := &model.ColumnInfo{ID: int64(i + 1), Name: model.NewCIStr(col), Offset: i, State: model.StatePublic}
+		colInfo.FieldType = *types.NewFieldType(tps[i])
+		tblInfo.Columns = append(tblInfo.Columns, colInfo)
+	}
+	for i, col := range idxCols {
+		tblInfo.Indices = append(tblInfo.Indices, &model.IndexInfo{
+			ID:      int64(i + 1),
+			Name:    model.NewCIStr("idx_" + col),
+			State:   model.StatePublic,
+			Columns: []*model.IndexColumn{{Name: model.NewCIStr(col), Length: types.UnspecifiedLength}},
+		})
+	}
+	return tblInfo
+}
+
+func TestExtractIndexableColumns(t *testing.T) {
+	is := infoschema.MockInfoSchema([]*model.TableInfo{
+		mockTableInfo(1, "t1", []string{"a", "b", "c", "d"}, []byte{mysql.TypeLong, mysql.TypeLong, mysql.TypeVarchar, mysql.TypeBlob}, "c"),
+		mockTableInfo(2, "t2", []string{"a", "e"}, []byte{mysql.TypeLong, mysql.TypeLong}),
+	})
+	colNames := func(cols []*model.ColumnInfo) []string {
+		names := make([]string, 0, len(cols))
+		for _, col := range cols {
+			names = append(names, col.Name.L)
+		}
+		return names
+	}
+
+	tests := []struct {
+		sql   string
+		table []string
+		eq    [][]string
+		rng   [][]string
+		order [][]string
+	}{
+		{
+			sql:   "select * from t1 where a = 1 and b > 2 order by c",
+			table: []string{"test.t1"},
+			eq:    [][]string{{"a"}},
+			rng:   [][]string{{"b"}},
+			order: [][]string{{"c"}},
+		},
+		{
+			sql:   "select * from t1 x join test.t2 on x.a = t2.a where e in (1, 2) and (d = 'x' or c like 'ab%')",
+			table: []string{"test.t1", "test.t2"},
+			eq:    [][]string{{"a"}, {"a", "e"}},
+			rng:   [][]string{{"c"}, nil},
+			order: [][]string{nil, nil},
+		},
+		{
+			sql:   "update t1 set a = 1 where b between 1 and 10 and c like '%a' and a is null",
+			table: []string{"test.t1"},
+			eq:    [][]string{{"a"}},
+			rng:   [][]string{{"b"}},
+			order: [][]string{nil},
+		},
+		{
+			sql:   "delete from t2 where e = 1 and a in (select b from t1 where c = 'x')",
+			table: []string{"test.t2", "test.t1"},
+			eq:    [][]string{{"e"}, {"c"}},
+			rng:   [][]string{nil, nil},
+			order: [][]string{nil, nil},
+		},
+		{
+			sql: "select * from mysql.user where user = 'root'",
+		},
+	}
+	p := parser.New()
+	for _, tt := range tests {
+		stmt, err := p.ParseOneStmt(tt.sql, "", "")
+		require.NoError(t, err)
+		result := extractIndexableColumns(is, "test", stmt)
+		require.Len(t, result, len(tt.table), tt.sql)
+		for i, cols := range result {
+			require.Equal(t, tt.table[i], cols.table.key(), tt.sql)
+			require.Equal(t, tt.eq[i], nilIfEmpty(colNames(cols.eq)), tt.sql)
+			require.Equal(t, tt.rng[i], nilIfEmpty(colNames(cols.rng)), tt.sql)
+			require.Equal(t, tt.order[i], nilIfEmpty(colNames(cols.order)), tt.sql)
+		}
+	}
+}
+
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func TestGenerateCandidates(t *testing.T) {
+	is := infoschema.MockInfoSchema([]*model.TableInfo{
+		mockTableInfo(1, "t1", []string{"a", "b", "c", "d"}, []byte{mysql.TypeLong, mysql.TypeLong, mysql.TypeLong, mysql.TypeLong}, "c"),
+	})
+	p := parser.New()
+	candidates := func(sql string, maxIndexColumns int) []string {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		result := extractIndexableColumns(is, "test", stmt)
+		require.Len(t, result, 1)
+		var keys []string
+		for _, c := range generateCandidates(result[0], maxIndexColumns) {
+			keys = append(keys, c.columnNames())
+		}
+		return keys
+	}
+
+	require.Equal(t, []string{"a", "b", "d", "a,b,d"}, candidates("select * from t1 where a = 1 and b = 1 and d > 1", 3))
+	require.Equal(t, []string{"a", "b", "d", "a,b"}, candidates("select * from t1 where a = 1 and b = 1 and d > 1 and d < 10", 2))
+	// The candidates covered by the existing index idx_c are pruned.
+	require.Equal(t, []string{"a", "c,a"}, candidates("select * from t1 where c = 1 order by a", 3))
+	require.Equal(t, []string{"a"}, candidates("select * from t1 where a = 1 order by a", 3))
+}
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions(nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultOptions(), opts)
+
+	opts, err = ParseOptions([]*ast.RecommendIndexOption{{Name: "max_num_index", Value: 3}, {Name: "max_index_columns", Value: 2}})
+	require.NoError(t, err)
+	require.Equal(t, &Options{MaxNumIndex: 3, MaxIndexColumns: 2, MaxNumQuery: 1000}, opts)
+
+	_, err = ParseOptions([]*ast.RecommendIndexOption{{Name: "max_index_columns", Value: 17}})
+	require.EqualError(t, err, "Recommend Index: option max_index_columns should be in [1, 16]")
+	_, err = ParseOptions([]*ast.RecommendIndexOption{{Name: "unknown", Value: 1}})
+	require.EqualError(t, err, "Recommend Index: unknown option unknown")
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+const (
+	optMaxNumIndex     = "max_num_index"
+	optMaxIndexColumns = "max_index_columns"
+	optMaxNumQuery     = "max_num_query"
+)
+
+// Options are the options of the index advisor.
+type Options struct {
+	// MaxNumIndex is the maximum number of indexes to recommend.
+	MaxNumIndex int
+	// MaxIndexColumns is the maximum number of columns of a recommended index.
+	MaxIndexColumns int
+	// MaxNumQuery is the maximum number of queries read from the statement summary.
+	MaxNumQuery int
+}
+
+// DefaultOptions returns the default options of the index advisor.
+func DefaultOptions() *Options {
+	return &Options{
+		MaxNumIndex:     5,
+		MaxIndexColumns: 3,
+		MaxNumQuery:     1000,
+	}
+}
+
+// ParseOptions builds the options from the WITH clause of the RECOMMEND INDEX statement.
+func ParseOptions(opts []*ast.RecommendIndexOption) (*Options, error) {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		var target *int
+		var upper uint64
+		switch opt.Name {
+		case optMaxNumIndex:
+			target, upper = &options.MaxNumIndex, 64
+		case optMaxIndexColumns:
+			target, upper = &options.MaxIndexColumns, mysql.MaxKeyParts
+		case optMaxNumQuery:
+			target, upper = &options.MaxNumQuery, 5000
+		default:
+			return nil, errors.Errorf("Recommend Index: unknown option %s", opt.Name)
+		}
+		if opt.Value < 1 || opt.Value > upper {
+			return nil, errors.Errorf("Recommend Index: option %s should be in [1, %d]", opt.Name, upper)
+		}
+		*target = int(opt.Value)
+	}
+	return options, nil
+}
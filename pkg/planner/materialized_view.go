@@ -0,0 +1,119 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package planner
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	"github.com/pingcap/tidb/pkg/util/mview"
+)
+
+// materializedViewQuery returns the SELECT statement which may be answered by the materialized
+// views of its table and its text. The text is restored before the plan is built since building
+// the plan modifies the statement.
+func materializedViewQuery(sctx sessionctx.Context, node ast.Node) (*ast.TableName, string) {
+	sessVars := sctx.GetSessionVars()
+	if !sessVars.EnableMaterializedViewRewrite || sessVars.InRestrictedSQL {
+		return nil, ""
+	}
+	if explain, ok := node.(*ast.ExplainStmt); ok {
+		node = explain.Stmt
+	}
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil, ""
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, ""
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.TableInfo == nil || len(tn.TableInfo.MaterializedViews) == 0 {
+		return nil, ""
+	}
+	// The materialized views don't see the changes of the current transaction.
+	if _, ok := sessVars.TxnCtx.TableDeltaMap[tn.TableInfo.ID]; ok {
+		return nil, ""
+	}
+	var sb strings.Builder
+	if err := sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, ""
+	}
+	return tn, sb.String()
+}
+
+// buildByMaterializedView builds the plan of the statement whose SELECT statement is answered by
+// a materialized view of the table. It returns a nil plan if no materialized view can answer it,
+// otherwise the returned builder should be put back to the pool after the plan is optimized.
+func buildByMaterializedView(ctx context.Context, sctx sessionctx.Context, node ast.Node, tn *ast.TableName,
+	text string, is infoschema.InfoSchema) (core.Plan, *core.PlanBuilder) {
+	sessVars := sctx.GetSessionVars()
+	checker := privilege.GetPrivilegeManager(sctx)
+	for _, ref := range tn.TableInfo.MaterializedViews {
+		view, ok := is.TableByID(ref.ViewID)
+		if !ok || !view.Meta().IsMaterializedView() {
+			continue
+		}
+		viewSchema, ok := is.SchemaByTable(view.Meta())
+		if !ok {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(sessVars.ActiveRoles, viewSchema.Name.L, view.Meta().Name.L, "", mysql.SelectPriv) {
+			continue
+		}
+		defSel, err := mview.Parse(view.Meta().MaterializedView.SelectStmt)
+		if err != nil {
+			continue
+		}
+		def, err := mview.Analyze(defSel)
+		if err != nil {
+			continue
+		}
+		sel, err := mview.Parse(text)
+		if err != nil || !mview.RewriteQuery(sel, sessVars.CurrentDB, def, viewSchema.Name, view.Meta()) {
+			continue
+		}
+
+		var stmt ast.StmtNode = sel
+		if explain, ok := node.(*ast.ExplainStmt); ok {
+			stmt = &ast.ExplainStmt{Stmt: sel, Format: explain.Format, Analyze: explain.Analyze}
+		}
+		if err = core.Preprocess(ctx, sctx, stmt, core.WithPreprocessorReturn(&core.PreprocessorReturn{InfoSchema: is})); err != nil {
+			continue
+		}
+		hintProcessor := hint.NewQBHintHandler(sctx)
+		stmt.Accept(hintProcessor)
+		builder := planBuilderPool.Get().(*core.PlanBuilder)
+		builder.Init(sctx, is, hintProcessor)
+		p, err := buildLogicalPlan(ctx, sctx, stmt, builder)
+		if err != nil {
+			planBuilderPool.Put(builder.ResetForReuse())
+			continue
+		}
+		sessVars.StmtCtx.SetSkipPlanCache(errors.New("query is answered by a materialized view"))
+		return p, builder
+	}
+	return nil, nil
+}
@@ -458,6 +458,8 @@ func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		defer debugtrace.LeaveContextCommon(sctx)
 	}
 
+	mvTable, mvText := materializedViewQuery(sctx, node)
+
 	// build logical plan
 	hintProcessor := hint.NewQBHintHandler(sctx)
 	node.Accept(hintProcessor)
@@ -487,6 +489,17 @@ func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 
 	names := p.OutputNames()
 
+	// Answer the query by a materialized view after the privileges of the query are checked,
+	// the names of the output columns are kept.
+	if mvTable != nil {
+		if mvPlan, mvBuilder := buildByMaterializedView(ctx, sctx, node, mvTable, mvText, is); mvPlan != nil {
+			defer planBuilderPool.Put(mvBuilder.ResetForReuse())
+			if len(mvPlan.OutputNames()) == len(names) {
+				p, builder = mvPlan, mvBuilder
+			}
+		}
+	}
+
 	// Handle the non-logical plan statement.
 	logic, isLogicalPlan := p.(core.LogicalPlan)
 	if !isLogicalPlan {
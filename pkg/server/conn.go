@@ -2052,6 +2052,9 @@ func (cc *clientConn) handleStmt(
 		if cc.getStatus() == connStatusShutdown {
 			return false, exeerrors.ErrQueryInterrupted
 		}
+		if mrs, ok := rs.(resultset.MultiResultSet); ok {
+			return false, cc.writeMultiResultSet(ctx, stmt, mrs, false, status)
+		}
 		if retryable, err := cc.writeResultSet(ctx, rs, false, status, 0); err != nil {
 			return retryable, err
 		}
@@ -2266,6 +2269,44 @@ func (cc *clientConn) writeResultSet(ctx context.Context, rs resultset.ResultSet
 	return false, cc.flush(ctx)
 }
 
+// writeMultiResultSet writes the result sets one by one, e.g. the results of a stored procedure.
+// Like MySQL, an OK packet is written at last to report the status of the whole statement, and
+// the client must set CLIENT_MULTI_RESULTS to receive them.
+func (cc *clientConn) writeMultiResultSet(ctx context.Context, stmt ast.StmtNode, rs resultset.MultiResultSet, binary bool, serverStatus uint16) error {
+	if cc.capability&mysql.ClientMultiResults == 0 {
+		return exeerrors.ErrSpBadselect.GenWithStackByArgs(cc.procedureNameOf(stmt))
+	}
+	for {
+		if _, err := cc.writeResultSet(ctx, rs, binary, serverStatus|mysql.ServerMoreResultsExists, 0); err != nil {
+			return err
+		}
+		if !rs.NextResultSet() {
+			break
+		}
+	}
+	return cc.writeOkWith(ctx, mysql.OKHeader, true, serverStatus)
+}
+
+// procedureNameOf returns the name of the procedure called by the CALL statement, or by the
+// EXECUTE statement of a prepared CALL statement.
+func (cc *clientConn) procedureNameOf(stmt ast.StmtNode) string {
+	vars := cc.ctx.GetSessionVars()
+	if execStmt, ok := stmt.(*ast.ExecuteStmt); ok {
+		if prepStmt, err := plannercore.GetPreparedStmt(execStmt, vars); err == nil {
+			stmt = prepStmt.PreparedAst.Stmt
+		}
+	}
+	callStmt, ok := stmt.(*ast.CallStmt)
+	if !ok {
+		return ""
+	}
+	schema := callStmt.Procedure.Schema.O
+	if schema == "" {
+		schema = vars.CurrentDB
+	}
+	return schema + "." + callStmt.Procedure.FnName.O
+}
+
 func (cc *clientConn) writeColumnInfo(columns []*column.Info) error {
 	data := cc.alloc.AllocWithLen(4, 1024)
 	data = dump.LengthEncodedInt(data, uint64(len(columns)))
@@ -416,6 +416,12 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 
 		return false, cc.flush(ctx)
 	}
+	if mrs, ok := rs.(resultset.MultiResultSet); ok {
+		if err = cc.writeMultiResultSet(ctx, execStmt, mrs, true, cc.ctx.Status()); err != nil {
+			return false, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
+		}
+		return false, nil
+	}
 	retryable, err := cc.writeResultSet(ctx, rs, true, cc.ctx.Status(), 0)
 	if err != nil {
 		return retryable, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
@@ -1741,6 +1741,45 @@ func TestOkEof(t *testing.T) {
 	require.Equal(t, []byte{0x7, 0x0, 0x0, 0x1, 0xfe, 0x0, 0x0, 0x2, 0x0, 0x0, 0x0}, outBuffer.Bytes())
 }
 
+func TestCallProcedureMultiResults(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	var outBuffer bytes.Buffer
+	tidbdrv := NewTiDBDriver(store)
+	cfg := serverutil.NewTestConfig()
+	cfg.Port, cfg.Status.StatusPort = 0, 0
+	cfg.Status.ReportStatus = false
+	server, err := NewServer(cfg, tidbdrv)
+	require.NoError(t, err)
+	defer server.Close()
+
+	cc := &clientConn{
+		connectionID: 1,
+		server:       server,
+		pkt:          internal.NewPacketIOForTest(bufio.NewWriter(&outBuffer)),
+		collation:    mysql.DefaultCollationID,
+		peerHost:     "localhost",
+		alloc:        arena.NewAllocator(512),
+		chunkAlloc:   chunk.NewAllocator(),
+		capability:   mysql.ClientProtocol41,
+	}
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p() begin select 1; select 2; end")
+	cc.SetCtx(&TiDBContext{Session: tk.Session()})
+
+	// The result sets can't be returned if the client doesn't set CLIENT_MULTI_RESULTS.
+	err = cc.handleQuery(context.Background(), "call p()")
+	require.True(t, exeerrors.ErrSpBadselect.Equal(err))
+	require.ErrorContains(t, err, "PROCEDURE test.p can't return a result set in the given context")
+
+	outBuffer.Reset()
+	cc.capability |= mysql.ClientMultiResults
+	require.NoError(t, cc.handleQuery(context.Background(), "call p()"))
+	require.NoError(t, cc.flush(context.Background()))
+	require.NotEmpty(t, outBuffer.Bytes())
+}
+
 func TestExtensionChangeUser(t *testing.T) {
 	defer extension.Reset()
 	extension.Reset()
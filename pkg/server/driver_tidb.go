@@ -288,9 +288,10 @@ func (tc *TiDBContext) ExecuteStmt(ctx context.Context, stmt ast.StmtNode) (resu
 	if err = tc.checkSandBoxMode(stmt); err != nil {
 		return nil, err
 	}
-	if s, ok := stmt.(*ast.NonTransactionalDMLStmt); ok {
+	switch s := stmt.(type) {
+	case *ast.NonTransactionalDMLStmt:
 		rs, err = session.HandleNonTransactionalDML(ctx, s, tc.Session)
-	} else {
+	default:
 		rs, err = tc.Session.ExecuteStmt(ctx, stmt)
 	}
 	if err != nil {
@@ -134,7 +134,7 @@ func (d *ResultEncoder) EncodeWith(src []byte, enc charset.Encoding) []byte {
 func isStringColumnType(tp byte) bool {
 	switch tp {
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
-		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeGeometry,
 		mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON:
 		return true
 	}
@@ -39,14 +39,26 @@ type ResultSet interface {
 	Finish() error
 }
 
+// MultiResultSet is a result set which contains several result sets, e.g. the results of a stored procedure.
+type MultiResultSet interface {
+	ResultSet
+	// NextResultSet switches to the next result set, it returns false if there is no more result set.
+	NextResultSet() bool
+}
+
 var _ ResultSet = &tidbResultSet{}
+var _ MultiResultSet = &tidbMultiResultSet{}
 
 // New creates a new result set
 func New(recordSet sqlexec.RecordSet, preparedStmt *core.PlanCacheStmt) ResultSet {
-	return &tidbResultSet{
+	trs := &tidbResultSet{
 		recordSet:    recordSet,
 		preparedStmt: preparedStmt,
 	}
+	if _, ok := recordSet.(sqlexec.MultiRecordSet); ok {
+		return &tidbMultiResultSet{trs}
+	}
+	return trs
 }
 
 type tidbResultSet struct {
@@ -130,3 +142,16 @@ func (trs *tidbResultSet) FieldTypes() []*types.FieldType {
 func (trs *tidbResultSet) SetPreparedStmt(stmt *core.PlanCacheStmt) {
 	trs.preparedStmt = stmt
 }
+
+type tidbMultiResultSet struct {
+	*tidbResultSet
+}
+
+// NextResultSet implements MultiResultSet.NextResultSet interface.
+func (trs *tidbMultiResultSet) NextResultSet() bool {
+	if !trs.recordSet.(sqlexec.MultiRecordSet).NextRecordSet() {
+		return false
+	}
+	trs.columns = nil
+	return true
+}
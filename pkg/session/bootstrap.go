@@ -706,6 +706,23 @@ const (
 		PRIMARY KEY (id),
 		KEY (created_by),
 		KEY (status));`
+
+	// CreateRoutinesTable stores the definitions of the stored routines.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		routine_schema VARCHAR(64) NOT NULL,
+		routine_name VARCHAR(64) NOT NULL,
+		routine_type ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		param_list BLOB NOT NULL,
+		body LONGBLOB NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		sql_mode VARCHAR(1024) NOT NULL,
+		character_set_client VARCHAR(32) NOT NULL,
+		collation_connection VARCHAR(32) NOT NULL,
+		db_collation VARCHAR(32) NOT NULL,
+		created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (routine_schema, routine_name, routine_type)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1063,11 +1080,15 @@ const (
 	// version 183
 	//   replace `mysql.tidb_mdl_view` table
 	version183 = 183
+
+	// version 184
+	//   add new system table `mysql.routines`, which is used to store the stored procedures.
+	version184 = 184
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version183
+var currentBootstrapVersion int64 = version184
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1226,6 +1247,7 @@ var (
 		upgradeToVer181,
 		upgradeToVer182,
 		upgradeToVer183,
+		upgradeToVer184,
 	}
 )
 
@@ -2989,6 +3011,13 @@ func upgradeToVer183(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, CreateMDLView)
 }
 
+func upgradeToVer184(s sessiontypes.Session, ver int64) {
+	if ver >= version184 {
+		return
+	}
+	doReentrantDDL(s, CreateRoutinesTable)
+}
+
 func writeOOMAction(s sessiontypes.Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -3120,6 +3149,8 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateDistFrameworkMeta)
 	// create request_unit_by_group
 	mustExecute(s, CreateRequestUnitByGroupTable)
+	// create routines
+	mustExecute(s, CreateRoutinesTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
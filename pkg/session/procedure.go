@@ -0,0 +1,1163 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/extension"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/privilege/privileges"
+	sessiontypes "github.com/pingcap/tidb/pkg/session/types"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// handleCallProcedure executes the stored procedure called by the CALL statement.
+// The statements of the procedure body are executed one by one in the session with the
+// privileges of the definer, the result sets produced by them are returned together as
+// a sqlexec.MultiRecordSet. The parameter markers in the arguments of a prepared CALL
+// statement are replaced by the params during the execution.
+func handleCallProcedure(ctx context.Context, stmt *ast.CallStmt, se sessiontypes.Session, params []types.Datum) (sqlexec.RecordSet, error) {
+	args := stmt.Procedure.Args
+	if len(params) > 0 {
+		sub := &paramMarkerSubstitutor{params: params, replaced: make(map[*driver.ValueExpr]*driver.ParamMarkerExpr)}
+		replaceArgs := func() {
+			for i, arg := range args {
+				node, _ := arg.Accept(sub)
+				args[i] = node.(ast.ExprNode)
+			}
+		}
+		replaceArgs()
+		defer func() {
+			// Put the parameter markers back, the prepared statement may be executed again.
+			sub.restore = true
+			replaceArgs()
+		}()
+	}
+
+	vars := se.GetSessionVars()
+	schema := stmt.Procedure.Schema.O
+	if schema == "" {
+		schema = vars.CurrentDB
+	}
+	if schema == "" {
+		return nil, core.ErrNoDB
+	}
+	name := stmt.Procedure.FnName.O
+	// In a nested CALL, the privilege manager of the definer of the outer procedure is checked.
+	if checker := privilege.GetPrivilegeManager(se); checker != nil && vars.User != nil &&
+		!checker.RequestVerification(vars.ActiveRoles, strings.ToLower(schema), "", "", mysql.ExecutePriv) {
+		return nil, exeerrors.ErrProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, schema+"."+name)
+	}
+	proc, err := executor.GetStoredProcedure(ctx, se, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	if proc == nil {
+		return nil, exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", schema+"."+name)
+	}
+	parsed, err := getParsedProcedure(se, proc)
+	if err != nil {
+		return nil, err
+	}
+	info := parsed.info
+	fullName := proc.Schema + "." + proc.Name
+	if len(args) != len(info.ProcedureParam) {
+		return nil, exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(info.ProcedureParam), len(args))
+	}
+	outVars := make([]string, len(args))
+	for i, param := range info.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		v, ok := args[i].(*ast.VariableExpr)
+		if !ok || v.IsSystem {
+			return nil, exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+		}
+		outVars[i] = strings.ToLower(v.Name)
+	}
+
+	// A procedure calling itself directly or indirectly is limited by max_sp_recursion_depth. The
+	// recursive calls don't share the statements cached in the procedure, because the values bound
+	// to them are still used by the outer calls.
+	stmts := parsed.stmts
+	active := activeProcedures(se)
+	key := procedureKey(proc)
+	if depth := active[key]; depth > 0 {
+		val, err := vars.GetSessionOrGlobalSystemVar(ctx, variable.MaxSpRecursionDepth)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(depth) > limit {
+			return nil, exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(limit, proc.Name)
+		}
+		stmts = make(map[ast.Node]*procedureStmt)
+	}
+	active[key]++
+	defer func() {
+		if active[key]--; active[key] == 0 {
+			delete(active, key)
+		}
+	}()
+
+	e, err := newProcedureExec(se, proc, stmts)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := e.call(ctx, proc, info, args, outVars)
+	if err != nil || rs == nil {
+		e.memTracker.Detach()
+	}
+	return rs, err
+}
+
+// call executes the body of the procedure with the arguments, the OUT and INOUT parameters are
+// written back to the user variables.
+func (e *procedureExec) call(ctx context.Context, proc *executor.StoredProcedure, info *ast.ProcedureInfo,
+	args []ast.ExprNode, outVars []string) (sqlexec.RecordSet, error) {
+	vars := e.se.GetSessionVars()
+	// The arguments are evaluated in the context of the caller. They are not cached in the
+	// procedure like the statements in the body, because they belong to the caller.
+	var argVals []types.Datum
+	if len(args) > 0 {
+		sql, err := selectSQL(args)
+		if err != nil {
+			return nil, err
+		}
+		ps, err := e.parse(sql)
+		if err != nil {
+			return nil, err
+		}
+		if argVals, err = e.evalSelect(ctx, ps); err != nil {
+			return nil, err
+		}
+	}
+	params := newProcedureScope()
+	for i, param := range info.ProcedureParam {
+		if param.Paramstatus == ast.MODE_OUT {
+			argVals[i].SetNull()
+		}
+		v, err := e.newVar(param.ParamType, argVals[i])
+		if err != nil {
+			return nil, err
+		}
+		params.vars[strings.ToLower(param.ParamName)] = v
+	}
+	e.scopes = append(e.scopes, params)
+
+	if err := e.execBody(ctx, proc, info.ProcedureBody); err != nil {
+		return nil, err
+	}
+
+	for i, param := range info.ProcedureParam {
+		if outVars[i] == "" {
+			continue
+		}
+		v := params.vars[strings.ToLower(param.ParamName)]
+		if v.val.IsNull() {
+			vars.UnsetUserVar(outVars[i])
+			continue
+		}
+		vars.SetUserVarVal(outVars[i], v.val)
+		vars.SetUserVarType(outVars[i], v.tp)
+	}
+	if len(e.results) == 0 {
+		return nil, nil
+	}
+	return &procedureRecordSet{results: e.results, maxChunkSize: vars.MaxChunkSize, memTracker: e.memTracker}, nil
+}
+
+// execBody executes the body of the procedure as the definer, with the SQL mode of the procedure
+// and its schema as the current database. They are restored even if the execution panics.
+func (e *procedureExec) execBody(ctx context.Context, proc *executor.StoredProcedure, body ast.StmtNode) error {
+	vars := e.se.GetSessionVars()
+	switchBack, err := switchToDefiner(e.se, proc)
+	if err != nil {
+		return err
+	}
+	defer switchBack()
+	oldSQLMode, oldDB := vars.SQLMode, vars.CurrentDB
+	defer func() {
+		vars.SQLMode, vars.CurrentDB = oldSQLMode, oldDB
+	}()
+	vars.SQLMode, vars.CurrentDB = e.sqlMode, proc.Schema
+	return e.execStmt(ctx, body)
+}
+
+// switchToDefiner switches the security context to the definer of the procedure like
+// SQL SECURITY DEFINER in MySQL, so that the statements in the body are checked against the
+// privileges of the definer. It returns a function to switch back to the invoker.
+func switchToDefiner(se sessiontypes.Session, proc *executor.StoredProcedure) (func(), error) {
+	vars := se.GetSessionVars()
+	pm := privilege.GetPrivilegeManager(se)
+	definer := proc.DefinerIdentity()
+	dom := domain.GetDomain(se)
+	if pm == nil || definer == nil || dom == nil {
+		return func() {}, nil
+	}
+	extensions, err := extension.GetExtensions()
+	if err != nil {
+		return nil, err
+	}
+	definerPM := privileges.NewUserPrivileges(dom.PrivilegeHandle(), extensions)
+	definerPM.AuthSuccess(definer.AuthUsername, definer.AuthHostname)
+	oldUser, oldRoles := vars.User, vars.ActiveRoles
+	user := *definer
+	if oldUser != nil {
+		// USER() still returns the invoker, while CURRENT_USER() returns the definer.
+		user.Username, user.Hostname = oldUser.Username, oldUser.Hostname
+	}
+	privilege.BindPrivilegeManager(se, definerPM)
+	vars.User, vars.ActiveRoles = &user, definerPM.GetDefaultRoles(definer.AuthUsername, definer.AuthHostname)
+	return func() {
+		privilege.BindPrivilegeManager(se, pm)
+		vars.User, vars.ActiveRoles = oldUser, oldRoles
+	}, nil
+}
+
+type procedureCacheKeyType int
+
+func (procedureCacheKeyType) String() string {
+	return "procedure_cache"
+}
+
+const (
+	// procedureCacheKey is the key of the procedures parsed by the session.
+	procedureCacheKey procedureCacheKeyType = iota
+	// activeProceduresKey is the key of the number of the running calls of each procedure in the session.
+	activeProceduresKey
+)
+
+func procedureKey(proc *executor.StoredProcedure) string {
+	return strings.ToLower(proc.Schema) + "." + strings.ToLower(proc.Name)
+}
+
+// activeProcedures returns the number of the running calls of each procedure in the session,
+// it's used to limit the depth of the recursive calls.
+func activeProcedures(se sessiontypes.Session) map[string]int {
+	active, _ := se.Value(activeProceduresKey).(map[string]int)
+	if active == nil {
+		active = make(map[string]int)
+		se.SetValue(activeProceduresKey, active)
+	}
+	return active
+}
+
+// parsedProcedure is a procedure cached in the session, so that the procedure and the
+// statements in the body are not parsed again on every call.
+type parsedProcedure struct {
+	proc *executor.StoredProcedure
+	info *ast.ProcedureInfo
+	// stmts caches the statements restored and parsed from the nodes of the body, they are
+	// parsed on the first execution.
+	stmts map[ast.Node]*procedureStmt
+}
+
+// getParsedProcedure returns the parsed procedure cached in the session, the procedure is
+// parsed again if its definition is changed.
+func getParsedProcedure(se sessiontypes.Session, proc *executor.StoredProcedure) (*parsedProcedure, error) {
+	cache, _ := se.Value(procedureCacheKey).(map[string]*parsedProcedure)
+	if cache == nil {
+		cache = make(map[string]*parsedProcedure)
+		se.SetValue(procedureCacheKey, cache)
+	}
+	key := procedureKey(proc)
+	if p, ok := cache[key]; ok && p.proc.ParamList == proc.ParamList && p.proc.Body == proc.Body &&
+		p.proc.SQLMode == proc.SQLMode && p.proc.CharsetClient == proc.CharsetClient &&
+		p.proc.CollationConnection == proc.CollationConnection {
+		return p, nil
+	}
+	info, err := proc.Parse(se)
+	if err != nil {
+		return nil, err
+	}
+	p := &parsedProcedure{proc: proc, info: info, stmts: make(map[ast.Node]*procedureStmt)}
+	cache[key] = p
+	return p, nil
+}
+
+// procedureStmt is a statement parsed from the SQL restored from the procedure. The local
+// variables in it are replaced by the value expressions, which are bound before each execution.
+type procedureStmt struct {
+	stmt  ast.StmtNode
+	binds []procedureBind
+}
+
+type procedureBind struct {
+	name string
+	expr *driver.ValueExpr
+}
+
+// procedureVar is a parameter or a local variable of the stored procedure.
+type procedureVar struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+// procedureCursor reads the rows of the result set from the position (chkIdx, rowIdx).
+type procedureCursor struct {
+	stmt   ast.StmtNode
+	open   bool
+	res    *procedureResult
+	chkIdx int
+	rowIdx int
+}
+
+// procedureScope holds the parameters, or the variables, cursors and handlers declared in a BEGIN ... END block.
+type procedureScope struct {
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+// procedureJump is returned by LEAVE and ITERATE to unwind to the statement with the label.
+type procedureJump struct {
+	label string
+	leave bool
+}
+
+func (*procedureJump) Error() string {
+	return "procedure jump"
+}
+
+// procedureExit is returned after an EXIT handler is executed to unwind to the block declaring the handler.
+type procedureExit struct {
+	scope int
+}
+
+func (*procedureExit) Error() string {
+	return "procedure exit"
+}
+
+type procedureResult struct {
+	fields   []*ast.ResultField
+	chunks   []*chunk.Chunk
+	memUsage int64
+}
+
+type procedureExec struct {
+	se        sessiontypes.Session
+	sqlMode   mysql.SQLMode
+	charset   string
+	collation string
+
+	scopes []*procedureScope
+	// The handlers declared in scopes [excludeFrom, excludeTo) are invisible when a handler is running.
+	excludeFrom int
+	excludeTo   int
+
+	// stmts caches the parsed statements of the procedure.
+	stmts   map[ast.Node]*procedureStmt
+	results []*procedureResult
+	// memTracker tracks the memory of the result sets and the rows of the opened cursors.
+	memTracker *memory.Tracker
+}
+
+func newProcedureExec(se sessiontypes.Session, proc *executor.StoredProcedure, stmts map[ast.Node]*procedureStmt) (*procedureExec, error) {
+	sqlMode, err := mysql.GetSQLMode(proc.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	e := &procedureExec{
+		se:         se,
+		sqlMode:    sqlMode,
+		charset:    mysql.DefaultCharset,
+		collation:  mysql.DefaultCollationName,
+		stmts:      stmts,
+		memTracker: memory.NewTracker(memory.LabelForStoredProcedure, -1),
+	}
+	e.memTracker.AttachTo(se.GetSessionVars().MemTracker)
+	if coll, err := charset.GetCollationByName(proc.DBCollation); err == nil {
+		e.charset, e.collation = coll.CharsetName, coll.Name
+	}
+	return e, nil
+}
+
+func (e *procedureExec) newVar(tp *types.FieldType, d types.Datum) (*procedureVar, error) {
+	tp = tp.Clone()
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		tp.SetCharset(e.charset)
+		tp.SetCollate(e.collation)
+	}
+	if tp.GetFlen() == types.UnspecifiedLength {
+		flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+		tp.SetFlen(flen)
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(decimal)
+		}
+	}
+	v := &procedureVar{tp: tp}
+	return v, e.assign(v, d)
+}
+
+func (e *procedureExec) assign(v *procedureVar, d types.Datum) error {
+	if d.IsNull() {
+		v.val.SetNull()
+		return nil
+	}
+	val, err := d.ConvertTo(e.se.GetSessionVars().StmtCtx.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	v.val = val
+	return nil
+}
+
+func (e *procedureExec) lookupVar(name string) *procedureVar {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if v, ok := e.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) lookupCursor(name string) *procedureCursor {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if c, ok := e.scopes[i].cursors[name]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+func restoreSQL(node ast.Node) (string, error) {
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// selectSQL returns the SELECT statement to evaluate the expressions.
+func selectSQL(exprs []ast.ExprNode) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, expr := range exprs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// prepare returns the statement of the node in the procedure, it's parsed from the SQL
+// restored by the function on the first execution and reused by the following ones.
+func (e *procedureExec) prepare(node ast.Node, restore func() (string, error)) (*procedureStmt, error) {
+	if ps, ok := e.stmts[node]; ok {
+		return ps, nil
+	}
+	sql, err := restore()
+	if err != nil {
+		return nil, err
+	}
+	ps, err := e.parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	e.stmts[node] = ps
+	return ps, nil
+}
+
+// parse parses the SQL and replaces the local variables in it with the value expressions.
+func (e *procedureExec) parse(sql string) (*procedureStmt, error) {
+	vars := e.se.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(e.sqlMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	chs, coll := vars.GetCharsetInfo()
+	stmt, err := p.ParseOneStmt(sql, chs, coll)
+	if err != nil {
+		return nil, err
+	}
+	sub := &procedureVarSubstitutor{e: e}
+	stmt.Accept(sub)
+	return &procedureStmt{stmt: stmt, binds: sub.binds}, nil
+}
+
+// run binds the current values of the local variables and executes the statement, it returns
+// the result set if there is one.
+func (e *procedureExec) run(ctx context.Context, ps *procedureStmt) (*procedureResult, error) {
+	for _, b := range ps.binds {
+		if v := e.lookupVar(b.name); v != nil {
+			v.val.Copy(&b.expr.Datum)
+			b.expr.SetType(v.tp)
+		}
+	}
+	rs, err := e.se.ExecuteStmt(ctx, ps.stmt)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	res, err := e.drain(ctx, rs)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		e.release(res)
+		return nil, err
+	}
+	return res, nil
+}
+
+// drain reads all the rows of the record set, the memory of them is tracked and limited by
+// tidb_mem_quota_query like the other statements.
+func (e *procedureExec) drain(ctx context.Context, rs sqlexec.RecordSet) (*procedureResult, error) {
+	vars := e.se.GetSessionVars()
+	res := &procedureResult{fields: rs.Fields()}
+	for {
+		chk := rs.NewChunk(nil)
+		if err := rs.Next(ctx, chk); err != nil {
+			return res, err
+		}
+		if chk.NumRows() == 0 {
+			return res, nil
+		}
+		res.chunks = append(res.chunks, chk)
+		memUsage := chk.MemoryUsage()
+		res.memUsage += memUsage
+		e.memTracker.Consume(memUsage)
+		if quota := vars.MemQuotaQuery; quota > 0 && e.memTracker.BytesConsumed() > quota {
+			return res, exeerrors.ErrMemoryExceedForQuery.GenWithStackByArgs(vars.ConnectionID)
+		}
+	}
+}
+
+// release releases the memory of the result set which is not used any more.
+func (e *procedureExec) release(res *procedureResult) {
+	if res != nil {
+		e.memTracker.Consume(-res.memUsage)
+		res.chunks, res.memUsage = nil, 0
+	}
+}
+
+func (res *procedureResult) rows() [][]types.Datum {
+	fts := make([]*types.FieldType, 0, len(res.fields))
+	for _, f := range res.fields {
+		fts = append(fts, &f.Column.FieldType)
+	}
+	var rows [][]types.Datum
+	for _, chk := range res.chunks {
+		for i := 0; i < chk.NumRows(); i++ {
+			row := chk.GetRow(i).GetDatumRow(fts)
+			for j := range row {
+				row[j] = *row[j].Clone()
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// evalExprs evaluates the expressions by a SELECT statement.
+func (e *procedureExec) evalExprs(ctx context.Context, exprs []ast.ExprNode) ([]types.Datum, error) {
+	ps, err := e.prepare(exprs[0], func() (string, error) {
+		return selectSQL(exprs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.evalSelect(ctx, ps)
+}
+
+func (e *procedureExec) evalSelect(ctx context.Context, ps *procedureStmt) ([]types.Datum, error) {
+	res, err := e.run(ctx, ps)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]types.Datum
+	if res != nil {
+		rows = res.rows()
+		e.release(res)
+	}
+	if len(rows) != 1 {
+		return nil, errors.Errorf("unexpected rows %d when evaluating %s", len(rows), ps.stmt.Text())
+	}
+	return rows[0], nil
+}
+
+func (e *procedureExec) evalCond(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	vals, err := e.evalExprs(ctx, []ast.ExprNode{expr})
+	if err != nil || vals[0].IsNull() {
+		return false, err
+	}
+	b, err := vals[0].ToBool(e.se.GetSessionVars().StmtCtx.TypeCtx())
+	return b != 0, err
+}
+
+func (e *procedureExec) execStmts(ctx context.Context, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := e.execStmt(ctx, stmt); err != nil {
+			if err = e.handleError(ctx, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) execStmt(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return e.execBlock(ctx, x)
+	case *ast.ProcedureLabelBlock:
+		err := e.execBlock(ctx, x.Block)
+		if jump, ok := err.(*procedureJump); ok && jump.leave && strings.EqualFold(jump.label, x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return e.execLoop(ctx, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt:
+		return e.execLoop(ctx, x, "")
+	case *ast.ProcedureJump:
+		return &procedureJump{label: x.Name, leave: x.IsLeave}
+	case *ast.ProcedureIfInfo:
+		return e.execIf(ctx, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return e.execSimpleCase(ctx, x)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			ok, err := e.evalCond(ctx, when.Expr)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return e.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		return e.execElse(ctx, x.ElseCases)
+	case *ast.ProcedureOpenCur:
+		return e.openCursor(ctx, x.CurName)
+	case *ast.ProcedureFetchInto:
+		return e.fetchCursor(x)
+	case *ast.ProcedureCloseCur:
+		c := e.lookupCursor(x.CurName)
+		if c == nil || !c.open {
+			return exeerrors.ErrSpCursorNotOpen
+		}
+		e.closeCursor(c)
+		return nil
+	case *ast.SetStmt:
+		return e.execSet(ctx, x)
+	}
+	ps, err := e.prepare(stmt, func() (string, error) {
+		return restoreSQL(stmt)
+	})
+	if err != nil {
+		return err
+	}
+	res, err := e.run(ctx, ps)
+	if err == nil && res != nil {
+		e.results = append(e.results, res)
+	}
+	return err
+}
+
+func (e *procedureExec) execBlock(ctx context.Context, block *ast.ProcedureBlock) error {
+	scope := newProcedureScope()
+	e.scopes = append(e.scopes, scope)
+	depth := len(e.scopes) - 1
+	defer func() {
+		// The cursors are closed at the end of the block.
+		for _, c := range scope.cursors {
+			if c.open {
+				e.closeCursor(c)
+			}
+		}
+		e.scopes = e.scopes[:depth]
+	}()
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			var d types.Datum
+			if x.DeclDefault != nil {
+				vals, err := e.evalExprs(ctx, []ast.ExprNode{x.DeclDefault})
+				if err != nil {
+					return err
+				}
+				d = vals[0]
+			}
+			for _, name := range x.DeclNames {
+				v, err := e.newVar(x.DeclType, d)
+				if err != nil {
+					return err
+				}
+				scope.vars[name] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[x.CurName] = &procedureCursor{stmt: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	err := e.execStmts(ctx, block.ProcedureProcStmts)
+	if exit, ok := err.(*procedureExit); ok && exit.scope == depth {
+		return nil
+	}
+	return err
+}
+
+func (e *procedureExec) execLoop(ctx context.Context, stmt ast.StmtNode, label string) error {
+	for {
+		var (
+			body    []ast.StmtNode
+			cond    ast.ExprNode
+			isWhile bool
+		)
+		switch x := stmt.(type) {
+		case *ast.ProcedureWhileStmt:
+			body, cond, isWhile = x.Body, x.Condition, true
+		case *ast.ProcedureRepeatStmt:
+			body, cond = x.Body, x.Condition
+		default:
+			return errors.Errorf("unsupported loop %T", stmt)
+		}
+		if isWhile {
+			ok, err := e.evalCond(ctx, cond)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		err := e.execStmts(ctx, body)
+		if jump, ok := err.(*procedureJump); ok && label != "" && strings.EqualFold(jump.label, label) {
+			if jump.leave {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !isWhile {
+			done, err := e.evalCond(ctx, cond)
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+}
+
+func (e *procedureExec) execIf(ctx context.Context, block *ast.ProcedureIfBlock) error {
+	ok, err := e.evalCond(ctx, block.IfExpr)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return e.execStmts(ctx, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return e.execIf(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return e.execStmts(ctx, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (e *procedureExec) execSimpleCase(ctx context.Context, stmt *ast.SimpleCaseStmt) error {
+	vals, err := e.evalExprs(ctx, []ast.ExprNode{stmt.Condition})
+	if err != nil {
+		return err
+	}
+	cond := vals[0]
+	typeCtx := e.se.GetSessionVars().StmtCtx.TypeCtx()
+	for _, when := range stmt.WhenCases {
+		vals, err := e.evalExprs(ctx, []ast.ExprNode{when.Expr})
+		if err != nil {
+			return err
+		}
+		if cond.IsNull() || vals[0].IsNull() {
+			continue
+		}
+		cmp, err := cond.Compare(typeCtx, &vals[0], collate.GetCollator(cond.Collation()))
+		if err != nil {
+			return err
+		}
+		if cmp == 0 {
+			return e.execStmts(ctx, when.ProcedureStmts)
+		}
+	}
+	return e.execElse(ctx, stmt.ElseCases)
+}
+
+func (e *procedureExec) execElse(ctx context.Context, stmts []ast.StmtNode) error {
+	if stmts == nil {
+		return exeerrors.ErrSpCaseNotFound
+	}
+	return e.execStmts(ctx, stmts)
+}
+
+func (e *procedureExec) execSet(ctx context.Context, stmt *ast.SetStmt) error {
+	for _, assign := range stmt.Variables {
+		if assign.IsSystem && !assign.IsGlobal {
+			if v := e.lookupVar(strings.ToLower(assign.Name)); v != nil {
+				vals, err := e.evalExprs(ctx, []ast.ExprNode{assign.Value})
+				if err != nil {
+					return err
+				}
+				if err = e.assign(v, vals[0]); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		ps, err := e.prepare(assign, func() (string, error) {
+			return restoreSQL(&ast.SetStmt{Variables: []*ast.VariableAssignment{assign}})
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := e.run(ctx, ps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) openCursor(ctx context.Context, name string) error {
+	c := e.lookupCursor(name)
+	if c.open {
+		return exeerrors.ErrSpCursorAlreadyOpen
+	}
+	ps, err := e.prepare(c.stmt, func() (string, error) {
+		return restoreSQL(c.stmt)
+	})
+	if err != nil {
+		return err
+	}
+	res, err := e.run(ctx, ps)
+	if err != nil {
+		return err
+	}
+	c.open, c.res, c.chkIdx, c.rowIdx = true, res, 0, 0
+	return nil
+}
+
+func (e *procedureExec) closeCursor(c *procedureCursor) {
+	e.release(c.res)
+	c.open, c.res, c.chkIdx, c.rowIdx = false, nil, 0, 0
+}
+
+func (e *procedureExec) fetchCursor(stmt *ast.ProcedureFetchInto) error {
+	c := e.lookupCursor(stmt.CurName)
+	if c == nil || !c.open {
+		return exeerrors.ErrSpCursorNotOpen
+	}
+	for c.res != nil && c.chkIdx < len(c.res.chunks) && c.rowIdx >= c.res.chunks[c.chkIdx].NumRows() {
+		c.chkIdx, c.rowIdx = c.chkIdx+1, 0
+	}
+	if c.res == nil || c.chkIdx >= len(c.res.chunks) {
+		return exeerrors.ErrSpFetchNoData
+	}
+	if len(c.res.fields) != len(stmt.Variables) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs
+	}
+	row := c.res.chunks[c.chkIdx].GetRow(c.rowIdx)
+	c.rowIdx++
+	for i, name := range stmt.Variables {
+		d := row.GetDatum(i, &c.res.fields[i].Column.FieldType)
+		if err := e.assign(e.lookupVar(name), *d.Clone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleError executes the handler for the error, it returns nil if the execution should continue.
+func (e *procedureExec) handleError(ctx context.Context, err error) error {
+	switch err.(type) {
+	case *procedureJump, *procedureExit:
+		return err
+	}
+	if ctx.Err() != nil || exeerrors.ErrQueryInterrupted.Equal(err) {
+		return err
+	}
+	depth, handler := e.findHandler(procedureSQLError(err))
+	if handler == nil {
+		return err
+	}
+	oldFrom, oldTo := e.excludeFrom, e.excludeTo
+	e.excludeFrom, e.excludeTo = depth, len(e.scopes)
+	handlerErr := e.execStmt(ctx, handler.Operate)
+	e.excludeFrom, e.excludeTo = oldFrom, oldTo
+	if handlerErr != nil {
+		return handlerErr
+	}
+	if handler.ControlHandle == ast.PROCEDUR_EXIT {
+		return &procedureExit{scope: depth}
+	}
+	return nil
+}
+
+// findHandler finds the handler for the error in the innermost scope. In a scope,
+// the handler for the error code is preferred to the one for the SQLSTATE, and then the condition class.
+func (e *procedureExec) findHandler(sqlErr *mysql.SQLError) (int, *ast.ProcedureErrorControl) {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if i >= e.excludeFrom && i < e.excludeTo {
+			continue
+		}
+		var (
+			best     *ast.ProcedureErrorControl
+			bestRank int
+		)
+		for _, handler := range e.scopes[i].handlers {
+			for _, cond := range handler.ErrorCon {
+				if rank := matchProcedureCondition(cond, sqlErr); rank > bestRank {
+					best, bestRank = handler, rank
+				}
+			}
+		}
+		if best != nil {
+			return i, best
+		}
+	}
+	return 0, nil
+}
+
+func matchProcedureCondition(cond ast.ErrNode, sqlErr *mysql.SQLError) int {
+	switch x := cond.(type) {
+	case *ast.ProcedureErrorVal:
+		if x.ErrorNum == uint64(sqlErr.Code) {
+			return 3
+		}
+	case *ast.ProcedureErrorState:
+		if x.CodeStatus == sqlErr.State {
+			return 2
+		}
+	case *ast.ProcedureErrorCon:
+		class := sqlErr.State[:2]
+		switch x.ErrorCon {
+		case ast.PROCEDUR_SQLWARNING:
+			if class == "01" {
+				return 1
+			}
+		case ast.PROCEDUR_NOT_FOUND:
+			if class == "02" {
+				return 1
+			}
+		case ast.PROCEDUR_SQLEXCEPTION:
+			if class != "00" && class != "01" && class != "02" {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+func procedureSQLError(err error) *mysql.SQLError {
+	if tErr, ok := errors.Cause(err).(*terror.Error); ok {
+		return terror.ToSQLError(tErr)
+	}
+	return mysql.NewErrf(mysql.ErrUnknown, "%s", nil, err.Error())
+}
+
+// procedureVarSubstitutor replaces the local variables in the statement with the value
+// expressions, the values are bound before each execution.
+type procedureVarSubstitutor struct {
+	e     *procedureExec
+	binds []procedureBind
+}
+
+// Enter implements ast.Visitor interface.
+func (*procedureVarSubstitutor) Enter(n ast.Node) (ast.Node, bool) {
+	if _, ok := n.(*ast.ValuesExpr); ok {
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (s *procedureVarSubstitutor) Leave(n ast.Node) (ast.Node, bool) {
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" || col.Name.Table.L != "" {
+		return n, true
+	}
+	if s.e.lookupVar(col.Name.Name.L) == nil {
+		return n, true
+	}
+	expr := &driver.ValueExpr{}
+	s.binds = append(s.binds, procedureBind{name: col.Name.Name.L, expr: expr})
+	return expr, true
+}
+
+// paramMarkerSubstitutor replaces the parameter markers in the arguments of a prepared
+// CALL statement with the values, or puts the parameter markers back if restore is set.
+type paramMarkerSubstitutor struct {
+	params   []types.Datum
+	replaced map[*driver.ValueExpr]*driver.ParamMarkerExpr
+	restore  bool
+}
+
+// Enter implements ast.Visitor interface.
+func (*paramMarkerSubstitutor) Enter(n ast.Node) (ast.Node, bool) {
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (s *paramMarkerSubstitutor) Leave(n ast.Node) (ast.Node, bool) {
+	if s.restore {
+		if expr, ok := n.(*driver.ValueExpr); ok {
+			if marker, ok := s.replaced[expr]; ok {
+				return marker, true
+			}
+		}
+		return n, true
+	}
+	marker, ok := n.(*driver.ParamMarkerExpr)
+	if !ok {
+		return n, true
+	}
+	expr := &driver.ValueExpr{}
+	s.params[marker.Order].Copy(&expr.Datum)
+	tp := types.NewFieldType(mysql.TypeUnspecified)
+	types.InferParamTypeFromDatum(&expr.Datum, tp)
+	expr.SetType(tp)
+	s.replaced[expr] = marker
+	return expr, true
+}
+
+// procedureRecordSet returns the result sets produced by the stored procedure one by one.
+type procedureRecordSet struct {
+	results      []*procedureResult
+	idx          int
+	chkIdx       int
+	maxChunkSize int
+	memTracker   *memory.Tracker
+}
+
+// Fields implements sqlexec.RecordSet interface.
+func (rs *procedureRecordSet) Fields() []*ast.ResultField {
+	return rs.results[rs.idx].fields
+}
+
+// Next implements sqlexec.RecordSet interface.
+func (rs *procedureRecordSet) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	res := rs.results[rs.idx]
+	if rs.chkIdx < len(res.chunks) {
+		req.SwapColumns(res.chunks[rs.chkIdx])
+		rs.chkIdx++
+	}
+	return nil
+}
+
+// NewChunk implements sqlexec.RecordSet interface.
+func (rs *procedureRecordSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	fields := make([]*types.FieldType, 0, len(rs.Fields()))
+	for _, field := range rs.Fields() {
+		fields = append(fields, &field.Column.FieldType)
+	}
+	if alloc != nil {
+		return alloc.Alloc(fields, 0, rs.maxChunkSize)
+	}
+	return chunk.New(fields, rs.maxChunkSize, rs.maxChunkSize)
+}
+
+// Close implements sqlexec.RecordSet interface.
+func (rs *procedureRecordSet) Close() error {
+	for _, res := range rs.results {
+		res.chunks = nil
+	}
+	rs.memTracker.Detach()
+	return nil
+}
+
+// NextRecordSet implements sqlexec.MultiRecordSet interface.
+func (rs *procedureRecordSet) NextRecordSet() bool {
+	if rs.idx+1 >= len(rs.results) {
+		return false
+	}
+	rs.idx++
+	rs.chkIdx = 0
+	return true
+}
+
+// callStmtOf returns the CALL statement to execute, including the one executed by EXECUTE,
+// and the values of the parameter markers in it.
+func (s *session) callStmtOf(stmtNode ast.StmtNode) (*ast.CallStmt, []types.Datum, bool, error) {
+	switch x := stmtNode.(type) {
+	case *ast.CallStmt:
+		return x, nil, true, nil
+	case *ast.ExecuteStmt:
+		prepStmt, err := core.GetPreparedStmt(x, s.sessionVars)
+		if err != nil {
+			// The error is reported when the EXECUTE statement is compiled.
+			return nil, nil, false, nil
+		}
+		callStmt, ok := prepStmt.PreparedAst.Stmt.(*ast.CallStmt)
+		if !ok {
+			return nil, nil, false, nil
+		}
+		var params []types.Datum
+		if args, ok := x.BinaryArgs.([]expression.Expression); ok {
+			params = make([]types.Datum, 0, len(args))
+			for _, arg := range args {
+				val, err := arg.Eval(s, chunk.Row{})
+				if err != nil {
+					return nil, nil, false, err
+				}
+				params = append(params, val)
+			}
+		} else {
+			params = make([]types.Datum, 0, len(x.UsingVars))
+			for _, expr := range x.UsingVars {
+				var val types.Datum
+				if v, ok := expr.(*ast.VariableExpr); ok {
+					val, _ = s.sessionVars.GetUserVarVal(strings.ToLower(v.Name))
+				}
+				params = append(params, val)
+			}
+		}
+		if len(params) != len(prepStmt.PreparedAst.Params) {
+			return nil, nil, false, core.ErrWrongParamCount
+		}
+		return callStmt, params, true, nil
+	}
+	return nil, nil, false, nil
+}
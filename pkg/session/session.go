@@ -46,6 +46,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	eventsched "github.com/pingcap/tidb/pkg/event"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/extension"
@@ -415,6 +416,22 @@ func (s *session) UpdateColStatsUsage(predicateColumns []model.TableItemID) {
 	s.statsCollector.UpdateColStatsUsage(colMap)
 }
 
+func (s *session) UpdateIndexUsage(indexes []model.TableItemID) {
+	if s.idxUsageCollector == nil || len(indexes) == 0 {
+		return
+	}
+	// An index may be read by several operators of one statement, but it's only counted once.
+	now := time.Now()
+	reported := make(map[model.TableItemID]struct{}, len(indexes))
+	for _, idx := range indexes {
+		if _, ok := reported[idx]; ok {
+			continue
+		}
+		reported[idx] = struct{}{}
+		s.idxUsageCollector.Update(idx.TableID, idx.ID, indexusage.Sample{QueryTotal: 1, LastUsedAt: now})
+	}
+}
+
 // FieldList returns fields list of a table.
 func (s *session) FieldList(tableName string) ([]*ast.ResultField, error) {
 	is := s.GetInfoSchema().(infoschema.InfoSchema)
@@ -2109,6 +2126,12 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 			execStmt.BinaryArgs = args
 		}
 	}
+	// The statements in the body of the stored procedure are executed one by one in the session.
+	if callStmt, params, ok, err := s.callStmtOf(stmtNode); err != nil {
+		return nil, err
+	} else if ok {
+		return handleCallProcedure(ctx, callStmt, s, params)
+	}
 
 	normalizedSQL, digest := s.sessionVars.StmtCtx.SQLDigest()
 	cmdByte := byte(atomic.LoadUint32(&s.GetSessionVars().CommandValue))
@@ -3460,6 +3483,9 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(func() (eventsched.Session, error) {
+		return CreateSession(store)
+	})
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -63,6 +63,17 @@ type PlanCache interface {
 	Close()
 }
 
+// InstancePlanCache is an interface for the plan cache shared by all sessions of the instance.
+// The cached plans are cloned and bound to the session when they are got from or put into the cache.
+type InstancePlanCache interface {
+	Get(sctx Context, key kvcache.Key, opts *utilpc.PlanCacheMatchOpts) (value kvcache.Value, ok bool)
+	Put(sctx Context, key kvcache.Key, value kvcache.Value, opts *utilpc.PlanCacheMatchOpts) bool
+	Delete(key kvcache.Key)
+	DeleteAll()
+	Size() int
+	MemoryUsage() int64
+}
+
 // Context is an interface for transaction and executive args environment.
 type Context interface {
 	SessionStatesHandler
@@ -121,6 +132,9 @@ type Context interface {
 	// UpdateColStatsUsage updates the column stats usage.
 	UpdateColStatsUsage(predicateColumns []model.TableItemID)
 
+	// UpdateIndexUsage updates the usage of the indexes read by the statement.
+	UpdateIndexUsage(indexes []model.TableItemID)
+
 	// HasDirtyContent checks whether there's dirty update on the given table.
 	HasDirtyContent(tid int64) bool
 
@@ -262,6 +262,7 @@ type StatementContext struct {
 	RuntimeStatsColl  *execdetails.RuntimeStatsColl
 	TableIDs          []int64
 	IndexNames        []string
+	UsedIndexes       []model.TableItemID
 	StmtType          string
 	OriginalSQL       string
 	digestMemo        struct {
@@ -1117,6 +1118,11 @@ func (sc *StatementContext) resetMuForRetry() {
 	sc.mu.detailsSummary.Reset()
 }
 
+// AppendUsedIndex records that the index of the table is read by the statement.
+func (sc *StatementContext) AppendUsedIndex(tableID, indexID int64) {
+	sc.UsedIndexes = append(sc.UsedIndexes, model.TableItemID{TableID: tableID, ID: indexID, IsIndex: true})
+}
+
 // ResetForRetry resets the changed states during execution.
 func (sc *StatementContext) ResetForRetry() {
 	sc.resetMuForRetry()
@@ -1124,6 +1130,7 @@ func (sc *StatementContext) ResetForRetry() {
 	sc.BaseRowID = 0
 	sc.TableIDs = sc.TableIDs[:0]
 	sc.IndexNames = sc.IndexNames[:0]
+	sc.UsedIndexes = sc.UsedIndexes[:0]
 	sc.TaskID = AllocateTaskID()
 }
 
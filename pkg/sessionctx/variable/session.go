@@ -686,6 +686,8 @@ type SessionVars struct {
 	// stmtVars variables are temporarily set by SET_VAR hint
 	// It only take effect for the duration of a single statement
 	stmtVars map[string]string
+	// planAffectedVarsHash caches the result of PlanAffectedVarsHash, it's reset when the variables change.
+	planAffectedVarsHash []byte
 	// SysWarningCount is the system variable "warning_count", because it is on the hot path, so we extract it from the systems
 	SysWarningCount int
 	// SysErrorCount is the system variable "error_count", because it is on the hot path, so we extract it from the systems
@@ -1497,6 +1499,9 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMaterializedViewRewrite indicates whether the queries can be answered by the materialized views.
+	EnableMaterializedViewRewrite bool
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -2463,11 +2468,15 @@ func (s *SessionVars) WithdrawAllPreparedStmt() {
 // SetStmtVar sets the value of a system variable temporarily
 func (s *SessionVars) setStmtVar(name string, val string) error {
 	s.stmtVars[name] = val
+	s.resetPlanAffectedVarsHash(GetSysVar(name))
 	return nil
 }
 
 // ClearStmtVars clear temporarily system variables.
 func (s *SessionVars) ClearStmtVars() {
+	if len(s.stmtVars) > 0 {
+		s.planAffectedVarsHash = nil
+	}
 	s.stmtVars = make(map[string]string)
 }
 
@@ -2497,6 +2506,7 @@ func (s *SessionVars) GetSessionOrGlobalSystemVar(ctx context.Context, name stri
 			} else {
 				s.systems[sv.Name] = sv.Value // no global scope, use default
 			}
+			s.resetPlanAffectedVarsHash(sv)
 		}
 		return sv.GetSessionFromHook(s)
 	}
@@ -78,6 +78,20 @@ func TestSetSystemVariable(t *testing.T) {
 	}
 }
 
+func TestPlanAffectedVarsHash(t *testing.T) {
+	v := variable.NewSessionVars(nil)
+	v.GlobalVarsAccessor = variable.NewMockGlobalAccessor4Tests()
+	require.NoError(t, v.SetSystemVar(variable.TiDBOptCPUFactor, "3"))
+	hash := v.PlanAffectedVarsHash()
+	// The hash is cached until the variables affecting the plan change.
+	require.NoError(t, v.SetSystemVar(variable.TiDBMemQuotaQuery, "1024"))
+	require.Same(t, &hash[0], &v.PlanAffectedVarsHash()[0])
+	require.NoError(t, v.SetSystemVar(variable.TiDBOptCPUFactor, "10"))
+	require.NotEqual(t, hash, v.PlanAffectedVarsHash())
+	require.NoError(t, v.SetSystemVar(variable.TiDBOptCPUFactor, "3"))
+	require.Equal(t, hash, v.PlanAffectedVarsHash())
+}
+
 func TestSession(t *testing.T) {
 	ctx := mock.NewContext()
 
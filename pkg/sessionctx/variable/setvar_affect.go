@@ -14,6 +14,8 @@
 
 package variable
 
+import "sort"
+
 var isHintUpdatableVerified = map[string]struct{}{
 	"tidb_opt_agg_push_down":                           {},
 	"tidb_opt_derive_topn":                             {},
@@ -120,3 +122,36 @@ func setHintUpdatable(vars []*SysVar) {
 		}
 	}
 }
+
+// planAffectedVars is the sorted names of the variables which may affect the plan.
+var planAffectedVars = func() []string {
+	names := make([]string, 0, len(isHintUpdatableVerified))
+	for name := range isHintUpdatableVerified {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}()
+
+// PlanAffectedVarsHash encodes the values of the variables which may affect the plan, the plans can be
+// shared between the sessions with the same hash. The hash is computed once after the variables change,
+// the returned slice must not be modified.
+func (s *SessionVars) PlanAffectedVarsHash() []byte {
+	if s.planAffectedVarsHash == nil {
+		b := make([]byte, 0, 4*len(planAffectedVars))
+		for _, name := range planAffectedVars {
+			val, _ := s.GetSystemVar(name)
+			b = append(b, val...)
+			b = append(b, 0)
+		}
+		s.planAffectedVarsHash = b
+	}
+	return s.planAffectedVarsHash
+}
+
+// resetPlanAffectedVarsHash resets the cached hash if the variable may affect the plan.
+func (s *SessionVars) resetPlanAffectedVarsHash(sv *SysVar) {
+	if sv != nil && sv.IsHintUpdatableVerfied {
+		s.planAffectedVarsHash = nil
+	}
+}
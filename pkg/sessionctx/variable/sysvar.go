@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/duration"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
@@ -56,6 +57,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/tls"
 	topsqlstate "github.com/pingcap/tidb/pkg/util/topsql/state"
 	"github.com/pingcap/tidb/pkg/util/versioninfo"
+	"github.com/robfig/cron/v3"
 	tikvcfg "github.com/tikv/client-go/v2/config"
 	tikvstore "github.com/tikv/client-go/v2/kv"
 	tikvcliutil "github.com/tikv/client-go/v2/util"
@@ -752,6 +754,30 @@ var defaultSysVars = []*SysVar{
 		SetDDLErrorCountLimit(TidbOptInt64(val, DefTiDBDDLErrorCountLimit))
 		return nil
 	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLReorgSchedule, Value: DefTiDBDDLReorgSchedule, Type: TypeStr, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
+		if normalizedValue == "" {
+			return normalizedValue, nil
+		}
+		if _, err := cron.ParseStandard(normalizedValue); err != nil {
+			return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(TiDBDDLReorgSchedule, originalValue)
+		}
+		return normalizedValue, nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLReorgSchedule.Store(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLReorgScheduleDuration, Value: DefTiDBDDLReorgScheduleDuration, Type: TypeStr, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
+		if normalizedValue == "" {
+			return normalizedValue, nil
+		}
+		if d, err := duration.ParseDuration(normalizedValue); err != nil || d <= 0 {
+			return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(TiDBDDLReorgScheduleDuration, originalValue)
+		}
+		return normalizedValue, nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLReorgScheduleDuration.Store(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBMaxDeltaSchemaCount, Value: strconv.Itoa(DefTiDBMaxDeltaSchemaCount), Type: TypeUnsigned, MinValue: 100, MaxValue: 16384, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 		// It's a global variable, but it also wants to be cached in server.
 		SetMaxDeltaSchemaCount(TidbOptInt64(val, DefTiDBMaxDeltaSchemaCount))
@@ -964,6 +990,15 @@ var defaultSysVars = []*SysVar{
 		return nil
 	}},
 	{Scope: ScopeGlobal, Name: SkipNameResolve, Value: Off, Type: TypeBool},
+	{Scope: ScopeGlobal, Name: EventScheduler, Value: BoolToOnOff(DefEventScheduler), Type: TypeBool,
+		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+			return BoolToOnOff(EnableEventScheduler.Load()), nil
+		},
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			EnableEventScheduler.Store(TiDBOptOn(val))
+			return nil
+		},
+	},
 	{Scope: ScopeGlobal, Name: DefaultAuthPlugin, Value: mysql.AuthNativePassword, Type: TypeEnum, PossibleValues: []string{mysql.AuthNativePassword, mysql.AuthCachingSha2Password, mysql.AuthTiDBSM3Password, mysql.AuthLDAPSASL, mysql.AuthLDAPSimple}},
 	{Scope: ScopeGlobal, Name: TiDBPersistAnalyzeOptions, Value: BoolToOnOff(DefTiDBPersistAnalyzeOptions), Type: TypeBool,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
@@ -983,6 +1018,15 @@ var defaultSysVars = []*SysVar{
 			return nil
 		},
 	},
+	{Scope: ScopeGlobal, Name: TiDBEnableAutoAnalyzePriorityQueue, Value: BoolToOnOff(DefTiDBEnableAutoAnalyzePriorityQueue), Type: TypeBool,
+		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+			return BoolToOnOff(EnableAutoAnalyzePriorityQueue.Load()), nil
+		},
+		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+			EnableAutoAnalyzePriorityQueue.Store(TiDBOptOn(val))
+			return nil
+		},
+	},
 	{Scope: ScopeGlobal, Name: TiDBGOGCTunerThreshold, Value: strconv.FormatFloat(DefTiDBGOGCTunerThreshold, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: math.MaxUint64,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 			return strconv.FormatFloat(GOGCTunerThreshold.Load(), 'f', -1, 64), nil
@@ -1250,6 +1294,21 @@ var defaultSysVars = []*SysVar{
 		}
 		return err
 	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableInstancePlanCache, Value: BoolToOnOff(DefTiDBEnableInstancePlanCache), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableInstancePlanCache.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableInstancePlanCache.Load()), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBInstancePlanCacheMaxMemSize, Value: strconv.FormatUint(DefTiDBInstancePlanCacheMaxMemSize, 10), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint64, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		uVal, err := strconv.ParseUint(val, 10, 64)
+		if err == nil {
+			InstancePlanCacheMaxMemSize.Store(uVal)
+		}
+		return err
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatUint(InstancePlanCacheMaxMemSize.Load(), 10), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBMemOOMAction, Value: DefTiDBMemOOMAction, PossibleValues: []string{"CANCEL", "LOG"}, Type: TypeEnum,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 			return OOMAction.Load(), nil
@@ -2632,6 +2691,10 @@ var defaultSysVars = []*SysVar{
 		s.EnableLateMaterialization = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptEnableMaterializedViewRewrite, Value: BoolToOnOff(DefTiDBOptEnableMaterializedViewRewrite), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableMaterializedViewRewrite = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBLoadBasedReplicaReadThreshold, Value: DefTiDBLoadBasedReplicaReadThreshold.String(), Type: TypeDuration, MaxValue: uint64(time.Hour), SetSession: func(s *SessionVars, val string) error {
 		d, err := time.ParseDuration(val)
 		if err != nil {
@@ -3144,6 +3207,8 @@ const (
 	MaxConnections = "max_connections"
 	// SkipNameResolve is the name for 'skip_name_resolve' system variable.
 	SkipNameResolve = "skip_name_resolve"
+	// EventScheduler is the name for 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// ForeignKeyChecks is the name for 'foreign_key_checks' system variable.
 	ForeignKeyChecks = "foreign_key_checks"
 	// SQLSafeUpdates is the name for 'sql_safe_updates' system variable.
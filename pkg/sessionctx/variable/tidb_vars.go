@@ -506,6 +506,13 @@ const (
 	// TiDBDDLErrorCountLimit defines the count of ddl error limit.
 	TiDBDDLErrorCountLimit = "tidb_ddl_error_count_limit"
 
+	// TiDBDDLReorgSchedule defines the cron expression of the global maintenance window of the ddl reorg jobs.
+	// The reorganization is paused outside the window, and resumed inside it.
+	TiDBDDLReorgSchedule = "tidb_ddl_reorg_schedule"
+
+	// TiDBDDLReorgScheduleDuration defines how long the global maintenance window of the ddl reorg jobs lasts.
+	TiDBDDLReorgScheduleDuration = "tidb_ddl_reorg_schedule_duration"
+
 	// TiDBDDLReorgPriority defines the operations' priority of adding indices.
 	// It can be: PRIORITY_LOW, PRIORITY_NORMAL, PRIORITY_HIGH
 	TiDBDDLReorgPriority = "tidb_ddl_reorg_priority"
@@ -836,6 +843,10 @@ const (
 	TiDBPlanCacheInvalidationOnFreshStats = "tidb_plan_cache_invalidation_on_fresh_stats"
 	// TiDBSessionPlanCacheSize controls the size of session plan cache.
 	TiDBSessionPlanCacheSize = "tidb_session_plan_cache_size"
+	// TiDBEnableInstancePlanCache indicates whether to share the cached plans between sessions by the instance plan cache.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize controls the maximum memory usage of the instance plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_size"
 
 	// TiDBConstraintCheckInPlacePessimistic controls whether to skip certain kinds of pessimistic locks.
 	TiDBConstraintCheckInPlacePessimistic = "tidb_constraint_check_in_place_pessimistic"
@@ -900,6 +911,8 @@ const (
 
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
+	// TiDBOptEnableMaterializedViewRewrite indicates whether to answer the queries by the materialized views.
+	TiDBOptEnableMaterializedViewRewrite = "tidb_opt_enable_materialized_view_rewrite"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
 	TiDBLoadBasedReplicaReadThreshold = "tidb_load_based_replica_read_threshold"
 
@@ -987,6 +1000,8 @@ const (
 	TiDBMemQuotaAnalyze = "tidb_mem_quota_analyze"
 	// TiDBEnableAutoAnalyze determines whether TiDB executes automatic analysis.
 	TiDBEnableAutoAnalyze = "tidb_enable_auto_analyze"
+	// TiDBEnableAutoAnalyzePriorityQueue determines whether TiDB picks the tables to auto analyze by their priorities.
+	TiDBEnableAutoAnalyzePriorityQueue = "tidb_enable_auto_analyze_priority_queue"
 	// TiDBMemOOMAction indicates what operation TiDB perform when a single SQL statement exceeds
 	// the memory quota specified by tidb_mem_quota_query and cannot be spilled to disk.
 	TiDBMemOOMAction = "tidb_mem_oom_action"
@@ -1240,6 +1255,8 @@ const (
 	DefTiDBDDLReorgBatchSize                       = 256
 	DefTiDBDDLFlashbackConcurrency                 = 64
 	DefTiDBDDLErrorCountLimit                      = 512
+	DefTiDBDDLReorgSchedule                        = ""
+	DefTiDBDDLReorgScheduleDuration                = ""
 	DefTiDBMaxDeltaSchemaCount                     = 1024
 	DefTiDBPlacementMode                           = PlacementModeStrict
 	DefTiDBEnableAutoIncrementInGenerated          = false
@@ -1346,6 +1363,7 @@ const (
 	DefTiDBBatchDMLIgnoreError                     = false
 	DefTiDBMemQuotaAnalyze                         = -1
 	DefTiDBEnableAutoAnalyze                       = true
+	DefTiDBEnableAutoAnalyzePriorityQueue          = true
 	DefTiDBMemOOMAction                            = "CANCEL"
 	DefTiDBMaxAutoAnalyzeTime                      = 12 * 60 * 60
 	DefTiDBEnablePrepPlanCache                     = true
@@ -1376,6 +1394,8 @@ const (
 	DefTiDBEnableNonPreparedPlanCacheForDML        = false
 	DefTiDBNonPreparedPlanCacheSize                = 100
 	DefTiDBPlanCacheMaxPlanSize                    = 2 * size.MB
+	DefTiDBEnableInstancePlanCache                 = false
+	DefTiDBInstancePlanCacheMaxMemSize             = 100 * size.MB
 	// MaxDDLReorgBatchSize is exported for testing.
 	MaxDDLReorgBatchSize                  int32  = 10240
 	MinDDLReorgBatchSize                  int32  = 32
@@ -1431,6 +1451,7 @@ const (
 	DefTiDBEnablePlanCacheForSubquery                 = true
 	DefTiDBLoadBasedReplicaReadThreshold              = time.Second
 	DefTiDBOptEnableLateMaterialization               = true
+	DefTiDBOptEnableMaterializedViewRewrite           = false
 	DefTiDBOptOrderingIdxSelThresh                    = 0.0
 	DefTiDBOptOrderingIdxSelRatio                     = -1
 	DefTiDBOptEnableMPPSharedCTEExecution             = false
@@ -1460,23 +1481,25 @@ const (
 	DefTiDBSchemaVersionCacheLimit                    = 16
 	DefTiDBIdleTransactionTimeout                     = 0
 	DefTiDBTxnEntrySizeLimit                          = 0
+	DefEventScheduler                                 = true
 )
 
 // Process global variables.
 var (
-	ProcessGeneralLog             = atomic.NewBool(false)
-	RunAutoAnalyze                = atomic.NewBool(DefTiDBEnableAutoAnalyze)
-	GlobalLogMaxDays              = atomic.NewInt32(int32(config.GetGlobalConfig().Log.File.MaxDays))
-	QueryLogMaxLen                = atomic.NewInt32(DefTiDBQueryLogMaxLen)
-	EnablePProfSQLCPU             = atomic.NewBool(false)
-	EnableBatchDML                = atomic.NewBool(false)
-	EnableTmpStorageOnOOM         = atomic.NewBool(DefTiDBEnableTmpStorageOnOOM)
-	ddlReorgWorkerCounter   int32 = DefTiDBDDLReorgWorkerCount
-	ddlReorgBatchSize       int32 = DefTiDBDDLReorgBatchSize
-	ddlFlashbackConcurrency int32 = DefTiDBDDLFlashbackConcurrency
-	ddlErrorCountLimit      int64 = DefTiDBDDLErrorCountLimit
-	ddlReorgRowFormat       int64 = DefTiDBRowFormatV2
-	maxDeltaSchemaCount     int64 = DefTiDBMaxDeltaSchemaCount
+	ProcessGeneralLog                    = atomic.NewBool(false)
+	RunAutoAnalyze                       = atomic.NewBool(DefTiDBEnableAutoAnalyze)
+	EnableAutoAnalyzePriorityQueue       = atomic.NewBool(DefTiDBEnableAutoAnalyzePriorityQueue)
+	GlobalLogMaxDays                     = atomic.NewInt32(int32(config.GetGlobalConfig().Log.File.MaxDays))
+	QueryLogMaxLen                       = atomic.NewInt32(DefTiDBQueryLogMaxLen)
+	EnablePProfSQLCPU                    = atomic.NewBool(false)
+	EnableBatchDML                       = atomic.NewBool(false)
+	EnableTmpStorageOnOOM                = atomic.NewBool(DefTiDBEnableTmpStorageOnOOM)
+	ddlReorgWorkerCounter          int32 = DefTiDBDDLReorgWorkerCount
+	ddlReorgBatchSize              int32 = DefTiDBDDLReorgBatchSize
+	ddlFlashbackConcurrency        int32 = DefTiDBDDLFlashbackConcurrency
+	ddlErrorCountLimit             int64 = DefTiDBDDLErrorCountLimit
+	ddlReorgRowFormat              int64 = DefTiDBRowFormatV2
+	maxDeltaSchemaCount            int64 = DefTiDBMaxDeltaSchemaCount
 	// DDLSlowOprThreshold is the threshold for ddl slow operations, uint is millisecond.
 	DDLSlowOprThreshold                  = config.GetGlobalConfig().Instance.DDLSlowOprThreshold
 	ForcePriority                        = int32(DefTiDBForcePriority)
@@ -1503,6 +1526,8 @@ var (
 	MaxAutoAnalyzeTime                   = atomic.NewInt64(DefTiDBMaxAutoAnalyzeTime)
 	// variables for plan cache
 	PreparedPlanCacheMemoryGuardRatio = atomic.NewFloat64(DefTiDBPrepPlanCacheMemoryGuardRatio)
+	EnableInstancePlanCache           = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	InstancePlanCacheMaxMemSize       = atomic.NewUint64(DefTiDBInstancePlanCacheMaxMemSize)
 	EnableDistTask                    = atomic.NewBool(DefTiDBEnableDistTask)
 	DDLVersion                        = atomic.NewInt64(model.TiDBDDLV1)
 	DDLForce2Queue                    = atomic.NewBool(false)
@@ -1563,6 +1588,9 @@ var (
 	CloudStorageURI           = atomic.NewString("")
 	IgnoreInlistPlanDigest    = atomic.NewBool(DefTiDBIgnoreInlistPlanDigest)
 	TxnEntrySizeLimit         = atomic.NewUint64(DefTiDBTxnEntrySizeLimit)
+	EnableEventScheduler      = atomic.NewBool(DefEventScheduler)
+	DDLReorgSchedule          = atomic.NewString(DefTiDBDDLReorgSchedule)
+	DDLReorgScheduleDuration  = atomic.NewString(DefTiDBDDLReorgScheduleDuration)
 )
 
 var (
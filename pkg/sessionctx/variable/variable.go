@@ -228,6 +228,7 @@ func (sv *SysVar) SetSessionFromHook(s *SessionVars, val string) error {
 		}
 	}
 	s.systems[sv.Name] = val
+	s.resetPlanAffectedVarsHash(sv)
 
 	// Call the Set function on all the aliases for this sysVar
 	// Skipping the validation function, and not calling aliases of
@@ -243,6 +244,7 @@ func (sv *SysVar) SetSessionFromHook(s *SessionVars, val string) error {
 				}
 			}
 			s.systems[aliasSv.Name] = val
+			s.resetPlanAffectedVarsHash(aliasSv)
 		}
 	}
 	return nil
@@ -517,12 +517,14 @@ func updatePasswordValidationLength(s *SessionVars, length int32) error {
 
 // GAFunction4ExpressionIndex stores functions GA for expression index.
 var GAFunction4ExpressionIndex = map[string]struct{}{
-	ast.Lower:      {},
-	ast.Upper:      {},
-	ast.MD5:        {},
-	ast.Reverse:    {},
-	ast.VitessHash: {},
-	ast.TiDBShard:  {},
+	ast.Lower:                {},
+	ast.Upper:                {},
+	ast.MD5:                  {},
+	ast.Reverse:              {},
+	ast.VitessHash:           {},
+	ast.TiDBShard:            {},
+	ast.TiDBFullTextTokenize: {},
+	ast.TiDBSpatialCells:     {},
 	// JSON functions.
 	ast.JSONType:          {},
 	ast.JSONExtract:       {},
@@ -29,8 +29,10 @@ import (
 )
 
 // BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// rowSamples are the sampled rows of the columns, which are only available in analyze version 2 and are
+// required by the MULTI_HISTOGRAM statistics.
 func BuildExtendedStats(sctx sessionctx.Context,
-	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector) (*ExtendedStatsColl, error) {
+	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector, rowSamples []*ReservoirRowSampleItem) (*ExtendedStatsColl, error) {
 	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
 
 	sqlExec, ok := sctx.(sqlexec.RestrictedSQLExecutor)
@@ -54,7 +56,7 @@ func BuildExtendedStats(sctx sessionctx.Context,
 			logutil.BgLogger().Error("invalid column_ids in mysql.stats_extended, skip collecting extended stats for this row", zap.String("column_ids", colIDs), zap.Error(err))
 			continue
 		}
-		item = fillExtendedStatsItemVals(sctx, item, cols, collectors)
+		item = fillExtendedStatsItemVals(sctx, item, cols, collectors, rowSamples)
 		if item != nil {
 			statsColl.Stats[name] = item
 		}
@@ -65,16 +67,43 @@ func BuildExtendedStats(sctx sessionctx.Context,
 	return statsColl, nil
 }
 
-func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector, rowSamples []*ReservoirRowSampleItem) *ExtendedStatsItem {
 	switch item.Tp {
 	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
 		return nil
 	case ast.StatsTypeCorrelation:
 		return fillExtStatsCorrVals(sctx, item, cols, collectors)
+	case ast.StatsTypeMultiHistogram:
+		return fillExtStatsMultiHistVals(sctx, item, cols, rowSamples)
 	}
 	return nil
 }
 
+func fillExtStatsMultiHistVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, rowSamples []*ReservoirRowSampleItem) *ExtendedStatsItem {
+	if rowSamples == nil {
+		return nil
+	}
+	colOffsets := make([]int, 0, len(item.ColIDs))
+	for _, id := range item.ColIDs {
+		for i, col := range cols {
+			if col.ID == id {
+				colOffsets = append(colOffsets, i)
+				break
+			}
+		}
+	}
+	if len(colOffsets) != len(item.ColIDs) {
+		return nil
+	}
+	var err error
+	item.StringVals, err = EncodeMultiColumnSketch(sctx.GetSessionVars().StmtCtx.TimeZone(), rowSamples, colOffsets)
+	if err != nil {
+		logutil.BgLogger().Error("encode multi-column sketch failed", zap.Int64s("column_ids", item.ColIDs), zap.Error(err))
+		return nil
+	}
+	return item
+}
+
 func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
 	colOffsets := make([]int, 0, 2)
 	for _, id := range item.ColIDs {
@@ -243,6 +243,22 @@ func (sa *statsAnalyze) HandleAutoAnalyze() (analyzed bool) {
 	return
 }
 
+// GetAutoAnalyzeQueue builds the auto-analyze priority queue with the current stats,
+// and returns the jobs in the queue in the order they will be analyzed.
+func (sa *statsAnalyze) GetAutoAnalyzeQueue() (jobInfos []*statstypes.AnalysisJobInfo, err error) {
+	if BuildAnalysisJobInfos == nil {
+		return nil, nil
+	}
+	err = statsutil.CallWithSCtx(sa.statsHandle.SPool(), func(sctx sessionctx.Context) error {
+		parameters := getAutoAnalyzeParameters(sctx)
+		autoAnalyzeRatio := parseAutoAnalyzeRatio(parameters[variable.TiDBAutoAnalyzeRatio])
+		pruneMode := variable.PartitionPruneMode(sctx.GetSessionVars().PartitionPruneMode.Load())
+		jobInfos, err = BuildAnalysisJobInfos(sctx, sa.statsHandle, autoAnalyzeRatio, pruneMode)
+		return err
+	})
+	return
+}
+
 // CheckAnalyzeVersion checks whether all the statistics versions of this table's columns and indexes are the same.
 func (sa *statsAnalyze) CheckAnalyzeVersion(tblInfo *model.TableInfo, physicalIDs []int64, version *int) bool {
 	// We simply choose one physical id to get its stats.
@@ -332,6 +348,18 @@ func HandleAutoAnalyze(
 	}
 	pruneMode := variable.PartitionPruneMode(sctx.GetSessionVars().PartitionPruneMode.Load())
 
+	if variable.EnableAutoAnalyzePriorityQueue.Load() && PickOneTableAndAnalyzeByPriority != nil {
+		return PickOneTableAndAnalyzeByPriority(
+			sctx,
+			statsHandle,
+			sysProcTracker,
+			autoAnalyzeRatio,
+			pruneMode,
+			start,
+			end,
+		)
+	}
+
 	return RandomPickOneTableAndTryAutoAnalyze(
 		sctx,
 		statsHandle,
@@ -385,7 +413,6 @@ func RandomPickOneTableAndTryAutoAnalyze(
 		// We shuffle dbs and tbls so that the order of iterating tables is random. If the order is fixed and the auto
 		// analyze job of one table fails for some reason, it may always analyze the same table and fail again and again
 		// when the HandleAutoAnalyze is triggered. Randomizing the order can avoid the problem.
-		// TODO: Design a priority queue to place the table which needs analyze most in the front.
 		rd.Shuffle(len(tbls), func(i, j int) {
 			tbls[i], tbls[j] = tbls[j], tbls[i]
 		})
@@ -428,7 +455,7 @@ func RandomPickOneTableAndTryAutoAnalyze(
 					partitionDefs = append(partitionDefs, def)
 				}
 			}
-			partitionStats := getPartitionStats(statsHandle, tblInfo, partitionDefs)
+			partitionStats := GetPartitionStats(statsHandle, tblInfo, partitionDefs)
 			if pruneMode == variable.Dynamic {
 				analyzed := tryAutoAnalyzePartitionTableInDynamicMode(
 					sctx,
@@ -459,7 +486,8 @@ func RandomPickOneTableAndTryAutoAnalyze(
 	return false
 }
 
-func getPartitionStats(
+// GetPartitionStats gets the stats of the partitions of the table.
+func GetPartitionStats(
 	statsHandle statstypes.StatsHandle,
 	tblInfo *model.TableInfo,
 	defs []model.PartitionDefinition,
@@ -473,6 +501,27 @@ func getPartitionStats(
 	return partitionStats
 }
 
+// The auto-analyze priority queue is registered by the refresher package. They are nil if the package
+// isn't imported, then the tables are picked randomly.
+var (
+	// PickOneTableAndAnalyzeByPriority analyzes the table with the highest priority.
+	PickOneTableAndAnalyzeByPriority func(
+		sctx sessionctx.Context,
+		statsHandle statstypes.StatsHandle,
+		sysProcTracker sessionctx.SysProcTracker,
+		autoAnalyzeRatio float64,
+		pruneMode variable.PartitionPruneMode,
+		start, end time.Time,
+	) bool
+	// BuildAnalysisJobInfos returns the jobs in the priority queue in the order they will be analyzed.
+	BuildAnalysisJobInfos func(
+		sctx sessionctx.Context,
+		statsHandle statstypes.StatsHandle,
+		autoAnalyzeRatio float64,
+		pruneMode variable.PartitionPruneMode,
+	) ([]*statstypes.AnalysisJobInfo, error)
+)
+
 // AutoAnalyzeMinCnt means if the count of table is less than this value, we don't need to do auto analyze.
 // Exported for testing.
 var AutoAnalyzeMinCnt int64 = 1000
@@ -0,0 +1,63 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import "math"
+
+const (
+	// The weights of the factors used to calculate the weight of a job.
+	changeRatioWeight = 0.6
+	sizeWeight        = 0.1
+	analysisInterval  = 0.3
+)
+
+// EventNewIndex is the special event weight of the jobs that analyze the
+// newly added indexes. They are put at the front of the queue, because the
+// optimizer can't make use of an index without stats.
+const EventNewIndex = 2.0
+
+// PriorityCalculator calculates the weights of the analysis jobs.
+type PriorityCalculator struct{}
+
+// NewPriorityCalculator creates a new PriorityCalculator.
+func NewPriorityCalculator() *PriorityCalculator {
+	return &PriorityCalculator{}
+}
+
+// CalculateWeight calculates the weight of the job:
+//
+//	0.6 * log10(1 + ChangeRatio) +
+//	0.1 * (1 - log10(1 + TableSize)) +
+//	0.3 * log10(1 + sqrt(AnalysisInterval)) +
+//	special event
+//
+// The change ratio is in percent and the analysis interval is in seconds.
+// The tables with more changes and longer intervals since the last analysis
+// go first, and the smaller tables are preferred since they are cheaper to
+// analyze.
+func (pc *PriorityCalculator) CalculateWeight(job *TableAnalysisJob) float64 {
+	changeRatio := 100 * job.ChangePercentage
+	return changeRatioWeight*math.Log10(1+changeRatio) +
+		sizeWeight*(1-math.Log10(1+job.TableSize)) +
+		analysisInterval*math.Log10(1+math.Sqrt(job.LastAnalysisDuration.Seconds())) +
+		pc.getSpecialEvent(job)
+}
+
+func (*PriorityCalculator) getSpecialEvent(job *TableAnalysisJob) float64 {
+	if job.HasNewlyAddedIndex() {
+		return EventNewIndex
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityqueue
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateWeight(t *testing.T) {
+	calculator := NewPriorityCalculator()
+	base := &TableAnalysisJob{
+		ChangePercentage:     0.5,
+		TableSize:            1000,
+		LastAnalysisDuration: time.Hour,
+	}
+	baseWeight := calculator.CalculateWeight(base)
+	require.Greater(t, baseWeight, 0.0)
+
+	// More changes.
+	job := *base
+	job.ChangePercentage = 0.9
+	require.Greater(t, calculator.CalculateWeight(&job), baseWeight)
+
+	// Smaller table.
+	job = *base
+	job.TableSize = 100
+	require.Greater(t, calculator.CalculateWeight(&job), baseWeight)
+
+	// Longer since the last analysis.
+	job = *base
+	job.LastAnalysisDuration = 24 * time.Hour
+	require.Greater(t, calculator.CalculateWeight(&job), baseWeight)
+
+	// Newly added index.
+	job = *base
+	job.ChangePercentage = 0
+	job.Indexes = []string{"idx"}
+	require.Greater(t, calculator.CalculateWeight(&job), baseWeight)
+	job.Indexes = nil
+	job.PartitionIndexes = map[string][]string{"idx": {"p0"}}
+	require.Greater(t, calculator.CalculateWeight(&job), baseWeight)
+}
+
+func TestCalculateWeightOrder(t *testing.T) {
+	calculator := NewPriorityCalculator()
+	jobs := []*TableAnalysisJob{
+		{TableName: "small_change", ChangePercentage: 0.6, TableSize: 1000, LastAnalysisDuration: time.Hour},
+		{TableName: "big_change", ChangePercentage: 1, TableSize: 1000, LastAnalysisDuration: time.Hour},
+		{TableName: "new_index", Indexes: []string{"idx"}, TableSize: 1000, LastAnalysisDuration: time.Hour},
+	}
+	queue := make(AnalysisQueue, 0, len(jobs))
+	for _, job := range jobs {
+		job.Weight = calculator.CalculateWeight(job)
+		heap.Push(&queue, job)
+	}
+	require.Equal(t, "new_index", heap.Pop(&queue).(*TableAnalysisJob).TableName)
+	require.Equal(t, "big_change", heap.Pop(&queue).(*TableAnalysisJob).TableName)
+	require.Equal(t, "small_change", heap.Pop(&queue).(*TableAnalysisJob).TableName)
+}
@@ -21,6 +21,10 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics/handle/util"
 )
 
+// NoRecord is returned when there is no analysis record of the table or partitions.
+// It's distinguished from 0, which can be a real duration, e.g. the analysis just failed.
+const NoRecord = -1
+
 const avgDurationQueryForTable = `
 	SELECT AVG(TIMESTAMPDIFF(SECOND, start_time, end_time)) AS avg_duration
 	FROM (
@@ -68,7 +72,7 @@ const lastFailedDurationQueryForPartition = `
 `
 
 // getAverageAnalysisDuration returns the average duration of the last 5 successful analyses for each specified partition.
-// If there are no successful analyses, it returns 0.
+// If there are no successful analyses, it returns NoRecord.
 func getAverageAnalysisDuration(
 	sctx sessionctx.Context,
 	schema, tableName string,
@@ -89,9 +93,9 @@ func getAverageAnalysisDuration(
 		return 0, err
 	}
 
-	// NOTE: if there are no successful analyses, we return 0.
+	// NOTE: if there are no successful analyses, we return NoRecord.
 	if len(rows) == 0 || rows[0].IsNull(0) {
-		return 0, nil
+		return NoRecord, nil
 	}
 	avgDuration := rows[0].GetMyDecimal(0)
 	duration, err := avgDuration.ToFloat64()
@@ -103,7 +107,7 @@ func getAverageAnalysisDuration(
 }
 
 // getLastFailedAnalysisDuration returns the duration since the last failed analysis.
-// If there is no failed analysis, it returns 0.
+// If there is no failed analysis, it returns NoRecord.
 func getLastFailedAnalysisDuration(
 	sctx sessionctx.Context,
 	schema, tableName string,
@@ -124,9 +128,9 @@ func getLastFailedAnalysisDuration(
 		return 0, err
 	}
 
-	// NOTE: if there are no failed analyses, we return 0.
+	// NOTE: if there are no failed analyses, we return NoRecord.
 	if len(rows) == 0 || rows[0].IsNull(0) {
-		return 0, nil
+		return NoRecord, nil
 	}
 	lastFailedDuration := rows[0].GetUint64(0)
 
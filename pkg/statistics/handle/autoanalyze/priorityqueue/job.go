@@ -15,15 +15,24 @@
 package priorityqueue
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/exec"
+	statslogutil "github.com/pingcap/tidb/pkg/statistics/handle/logutil"
 	statstypes "github.com/pingcap/tidb/pkg/statistics/handle/types"
 	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"go.uber.org/zap"
 )
 
+// defaultFailedAnalysisWaitTime is the default wait time for the next analysis after a failed analysis.
+// NOTE: this is only used when the average analysis duration is not available.(No successful analysis before)
+const defaultFailedAnalysisWaitTime = 30 * time.Minute
+
 // TableAnalysisJob defines the structure for table analysis job information.
 type TableAnalysisJob struct {
 	// Only set when partitions's indexes need to be analyzed.
@@ -37,7 +46,125 @@ type TableAnalysisJob struct {
 	TableID          int64
 	TableStatsVer    int
 	ChangePercentage float64
+	TableSize        float64
 	Weight           float64
+	// LastAnalysisDuration is the time elapsed since the last analysis.
+	LastAnalysisDuration time.Duration
+}
+
+// HasNewlyAddedIndex checks whether the job analyzes the indexes that have no stats.
+func (j *TableAnalysisJob) HasNewlyAddedIndex() bool {
+	return len(j.PartitionIndexes) > 0 || len(j.Indexes) > 0
+}
+
+// GetPartitionNames returns the names of the partitions analyzed by the job.
+func (j *TableAnalysisJob) GetPartitionNames() []string {
+	if len(j.PartitionIndexes) == 0 {
+		return j.Partitions
+	}
+	names := make(map[string]struct{}, len(j.PartitionIndexes))
+	for _, partitionNames := range j.PartitionIndexes {
+		for _, name := range partitionNames {
+			names[name] = struct{}{}
+		}
+	}
+	partitions := make([]string, 0, len(names))
+	for name := range names {
+		partitions = append(partitions, name)
+	}
+	sort.Strings(partitions)
+	return partitions
+}
+
+// GetIndexNames returns the names of the indexes analyzed by the job.
+func (j *TableAnalysisJob) GetIndexNames() []string {
+	if len(j.PartitionIndexes) == 0 {
+		return j.Indexes
+	}
+	indexes := make([]string, 0, len(j.PartitionIndexes))
+	for name := range j.PartitionIndexes {
+		indexes = append(indexes, name)
+	}
+	sort.Strings(indexes)
+	return indexes
+}
+
+// IsValidToAnalyze checks whether the table or partitions are valid to analyze.
+// We need to check the last failed analysis duration and the average analysis duration.
+// If the last failed analysis duration is less than 2 times the average analysis duration,
+// we skip this table to avoid too much failed analysis.
+func (j *TableAnalysisJob) IsValidToAnalyze(sctx sessionctx.Context) (bool, string) {
+	partitionNames := j.GetPartitionNames()
+	lastFailedAnalysisDuration, err := getLastFailedAnalysisDuration(sctx, j.TableSchema, j.TableName, partitionNames...)
+	if err != nil {
+		statslogutil.StatsLogger().Warn(
+			"Fail to get last failed analysis duration",
+			zap.String("schema", j.TableSchema),
+			zap.String("table", j.TableName),
+			zap.Strings("partitions", partitionNames),
+			zap.Error(err),
+		)
+		return false, fmt.Sprintf("fail to get last failed analysis duration: %v", err)
+	}
+	// No failed analysis, it's always valid.
+	if lastFailedAnalysisDuration == NoRecord {
+		return true, ""
+	}
+
+	averageAnalysisDuration, err := getAverageAnalysisDuration(sctx, j.TableSchema, j.TableName, partitionNames...)
+	if err != nil {
+		statslogutil.StatsLogger().Warn(
+			"Fail to get average analysis duration",
+			zap.String("schema", j.TableSchema),
+			zap.String("table", j.TableName),
+			zap.Strings("partitions", partitionNames),
+			zap.Error(err),
+		)
+		return false, fmt.Sprintf("fail to get average analysis duration: %v", err)
+	}
+
+	// Only failed analyses before, wait for a while to avoid retrying too frequently.
+	if averageAnalysisDuration == NoRecord {
+		if lastFailedAnalysisDuration < defaultFailedAnalysisWaitTime {
+			return false, fmt.Sprintf(
+				"last failed analysis duration is less than %v",
+				defaultFailedAnalysisWaitTime,
+			)
+		}
+		return true, ""
+	}
+
+	if lastFailedAnalysisDuration < 2*averageAnalysisDuration {
+		return false, fmt.Sprintf(
+			"last failed analysis duration %v is less than 2 times the average analysis duration %v",
+			lastFailedAnalysisDuration,
+			averageAnalysisDuration,
+		)
+	}
+	return true, ""
+}
+
+// String implements fmt.Stringer interface.
+func (j *TableAnalysisJob) String() string {
+	return fmt.Sprintf(
+		"TableAnalysisJob: {AnalyzeType: %s, Schema: %s, Table: %s, TableID: %d, TableStatsVer: %d, ChangePercentage: %.2f, TableSize: %.2f, LastAnalysisDuration: %v, Weight: %.6f}",
+		j.getAnalyzeType(),
+		j.TableSchema, j.TableName, j.TableID, j.TableStatsVer,
+		j.ChangePercentage, j.TableSize, j.LastAnalysisDuration, j.Weight,
+	)
+}
+
+func (j *TableAnalysisJob) getAnalyzeType() string {
+	switch {
+	case len(j.PartitionIndexes) > 0:
+		return "analyzePartitionIndexes"
+	case len(j.Partitions) > 0:
+		return "analyzePartitions"
+	case len(j.Indexes) > 0:
+		return "analyzeIndexes"
+	default:
+		return "analyzeTable"
+	}
 }
 
 // Execute executes the analyze statement.
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/stretchr/testify/require"
@@ -234,3 +235,86 @@ func TestAnalyzePartitionIndexes(t *testing.T) {
 	require.NotNil(t, tblStats.Indices[1])
 	require.True(t, tblStats.Indices[1].IsAnalyzed())
 }
+
+func TestIsValidToAnalyze(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(session.CreateAnalyzeJobs)
+	se := tk.Session()
+	sctx := se.(sessionctx.Context)
+	job := &TableAnalysisJob{
+		TableSchema: "example_schema",
+		TableName:   "example_table1",
+	}
+	// No analysis before.
+	valid, _ := job.IsValidToAnalyze(sctx)
+	require.True(t, valid)
+
+	// The last analysis failed long ago.
+	insertMultipleFinishedJobs(tk, "example_table1", "")
+	insertFailedJob(tk, "example_schema", "example_table1", "")
+	valid, _ = job.IsValidToAnalyze(sctx)
+	require.True(t, valid)
+
+	// The last analysis just failed, and the average duration is 1 hour.
+	tk.MustExec(`
+	INSERT INTO mysql.analyze_jobs (
+		table_schema,
+		table_name,
+		job_info,
+		start_time,
+		end_time,
+		state,
+		fail_reason,
+		instance
+	) VALUES (
+		'example_schema',
+		'example_table1',
+		'Job information for failed job',
+		NOW(),
+		NOW(),
+		'failed',
+		'Some reason for failure',
+		'example_instance'
+	);
+	`)
+	valid, reason := job.IsValidToAnalyze(sctx)
+	require.False(t, valid)
+	require.Contains(t, reason, "less than 2 times the average analysis duration")
+
+	// Only failed analyses before.
+	job = &TableAnalysisJob{
+		TableSchema: "example_schema",
+		TableName:   "example_table2",
+		Partitions:  []string{"p0", "p1"},
+	}
+	insertFailedJob(tk, "example_schema", "example_table2", "p0")
+	valid, _ = job.IsValidToAnalyze(sctx)
+	require.True(t, valid)
+	tk.MustExec(`
+	INSERT INTO mysql.analyze_jobs (
+		table_schema,
+		table_name,
+		partition_name,
+		job_info,
+		start_time,
+		end_time,
+		state,
+		fail_reason,
+		instance
+	) VALUES (
+		'example_schema',
+		'example_table2',
+		'p1',
+		'Job information for failed job',
+		NOW(),
+		NOW(),
+		'failed',
+		'Some reason for failure',
+		'example_instance'
+	);
+	`)
+	valid, reason = job.IsValidToAnalyze(sctx)
+	require.False(t, valid)
+	require.Contains(t, reason, "last failed analysis duration is less than")
+}
@@ -0,0 +1,432 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package refresher picks the tables to auto analyze by a priority queue. It's registered into the
+// autoanalyze package by importing it, because the tests of the priority queue use the session,
+// which can't depend on the priority queue.
+package refresher
+
+import (
+	"container/heap"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/priorityqueue"
+	"github.com/pingcap/tidb/pkg/statistics/handle/lockstats"
+	statslogutil "github.com/pingcap/tidb/pkg/statistics/handle/logutil"
+	statstypes "github.com/pingcap/tidb/pkg/statistics/handle/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+func init() {
+	autoanalyze.PickOneTableAndAnalyzeByPriority = PickOneTableAndAnalyzeByPriority
+	autoanalyze.BuildAnalysisJobInfos = BuildAnalysisJobInfos
+}
+
+const (
+	// unanalyzedTableDefaultChangePercentage is the default change percentage of unanalyzed table.
+	unanalyzedTableDefaultChangePercentage = 1
+	// unanalyzedTableDefaultLastUpdateDuration is the default last update duration of unanalyzed table.
+	unanalyzedTableDefaultLastUpdateDuration = -30 * time.Minute
+)
+
+// PickOneTableAndAnalyzeByPriority builds the priority queue of the tables that need to be analyzed,
+// and analyzes the one with the highest weight. The tables whose recent analyses failed are skipped.
+func PickOneTableAndAnalyzeByPriority(
+	sctx sessionctx.Context,
+	statsHandle statstypes.StatsHandle,
+	sysProcTracker sessionctx.SysProcTracker,
+	autoAnalyzeRatio float64,
+	pruneMode variable.PartitionPruneMode,
+	start, end time.Time,
+) bool {
+	jobs, err := BuildAnalysisJobQueue(sctx, statsHandle, autoAnalyzeRatio, pruneMode)
+	if err != nil {
+		statslogutil.StatsLogger().Error(
+			"build the auto analyze priority queue failed",
+			zap.Error(err),
+		)
+		return false
+	}
+	for jobs.Len() > 0 {
+		// Sometimes the tables are too many. Auto-analyze will take too much time on it.
+		// so we need to check the available time.
+		if !timeutil.WithinDayTimePeriod(start, end, time.Now()) {
+			return false
+		}
+		job := heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+		if valid, reason := job.IsValidToAnalyze(sctx); !valid {
+			statslogutil.StatsLogger().Info(
+				"skip the auto analyze job",
+				zap.Stringer("job", job),
+				zap.String("reason", reason),
+			)
+			continue
+		}
+		statslogutil.StatsLogger().Info(
+			"auto analyze triggered",
+			zap.Stringer("job", job),
+		)
+		if err := job.Execute(statsHandle, sysProcTracker); err != nil {
+			statslogutil.StatsLogger().Error(
+				"execute the auto analyze job failed",
+				zap.Stringer("job", job),
+				zap.Error(err),
+			)
+		}
+		// Analyze one table at a time to let it get the freshest parameters.
+		// Others will be analyzed next round.
+		return true
+	}
+	return false
+}
+
+// BuildAnalysisJobInfos builds the priority queue of the tables that need to be analyzed,
+// and returns the jobs in the queue in the order they will be analyzed.
+func BuildAnalysisJobInfos(
+	sctx sessionctx.Context,
+	statsHandle statstypes.StatsHandle,
+	autoAnalyzeRatio float64,
+	pruneMode variable.PartitionPruneMode,
+) ([]*statstypes.AnalysisJobInfo, error) {
+	jobs, err := BuildAnalysisJobQueue(sctx, statsHandle, autoAnalyzeRatio, pruneMode)
+	if err != nil {
+		return nil, err
+	}
+	jobInfos := make([]*statstypes.AnalysisJobInfo, 0, jobs.Len())
+	for jobs.Len() > 0 {
+		job := heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+		jobInfos = append(jobInfos, &statstypes.AnalysisJobInfo{
+			TableSchema:          job.TableSchema,
+			TableName:            job.TableName,
+			Partitions:           job.GetPartitionNames(),
+			Indexes:              job.GetIndexNames(),
+			ChangePercentage:     job.ChangePercentage,
+			TableSize:            job.TableSize,
+			LastAnalysisDuration: job.LastAnalysisDuration,
+			Weight:               job.Weight,
+		})
+	}
+	return jobInfos, nil
+}
+
+// BuildAnalysisJobQueue walks all the tables and builds the priority queue of the tables,
+// partitions and indexes that need to be analyzed.
+func BuildAnalysisJobQueue(
+	sctx sessionctx.Context,
+	statsHandle statstypes.StatsHandle,
+	autoAnalyzeRatio float64,
+	pruneMode variable.PartitionPruneMode,
+) (*priorityqueue.AnalysisQueue, error) {
+	// Query locked tables once to minimize overhead.
+	// Outdated lock info is acceptable as we verify table lock status pre-analysis.
+	lockedTables, err := lockstats.QueryLockedTables(sctx)
+	if err != nil {
+		return nil, err
+	}
+	currentVer, err := sctx.GetStore().CurrentVersion(oracle.GlobalTxnScope)
+	if err != nil {
+		return nil, err
+	}
+	currentTs := currentVer.Ver
+	calculator := priorityqueue.NewPriorityCalculator()
+	jobs := make(priorityqueue.AnalysisQueue, 0)
+	pushJob := func(job *priorityqueue.TableAnalysisJob) {
+		if job == nil {
+			return
+		}
+		job.Weight = calculator.CalculateWeight(job)
+		heap.Push(&jobs, job)
+	}
+
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	for _, db := range is.AllSchemaNames() {
+		// Ignore the memory and system database.
+		if util.IsMemOrSysDB(strings.ToLower(db)) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(model.NewCIStr(db)) {
+			tblInfo := tbl.Meta()
+			// If table locked, skip analyze all partitions of the table.
+			if _, ok := lockedTables[tblInfo.ID]; ok {
+				continue
+			}
+			if tblInfo.IsView() {
+				continue
+			}
+			pi := tblInfo.GetPartitionInfo()
+			if pi == nil {
+				pushJob(createTableAnalysisJob(
+					sctx,
+					db,
+					tblInfo,
+					statsHandle.GetTableStatsForAutoAnalyze(tblInfo),
+					autoAnalyzeRatio,
+					currentTs,
+				))
+				continue
+			}
+			// Only analyze the partition that has not been locked.
+			partitionDefs := make([]model.PartitionDefinition, 0, len(pi.Definitions))
+			for _, def := range pi.Definitions {
+				if _, ok := lockedTables[def.ID]; !ok {
+					partitionDefs = append(partitionDefs, def)
+				}
+			}
+			partitionStats := autoanalyze.GetPartitionStats(statsHandle, tblInfo, partitionDefs)
+			if pruneMode == variable.Dynamic {
+				pushJob(createTableAnalysisJobForPartitions(
+					sctx,
+					db,
+					tblInfo,
+					statsHandle.GetTableStatsForAutoAnalyze(tblInfo),
+					partitionDefs,
+					partitionStats,
+					autoAnalyzeRatio,
+					currentTs,
+				))
+				continue
+			}
+			// In static mode, every partition is analyzed as a standalone table.
+			for _, def := range partitionDefs {
+				pushJob(createTableAnalysisJobForPartitions(
+					sctx,
+					db,
+					tblInfo,
+					partitionStats[def.ID],
+					[]model.PartitionDefinition{def},
+					partitionStats,
+					autoAnalyzeRatio,
+					currentTs,
+				))
+			}
+		}
+	}
+	return &jobs, nil
+}
+
+func createTableAnalysisJob(
+	sctx sessionctx.Context,
+	tableSchema string,
+	tblInfo *model.TableInfo,
+	tblStats *statistics.Table,
+	autoAnalyzeRatio float64,
+	currentTs uint64,
+) *priorityqueue.TableAnalysisJob {
+	// 1. If the statistics are either not loaded or are classified as pseudo, there is no need for analyze.
+	// 2. If the table is too small, we don't want to waste time to analyze it.
+	//    Leave the opportunity to other bigger tables.
+	if tblStats == nil || tblStats.Pseudo || tblStats.RealtimeCount < autoanalyze.AutoAnalyzeMinCnt {
+		return nil
+	}
+	tableStatsVer := sctx.GetSessionVars().AnalyzeVersion
+	statistics.CheckAnalyzeVerOnTable(tblStats, &tableStatsVer)
+
+	changePercentage := CalculateChangePercentage(tblStats, autoAnalyzeRatio)
+	var indexes []string
+	// Analyzing the whole table analyzes all its indexes, so the indexes
+	// without stats are only checked when the table doesn't need to analyze.
+	if changePercentage == 0 {
+		indexes = CheckIndexesNeedAnalyze(tblInfo, tblStats)
+		if len(indexes) == 0 {
+			return nil
+		}
+	}
+
+	return &priorityqueue.TableAnalysisJob{
+		TableID:              tblInfo.ID,
+		TableSchema:          tableSchema,
+		TableName:            tblInfo.Name.O,
+		TableStatsVer:        tableStatsVer,
+		ChangePercentage:     changePercentage,
+		TableSize:            calculateTableSize(tblInfo, tblStats),
+		LastAnalysisDuration: GetTableLastAnalyzeDuration(tblStats, currentTs),
+		Indexes:              indexes,
+	}
+}
+
+// createTableAnalysisJobForPartitions creates the job to analyze the partitions of the table.
+// The partitions are analyzed together to reduce the overhead of merging the global stats.
+func createTableAnalysisJobForPartitions(
+	sctx sessionctx.Context,
+	tableSchema string,
+	tblInfo *model.TableInfo,
+	tblStats *statistics.Table,
+	defs []model.PartitionDefinition,
+	partitionStats map[int64]*statistics.Table,
+	autoAnalyzeRatio float64,
+	currentTs uint64,
+) *priorityqueue.TableAnalysisJob {
+	if tblStats == nil {
+		return nil
+	}
+	tableStatsVer := sctx.GetSessionVars().AnalyzeVersion
+	statistics.CheckAnalyzeVerOnTable(tblStats, &tableStatsVer)
+
+	var (
+		partitionNames        []string
+		totalChangePercentage float64
+		totalTableSize        float64
+		lastAnalysisDuration  time.Duration
+		availableDefs         = make([]model.PartitionDefinition, 0, len(defs))
+	)
+	for _, def := range defs {
+		partitionStatsTbl := partitionStats[def.ID]
+		// 1. If the stats are not loaded, we don't need to analyze it.
+		// 2. If the table is too small, we don't want to waste time to analyze it.
+		//    Leave the opportunity to other bigger tables.
+		if partitionStatsTbl == nil || partitionStatsTbl.Pseudo || partitionStatsTbl.RealtimeCount < autoanalyze.AutoAnalyzeMinCnt {
+			continue
+		}
+		availableDefs = append(availableDefs, def)
+		totalTableSize += calculateTableSize(tblInfo, partitionStatsTbl)
+		lastAnalysisDuration = max(lastAnalysisDuration, GetTableLastAnalyzeDuration(partitionStatsTbl, currentTs))
+		changePercentage := CalculateChangePercentage(partitionStatsTbl, autoAnalyzeRatio)
+		if changePercentage == 0 {
+			continue
+		}
+		partitionNames = append(partitionNames, def.Name.O)
+		totalChangePercentage += changePercentage
+		statistics.CheckAnalyzeVerOnTable(partitionStatsTbl, &tableStatsVer)
+	}
+
+	job := &priorityqueue.TableAnalysisJob{
+		TableID:              tblInfo.ID,
+		TableSchema:          tableSchema,
+		TableName:            tblInfo.Name.O,
+		TableStatsVer:        tableStatsVer,
+		TableSize:            totalTableSize,
+		LastAnalysisDuration: lastAnalysisDuration,
+	}
+	if len(partitionNames) > 0 {
+		job.Partitions = partitionNames
+		job.ChangePercentage = totalChangePercentage / float64(len(partitionNames))
+		return job
+	}
+	partitionIndexes := CheckNewlyAddedIndexesNeedAnalyzeForPartitionedTable(tblInfo, availableDefs, partitionStats)
+	if len(partitionIndexes) == 0 {
+		return nil
+	}
+	job.PartitionIndexes = partitionIndexes
+	return job
+}
+
+// CalculateChangePercentage calculates the change percentage of the table
+// based on the change count and the analysis count. It returns 0 if the table
+// doesn't need to be analyzed.
+func CalculateChangePercentage(tblStats *statistics.Table, autoAnalyzeRatio float64) float64 {
+	if !autoanalyze.TableAnalyzed(tblStats) {
+		return unanalyzedTableDefaultChangePercentage
+	}
+	// Auto analyze based on the change percentage is disabled.
+	// However, this check should not affect the analysis of indexes,
+	// as index analysis is still needed for query performance.
+	if autoAnalyzeRatio == 0 {
+		return 0
+	}
+	tblCnt := float64(tblStats.RealtimeCount)
+	if histCnt := tblStats.GetAnalyzeRowCount(); histCnt > 0 {
+		tblCnt = histCnt
+	}
+	res := float64(tblStats.ModifyCount) / tblCnt
+	if res > autoAnalyzeRatio {
+		return res
+	}
+	return 0
+}
+
+// calculateTableSize estimates the size of the table by its row count and
+// column count, which is roughly proportional to the cost of analyzing it.
+func calculateTableSize(tblInfo *model.TableInfo, tblStats *statistics.Table) float64 {
+	return float64(tblStats.RealtimeCount) * float64(len(tblInfo.Columns))
+}
+
+// GetTableLastAnalyzeDuration gets the duration since the last analysis of the table.
+func GetTableLastAnalyzeDuration(tblStats *statistics.Table, currentTs uint64) time.Duration {
+	lastTime := findLastAnalyzeTime(tblStats, currentTs)
+	return oracle.GetTimeFromTS(currentTs).Sub(lastTime)
+}
+
+// findLastAnalyzeTime finds the last analyze time of the table.
+// It uses `LastUpdateVersion` to find the last analyze time.
+// The `LastUpdateVersion` is the version of the transaction that updates the statistics.
+// It always not null(default 0), so we can use it to find the last analyze time.
+func findLastAnalyzeTime(tblStats *statistics.Table, currentTs uint64) time.Time {
+	maxVersion := uint64(0)
+	for _, idx := range tblStats.Indices {
+		if idx.IsAnalyzed() {
+			maxVersion = max(maxVersion, idx.LastUpdateVersion)
+		}
+	}
+	for _, col := range tblStats.Columns {
+		if col.IsAnalyzed() {
+			maxVersion = max(maxVersion, col.LastUpdateVersion)
+		}
+	}
+	// Table is not analyzed, compose a fake version.
+	if maxVersion == 0 {
+		return oracle.GetTimeFromTS(currentTs).Add(unanalyzedTableDefaultLastUpdateDuration)
+	}
+	return oracle.GetTimeFromTS(maxVersion)
+}
+
+// CheckIndexesNeedAnalyze checks if the indexes of the table need to be analyzed.
+func CheckIndexesNeedAnalyze(tblInfo *model.TableInfo, tblStats *statistics.Table) []string {
+	// If table is not analyzed, we need to analyze whole table.
+	// So we don't need to check indexes.
+	if !autoanalyze.TableAnalyzed(tblStats) {
+		return nil
+	}
+
+	indexes := make([]string, 0, len(tblInfo.Indices))
+	// Check if missing index stats.
+	for _, idx := range tblInfo.Indices {
+		if _, ok := tblStats.Indices[idx.ID]; !ok && idx.State == model.StatePublic {
+			indexes = append(indexes, idx.Name.O)
+		}
+	}
+	return indexes
+}
+
+// CheckNewlyAddedIndexesNeedAnalyzeForPartitionedTable checks if the indexes of the partitioned table need to be analyzed.
+// It returns a map from index name to the names of the partitions that need to be analyzed.
+func CheckNewlyAddedIndexesNeedAnalyzeForPartitionedTable(
+	tblInfo *model.TableInfo,
+	defs []model.PartitionDefinition,
+	partitionStats map[int64]*statistics.Table,
+) map[string][]string {
+	partitionIndexes := make(map[string][]string, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic {
+			continue
+		}
+		// Index on all partitions need to be analyzed.
+		for _, def := range defs {
+			tblStats := partitionStats[def.ID]
+			if _, ok := tblStats.Indices[idx.ID]; !ok {
+				partitionIndexes[idx.Name.O] = append(partitionIndexes[idx.Name.O], def.Name.O)
+			}
+		}
+	}
+	return partitionIndexes
+}
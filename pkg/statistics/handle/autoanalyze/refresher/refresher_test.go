@@ -0,0 +1,205 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresher_test
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/priorityqueue"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/refresher"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestBuildAnalysisJobQueue(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	handle := dom.StatsHandle()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b int, index ia(a))")
+	tk.MustExec("create table t2 (a int, b int, index ia(a))")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6)")
+	require.NoError(t, handle.DumpStatsDeltaToKV(true))
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	autoanalyze.AutoAnalyzeMinCnt = 0
+	defer func() {
+		autoanalyze.AutoAnalyzeMinCnt = 1000
+	}()
+
+	sctx := tk.Session().(sessionctx.Context)
+	buildQueue := func() *priorityqueue.AnalysisQueue {
+		jobs, err := refresher.BuildAnalysisJobQueue(sctx, handle, 0.5, variable.Static)
+		require.NoError(t, err)
+		return jobs
+	}
+	// Both tables are unanalyzed, the smaller one goes first.
+	jobs := buildQueue()
+	require.Equal(t, 2, jobs.Len())
+	job := heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, "t1", job.TableName)
+	require.Equal(t, float64(1), job.ChangePercentage)
+	require.Equal(t, float64(3*2), job.TableSize)
+	require.Empty(t, job.Indexes)
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, "t2", job.TableName)
+	require.Equal(t, float64(6*2), job.TableSize)
+	tk.MustQuery("select table_schema, table_name, partition_names, index_names, change_percentage, table_size from information_schema.tidb_auto_analyze_queue").Check(testkit.Rows(
+		"test t1   1 6",
+		"test t2   1 12",
+	))
+
+	// No jobs after analyzing the tables.
+	tk.MustExec("analyze table t1, t2")
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	require.Equal(t, 0, buildQueue().Len())
+
+	// The newly added index goes first.
+	tk.MustExec("alter table t1 add index ib(b)")
+	tk.MustExec("insert into t2 values (7, 7), (8, 8), (9, 9), (10, 10), (11, 11), (12, 12)")
+	require.NoError(t, handle.DumpStatsDeltaToKV(true))
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	jobs = buildQueue()
+	require.Equal(t, 2, jobs.Len())
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, "t1", job.TableName)
+	require.Equal(t, []string{"ib"}, job.Indexes)
+	require.Equal(t, float64(0), job.ChangePercentage)
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, "t2", job.TableName)
+	require.Equal(t, float64(1), job.ChangePercentage)
+	require.Empty(t, job.Indexes)
+	tk.MustQuery("select table_name, index_names from information_schema.tidb_auto_analyze_queue").Check(testkit.Rows(
+		"t1 ib",
+		"t2 ",
+	))
+
+	// The locked table is skipped.
+	tk.MustExec("lock stats t1")
+	jobs = buildQueue()
+	require.Equal(t, 1, jobs.Len())
+	require.Equal(t, "t2", heap.Pop(jobs).(*priorityqueue.TableAnalysisJob).TableName)
+}
+
+func TestBuildAnalysisJobQueueForPartitionedTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	handle := dom.StatsHandle()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index ia(a)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	require.NoError(t, handle.HandleDDLEvent(<-handle.DDLEventCh()))
+	tk.MustExec("insert into t values (1, 1), (2, 2), (11, 11)")
+	require.NoError(t, handle.DumpStatsDeltaToKV(true))
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	autoanalyze.AutoAnalyzeMinCnt = 0
+	defer func() {
+		autoanalyze.AutoAnalyzeMinCnt = 1000
+	}()
+
+	sctx := tk.Session().(sessionctx.Context)
+	// In dynamic mode, the partitions are analyzed together.
+	jobs, err := refresher.BuildAnalysisJobQueue(sctx, handle, 0.5, variable.Dynamic)
+	require.NoError(t, err)
+	require.Equal(t, 1, jobs.Len())
+	job := heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, []string{"p0", "p1"}, job.Partitions)
+	require.Equal(t, float64(3*2), job.TableSize)
+
+	// In static mode, every partition is analyzed alone.
+	jobs, err = refresher.BuildAnalysisJobQueue(sctx, handle, 0.5, variable.Static)
+	require.NoError(t, err)
+	require.Equal(t, 2, jobs.Len())
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, []string{"p1"}, job.Partitions)
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, []string{"p0"}, job.Partitions)
+
+	// The newly added index is analyzed on all the partitions.
+	tk.MustExec("analyze table t")
+	tk.MustExec("alter table t add index ib(b)")
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	jobs, err = refresher.BuildAnalysisJobQueue(sctx, handle, 0.5, variable.Dynamic)
+	require.NoError(t, err)
+	require.Equal(t, 1, jobs.Len())
+	job = heap.Pop(jobs).(*priorityqueue.TableAnalysisJob)
+	require.Equal(t, map[string][]string{"ib": {"p0", "p1"}}, job.PartitionIndexes)
+	require.Empty(t, job.Partitions)
+}
+
+func TestAutoAnalyzeByPriority(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	handle := dom.StatsHandle()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t1 values (1)")
+	tk.MustExec("insert into t2 values (1), (2), (3)")
+	require.NoError(t, handle.DumpStatsDeltaToKV(true))
+	require.NoError(t, handle.Update(dom.InfoSchema()))
+	autoanalyze.AutoAnalyzeMinCnt = 0
+	defer func() {
+		autoanalyze.AutoAnalyzeMinCnt = 1000
+	}()
+	is := dom.InfoSchema()
+	tbl1, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t1"))
+	require.NoError(t, err)
+	tbl2, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t2"))
+	require.NoError(t, err)
+
+	// The smaller table is analyzed first.
+	require.True(t, handle.HandleAutoAnalyze())
+	require.NoError(t, handle.Update(is))
+	require.True(t, autoanalyze.TableAnalyzed(handle.GetTableStats(tbl1.Meta())))
+	require.False(t, autoanalyze.TableAnalyzed(handle.GetTableStats(tbl2.Meta())))
+	require.True(t, handle.HandleAutoAnalyze())
+	require.NoError(t, handle.Update(is))
+	require.True(t, autoanalyze.TableAnalyzed(handle.GetTableStats(tbl2.Meta())))
+	require.False(t, handle.HandleAutoAnalyze())
+}
+
+func TestGetTableLastAnalyzeDuration(t *testing.T) {
+	// 2023-12-31 10:00:00
+	lastUpdateTime := time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC)
+	lastUpdateTs := oracle.GoTimeToTS(lastUpdateTime)
+	tblStats := &statistics.Table{
+		HistColl: statistics.HistColl{
+			Columns: map[int64]*statistics.Column{
+				1: {
+					StatsVer: 2,
+					Histogram: statistics.Histogram{
+						LastUpdateVersion: lastUpdateTs,
+					},
+				},
+			},
+		},
+	}
+	// 2024-01-01 10:00:00
+	currentTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	currentTs := oracle.GoTimeToTS(currentTime)
+	require.Equal(t, 24*time.Hour, refresher.GetTableLastAnalyzeDuration(tblStats, currentTs))
+
+	// The unanalyzed table is treated as analyzed 30 minutes ago.
+	tblStats = &statistics.Table{HistColl: statistics.HistColl{Columns: map[int64]*statistics.Column{}}}
+	require.Equal(t, 30*time.Minute, refresher.GetTableLastAnalyzeDuration(tblStats, currentTs))
+}
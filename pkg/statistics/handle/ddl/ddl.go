@@ -166,6 +166,10 @@ func (h *ddlHandlerImpl) HandleDDLEvent(t *util.DDLEvent) error {
 				return err
 			}
 		}
+	case model.ActionAddClusteredPrimaryKey, model.ActionDropClusteredPrimaryKey:
+		// Change id for the table stats, since the data has not changed!
+		oldTblID, newTableInfo := t.GetRewriteHandleInfo()
+		return h.statsWriter.ChangeGlobalStatsID(oldTblID, newTableInfo.ID)
 	case model.ActionFlashbackCluster:
 		return h.statsWriter.UpdateStatsVersion()
 	}
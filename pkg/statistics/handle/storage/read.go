@@ -215,6 +215,25 @@ func ExtendedStatsFromStorage(sctx sessionctx.Context, table *statistics.Table,
 			} else {
 				item.StringVals = statsStr
 			}
+			if item.Tp == ast.StatsTypeMultiHistogram {
+				fts := make([]*types.FieldType, 0, len(item.ColIDs))
+				for _, colID := range item.ColIDs {
+					col, ok := table.Columns[colID]
+					if !ok || col.Info == nil {
+						fts = nil
+						break
+					}
+					fts = append(fts, &col.Info.FieldType)
+				}
+				// The sketch can't be used without the field types of its columns, e.g. the columns are dropped.
+				if fts != nil {
+					item.Sketch, err = statistics.DecodeMultiColumnSketch(item.StringVals, fts)
+					if err != nil {
+						statslogutil.StatsLogger().Error("decode multi-column sketch failed", zap.String("name", name), zap.Error(err))
+						return nil, err
+					}
+				}
+			}
 			table.ExtendedStats.Stats[name] = item
 		}
 	}
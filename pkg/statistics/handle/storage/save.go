@@ -311,7 +311,7 @@ func SaveTableStatsToStorage(sctx sessionctx.Context,
 		switch item.Tp {
 		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation:
 			statsStr = fmt.Sprintf("%f", item.ScalarVals)
-		case ast.StatsTypeDependency:
+		case ast.StatsTypeDependency, ast.StatsTypeMultiHistogram:
 			statsStr = item.StringVals
 		}
 		if _, err = util.Exec(sctx, "replace into mysql.stats_extended values (%?, %?, %?, %?, %?, %?, %?)", name, item.Tp, tableID, strColIDs, statsStr, version, statistics.ExtendedStatsAnalyzed); err != nil {
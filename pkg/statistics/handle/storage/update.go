@@ -178,7 +178,7 @@ func SaveExtendedStatsToStorage(sctx sessionctx.Context,
 		switch item.Tp {
 		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation:
 			statsStr = fmt.Sprintf("%f", item.ScalarVals)
-		case ast.StatsTypeDependency:
+		case ast.StatsTypeDependency, ast.StatsTypeMultiHistogram:
 			statsStr = item.StringVals
 		}
 		// If isLoad is true, it's INSERT; otherwise, it's UPDATE.
@@ -139,10 +139,27 @@ type StatsAnalyze interface {
 	// It also analyzes newly created tables and newly added indexes.
 	HandleAutoAnalyze() (analyzed bool)
 
+	// GetAutoAnalyzeQueue builds the auto-analyze priority queue with the current stats,
+	// and returns the jobs in the queue in the order they will be analyzed.
+	GetAutoAnalyzeQueue() ([]*AnalysisJobInfo, error)
+
 	// CheckAnalyzeVersion checks whether all the statistics versions of this table's columns and indexes are the same.
 	CheckAnalyzeVersion(tblInfo *model.TableInfo, physicalIDs []int64, version *int) bool
 }
 
+// AnalysisJobInfo is the information of a job in the auto-analyze priority queue.
+type AnalysisJobInfo struct {
+	TableSchema string
+	TableName   string
+	// Partitions and Indexes are empty if the job analyzes the whole table.
+	Partitions           []string
+	Indexes              []string
+	ChangePercentage     float64
+	TableSize            float64
+	LastAnalysisDuration time.Duration
+	Weight               float64
+}
+
 // StatsCache is used to manage all table statistics in memory.
 type StatsCache interface {
 	// Close closes this cache.
@@ -292,6 +292,28 @@ func (e *DDLEvent) GetRemovePartitioningInfo() (
 	return e.oldTableID, e.tableInfo, e.oldPartInfo
 }
 
+// NewRewriteHandleEvent creates a new ddl event that rewrites a table under a new handle encoding.
+// For example, `alter table t add primary key(c1) clustered`.
+func NewRewriteHandleEvent(
+	tp model.ActionType,
+	oldTableID int64,
+	newTableInfo *model.TableInfo,
+) *DDLEvent {
+	return &DDLEvent{
+		tp:         tp,
+		oldTableID: oldTableID,
+		tableInfo:  newTableInfo,
+	}
+}
+
+// GetRewriteHandleInfo gets the table info of the table that is rewritten under a new handle encoding.
+func (e *DDLEvent) GetRewriteHandleInfo() (
+	oldTableID int64,
+	newTableInfo *model.TableInfo,
+) {
+	return e.oldTableID, e.tableInfo
+}
+
 // NewFlashbackClusterEvent creates a new ddl event that flashes back the cluster.
 func NewFlashbackClusterEvent() *DDLEvent {
 	return &DDLEvent{
@@ -0,0 +1,136 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+)
+
+const (
+	// MaxMultiColumnSketchRows is the max number of sampled rows kept in a MultiColumnSketch.
+	MaxMultiColumnSketchRows = 1024
+	// maxMultiColumnSketchSize is the max length of an encoded MultiColumnSketch. It must fit in the
+	// `stats` blob column of mysql.stats_extended.
+	maxMultiColumnSketchSize = 60 * 1024
+)
+
+// MultiColumnSketch is the joint sample of a column group collected for the MULTI_HISTOGRAM extended statistics.
+// Unlike the histograms of single columns, it keeps the values of the same row together, so it reflects the
+// correlation between the columns and can be used to estimate the conjunctive conditions on them.
+type MultiColumnSketch struct {
+	// samples are the values of the sampled rows, the columns are in the order of ExtendedStatsItem.ColIDs.
+	// The values are restored with the field types of the columns once the sketch is decoded, and the
+	// timestamps are kept in UTC. The sketch is shared by the sessions, so samples must not be modified.
+	samples *chunk.Chunk
+	fts     []*types.FieldType
+}
+
+// NumRows returns the number of the sampled rows.
+func (s *MultiColumnSketch) NumRows() int {
+	if s.samples == nil {
+		return 0
+	}
+	return s.samples.NumRows()
+}
+
+// Chunk returns the sampled values of the columns at offsets as a chunk, the timestamps are converted to loc.
+// The returned chunk shares the columns with the sketch, so it must not be modified.
+func (s *MultiColumnSketch) Chunk(offsets []int, loc *time.Location) (*chunk.Chunk, error) {
+	chk := s.samples.Prune(offsets)
+	if loc == nil || loc == time.UTC {
+		return chk, nil
+	}
+	for i, offset := range offsets {
+		if s.fts[offset].GetType() != mysql.TypeTimestamp {
+			continue
+		}
+		col := chk.Column(i).CopyConstruct(nil)
+		times := col.Times()
+		for j := range times {
+			if times[j].IsZero() {
+				continue
+			}
+			if err := times[j].ConvertTimeZone(time.UTC, loc); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		chk.SetCol(i, col)
+	}
+	return chk, nil
+}
+
+// EncodeMultiColumnSketch encodes the sampled values of the columns at offsets into the string stored in mysql.stats_extended.
+// The samples are picked evenly, and fewer samples are kept if the encoded result is too large.
+func EncodeMultiColumnSketch(loc *time.Location, samples []*ReservoirRowSampleItem, offsets []int) (string, error) {
+	values := make([]types.Datum, 0, min(len(samples), MaxMultiColumnSketchRows)*len(offsets))
+	for numRows := min(len(samples), MaxMultiColumnSketchRows); numRows > 0; numRows /= 2 {
+		values = values[:0]
+		for i := 0; i < numRows; i++ {
+			// The samples are ordered by handle, so we pick them with a fixed step instead of taking the first ones.
+			sample := samples[i*len(samples)/numRows]
+			for _, offset := range offsets {
+				values = append(values, sample.Columns[offset])
+			}
+		}
+		b, err := codec.EncodeValue(loc, nil, values...)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if base64.StdEncoding.EncodedLen(len(b)) <= maxMultiColumnSketchSize {
+			return base64.StdEncoding.EncodeToString(b), nil
+		}
+	}
+	return "", nil
+}
+
+// DecodeMultiColumnSketch decodes the MultiColumnSketch from the string stored in mysql.stats_extended, fts are
+// the field types of the columns. It's called once when the statistics are loaded, so the values are restored
+// with the field types here instead of for each estimation.
+func DecodeMultiColumnSketch(data string, fts []*types.FieldType) (*MultiColumnSketch, error) {
+	numCols := len(fts)
+	s := &MultiColumnSketch{fts: fts}
+	if data == "" || numCols == 0 {
+		s.samples = chunk.NewChunkWithCapacity(fts, 0)
+		return s, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values, err := codec.Decode(b, numCols*MaxMultiColumnSketchRows)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(values)%numCols != 0 {
+		return nil, errors.Errorf("invalid multi-column sketch, %d values for %d columns", len(values), numCols)
+	}
+	s.samples = chunk.NewChunkWithCapacity(fts, len(values)/numCols)
+	for i, value := range values {
+		d, err := tablecodec.Unflatten(value, fts[i%numCols], time.UTC)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s.samples.AppendDatum(i%numCols, &d)
+	}
+	return s, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiColumnSketch(t *testing.T) {
+	samples := make([]*ReservoirRowSampleItem, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		tm := types.NewTime(types.FromDate(2024, 1, 1+i%28, 0, 0, 0, 0), mysql.TypeDatetime, 0)
+		samples = append(samples, &ReservoirRowSampleItem{
+			Columns: []types.Datum{types.NewIntDatum(int64(i)), types.NewStringDatum(fmt.Sprintf("s%d", i%10)), types.NewTimeDatum(tm)},
+		})
+	}
+	samples[0].Columns[1].SetNull()
+
+	data, err := EncodeMultiColumnSketch(time.UTC, samples, []int{2, 1})
+	require.NoError(t, err)
+	fts := []*types.FieldType{types.NewFieldType(mysql.TypeDatetime), types.NewFieldType(mysql.TypeVarchar)}
+	s, err := DecodeMultiColumnSketch(data, fts)
+	require.NoError(t, err)
+	require.Equal(t, MaxMultiColumnSketchRows, s.NumRows())
+	c, err := s.Chunk([]int{0, 1}, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, MaxMultiColumnSketchRows, c.NumRows())
+	require.Equal(t, "2024-01-01 00:00:00", c.GetRow(0).GetTime(0).String())
+	require.True(t, c.GetRow(0).IsNull(1))
+	require.Equal(t, "2024-01-02 00:00:00", c.GetRow(1).GetTime(0).String())
+	require.Equal(t, "s1", c.GetRow(1).GetString(1))
+	c, err = s.Chunk([]int{1}, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, 1, c.NumCols())
+	require.Equal(t, "s1", c.GetRow(1).GetString(0))
+
+	// The timestamps are kept in UTC and converted to the time zone of the session.
+	data, err = EncodeMultiColumnSketch(time.UTC, samples, []int{2})
+	require.NoError(t, err)
+	s, err = DecodeMultiColumnSketch(data, []*types.FieldType{types.NewFieldType(mysql.TypeTimestamp)})
+	require.NoError(t, err)
+	c, err = s.Chunk([]int{0}, time.FixedZone("UTC+8", 8*3600))
+	require.NoError(t, err)
+	require.Equal(t, "2024-01-01 08:00:00", c.GetRow(0).GetTime(0).String())
+	c, err = s.Chunk([]int{0}, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "2024-01-01 00:00:00", c.GetRow(0).GetTime(0).String())
+
+	// Fewer samples are kept if the encoded sketch is too large.
+	long := strings.Repeat("x", 1000)
+	for _, sample := range samples {
+		sample.Columns[1] = types.NewStringDatum(long)
+	}
+	data, err = EncodeMultiColumnSketch(time.UTC, samples, []int{2, 1})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), maxMultiColumnSketchSize)
+	s, err = DecodeMultiColumnSketch(data, fts)
+	require.NoError(t, err)
+	require.Equal(t, 32, s.NumRows())
+
+	s, err = DecodeMultiColumnSketch("", fts)
+	require.NoError(t, err)
+	require.Equal(t, 0, s.NumRows())
+	_, err = DecodeMultiColumnSketch(data, append(fts, types.NewFieldType(mysql.TypeLonglong)))
+	require.Error(t, err)
+}
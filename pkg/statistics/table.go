@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -72,6 +73,8 @@ type Table struct {
 
 // ExtendedStatsItem is the cached item of a mysql.stats_extended record.
 type ExtendedStatsItem struct {
+	// Sketch is decoded from StringVals for the MULTI_HISTOGRAM statistics when the statistics are loaded.
+	Sketch     *MultiColumnSketch
 	StringVals string
 	ColIDs     []int64
 	ScalarVals float64
@@ -89,6 +92,25 @@ func NewExtendedStatsColl() *ExtendedStatsColl {
 	return &ExtendedStatsColl{Stats: make(map[string]*ExtendedStatsItem)}
 }
 
+// MultiColumnStats returns the analyzed MULTI_HISTOGRAM statistics in the collection, ordered by their names.
+func (c *ExtendedStatsColl) MultiColumnStats() []*ExtendedStatsItem {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Stats))
+	for name, item := range c.Stats {
+		if item.Tp == ast.StatsTypeMultiHistogram && item.Sketch != nil && item.Sketch.NumRows() > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	items := make([]*ExtendedStatsItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, c.Stats[name])
+	}
+	return items
+}
+
 const (
 	// ExtendedStatsInited is the status for extended stats which are just registered but have not been analyzed yet.
 	ExtendedStatsInited uint8 = iota
@@ -110,6 +132,9 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColumnIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// MultiColStats are the MULTI_HISTOGRAM extended statistics used to calculate the selectivity in planner.
+	// They are only set for the HistColl of a DataSource when the extended statistics are enabled.
+	MultiColStats []*ExtendedStatsItem
 	PhysicalID    int64
 	// TODO: add AnalyzeCount here
 	RealtimeCount int64 // RealtimeCount is the current table row count, maintained by applying stats delta based on AnalyzeCount.
@@ -48,6 +48,9 @@ type Column struct {
 	*model.ColumnInfo
 	// If this column is a generated column, the expression will be stored here.
 	GeneratedExpr *ClonableExprNode
+	// If this column is a generated column being added or modified, the expression built from the
+	// public columns will be stored here. It's evaluated when the rows are written during the reorganization.
+	NonPublicGeneratedExpr expression.Expression
 	// If this column has default expr value, this expression will be stored here.
 	DefaultExpr ast.ExprNode
 }
@@ -125,6 +128,7 @@ func ToColumn(col *model.ColumnInfo) *Column {
 		col,
 		nil,
 		nil,
+		nil,
 	}
 }
 
@@ -0,0 +1,200 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// handleRewriteTable is the table being rewritten under a new handle encoding, which happens
+// when a clustered primary key is added or dropped. All the writes are double written into
+// the rewritten table, so that the table can be switched to it without losing any rows.
+type handleRewriteTable struct {
+	TableCommon
+	// rewritten is the physical table encoded with the other handle.
+	rewritten *TableCommon
+}
+
+func newHandleRewriteTable(tbl *TableCommon) (table.Table, error) {
+	rewrittenInfo := tbl.meta.HandleRewriteTableInfo()
+	// The changes are logged once by the table being rewritten for the materialized views.
+	rewrittenInfo.MaterializedViews = nil
+	rewritten, err := TableFromMeta(handleRewriteAllocators(tbl.allocs, rewrittenInfo), rewrittenInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rewrittenTbl, ok := rewritten.(*TableCommon)
+	if !ok {
+		return nil, errors.Errorf("unexpected table type %T for rewriting the handle", rewritten)
+	}
+	return &handleRewriteTable{
+		TableCommon: *tbl,
+		rewritten:   rewrittenTbl,
+	}, nil
+}
+
+// handleRewriteAllocators returns the allocators of the rewritten table. Only the row ID allocator
+// is needed, which is appended after the allocators of the table itself by autoid.NewAllocatorsFromTblInfo.
+func handleRewriteAllocators(allocs autoid.Allocators, rewrittenInfo *model.TableInfo) autoid.Allocators {
+	if rewrittenInfo.PKIsHandle || rewrittenInfo.IsCommonHandle {
+		return autoid.Allocators{}
+	}
+	for i := len(allocs.Allocs) - 1; i >= 0; i-- {
+		if allocs.Allocs[i].GetType() == autoid.RowIDAllocType {
+			return autoid.NewAllocators(false, allocs.Allocs[i])
+		}
+	}
+	return autoid.Allocators{}
+}
+
+// GetHandleRewriteTable returns the rewritten table of a table being rewritten under a new handle encoding.
+func GetHandleRewriteTable(t table.Table) (table.PhysicalTable, error) {
+	if tbl, ok := t.(*handleRewriteTable); ok {
+		return tbl.rewritten, nil
+	}
+	return nil, errors.Errorf("table %s is not being rewritten under a new handle encoding", t.Meta().Name)
+}
+
+// HandleRewriteOriginKey returns the key which maps the handle of a row in the table being rewritten
+// to the handle of the row in the rewritten table.
+func HandleRewriteOriginKey(tblInfo *model.TableInfo, h kv.Handle) kv.Key {
+	rw := tblInfo.HandleRewrite
+	return tablecodec.EncodeIndexSeekKey(rw.TableID, rw.OriginIndexID, h.Encoded())
+}
+
+// HandleRewriteOriginValue returns the value of the origin key, which is the handle in the rewritten table.
+func HandleRewriteOriginValue(h kv.Handle) []byte {
+	return tablecodec.EncodeHandleInUniqueIndexValue(h, false)
+}
+
+// AddRecord implements the table.Table AddRecord interface.
+func (t *handleRewriteTable) AddRecord(sctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (recordID kv.Handle, err error) {
+	recordID, err = t.TableCommon.AddRecord(sctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = logMViewChange(sctx, t.mviewLogs, nil, r); err != nil {
+		return nil, err
+	}
+	if t.rewritten.meta.State == model.StateDeleteOnly {
+		return recordID, nil
+	}
+	if err = t.addRewrittenRecord(sctx, recordID, r[:len(t.Cols())]); err != nil {
+		return nil, err
+	}
+	return recordID, nil
+}
+
+// UpdateRecord implements the table.Table UpdateRecord interface.
+func (t *handleRewriteTable) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	rewrittenHandle, found, err := t.getRewrittenHandle(ctx, sctx, h)
+	if err != nil {
+		return err
+	}
+	if err = t.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched); err != nil {
+		return err
+	}
+	if err = logMViewChange(sctx, t.mviewLogs, oldData, newData); err != nil {
+		return err
+	}
+	if !found {
+		// The row hasn't been backfilled into the rewritten table, the latest row will be backfilled later.
+		return nil
+	}
+	colCnt := len(t.Cols())
+	if err = t.removeRewrittenRecord(sctx, h, rewrittenHandle, oldData[:colCnt]); err != nil {
+		return err
+	}
+	if t.rewritten.meta.State == model.StateDeleteOnly {
+		return nil
+	}
+	row := newData[:colCnt:colCnt]
+	if !t.rewritten.meta.PKIsHandle && !t.rewritten.meta.IsCommonHandle {
+		// Keep the _tidb_rowid of the row unchanged.
+		row = append(row, types.NewIntDatum(rewrittenHandle.IntValue()))
+	}
+	return t.addRewrittenRecord(sctx, h, row)
+}
+
+// RemoveRecord implements the table.Table RemoveRecord interface.
+func (t *handleRewriteTable) RemoveRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	rewrittenHandle, found, err := t.getRewrittenHandle(context.Background(), sctx, h)
+	if err != nil {
+		return err
+	}
+	if err = t.TableCommon.RemoveRecord(sctx, h, r); err != nil {
+		return err
+	}
+	if err = logMViewChange(sctx, t.mviewLogs, r, nil); err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	return t.removeRewrittenRecord(sctx, h, rewrittenHandle, r[:len(t.Cols())])
+}
+
+func (t *handleRewriteTable) addRewrittenRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	rewrittenHandle, err := t.rewritten.AddRecord(sctx, r)
+	if err != nil {
+		return err
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	return txn.Set(HandleRewriteOriginKey(t.meta, h), HandleRewriteOriginValue(rewrittenHandle))
+}
+
+func (t *handleRewriteTable) removeRewrittenRecord(sctx sessionctx.Context, h, rewrittenHandle kv.Handle, r []types.Datum) error {
+	if err := t.rewritten.RemoveRecord(sctx, rewrittenHandle, r); err != nil {
+		return err
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	return txn.Delete(HandleRewriteOriginKey(t.meta, h))
+}
+
+// getRewrittenHandle gets the handle of the row in the rewritten table by the origin key.
+// It returns false if the row hasn't been written into the rewritten table.
+func (t *handleRewriteTable) getRewrittenHandle(ctx context.Context, sctx sessionctx.Context, h kv.Handle) (kv.Handle, bool, error) {
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return nil, false, err
+	}
+	val, err := txn.Get(ctx, HandleRewriteOriginKey(t.meta, h))
+	if err != nil {
+		if kv.IsErrNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	rewrittenHandle, err := tablecodec.DecodeHandleInUniqueIndexValue(val, t.rewritten.meta.IsCommonHandle)
+	if err != nil {
+		return nil, false, err
+	}
+	return rewrittenHandle, true, nil
+}
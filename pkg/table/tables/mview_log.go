@@ -0,0 +1,145 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// Ops of the rows in the change log of a table.
+const (
+	mviewLogOpInsert int64 = 1
+	mviewLogOpDelete int64 = -1
+)
+
+// mviewLog is the change log of a table kept for a fast refreshed materialized view.
+type mviewLog struct {
+	TableCommon
+	// alloc allocates the _tidb_rowid of the change log. The handle is always allocated
+	// explicitly, because the IDs reserved by the statement belong to the base table.
+	alloc autoid.Allocator
+}
+
+// newMViewLogs creates the change logs of the table. The row ID allocators of the change logs
+// are appended after the allocators of the table itself by autoid.NewAllocatorsFromTblInfo.
+func newMViewLogs(allocs autoid.Allocators, tblInfo *model.TableInfo) []*mviewLog {
+	if !tblInfo.HasMaterializedViewLogs() {
+		return nil
+	}
+	var logAllocs []autoid.Allocator
+	for _, alloc := range allocs.Allocs {
+		if alloc.GetType() == autoid.RowIDAllocType {
+			logAllocs = append(logAllocs, alloc)
+		}
+	}
+	// Skip the row ID allocator of the table itself.
+	if len(logAllocs) > 0 && (!tblInfo.PKIsHandle && !tblInfo.IsCommonHandle || tblInfo.GetAutoIncrementColInfo() != nil) {
+		logAllocs = logAllocs[1:]
+	}
+	logs := make([]*mviewLog, 0, len(tblInfo.MaterializedViews))
+	for _, ref := range tblInfo.MaterializedViews {
+		if ref.LogTableID == 0 {
+			continue
+		}
+		logInfo := tblInfo.MaterializedViewLogTableInfo(ref)
+		cols := make([]*table.Column, 0, len(logInfo.Columns))
+		for _, colInfo := range logInfo.Columns {
+			cols = append(cols, table.ToColumn(colInfo))
+		}
+		log := &mviewLog{}
+		initTableCommon(&log.TableCommon, logInfo, logInfo.ID, cols, autoid.Allocators{}, nil)
+		if len(logAllocs) > 0 {
+			log.alloc = logAllocs[0]
+			logAllocs = logAllocs[1:]
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+func (l *mviewLog) addRow(sctx sessionctx.Context, r []types.Datum, op int64) error {
+	if l.alloc == nil {
+		return errors.Errorf("no row ID allocator for the change log %s", l.meta.Name)
+	}
+	_, rowID, err := l.alloc.Alloc(context.Background(), 1, 1, 1)
+	if err != nil {
+		return err
+	}
+	colCnt := len(l.Columns) - 1
+	row := make([]types.Datum, 0, colCnt+2)
+	row = append(row, r[:colCnt]...)
+	row = append(row, types.NewIntDatum(op), types.NewIntDatum(rowID))
+	_, err = l.TableCommon.AddRecord(sctx, row)
+	return err
+}
+
+// logMViewChange writes the deleted row and the inserted row into the change logs of the table,
+// any of them can be nil.
+func logMViewChange(sctx sessionctx.Context, logs []*mviewLog, deleted, inserted []types.Datum) error {
+	for _, log := range logs {
+		if deleted != nil {
+			if err := log.addRow(sctx, deleted, mviewLogOpDelete); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if inserted != nil {
+			if err := log.addRow(sctx, inserted, mviewLogOpInsert); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// mviewLogTable is the table whose changes are logged for the fast refreshed materialized views on it.
+type mviewLogTable struct {
+	TableCommon
+}
+
+// AddRecord implements the table.Table AddRecord interface.
+func (t *mviewLogTable) AddRecord(sctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (recordID kv.Handle, err error) {
+	recordID, err = t.TableCommon.AddRecord(sctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = logMViewChange(sctx, t.mviewLogs, nil, r); err != nil {
+		return nil, err
+	}
+	return recordID, nil
+}
+
+// UpdateRecord implements the table.Table UpdateRecord interface.
+func (t *mviewLogTable) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	if err := t.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched); err != nil {
+		return err
+	}
+	return logMViewChange(sctx, t.mviewLogs, oldData, newData)
+}
+
+// RemoveRecord implements the table.Table RemoveRecord interface.
+func (t *mviewLogTable) RemoveRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	if err := t.TableCommon.RemoveRecord(sctx, h, r); err != nil {
+		return err
+	}
+	return logMViewChange(sctx, t.mviewLogs, r, nil)
+}
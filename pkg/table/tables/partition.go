@@ -103,6 +103,9 @@ type partitionedTable struct {
 	// doubleWritePartitions are the partitions not visible, but we should double write to
 	doubleWritePartitions map[int64]interface{}
 	reorgPartitionExpr    *PartitionExpr
+	// reorgChangedCols are the column definitions used by the doubleWritePartitions,
+	// if the reorganization also changes columns, like MODIFY COLUMN of a partitioning column.
+	reorgChangedCols []*table.Column
 }
 
 // TODO: Check which data structures that can be shared between all partitions and which
@@ -148,11 +151,12 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 	if pi.DDLState == model.StateDeleteReorganization {
 		origIdx := setIndexesState(ret, pi.DDLState)
 		defer unsetIndexesState(ret, origIdx)
+		reorgMeta, reorgCols := initReorgChangedColumns(ret)
 		// TODO: Explicitly explain the different DDL/New fields!
 		if pi.NewTableID != 0 {
-			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DroppingDefinitions)
+			ret.reorgPartitionExpr, err = newPartitionExpr(reorgMeta, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DroppingDefinitions)
 		} else {
-			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.DroppingDefinitions)
+			ret.reorgPartitionExpr, err = newPartitionExpr(reorgMeta, pi.Type, pi.Expr, pi.Columns, pi.DroppingDefinitions)
 		}
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -163,7 +167,7 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		}
 		ret.doubleWritePartitions = make(map[int64]interface{}, len(pi.DroppingDefinitions))
 		for _, def := range pi.DroppingDefinitions {
-			p, err := initPartition(ret, def)
+			p, err := initPartition(ret, reorgMeta, reorgCols, def)
 			if err != nil {
 				return nil, err
 			}
@@ -174,12 +178,13 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		if len(pi.AddingDefinitions) > 0 {
 			origIdx := setIndexesState(ret, pi.DDLState)
 			defer unsetIndexesState(ret, origIdx)
+			reorgMeta, reorgCols := initReorgChangedColumns(ret)
 			if pi.NewTableID != 0 {
 				// REMOVE PARTITIONING or PARTITION BY
-				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.AddingDefinitions)
+				ret.reorgPartitionExpr, err = newPartitionExpr(reorgMeta, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.AddingDefinitions)
 			} else {
 				// REORGANIZE PARTITION
-				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.AddingDefinitions)
+				ret.reorgPartitionExpr, err = newPartitionExpr(reorgMeta, pi.Type, pi.Expr, pi.Columns, pi.AddingDefinitions)
 			}
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -187,7 +192,7 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 			ret.doubleWritePartitions = make(map[int64]interface{}, len(pi.AddingDefinitions))
 			for _, def := range pi.AddingDefinitions {
 				ret.doubleWritePartitions[def.ID] = nil
-				p, err := initPartition(ret, def)
+				p, err := initPartition(ret, reorgMeta, reorgCols, def)
 				if err != nil {
 					return nil, err
 				}
@@ -229,9 +234,77 @@ func unsetIndexesState(t *partitionedTable, orig []*model.IndexInfo) {
 	t.meta.Indices = orig
 }
 
-func initPartition(t *partitionedTable, def model.PartitionDefinition) (*partition, error) {
+// initReorgChangedColumns returns the table meta and columns used by the doubleWritePartitions.
+// They differ from the table's own only if the reorganization also changes column definitions.
+func initReorgChangedColumns(t *partitionedTable) (*model.TableInfo, []*table.Column) {
+	pi := t.meta.Partition
+	if len(pi.DDLChangedColumns) == 0 {
+		return t.meta, t.Columns
+	}
+	// Shallow copy, so the indexes are the ones with the states set by setIndexesState.
+	reorgMeta := *t.meta
+	reorgMeta.Columns = pi.ReplaceDDLChangedColumns(t.meta.Columns)
+	reorgCols := make([]*table.Column, len(t.Columns))
+	copy(reorgCols, t.Columns)
+	t.reorgChangedCols = make([]*table.Column, 0, len(pi.DDLChangedColumns))
+	for _, changed := range pi.DDLChangedColumns {
+		col := table.ToColumn(changed)
+		for i := range reorgCols {
+			if reorgCols[i].ID == changed.ID {
+				reorgCols[i] = col
+				break
+			}
+		}
+		t.reorgChangedCols = append(t.reorgChangedCols, col)
+	}
+	return &reorgMeta, reorgCols
+}
+
+// castReorgRow converts the row to the column definitions of the doubleWritePartitions.
+// The row is returned as is, if the reorganization does not change any columns.
+func (t *partitionedTable) castReorgRow(ctx sessionctx.Context, r []types.Datum) ([]types.Datum, error) {
+	if len(t.reorgChangedCols) == 0 {
+		return r, nil
+	}
+	// In StateDeleteReorganization the doubleWritePartitions use the old column
+	// definitions, and are only kept for sessions still on the previous schema
+	// version, so do not fail the statement on conversion errors.
+	ignoreErr := t.meta.Partition.DDLState == model.StateDeleteReorganization
+	row := make([]types.Datum, len(r))
+	copy(row, r)
+	for _, col := range t.reorgChangedCols {
+		if col.Offset >= len(row) {
+			continue
+		}
+		casted, err := table.CastValue(ctx, row[col.Offset], col.ColumnInfo, false, ignoreErr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !ignoreErr {
+			if err = col.CheckNotNull(&casted, 0); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		row[col.Offset] = casted
+	}
+	return row, nil
+}
+
+func (t *partitionedTable) castReorgRows(ctx sessionctx.Context, currData, newData []types.Datum) ([]types.Datum, []types.Datum, error) {
+	currData, err := t.castReorgRow(ctx, currData)
+	if err != nil {
+		return nil, nil, err
+	}
+	newData, err = t.castReorgRow(ctx, newData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return currData, newData, nil
+}
+
+func initPartition(t *partitionedTable, tblInfo *model.TableInfo, cols []*table.Column, def model.PartitionDefinition) (*partition, error) {
 	var newPart partition
-	err := initTableCommonWithIndices(&newPart.TableCommon, t.meta, def.ID, t.Columns, t.allocs, t.Constraints)
+	err := initTableCommonWithIndices(&newPart.TableCommon, tblInfo, def.ID, cols, t.allocs, t.Constraints)
 	if err != nil {
 		return nil, err
 	}
@@ -1521,12 +1594,23 @@ func GetReorganizedPartitionedTable(t table.Table) (table.PartitionedTable, erro
 	pi.Columns = pi.DDLColumns
 	tblInfo.ID = pi.NewTableID
 
+	// and the changed column definitions
+	cols := t.Cols()
+	if len(pi.DDLChangedColumns) > 0 {
+		tblInfo.Columns = pi.ReplaceDDLChangedColumns(tblInfo.Columns)
+		cols = make([]*table.Column, 0, len(t.Cols()))
+		for _, col := range t.Cols() {
+			cols = append(cols, table.ToColumn(model.FindColumnInfoByID(tblInfo.Columns, col.ID)))
+		}
+		pi.DDLChangedColumns = nil
+	}
+
 	constraints, err := table.LoadCheckConstraint(tblInfo)
 	if err != nil {
 		return nil, err
 	}
 	var tc TableCommon
-	initTableCommon(&tc, tblInfo, tblInfo.ID, t.Cols(), t.Allocators(nil), constraints)
+	initTableCommon(&tc, tblInfo, tblInfo.ID, cols, t.Allocators(nil), constraints)
 
 	// and rebuild the partitioning structure
 	return newPartitionedTable(&tc, tblInfo)
@@ -1618,17 +1702,24 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 	if err != nil {
 		return
 	}
+	if err = logMViewChange(ctx, t.mviewLogs, nil, r); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if t.Meta().Partition.DDLState == model.StateDeleteOnly {
 		return
 	}
 	if _, ok := t.reorganizePartitions[pid]; ok {
 		// Double write to the ongoing reorganized partition
+		r, err = t.castReorgRow(ctx, r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		pid, err = t.locateReorgPartition(ctx, r)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		tbl = t.GetPartition(pid)
-		recordID, err = tbl.AddRecord(ctx, r, opts...)
+		_, err = tbl.AddRecord(ctx, t.withRecordID(r, recordID), opts...)
 		if err != nil {
 			return
 		}
@@ -1636,6 +1727,18 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 	return
 }
 
+// withRecordID appends the handle as _tidb_rowid to the row if the table
+// has no clustered index, so the double written record in the reorganized
+// partition gets the same handle as the one in the current partition.
+func (t *partitionedTable) withRecordID(r []types.Datum, recordID kv.Handle) []types.Datum {
+	if t.Meta().HasClusteredIndex() || len(r) != len(t.Cols()) {
+		return r
+	}
+	row := make([]types.Datum, 0, len(r)+1)
+	row = append(row, r...)
+	return append(row, types.NewIntDatum(recordID.IntValue()))
+}
+
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
 // Basically it is the same as partitionedTable except that partitionTableWithGivenSets
 // checks the given partition set for AddRecord/UpdateRecord operations.
@@ -1680,8 +1783,15 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = logMViewChange(ctx, t.mviewLogs, r, nil); err != nil {
+		return errors.Trace(err)
+	}
 
 	if _, ok := t.reorganizePartitions[pid]; ok {
+		r, err = t.castReorgRow(ctx, r)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		pid, err = t.locateReorgPartition(ctx, r)
 		if err != nil {
 			return errors.Trace(err)
@@ -1750,7 +1860,7 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 	// The old and new data locate in different partitions.
 	// Remove record from old partition and add record to new partition.
 	if from != to {
-		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		newRecordID, err := t.GetPartition(to).AddRecord(ctx, newData)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1765,6 +1875,13 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
 			return errors.Trace(err)
 		}
+		if err = logMViewChange(ctx, t.mviewLogs, currData, newData); err != nil {
+			return errors.Trace(err)
+		}
+		currData, newData, err = t.castReorgRows(ctx, currData, newData)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		newTo, newFrom := int64(0), int64(0)
 		if _, ok := t.reorganizePartitions[to]; ok {
 			newTo, err = t.locateReorgPartition(ctx, newData)
@@ -1780,14 +1897,16 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 				return errors.Trace(err)
 			}
 		}
-		if newTo == newFrom && newTo != 0 {
+		// Without a clustered index the record got a new _tidb_rowid in the
+		// new partition, which must be kept in the reorganized partition too.
+		if newTo == newFrom && newTo != 0 && newRecordID.Equal(h) {
 			// Update needs to be done in StateDeleteOnly as well
 			tbl := t.GetPartition(newTo)
 			return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
 		}
 		if newTo != 0 && t.Meta().GetPartitionInfo().DDLState != model.StateDeleteOnly {
 			tbl := t.GetPartition(newTo)
-			_, err = tbl.AddRecord(ctx, newData)
+			_, err = tbl.AddRecord(ctx, t.withRecordID(newData, newRecordID))
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -1807,7 +1926,14 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = logMViewChange(ctx, t.mviewLogs, currData, newData); err != nil {
+		return errors.Trace(err)
+	}
 	if _, ok := t.reorganizePartitions[to]; ok {
+		currData, newData, err = t.castReorgRows(ctx, currData, newData)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		// Even if to == from, in the reorganized partitions they may differ
 		// like in case of a split
 		newTo, err := t.locateReorgPartition(ctx, newData)
@@ -1832,7 +1958,7 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 		}
 		if t.Meta().GetPartitionInfo().DDLState != model.StateDeleteOnly {
 			tbl = t.GetPartition(newTo)
-			_, err = tbl.AddRecord(ctx, newData)
+			_, err = tbl.AddRecord(ctx, t.withRecordID(newData, h))
 			if err != nil {
 				return errors.Trace(err)
 			}
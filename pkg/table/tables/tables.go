@@ -30,6 +30,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
@@ -50,6 +51,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/pingcap/tidb/pkg/util/tableutil"
@@ -78,6 +80,8 @@ type TableCommon struct {
 	dependencyColumnOffsets         []int
 	Constraints                     []*table.Constraint
 	writableConstraints             []*table.Constraint
+	// mviewLogs are the change logs of the table kept for the fast refreshed materialized views.
+	mviewLogs []*mviewLog
 
 	// recordPrefix and indexPrefix are generated using physicalTableID.
 	recordPrefix kv.Key
@@ -154,6 +158,13 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 				}
 				return newExpr
 			}, expr)
+			if colInfo.State != model.StatePublic {
+				// The generated column cannot rely on the session context, so use a default one like the partition expression.
+				col.NonPublicGeneratedExpr, err = expression.ParseSimpleExprWithTableInfo(mock.NewContext(), genStr, tblInfo)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 		// default value is expr.
 		if col.DefaultIsExpr {
@@ -172,6 +183,7 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 	}
 	var t TableCommon
 	initTableCommon(&t, tblInfo, tblInfo.ID, columns, allocs, constraints)
+	t.mviewLogs = newMViewLogs(allocs, tblInfo)
 	if tblInfo.GetPartitionInfo() == nil {
 		if err := initTableIndices(&t); err != nil {
 			return nil, err
@@ -179,6 +191,12 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 		if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 			return newCachedTable(&t)
 		}
+		if tblInfo.HandleRewrite != nil {
+			return newHandleRewriteTable(&t)
+		}
+		if len(t.mviewLogs) > 0 {
+			return &mviewLogTable{TableCommon: t}, nil
+		}
 		return &t, nil
 	}
 	return newPartitionedTable(&t, tblInfo)
@@ -410,6 +428,10 @@ func (t *TableCommon) RecordKey(h kv.Handle) kv.Key {
 // shouldAssert checks if the partition should be in consistent
 // state and can have assertion.
 func (t *TableCommon) shouldAssert(sctx sessionctx.Context) bool {
+	if t.meta.State != model.StatePublic {
+		// The table is being rewritten under a new handle encoding, the rows may not be backfilled yet.
+		return false
+	}
 	p := t.Meta().Partition
 	if p != nil {
 		// This disables asserting during Reorganize Partition.
@@ -474,19 +496,19 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 		if col.State == model.StateDeleteOnly || col.State == model.StateDeleteReorganization {
 			if col.ChangeStateInfo != nil {
 				// TODO: Check overflow or ignoreTruncate.
-				value, err = table.CastValue(sctx, oldData[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+				value, err = GetChangingColValFromRow(sctx, t.meta, col, oldData)
 				if err != nil {
 					logutil.BgLogger().Info("update record cast value failed", zap.Any("col", col), zap.Uint64("txnStartTS", txn.StartTS()),
 						zap.String("handle", h.String()), zap.Any("val", oldData[col.DependencyColumnOffset]), zap.Error(err))
 					return err
 				}
 				oldData = append(oldData, value)
-				touched = append(touched, touched[col.DependencyColumnOffset])
+				touched = append(touched, touched[col.DependencyColumnOffset] || col.IsGenerated())
 			}
 			if needChecksum {
 				if col.ChangeStateInfo != nil {
 					// TODO: Check overflow or ignoreTruncate.
-					v, err := table.CastValue(sctx, newData[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+					v, err := GetChangingColValFromRow(sctx, t.meta, col, newData)
 					if err != nil {
 						return err
 					}
@@ -508,15 +530,31 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 			value = oldData[col.Offset]
 			if col.ChangeStateInfo != nil {
 				// TODO: Check overflow or ignoreTruncate.
-				value, err = table.CastValue(sctx, newData[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+				value, err = GetChangingColValFromRow(sctx, t.meta, col, newData)
 				if err != nil {
 					return err
 				}
 				newData[col.Offset] = value
-				touched[col.Offset] = touched[col.DependencyColumnOffset]
+				// The generated column may be changed by any column it depends on.
+				touched[col.Offset] = touched[col.DependencyColumnOffset] || col.IsGenerated()
 				checksumData = t.appendInChangeColForChecksum(sctx, h, checksumData, col.ToInfo(), &newData[col.DependencyColumnOffset], &value)
-			} else if needChecksum {
-				checksumData = t.appendNonPublicColForChecksum(sctx, h, checksumData, col.ToInfo(), &value)
+			} else {
+				if isAddingStoredGeneratedCol(col) {
+					value, err = evalNonPublicGeneratedCol(sctx, t.meta, col, newData)
+					if err != nil {
+						return err
+					}
+					newData[col.Offset] = value
+				} else if isAddingAutoIncrementCol(col) {
+					value, err = t.allocAddingAutoIncrementCol(ctx, sctx, col, value)
+					if err != nil {
+						return err
+					}
+					newData[col.Offset] = value
+				}
+				if needChecksum {
+					checksumData = t.appendNonPublicColForChecksum(sctx, h, checksumData, col.ToInfo(), &value)
+				}
 			}
 		} else {
 			value = newData[col.Offset]
@@ -858,23 +896,8 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 	} else {
 		tblInfo := t.Meta()
 		txn.CacheTableInfo(t.physicalTableID, tblInfo)
-		if tblInfo.PKIsHandle {
-			recordID = kv.IntHandle(r[tblInfo.GetPkColInfo().Offset].GetInt64())
-			hasRecordID = true
-		} else if tblInfo.IsCommonHandle {
-			pkIdx := FindPrimaryIndex(tblInfo)
-			pkDts := make([]types.Datum, 0, len(pkIdx.Columns))
-			for _, idxCol := range pkIdx.Columns {
-				pkDts = append(pkDts, r[idxCol.Offset])
-			}
-			tablecodec.TruncateIndexValues(tblInfo, pkIdx, pkDts)
-			var handleBytes []byte
-			handleBytes, err = codec.EncodeKey(sctx.GetSessionVars().StmtCtx.TimeZone(), nil, pkDts...)
-			err = sctx.GetSessionVars().StmtCtx.HandleError(err)
-			if err != nil {
-				return
-			}
-			recordID, err = kv.NewCommonHandle(handleBytes)
+		if tblInfo.PKIsHandle || tblInfo.IsCommonHandle {
+			recordID, err = BuildHandleFromRow(sctx, tblInfo, r)
 			if err != nil {
 				return
 			}
@@ -924,7 +947,7 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			if needChecksum {
 				if col.ChangeStateInfo != nil {
 					// TODO: Check overflow or ignoreTruncate.
-					v, err := table.CastValue(sctx, r[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+					v, err := GetChangingColValFromRow(sctx, t.meta, col, r)
 					if err != nil {
 						return nil, err
 					}
@@ -943,7 +966,7 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 		// for the new insert statement, we should use the casted value of relative column to insert.
 		if col.ChangeStateInfo != nil && col.State != model.StatePublic {
 			// TODO: Check overflow or ignoreTruncate.
-			value, err = table.CastValue(sctx, r[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+			value, err = GetChangingColValFromRow(sctx, t.meta, col, r)
 			if err != nil {
 				return nil, err
 			}
@@ -952,8 +975,10 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			} else {
 				r[col.Offset] = value
 			}
-			row = append(row, value)
-			colIDs = append(colIDs, col.ID)
+			if !col.IsVirtualGenerated() {
+				row = append(row, value)
+				colIDs = append(colIDs, col.ID)
+			}
 			checksumData = t.appendInChangeColForChecksum(sctx, recordID, checksumData, col.ToInfo(), &r[col.DependencyColumnOffset], &value)
 			continue
 		}
@@ -965,7 +990,32 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			// because `col.State != model.StatePublic` is true here, if col.ChangeStateInfo is not nil, the col should
 			// be handle by the previous if-block.
 
-			if opt.IsUpdate {
+			if isAddingStoredGeneratedCol(col) {
+				// The stored generated column being added is evaluated from the public columns.
+				value, err = evalNonPublicGeneratedCol(sctx, t.meta, col, r)
+				if err != nil {
+					return nil, err
+				}
+				if col.Offset < len(r) {
+					r[col.Offset] = value
+				} else {
+					r = append(r, value)
+				}
+			} else if isAddingAutoIncrementCol(col) {
+				if opt.IsUpdate {
+					value = r[col.Offset]
+				}
+				// The auto_increment column being added is allocated for the new row, or the row written before it's added.
+				value, err = t.allocAddingAutoIncrementCol(ctx, sctx, col, value)
+				if err != nil {
+					return nil, err
+				}
+				if col.Offset < len(r) {
+					r[col.Offset] = value
+				} else {
+					r = append(r, value)
+				}
+			} else if opt.IsUpdate {
 				// If `AddRecord` is called by an update, the default value should be handled the update.
 				value = r[col.Offset]
 			} else {
@@ -1124,6 +1174,25 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 	return recordID, nil
 }
 
+// BuildHandleFromRow builds the handle of the row for the table whose primary key is the handle.
+func BuildHandleFromRow(sctx sessionctx.Context, tblInfo *model.TableInfo, r []types.Datum) (kv.Handle, error) {
+	if tblInfo.PKIsHandle {
+		return kv.IntHandle(r[tblInfo.GetPkColInfo().Offset].GetInt64()), nil
+	}
+	pkIdx := FindPrimaryIndex(tblInfo)
+	pkDts := make([]types.Datum, 0, len(pkIdx.Columns))
+	for _, idxCol := range pkIdx.Columns {
+		pkDts = append(pkDts, r[idxCol.Offset])
+	}
+	tablecodec.TruncateIndexValues(tblInfo, pkIdx, pkDts)
+	handleBytes, err := codec.EncodeKey(sctx.GetSessionVars().StmtCtx.TimeZone(), nil, pkDts...)
+	err = sctx.GetSessionVars().StmtCtx.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
+	return kv.NewCommonHandle(handleBytes)
+}
+
 // genIndexKeyStr generates index content string representation.
 func genIndexKeyStr(colVals []types.Datum) (string, error) {
 	// Pass pre-composed error to txn.
@@ -1282,6 +1351,71 @@ func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h kv.Handle
 	return v, rowMap, nil
 }
 
+// isAddingStoredGeneratedCol checks whether the column is a stored generated column being added by "add column".
+// Its value is written together with the row, so that the reorganization doesn't need to backfill the row again.
+func isAddingStoredGeneratedCol(col *table.Column) bool {
+	return col.State != model.StatePublic && col.ChangeStateInfo == nil && col.IsGenerated() && col.GeneratedStored
+}
+
+// isAddingAutoIncrementCol checks whether the column is an auto_increment column being added by "add column".
+// Its value is allocated when the row is written, so that the reorganization doesn't need to backfill the row again.
+func isAddingAutoIncrementCol(col *table.Column) bool {
+	return col.State != model.StatePublic && col.ChangeStateInfo == nil && mysql.HasAutoIncrementFlag(col.GetFlag())
+}
+
+// allocAddingAutoIncrementCol allocates the value of the auto_increment column being added if the row doesn't have one.
+// The value of the row written before the column is added is read as the zero value or NULL.
+func (t *TableCommon) allocAddingAutoIncrementCol(ctx context.Context, sctx sessionctx.Context, col *table.Column, value types.Datum) (types.Datum, error) {
+	switch value.Kind() {
+	case types.KindInt64:
+		if value.GetInt64() != 0 {
+			return value, nil
+		}
+	case types.KindUint64:
+		if value.GetUint64() != 0 {
+			return value, nil
+		}
+	case types.KindFloat32, types.KindFloat64:
+		if value.GetFloat64() != 0 {
+			return value, nil
+		}
+	}
+	id, err := table.AllocAutoIncrementValue(ctx, t, sctx)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	var d types.Datum
+	d.SetAutoID(id, col.GetFlag())
+	return table.CastValue(sctx, d, col.ColumnInfo, false, false)
+}
+
+// evalNonPublicGeneratedCol evaluates the generated column being added or modified with the public columns of the row.
+// The expression is built once with the table, it's only parsed here if the table is not created by TableFromMeta.
+func evalNonPublicGeneratedCol(sctx sessionctx.Context, tblInfo *model.TableInfo, col *table.Column, r []types.Datum) (types.Datum, error) {
+	expr := col.NonPublicGeneratedExpr
+	if expr == nil {
+		var err error
+		expr, err = expression.ParseSimpleExprWithTableInfo(sctx, col.GeneratedExprString, tblInfo)
+		if err != nil {
+			return types.Datum{}, err
+		}
+	}
+	val, err := expr.Eval(sctx, chunk.MutRowFromDatums(r).ToRow())
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return table.CastValue(sctx, val, col.ColumnInfo, false, false)
+}
+
+// GetChangingColValFromRow gets the changing column value from the row when executing "modify/change column" statement.
+// The value is casted from the relative column, or evaluated from the public columns if the changing column is generated.
+func GetChangingColValFromRow(ctx sessionctx.Context, tblInfo *model.TableInfo, col *table.Column, r []types.Datum) (types.Datum, error) {
+	if col.IsGenerated() {
+		return evalNonPublicGeneratedCol(ctx, tblInfo, col, r)
+	}
+	return table.CastValue(ctx, r[col.DependencyColumnOffset], col.ColumnInfo, false, false)
+}
+
 // GetChangingColVal gets the changing column value when executing "modify/change column" statement.
 // For statement like update-where, it will fetch the old row out and insert it into kv again.
 // Since update statement can see the writable columns, it is responsible for the casting relative column / get the fault value here.
@@ -1340,7 +1474,7 @@ func (t *TableCommon) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r []type
 		// The changing column datum derived from related column should be casted here.
 		// Otherwise, the existed changing indexes will not be deleted.
 		relatedColDatum := r[t.Columns[len(r)].ChangeStateInfo.DependencyColumnOffset]
-		value, err := table.CastValue(ctx, relatedColDatum, t.Columns[len(r)].ColumnInfo, false, false)
+		value, err := GetChangingColValFromRow(ctx, t.meta, t.Columns[len(r)], r)
 		if err != nil {
 			logutil.BgLogger().Info("remove record cast value failed", zap.Any("col", t.Columns[len(r)]),
 				zap.String("handle", h.String()), zap.Any("val", relatedColDatum), zap.Error(err))
@@ -1702,3 +1702,40 @@ func TestWriteWithChecksums(t *testing.T) {
 		tkDDL.MustExec("admin check table t")
 	}
 }
+
+func TestHandleRewriteTableWithMViewLogs(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create materialized view mv refresh fast as select a, count(*) from t group by a")
+	tb := external.GetTableByName(t, tk, "test", "t")
+	require.Len(t, tb.Meta().MaterializedViews, 1)
+	logTableID := tb.Meta().MaterializedViews[0].LogTableID
+
+	// The changes are still logged while the table is being rewritten under a new handle.
+	tblInfo := tb.Meta().Clone()
+	tblInfo.HandleRewrite = &model.HandleRewriteInfo{
+		TableID:  tblInfo.ID + 1000,
+		Columns:  tblInfo.Columns,
+		DDLState: model.StateWriteReorganization,
+	}
+	rewriting, err := tables.TableFromMeta(tb.Allocators(nil), tblInfo)
+	require.NoError(t, err)
+	_, err = tables.GetHandleRewriteTable(rewriting)
+	require.NoError(t, err)
+
+	sctx := tk.Session()
+	require.Nil(t, sessiontxn.NewTxn(context.Background(), sctx))
+	_, err = rewriting.AddRecord(sctx, types.MakeDatums(1, 1))
+	require.NoError(t, err)
+	txn, err := sctx.Txn(true)
+	require.NoError(t, err)
+	logPrefix := tablecodec.GenTableRecordPrefix(logTableID)
+	iter, err := txn.Iter(logPrefix, logPrefix.PrefixNext())
+	require.NoError(t, err)
+	require.True(t, iter.Valid())
+	require.True(t, iter.Key().HasPrefix(logPrefix))
+	iter.Close()
+	require.Nil(t, txn.Rollback())
+}
@@ -358,9 +358,10 @@ func (tk *TestKit) ExecWithContext(ctx context.Context, sql string, args ...inte
 		for i, stmt := range stmts {
 			var rs sqlexec.RecordSet
 			var err error
-			if s, ok := stmt.(*ast.NonTransactionalDMLStmt); ok {
+			switch s := stmt.(type) {
+			case *ast.NonTransactionalDMLStmt:
 				rs, err = session.HandleNonTransactionalDML(ctx, s, tk.Session())
-			} else {
+			default:
 				rs, err = tk.Session().ExecuteStmt(ctx, stmt)
 			}
 			if i == 0 {
@@ -148,6 +148,14 @@ func TestUpdateTimerOption(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "UTC", tz)
 	require.Equal(t, []string{"Tags", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
+
+	// test 'Data' field
+	require.False(t, update.Data.Present())
+	WithSetData([]byte("data1"))(&update)
+	data, ok := update.Data.Get()
+	require.True(t, ok)
+	require.Equal(t, []byte("data1"), data)
+	require.Equal(t, []string{"Tags", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData", "Data"}, update.FieldsSet())
 }
 
 func TestDefaultClient(t *testing.T) {
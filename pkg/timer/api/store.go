@@ -190,6 +190,8 @@ type TimerUpdate struct {
 	Watermark OptionalVal[time.Time]
 	// SummaryData indicates to set the timer's `Summary` field.
 	SummaryData OptionalVal[[]byte]
+	// Data indicates to set the timer's `Data` field.
+	Data OptionalVal[[]byte]
 	// CheckVersion indicates to check the timer's version when updated.
 	CheckVersion OptionalVal[uint64]
 	// CheckEventID indicates to check the timer's eventID.
@@ -263,6 +265,10 @@ func (u *TimerUpdate) apply(record *TimerRecord) (*TimerRecord, error) {
 		record.SummaryData = v
 	}
 
+	if v, ok := u.Data.Get(); ok {
+		record.Data = v
+	}
+
 	return record, nil
 }
 
@@ -251,6 +251,7 @@ func TestTimerUpdate(t *testing.T) {
 		SchedPolicyExpr: NewOptionalVal("5h"),
 		Watermark:       NewOptionalVal(now),
 		SummaryData:     NewOptionalVal([]byte("summarydata1")),
+		Data:            NewOptionalVal([]byte("data1")),
 		EventStatus:     NewOptionalVal(SchedEventTrigger),
 		EventID:         NewOptionalVal("event1"),
 		EventData:       NewOptionalVal([]byte("eventdata1")),
@@ -279,6 +280,7 @@ func TestTimerUpdate(t *testing.T) {
 	require.Equal(t, "5h", record.SchedPolicyExpr)
 	require.Equal(t, now, record.Watermark)
 	require.Equal(t, []byte("summarydata1"), record.SummaryData)
+	require.Equal(t, []byte("data1"), record.Data)
 	require.Equal(t, SchedEventTrigger, record.EventStatus)
 	require.Equal(t, "event1", record.EventID)
 	require.Equal(t, []byte("eventdata1"), record.EventData)
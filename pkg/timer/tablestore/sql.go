@@ -466,6 +466,11 @@ func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, er
 		args = append(args, val)
 	}
 
+	if val, ok := update.Data.Get(); ok {
+		updateFields = append(updateFields, "TIMER_DATA = %?")
+		args = append(args, val)
+	}
+
 	if len(extFields) > 0 {
 		jsonBytes, err := json.Marshal(extFields)
 		if err != nil {
@@ -436,17 +436,18 @@ func TestBuildUpdateCriteria(t *testing.T) {
 				}),
 				Watermark:    api.NewOptionalVal(now.Add(time.Second)),
 				SummaryData:  api.NewOptionalVal([]byte("summary")),
+				Data:         api.NewOptionalVal([]byte("timerdata")),
 				CheckEventID: api.NewOptionalVal("ee"),
 				CheckVersion: api.NewOptionalVal(uint64(1)),
 			},
 			criteria: "ENABLE = %?, TIMEZONE = %?, SCHED_POLICY_TYPE = %?, SCHED_POLICY_EXPR = %?, EVENT_STATUS = %?, " +
 				"EVENT_ID = %?, EVENT_DATA = %?, EVENT_START = FROM_UNIXTIME(%?), " +
-				"WATERMARK = FROM_UNIXTIME(%?), SUMMARY_DATA = %?, " +
+				"WATERMARK = FROM_UNIXTIME(%?), SUMMARY_DATA = %?, TIMER_DATA = %?, " +
 				"TIMER_EXT = JSON_MERGE_PATCH(TIMER_EXT, %?), " +
 				"VERSION = VERSION + 1",
 			args: []any{
 				false, "Asia/Shanghai", "INTERVAL", "1h", "TRIGGER", "event1", []byte("data1"), now.Unix(),
-				now.Unix() + 1, []byte("summary"),
+				now.Unix() + 1, []byte("summary"), []byte("timerdata"),
 				json.RawMessage(`{` +
 					`"event":{"manual_request_id":"req2","watermark_unix":456},` +
 					`"manual":{"request_id":"req1","request_time_unix":123,"timeout_sec":60,"processed":true,"event_id":"event1"},` +
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/parser/types"
 	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/geo"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
@@ -937,6 +938,8 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlSet(ctx, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry()
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1739,6 +1742,19 @@ func (d *Datum) convertToMysqlJSON(_ *FieldType) (ret Datum, err error) {
 	return ret, errors.Trace(err)
 }
 
+// convertToGeometry checks the datum is a geometry in the stored format, which is the SRID followed
+// by the WKB.
+func (d *Datum) convertToGeometry() (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes, KindBinaryLiteral:
+		if _, err = geo.Decode(d.GetBytes()); err == nil {
+			ret.SetBytes(d.GetBytes())
+			return ret, nil
+		}
+	}
+	return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
@@ -272,6 +272,16 @@ var (
 	ErrWrongObject = ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrBadFtColumn returns an error when a column can't be part of a FULLTEXT index.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrSpatialMustHaveGeomCol returns an error when a SPATIAL index is built on a non-geometry column.
+	ErrSpatialMustHaveGeomCol = ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrSpatialCantHaveNull returns an error when a SPATIAL index is built on a nullable column.
+	ErrSpatialCantHaveNull = ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrSpatialFunctionalIndex returns an error when a SPATIAL index is built on an expression.
+	ErrSpatialFunctionalIndex = ClassDDL.NewStd(mysql.ErrSpatialFunctionalIndex)
+	// ErrTooManyKeyParts returns an error when an index has more key parts than allowed.
+	ErrTooManyKeyParts = ClassDDL.NewStd(mysql.ErrTooManyKeyParts)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
@@ -363,6 +373,12 @@ var (
 	ErrOptOnTemporaryTable = ClassDDL.NewStd(mysql.ErrOptOnTemporaryTable)
 	// ErrOptOnCacheTable returns when exec unsupported opt at cache mode
 	ErrOptOnCacheTable = ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
+	// ErrOptOnMaterializedView returns when exec unsupported opt on materialized views or their change logs.
+	ErrOptOnMaterializedView = ClassDDL.NewStd(mysql.ErrOptOnMaterializedView)
+	// ErrOptOnMaterializedViewBase returns when exec unsupported opt on the base tables of materialized views.
+	ErrOptOnMaterializedViewBase = ClassDDL.NewStd(mysql.ErrOptOnMaterializedViewBase)
+	// ErrMaterializedViewNoFastRefresh returns when a materialized view can't be refreshed fast.
+	ErrMaterializedViewNoFastRefresh = ClassDDL.NewStd(mysql.ErrMaterializedViewNoFastRefresh)
 	// ErrUnsupportedOnCommitPreserve returns when exec unsupported opt on commit preserve
 	ErrUnsupportedOnCommitPreserve = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("TiDB doesn't support ON COMMIT PRESERVE ROWS for now", nil))
 	// ErrUnsupportedClusteredSecondaryKey returns when exec unsupported clustered secondary key
@@ -404,6 +420,8 @@ var (
 	ErrCannotPauseDDLJob = ClassDDL.NewStd(mysql.ErrCannotPauseDDLJob)
 	// ErrCannotResumeDDLJob returns  when the State is not qualified to be resumed.
 	ErrCannotResumeDDLJob = ClassDDL.NewStd(mysql.ErrCannotResumeDDLJob)
+	// ErrCannotAlterDDLJob returns when the job is not qualified to be altered.
+	ErrCannotAlterDDLJob = ClassDDL.NewStd(mysql.ErrCannotAlterDDLJob)
 	// ErrDDLSetting returns when failing to enable/disable DDL.
 	ErrDDLSetting = ClassDDL.NewStd(mysql.ErrDDLSetting)
 	// ErrIngestFailed returns when the DDL ingest job is failed.
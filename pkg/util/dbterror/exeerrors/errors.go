@@ -77,6 +77,42 @@ var (
 	ErrForeignKeyCascadeDepthExceeded = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
 	ErrPasswordExpireAnonymousUser    = dbterror.ClassExecutor.NewStd(mysql.ErrPasswordExpireAnonymousUser)
 	ErrMustChangePassword             = dbterror.ClassExecutor.NewStd(mysql.ErrMustChangePassword)
+	ErrMissingJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue            = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
+	ErrJSONTableValueOutOfRange       = dbterror.ClassExecutor.NewStd(mysql.ErrJSONTableValueOutOfRange)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventSameName                    = dbterror.ClassExecutor.NewStd(mysql.ErrEventSameName)
+	ErrEventRecursionForbidden          = dbterror.ClassExecutor.NewStd(mysql.ErrEventRecursionForbidden)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+
+	ErrSpAlreadyExists      = dbterror.ClassExecutor.NewStd(mysql.ErrSpAlreadyExists)
+	ErrSpDoesNotExist       = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpLilabelMismatch    = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpLabelRedefine      = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelRedefine)
+	ErrSpLabelMismatch      = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelMismatch)
+	ErrSpWrongNoOfArgs      = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpCursorMismatch     = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen  = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen      = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpUndeclaredVar      = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpWrongNoOfFetchArgs = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData        = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpDupParam           = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar             = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs            = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpCaseNotFound       = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrSpBadSQLstate        = dbterror.ClassExecutor.NewStd(mysql.ErrSpBadSQLstate)
+	ErrSpDupHandler         = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupHandler)
+	ErrSpNotVarArg          = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpRecursionLimit     = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrSpBadselect          = dbterror.ClassExecutor.NewStd(mysql.ErrSpBadselect)
+	ErrProcaccessDenied     = dbterror.ClassExecutor.NewStd(mysql.ErrProcaccessDenied)
 
 	ErrWrongStringLength            = dbterror.ClassDDL.NewStd(mysql.ErrWrongStringLength)
 	ErrUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
@@ -0,0 +1,111 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	builtin, ok := GetParser("")
+	require.True(t, ok)
+	ngram, ok := GetParser("NGRAM")
+	require.True(t, ok)
+	_, ok = GetParser("mecab")
+	require.False(t, ok)
+
+	require.Equal(t, []string{"quick", "brown", "fox_1", "jumps", "over", "lazy", "dog"},
+		DistinctTokens(builtin, "The quick brown FOX_1 jumps over the lazy dog.", "Quick dog"))
+	require.Equal(t, []string{"数据", "据库", "系统", "ab", "bc"}, DistinctTokens(ngram, "数据库，系统", "ABC a"))
+
+	require.Error(t, RegisterParser("ngram", ngram))
+	require.NoError(t, RegisterParser("test_trigram", ngramParser{size: 3}))
+	trigram, ok := GetParser("test_trigram")
+	require.True(t, ok)
+	require.Equal(t, []string{"abc", "bcd"}, DistinctTokens(trigram, "abcd"))
+
+	// The tokens longer than MaxTokenLength of the registered parsers are ignored.
+	require.NoError(t, RegisterParser("test_long", ngramParser{size: MaxTokenLength + 1}))
+	long, ok := GetParser("test_long")
+	require.True(t, ok)
+	require.Empty(t, DistinctTokens(long, strings.Repeat("a", MaxTokenLength+1)))
+}
+
+func TestQuery(t *testing.T) {
+	builtin, _ := GetParser("")
+	ngram, _ := GetParser(NgramParserName)
+	docs := []*Document{
+		NewDocument(builtin, "MySQL Tutorial", "DBMS stands for DataBase ..."),
+		NewDocument(builtin, "How To Use MySQL Well", "After you went through a MySQL tutorial ..."),
+		NewDocument(builtin, "Optimizing MySQL", "In this tutorial, we show how to optimize the database"),
+		NewDocument(builtin, "Security", "When configured properly, YourSQL is secure"),
+	}
+	cases := []struct {
+		query        string
+		mode         Mode
+		matched      []bool
+		terms        []IndexTerm
+		intersection bool
+		indexable    bool
+	}{
+		{"tutorial database", NaturalLanguageMode, []bool{true, true, true, false},
+			[]IndexTerm{{Token: "tutorial"}, {Token: "database"}}, false, true},
+		{"the of", NaturalLanguageMode, []bool{false, false, false, false}, nil, false, false},
+		{"+mysql -optimizing", BooleanMode, []bool{true, true, false, false},
+			[]IndexTerm{{Token: "mysql"}}, true, true},
+		{"+mysql +\"mysql tutorial\"", BooleanMode, []bool{true, true, false, false},
+			[]IndexTerm{{Token: "mysql"}, {Token: "tutorial"}}, true, true},
+		{"optimiz* secur*", BooleanMode, []bool{false, false, true, true},
+			[]IndexTerm{{Token: "optimiz", Prefix: true}, {Token: "secur", Prefix: true}}, false, true},
+		{"(database -dbms) security", BooleanMode, []bool{false, false, true, true},
+			[]IndexTerm{{Token: "database"}, {Token: "security"}}, false, true},
+		// A query with only `-` words matches nothing.
+		{"-mysql", BooleanMode, []bool{false, false, false, false}, nil, false, false},
+	}
+	for _, c := range cases {
+		q := ParseQuery(builtin, c.query, c.mode)
+		for i, doc := range docs {
+			score := q.Score(doc)
+			require.Equal(t, c.matched[i], score != 0, "%s %d", c.query, i)
+		}
+		terms, intersection, ok := q.IndexTerms()
+		require.Equal(t, c.indexable, ok, c.query)
+		if ok {
+			require.Equal(t, c.terms, terms, c.query)
+			require.Equal(t, c.intersection, intersection, c.query)
+		}
+	}
+
+	// The relevance grows with the frequency and the weights.
+	q := ParseQuery(builtin, "mysql", NaturalLanguageMode)
+	require.Greater(t, q.Score(docs[1]), q.Score(docs[0]))
+	require.Greater(t, ParseQuery(builtin, ">tutorial <mysql", BooleanMode).Score(docs[0]),
+		ParseQuery(builtin, "tutorial mysql", BooleanMode).Score(docs[0]))
+	require.Less(t, ParseQuery(builtin, "tutorial ~mysql", BooleanMode).Score(docs[0]),
+		ParseQuery(builtin, "tutorial", BooleanMode).Score(docs[0]))
+
+	// A word is split into a phrase by the ngram parser.
+	doc := NewDocument(ngram, "分布式数据库")
+	require.NotZero(t, ParseQuery(ngram, "+数据库", BooleanMode).Score(doc))
+	require.Zero(t, ParseQuery(ngram, "+数库", BooleanMode).Score(doc))
+	require.NotZero(t, ParseQuery(ngram, "数据仓库", NaturalLanguageMode).Score(doc))
+	terms, intersection, ok := ParseQuery(ngram, "+数据库", BooleanMode).IndexTerms()
+	require.True(t, ok)
+	require.True(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "数据"}, {Token: "据库"}}, terms)
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	// MaxTokenLength is the max length in characters of the indexed tokens, which is the same as
+	// InnoDB. The longer tokens are ignored by all the parsers, so they are neither indexed nor
+	// matched by the queries.
+	MaxTokenLength = 84
+	// MinTokenLength is the min length in characters of the tokens of the built-in parser.
+	MinTokenLength = 3
+	// NgramTokenSize is the length in characters of the tokens of the ngram parser.
+	NgramTokenSize = 2
+	// NgramParserName is the name of the ngram parser, which is used for the CJK text.
+	NgramParserName = "ngram"
+)
+
+// Parser splits the text of the full-text columns into tokens.
+type Parser interface {
+	// Tokenize calls fn with the tokens of the text in order. The tokens should be lower-cased,
+	// the tokens of the registered parsers longer than MaxTokenLength characters are ignored.
+	Tokenize(text string, fn func(token string))
+}
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]Parser{
+		"":              builtinParser{},
+		NgramParserName: ngramParser{size: NgramTokenSize},
+	}
+)
+
+// RegisterParser registers a parser, which can be used by `WITH PARSER name` of the FULLTEXT indexes.
+func RegisterParser(name string, p Parser) error {
+	name = strings.ToLower(name)
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	if _, ok := parsers[name]; ok {
+		return errors.Errorf("full-text parser %s already exists", name)
+	}
+	parsers[name] = lengthLimitedParser{p}
+	return nil
+}
+
+// lengthLimitedParser ignores the tokens longer than MaxTokenLength characters, which can't be
+// stored in the index.
+type lengthLimitedParser struct {
+	Parser
+}
+
+func (p lengthLimitedParser) Tokenize(text string, fn func(token string)) {
+	p.Parser.Tokenize(text, func(token string) {
+		if utf8.RuneCountInString(token) <= MaxTokenLength {
+			fn(token)
+		}
+	})
+}
+
+// GetParser returns the parser by its name, the empty name is the built-in parser.
+func GetParser(name string) (Parser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	p, ok := parsers[strings.ToLower(name)]
+	return p, ok
+}
+
+// stopwords are the default stopwords of InnoDB, which are ignored by the built-in parser.
+var stopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {}, "de": {},
+	"en": {}, "for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {}, "la": {}, "of": {},
+	"on": {}, "or": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "what": {}, "when": {},
+	"where": {}, "who": {}, "will": {}, "with": {}, "und": {}, "www": {},
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitWords calls fn with the lower-cased runs of word characters of the text.
+func splitWords(text string, fn func(word string)) {
+	start := -1
+	for i, r := range text {
+		if isWordChar(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fn(strings.ToLower(text[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fn(strings.ToLower(text[start:]))
+	}
+}
+
+// builtinParser splits the text into words by the non-word characters. The stopwords and the
+// words shorter than MinTokenLength characters are ignored.
+type builtinParser struct{}
+
+func (builtinParser) Tokenize(text string, fn func(token string)) {
+	splitWords(text, func(word string) {
+		n := utf8.RuneCountInString(word)
+		if n < MinTokenLength || n > MaxTokenLength {
+			return
+		}
+		if _, ok := stopwords[word]; ok {
+			return
+		}
+		fn(word)
+	})
+}
+
+// ngramParser splits the runs of word characters into the contiguous sequences of size characters.
+type ngramParser struct {
+	size int
+}
+
+func (p ngramParser) Tokenize(text string, fn func(token string)) {
+	var offsets []int
+	splitWords(text, func(word string) {
+		offsets = offsets[:0]
+		for i := range word {
+			offsets = append(offsets, i)
+		}
+		offsets = append(offsets, len(word))
+		for i := 0; i+p.size < len(offsets); i++ {
+			fn(word[offsets[i]:offsets[i+p.size]])
+		}
+	})
+}
+
+// DistinctTokens returns the distinct tokens of the texts in the order of their first appearances.
+func DistinctTokens(p Parser, texts ...string) []string {
+	tokens := make([]string, 0, 8)
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		p.Tokenize(text, func(token string) {
+			if _, ok := seen[token]; ok {
+				return
+			}
+			seen[token] = struct{}{}
+			tokens = append(tokens, token)
+		})
+	}
+	return tokens
+}
+
+// Document is the tokens of the full-text columns of a row.
+type Document struct {
+	// fields are the tokens of each column in order.
+	fields [][]string
+	// freqs are the frequencies of the tokens.
+	freqs map[string]int
+}
+
+// NewDocument tokenizes the texts of the full-text columns.
+func NewDocument(p Parser, texts ...string) *Document {
+	doc := &Document{
+		fields: make([][]string, 0, len(texts)),
+		freqs:  make(map[string]int),
+	}
+	for _, text := range texts {
+		var tokens []string
+		p.Tokenize(text, func(token string) {
+			tokens = append(tokens, token)
+			doc.freqs[token]++
+		})
+		doc.fields = append(doc.fields, tokens)
+	}
+	return doc
+}
+
+// phraseFreq returns how many times the tokens appear contiguously in a column of the document.
+func (d *Document) phraseFreq(phrase []string) int {
+	freq := 0
+	for _, tokens := range d.fields {
+	next:
+		for i := 0; i+len(phrase) <= len(tokens); i++ {
+			for j, token := range phrase {
+				if tokens[i+j] != token {
+					continue next
+				}
+			}
+			freq++
+		}
+	}
+	return freq
+}
+
+// prefixFreq returns the total frequency of the tokens with the prefix.
+func (d *Document) prefixFreq(prefix string) int {
+	freq := 0
+	for token, n := range d.freqs {
+		if strings.HasPrefix(token, prefix) {
+			freq += n
+		}
+	}
+	return freq
+}
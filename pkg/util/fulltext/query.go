@@ -0,0 +1,311 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Mode is the search mode of `MATCH ... AGAINST`.
+type Mode int
+
+const (
+	// NaturalLanguageMode matches the rows containing any of the words of the query.
+	NaturalLanguageMode Mode = iota
+	// BooleanMode matches the rows by the operators of the query, such as `+word -word "phrase" word*`.
+	BooleanMode
+)
+
+// The weights of the boolean operators changing the contributions of the words to the relevance.
+const (
+	increaseWeight = 2
+	decreaseWeight = 0.5
+	negateWeight   = -0.5
+)
+
+// item is a word, a phrase or a group of a boolean query.
+type item struct {
+	// op is one of '+', '-', '>', '<', '~', or 0 if there is no operator.
+	op byte
+	// tokens are the tokens of a word or a phrase. A word which is split into multiple tokens
+	// by the parser is matched as a phrase.
+	tokens []string
+	// prefix is true for `word*`, tokens is the lower-cased word.
+	prefix bool
+	// group is not nil for the parenthesized items.
+	group []*item
+}
+
+// Query is a parsed full-text search query.
+type Query struct {
+	mode Mode
+	// tokens are the distinct tokens of a natural language query.
+	tokens []string
+	// items are the items of a boolean query.
+	items []*item
+}
+
+// ParseQuery parses the query of `MATCH ... AGAINST` by the parser of the FULLTEXT index. The
+// boolean query is parsed leniently like MySQL, e.g. the unclosed quote or parenthesis ends at
+// the end of the query.
+func ParseQuery(p Parser, text string, mode Mode) *Query {
+	q := &Query{mode: mode}
+	if mode == NaturalLanguageMode {
+		q.tokens = DistinctTokens(p, text)
+		return q
+	}
+	qp := &queryParser{parser: p, text: text}
+	q.items = qp.parseItems(false)
+	return q
+}
+
+type queryParser struct {
+	parser Parser
+	text   string
+	pos    int
+}
+
+func (qp *queryParser) parseItems(inGroup bool) []*item {
+	var items []*item
+	for {
+		for qp.pos < len(qp.text) && unicode.IsSpace(rune(qp.text[qp.pos])) {
+			qp.pos++
+		}
+		if qp.pos >= len(qp.text) {
+			return items
+		}
+		if qp.text[qp.pos] == ')' {
+			qp.pos++
+			if inGroup {
+				return items
+			}
+			continue
+		}
+		var op byte
+		switch c := qp.text[qp.pos]; c {
+		case '+', '-', '>', '<', '~':
+			op = c
+			qp.pos++
+		}
+		if it := qp.parseItem(op); it != nil {
+			items = append(items, it)
+		}
+	}
+}
+
+func (qp *queryParser) parseItem(op byte) *item {
+	if qp.pos >= len(qp.text) {
+		return nil
+	}
+	switch qp.text[qp.pos] {
+	case '(':
+		qp.pos++
+		group := qp.parseItems(true)
+		if len(group) == 0 {
+			return nil
+		}
+		return &item{op: op, group: group}
+	case '"':
+		qp.pos++
+		end := strings.IndexByte(qp.text[qp.pos:], '"')
+		if end < 0 {
+			end = len(qp.text) - qp.pos
+		}
+		phrase := qp.text[qp.pos : qp.pos+end]
+		qp.pos = min(qp.pos+end+1, len(qp.text))
+		return qp.newItem(op, phrase)
+	}
+	start := qp.pos
+	for qp.pos < len(qp.text) {
+		c := qp.text[qp.pos]
+		if c == '(' || c == ')' || c == '"' || unicode.IsSpace(rune(c)) {
+			break
+		}
+		qp.pos++
+	}
+	word := qp.text[start:qp.pos]
+	if strings.HasSuffix(word, "*") {
+		prefix := strings.ToLower(strings.TrimRight(word, "*"))
+		if prefix == "" || strings.IndexFunc(prefix, func(r rune) bool { return !isWordChar(r) }) >= 0 {
+			return qp.newItem(op, prefix)
+		}
+		return &item{op: op, tokens: []string{prefix}, prefix: true}
+	}
+	return qp.newItem(op, word)
+}
+
+func (qp *queryParser) newItem(op byte, text string) *item {
+	var tokens []string
+	qp.parser.Tokenize(text, func(token string) {
+		tokens = append(tokens, token)
+	})
+	// The stopwords and the too short words are ignored.
+	if len(tokens) == 0 {
+		return nil
+	}
+	return &item{op: op, tokens: tokens}
+}
+
+func freqScore(freq int) float64 {
+	if freq == 0 {
+		return 0
+	}
+	return 1 + math.Log(float64(freq))
+}
+
+// Score returns the relevance of the document, it's 0 if the document doesn't match the query.
+// The relevance of a word is 1+ln(f) where f is the frequency of the word in the document, and
+// the relevance of the document is the sum of the relevance of the matched words weighted by
+// the boolean operators.
+func (q *Query) Score(doc *Document) float64 {
+	if q.mode == NaturalLanguageMode {
+		score := 0.0
+		for _, token := range q.tokens {
+			score += freqScore(doc.freqs[token])
+		}
+		return score
+	}
+	_, score := matchItems(q.items, doc)
+	return score
+}
+
+func (it *item) match(doc *Document) (bool, float64) {
+	switch {
+	case it.group != nil:
+		return matchItems(it.group, doc)
+	case it.prefix:
+		score := freqScore(doc.prefixFreq(it.tokens[0]))
+		return score > 0, score
+	case len(it.tokens) == 1:
+		score := freqScore(doc.freqs[it.tokens[0]])
+		return score > 0, score
+	default:
+		score := freqScore(doc.phraseFreq(it.tokens))
+		return score > 0, score
+	}
+}
+
+// matchItems matches the items of a group. The group matches if all the `+` items match and none
+// of the `-` items matches, and at least one item matches if there is no `+` item.
+func matchItems(items []*item, doc *Document) (bool, float64) {
+	hasRequired, matchedAny := false, false
+	score := 0.0
+	for _, it := range items {
+		matched, s := it.match(doc)
+		switch it.op {
+		case '-':
+			if matched {
+				return false, 0
+			}
+		case '+':
+			if !matched {
+				return false, 0
+			}
+			hasRequired = true
+			score += s
+		default:
+			if !matched {
+				continue
+			}
+			matchedAny = true
+			switch it.op {
+			case '>':
+				s *= increaseWeight
+			case '<':
+				s *= decreaseWeight
+			case '~':
+				s *= negateWeight
+			}
+			score += s
+		}
+	}
+	if !hasRequired && !matchedAny {
+		return false, 0
+	}
+	return true, score
+}
+
+// IndexTerm is a token to be looked up in a FULLTEXT index.
+type IndexTerm struct {
+	Token string
+	// Prefix is true if the tokens with the prefix Token should be looked up.
+	Prefix bool
+}
+
+// IndexTerms returns the terms to look up in the FULLTEXT index. The matched rows contain all
+// of the terms if intersection is true, otherwise they contain at least one of them. ok is false
+// if the index can't be used to find the matched rows.
+func (q *Query) IndexTerms() (terms []IndexTerm, intersection bool, ok bool) {
+	c := &termCollector{seen: make(map[IndexTerm]struct{})}
+	if q.mode == NaturalLanguageMode {
+		for _, token := range q.tokens {
+			c.add(IndexTerm{Token: token})
+		}
+		return c.terms, false, len(c.terms) > 0
+	}
+
+	for _, it := range q.items {
+		if it.op != '+' || it.group != nil {
+			continue
+		}
+		// Every token of the required words and phrases must be contained.
+		for _, token := range it.tokens {
+			c.add(IndexTerm{Token: token, Prefix: it.prefix})
+		}
+	}
+	if len(c.terms) > 0 {
+		return c.terms, true, true
+	}
+	if !c.collectAny(q.items) {
+		return nil, false, false
+	}
+	return c.terms, false, true
+}
+
+type termCollector struct {
+	terms []IndexTerm
+	seen  map[IndexTerm]struct{}
+}
+
+func (c *termCollector) add(term IndexTerm) {
+	if _, ok := c.seen[term]; ok {
+		return
+	}
+	c.seen[term] = struct{}{}
+	c.terms = append(c.terms, term)
+}
+
+// collectAny collects the terms of which at least one is contained by the rows matching the
+// items. It returns false if there are no such terms.
+func (c *termCollector) collectAny(items []*item) bool {
+	collected := false
+	for _, it := range items {
+		if it.op == '-' {
+			continue
+		}
+		if it.group != nil {
+			if !c.collectAny(it.group) {
+				return false
+			}
+		} else {
+			// The first token is enough for a phrase.
+			c.add(IndexTerm{Token: it.tokens[0], Prefix: it.prefix})
+		}
+		collected = true
+	}
+	return collected
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+// MaxCellLevel is the level of the smallest cells.
+const MaxCellLevel = 30
+
+// The space divided into the cells, which is the range of the longitude and the latitude. The
+// points out of the space belong to the cells on the edges.
+const (
+	spaceMinX = -180.0
+	spaceMaxX = 180.0
+	spaceMinY = -90.0
+	spaceMaxY = 90.0
+)
+
+// CellID identifies a cell of a quadtree on the space. The cell of level 0 is the whole space, and
+// a cell of level L is divided into 4 cells of level L+1. Like the cells of S2, the ID of a cell of
+// level L is the Z-order position of the cell in 2*L bits, followed by a 1 bit and then zero bits,
+// so the IDs of the descendants of a cell are in a continuous range around the ID of the cell.
+type CellID uint64
+
+// lsbForLevel returns the lowest set bit of the IDs of the cells of the level.
+func lsbForLevel(level int) CellID {
+	return 1 << (2 * (MaxCellLevel - level))
+}
+
+// cellFromIJ returns the ID of the cell of the level at (i, j), where i and j are the indexes of
+// the cell on the X axis and the Y axis at MaxCellLevel.
+func cellFromIJ(i, j uint32, level int) CellID {
+	var pos uint64
+	for b := MaxCellLevel - 1; b >= 0; b-- {
+		pos = pos<<2 | uint64(i>>b&1)<<1 | uint64(j>>b&1)
+	}
+	id := CellID(pos<<1 | 1)
+	return id.Parent(level)
+}
+
+// Level returns the level of the cell.
+func (c CellID) Level() int {
+	lsb := c & -c
+	level := MaxCellLevel
+	for lsb > 1 {
+		lsb >>= 2
+		level--
+	}
+	return level
+}
+
+// Parent returns the ancestor of the cell at the level, which must not be deeper than the level of
+// the cell.
+func (c CellID) Parent(level int) CellID {
+	lsb := lsbForLevel(level)
+	return c&-lsb | lsb
+}
+
+// RangeMin returns the minimum ID of the descendants of the cell.
+func (c CellID) RangeMin() CellID {
+	return c - (c&-c - 1)
+}
+
+// RangeMax returns the maximum ID of the descendants of the cell.
+func (c CellID) RangeMax() CellID {
+	return c + (c&-c - 1)
+}
+
+// cellIndex returns the index of the cell at MaxCellLevel containing v on an axis of [lo, hi].
+func cellIndex(v, lo, hi float64) uint32 {
+	const n = 1 << MaxCellLevel
+	idx := math.Floor((v - lo) / (hi - lo) * n)
+	return uint32(math.Max(0, math.Min(n-1, idx)))
+}
+
+// CoveringCells returns at most 4 cells covering the rectangle, which are the cells containing
+// the rectangle at the deepest level where it spans at most 2 cells on each axis.
+func CoveringCells(r Rect) []CellID {
+	i0, i1 := cellIndex(r.Min.X, spaceMinX, spaceMaxX), cellIndex(r.Max.X, spaceMinX, spaceMaxX)
+	j0, j1 := cellIndex(r.Min.Y, spaceMinY, spaceMaxY), cellIndex(r.Max.Y, spaceMinY, spaceMaxY)
+	level := MaxCellLevel
+	for shift := 0; i1>>shift-i0>>shift > 1 || j1>>shift-j0>>shift > 1; shift++ {
+		level--
+	}
+	cells := make([]CellID, 0, 4)
+	for _, i := range []uint32{i0, i1} {
+		for _, j := range []uint32{j0, j1} {
+			cells = append(cells, cellFromIJ(i, j, level))
+		}
+	}
+	slices.Sort(cells)
+	return slices.Compact(cells)
+}
+
+// CellRange is a range of the cell IDs, both ends are included.
+type CellRange struct {
+	Min, Max CellID
+}
+
+// QueryCells returns the cells to look up in a spatial index for the geometries whose MBRs
+// intersect the rectangle, where the MBR of every geometry is indexed by its CoveringCells. The
+// covering cells of a geometry intersecting the rectangle must be the descendants or the
+// ancestors of a covering cell of the rectangle, so they're in the returned ranges or the
+// returned ancestors.
+func QueryCells(r Rect) (ranges []CellRange, ancestors []CellID) {
+	for _, c := range CoveringCells(r) {
+		ranges = append(ranges, CellRange{c.RangeMin(), c.RangeMax()})
+		for level := c.Level() - 1; level >= 0; level-- {
+			ancestors = append(ancestors, c.Parent(level))
+		}
+	}
+	slices.Sort(ancestors)
+	return ranges, slices.Compact(ancestors)
+}
+
+// IndexCells returns the cells of the geometry to be stored in a spatial index, it's empty for the
+// empty geometry.
+func (g *Geometry) IndexCells() []CellID {
+	r, ok := g.Bound()
+	if !ok {
+		return nil
+	}
+	return CoveringCells(r)
+}
@@ -0,0 +1,225 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, text string) *Geometry {
+	g, err := ParseWKT(text)
+	require.NoError(t, err, text)
+	return g
+}
+
+func TestWKTAndWKB(t *testing.T) {
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 1,2 2,1 1))"},
+		{"MULTIPOINT(1 1, 2 2)", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, c := range cases {
+		g := mustParseWKT(t, c.input)
+		require.Equal(t, c.output, g.WKT())
+		g.SRID = 4326
+		decoded, err := Decode(g.Encode())
+		require.NoError(t, err)
+		require.Equal(t, c.output, decoded.WKT())
+		require.Equal(t, uint32(4326), decoded.SRID)
+	}
+
+	for _, text := range []string{
+		"", "POINT()", "POINT(1)", "POINT(1 2", "POINT(1 2) x", "LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))", "MULTIPOINT()", "CIRCLE(0 0)", "GEOMETRYCOLLECTION(POINT(1 1)",
+	} {
+		_, err := ParseWKT(text)
+		require.ErrorIs(t, err, ErrInvalidData, text)
+	}
+
+	// The big-endian WKB is accepted.
+	wkb, err := hex.DecodeString("00000000013FF00000000000004000000000000000")
+	require.NoError(t, err)
+	g, err := ParseWKB(wkb)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	require.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(g.WKB()))
+	for _, data := range []string{"", "01", "0101000000000000000000F03F", "010800000000", "0101000000000000000000F03F000000000000004000"} {
+		b, err := hex.DecodeString(data)
+		require.NoError(t, err)
+		_, err = ParseWKB(b)
+		require.ErrorIs(t, err, ErrInvalidData, data)
+	}
+	_, err = Decode([]byte{1, 2})
+	require.ErrorIs(t, err, ErrInvalidData)
+}
+
+func TestGeoJSON(t *testing.T) {
+	g := mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))")
+	require.Equal(t, map[string]any{
+		"type": "Polygon",
+		"coordinates": []any{[]any{
+			[]any{0.0, 0.0}, []any{10.0, 0.0}, []any{10.0, 10.0}, []any{0.0, 10.0}, []any{0.0, 0.0},
+		}},
+	}, g.GeoJSON(-1))
+	require.Equal(t, []any{1.23, 4.0}, mustParseWKT(t, "POINT(1.2345 4)").GeoJSON(2)["coordinates"])
+
+	cases := []struct {
+		json string
+		wkt  string
+	}{
+		{`{"type": "Point", "coordinates": [1, 2]}`, "POINT(1 2)"},
+		{`{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`, "LINESTRING(0 0,1 1)"},
+		{`{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]]]}`, "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))"},
+		{`{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}]}`, "GEOMETRYCOLLECTION(POINT(1 2))"},
+		{`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {}}`, "POINT(1 2)"},
+		{`{"type": "FeatureCollection", "features": []}`, "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, c := range cases {
+		g, err := ParseGeoJSON([]byte(c.json))
+		require.NoError(t, err, c.json)
+		require.Equal(t, c.wkt, g.WKT())
+	}
+	for _, data := range []string{`[]`, `{"type": "Point"}`, `{"type": "Circle", "coordinates": [1, 2]}`, `{"type": "LineString", "coordinates": [[0, 0]]}`} {
+		_, err := ParseGeoJSON([]byte(data))
+		require.ErrorIs(t, err, ErrInvalidData, data)
+	}
+}
+
+func TestRelations(t *testing.T) {
+	square := "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+	cases := []struct {
+		a, b                 string
+		intersects, contains bool
+	}{
+		{square, "POINT(5 5)", true, true},
+		{square, "POINT(10 5)", true, false},
+		{square, "POINT(11 5)", false, false},
+		{square, square, true, true},
+		{square, "POLYGON((1 1,9 1,9 9,1 9,1 1))", true, true},
+		{square, "POLYGON((0 0,10 0,5 5,0 0))", true, true},
+		{square, "POLYGON((5 5,15 5,15 15,5 15,5 5))", true, false},
+		{square, "POLYGON((20 20,30 20,30 30,20 20))", false, false},
+		{square, "LINESTRING(0 0,10 10)", true, true},
+		{square, "LINESTRING(0 0,10 0)", true, false},
+		{square, "LINESTRING(5 5,15 5)", true, false},
+		{square, "MULTIPOINT(1 1,9 9)", true, true},
+		{square, "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(2 2,3 3))", true, true},
+		// The hole isn't a part of the polygon.
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", "POINT(5 5)", false, false},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", "POLYGON((1 1,9 1,9 9,1 9,1 1))", true, false},
+		// A concave polygon.
+		{"POLYGON((0 0,10 0,10 10,5 2,0 10,0 0))", "LINESTRING(1 5,9 5)", true, false},
+		{"POLYGON((0 0,10 0,10 10,5 2,0 10,0 0))", "POINT(5 5)", false, false},
+		{"LINESTRING(0 0,10 10)", "POINT(5 5)", true, true},
+		{"LINESTRING(0 0,10 10)", "POINT(0 0)", true, false},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(2 2,3 3)", true, true},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(0 10,10 0)", true, false},
+		{"LINESTRING(0 0,1 1)", "LINESTRING(2 2,3 3)", false, false},
+		{"POINT(1 1)", "POINT(1 1)", true, true},
+		{"POINT(1 1)", "GEOMETRYCOLLECTION EMPTY", false, false},
+	}
+	for _, c := range cases {
+		a, b := mustParseWKT(t, c.a), mustParseWKT(t, c.b)
+		require.Equal(t, c.intersects, Intersects(a, b), "%s intersects %s", c.a, c.b)
+		require.Equal(t, c.intersects, Intersects(b, a), "%s intersects %s", c.b, c.a)
+		require.Equal(t, c.contains, Contains(a, b), "%s contains %s", c.a, c.b)
+	}
+}
+
+func TestMeasures(t *testing.T) {
+	dist, ok := Distance(mustParseWKT(t, "POINT(0 0)"), mustParseWKT(t, "POINT(3 4)"))
+	require.True(t, ok)
+	require.Equal(t, 5.0, dist)
+	dist, _ = Distance(mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))"), mustParseWKT(t, "LINESTRING(12 5,20 5)"))
+	require.Equal(t, 2.0, dist)
+	dist, _ = Distance(mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))"), mustParseWKT(t, "POINT(5 5)"))
+	require.Equal(t, 0.0, dist)
+	_, ok = Distance(mustParseWKT(t, "POINT(0 0)"), mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY"))
+	require.False(t, ok)
+
+	require.Equal(t, 96.0, mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))").Area())
+	require.Equal(t, 0.0, mustParseWKT(t, "LINESTRING(0 0,1 1)").Area())
+
+	// From Beijing to Shanghai.
+	dist = DistanceSphere(NewPoint(116.4074, 39.9042, 0), NewPoint(121.4737, 31.2304, 0), EarthRadius)
+	require.InDelta(t, 1067000, dist, 1000)
+	require.InDelta(t, math.Pi, DistanceSphere(NewPoint(0, 0, 0), NewPoint(180, 0, 0), 1), 1e-9)
+}
+
+func TestCells(t *testing.T) {
+	leaf := cellFromIJ(12345, 67890, MaxCellLevel)
+	require.Equal(t, MaxCellLevel, leaf.Level())
+	for level := 0; level <= MaxCellLevel; level++ {
+		c := leaf.Parent(level)
+		require.Equal(t, level, c.Level())
+		require.LessOrEqual(t, c.RangeMin(), leaf)
+		require.GreaterOrEqual(t, c.RangeMax(), leaf)
+	}
+	require.Equal(t, CellID(1)<<60, leaf.Parent(0))
+	require.Equal(t, CellID(1), leaf.Parent(0).RangeMin())
+
+	require.Equal(t, []CellID{cellFromIJ(cellIndex(1, -180, 180), cellIndex(2, -90, 90), MaxCellLevel)},
+		NewPoint(1, 2, 0).IndexCells())
+	require.Empty(t, mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY").IndexCells())
+	cells := mustParseWKT(t, "POLYGON((-10 -10,10 -10,10 10,-10 10,-10 -10))").IndexCells()
+	require.Len(t, cells, 4)
+	// The whole space is covered by the 4 cells of level 1.
+	cells = mustParseWKT(t, "LINESTRING(-180 -90,180 90)").IndexCells()
+	require.Len(t, cells, 4)
+	for _, c := range cells {
+		require.Equal(t, 1, c.Level())
+	}
+
+	// The index cells of a geometry intersecting the rectangle must be found by the query cells.
+	query := Rect{Point{-1, -1}, Point{1, 1}}
+	ranges, ancestors := QueryCells(query)
+	found := func(c CellID) bool {
+		for _, r := range ranges {
+			if r.Min <= c && c <= r.Max {
+				return true
+			}
+		}
+		for _, a := range ancestors {
+			if a == c {
+				return true
+			}
+		}
+		return false
+	}
+	for _, text := range []string{"POINT(0 0)", "POINT(1 1)", "POINT(-1 0.5)", "POLYGON((-100 -50,100 -50,100 50,-100 -50))", "LINESTRING(0.5 0.5,179 89)"} {
+		g := mustParseWKT(t, text)
+		hit := false
+		for _, c := range g.IndexCells() {
+			hit = hit || found(c)
+		}
+		require.True(t, hit, text)
+	}
+	require.False(t, found(NewPoint(50, 50, 0).IndexCells()[0]))
+}
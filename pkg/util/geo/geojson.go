@@ -0,0 +1,204 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"encoding/json"
+	"math"
+)
+
+var geoJSONTypes = map[Kind]string{
+	KindPoint:              "Point",
+	KindLineString:         "LineString",
+	KindPolygon:            "Polygon",
+	KindMultiPoint:         "MultiPoint",
+	KindMultiLineString:    "MultiLineString",
+	KindMultiPolygon:       "MultiPolygon",
+	KindGeometryCollection: "GeometryCollection",
+}
+
+// GeoJSON returns the GeoJSON object of the geometry, the coordinates are rounded to maxDecimals
+// decimal places if maxDecimals is not negative.
+func (g *Geometry) GeoJSON(maxDecimals int) map[string]any {
+	obj := map[string]any{"type": geoJSONTypes[g.Kind]}
+	if g.Kind == KindGeometryCollection {
+		geoms := make([]any, 0, len(g.Geoms))
+		for _, m := range g.Geoms {
+			geoms = append(geoms, m.GeoJSON(maxDecimals))
+		}
+		obj["geometries"] = geoms
+	} else {
+		obj["coordinates"] = g.geoJSONCoordinates(maxDecimals)
+	}
+	return obj
+}
+
+func (g *Geometry) geoJSONCoordinates(maxDecimals int) any {
+	switch g.Kind {
+	case KindPoint:
+		return geoJSONPoint(g.Points[0], maxDecimals)
+	case KindLineString:
+		return geoJSONPoints(g.Points, maxDecimals)
+	case KindPolygon:
+		rings := make([]any, 0, len(g.Rings))
+		for _, ring := range g.Rings {
+			rings = append(rings, geoJSONPoints(ring, maxDecimals))
+		}
+		return rings
+	default:
+		members := make([]any, 0, len(g.Geoms))
+		for _, m := range g.Geoms {
+			members = append(members, m.geoJSONCoordinates(maxDecimals))
+		}
+		return members
+	}
+}
+
+func geoJSONPoint(p Point, maxDecimals int) []any {
+	return []any{roundCoord(p.X, maxDecimals), roundCoord(p.Y, maxDecimals)}
+}
+
+func geoJSONPoints(points []Point, maxDecimals int) []any {
+	coords := make([]any, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, geoJSONPoint(p, maxDecimals))
+	}
+	return coords
+}
+
+func roundCoord(v float64, maxDecimals int) float64 {
+	if maxDecimals < 0 || maxDecimals > 17 {
+		return v
+	}
+	pow := math.Pow10(maxDecimals)
+	return math.Round(v*pow) / pow
+}
+
+// ParseGeoJSON parses a GeoJSON geometry object, the SRID of the result is 0. A Feature is parsed
+// as its geometry, and a FeatureCollection is parsed as a GeometryCollection.
+func ParseGeoJSON(data []byte) (*Geometry, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, ErrInvalidData
+	}
+	g, err := parseGeoJSONObject(obj, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func parseGeoJSONObject(obj map[string]json.RawMessage, depth int) (*Geometry, error) {
+	if depth > maxNestingDepth {
+		return nil, ErrInvalidData
+	}
+	var tp string
+	if err := json.Unmarshal(obj["type"], &tp); err != nil {
+		return nil, ErrInvalidData
+	}
+	switch tp {
+	case "Feature":
+		var geom map[string]json.RawMessage
+		if err := json.Unmarshal(obj["geometry"], &geom); err != nil || geom == nil {
+			return nil, ErrInvalidData
+		}
+		return parseGeoJSONObject(geom, depth+1)
+	case "FeatureCollection", "GeometryCollection":
+		key := "geometries"
+		if tp == "FeatureCollection" {
+			key = "features"
+		}
+		var members []map[string]json.RawMessage
+		if err := json.Unmarshal(obj[key], &members); err != nil {
+			return nil, ErrInvalidData
+		}
+		g := &Geometry{Kind: KindGeometryCollection}
+		for _, member := range members {
+			m, err := parseGeoJSONObject(member, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, m)
+		}
+		return g, nil
+	}
+	for kind, name := range geoJSONTypes {
+		if name == tp && kind != KindGeometryCollection {
+			return parseGeoJSONCoordinates(kind, obj["coordinates"])
+		}
+	}
+	return nil, ErrInvalidData
+}
+
+func parseGeoJSONCoordinates(kind Kind, data json.RawMessage) (*Geometry, error) {
+	g := &Geometry{Kind: kind}
+	switch kind {
+	case KindPoint:
+		var coord []float64
+		if err := json.Unmarshal(data, &coord); err != nil || len(coord) < 2 {
+			return nil, ErrInvalidData
+		}
+		g.Points = []Point{{coord[0], coord[1]}}
+	case KindLineString:
+		points, err := parseGeoJSONPoints(data)
+		if err != nil {
+			return nil, err
+		}
+		g.Points = points
+	case KindPolygon:
+		var rings []json.RawMessage
+		if err := json.Unmarshal(data, &rings); err != nil {
+			return nil, ErrInvalidData
+		}
+		for _, ring := range rings {
+			points, err := parseGeoJSONPoints(ring)
+			if err != nil {
+				return nil, err
+			}
+			g.Rings = append(g.Rings, points)
+		}
+	default:
+		var members []json.RawMessage
+		if err := json.Unmarshal(data, &members); err != nil {
+			return nil, ErrInvalidData
+		}
+		for _, member := range members {
+			m, err := parseGeoJSONCoordinates(kind.memberKind(), member)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, m)
+		}
+	}
+	return g, nil
+}
+
+func parseGeoJSONPoints(data json.RawMessage) ([]Point, error) {
+	var coords [][]float64
+	if err := json.Unmarshal(data, &coords); err != nil {
+		return nil, ErrInvalidData
+	}
+	points := make([]Point, 0, len(coords))
+	for _, coord := range coords {
+		if len(coord) < 2 {
+			return nil, ErrInvalidData
+		}
+		points = append(points, Point{coord[0], coord[1]})
+	}
+	return points, nil
+}
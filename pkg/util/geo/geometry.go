@@ -0,0 +1,355 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/errors"
+)
+
+// Kind is the type of a geometry, the values are the type codes of WKB.
+type Kind uint32
+
+// The kinds of the geometries.
+const (
+	KindPoint              Kind = 1
+	KindLineString         Kind = 2
+	KindPolygon            Kind = 3
+	KindMultiPoint         Kind = 4
+	KindMultiLineString    Kind = 5
+	KindMultiPolygon       Kind = 6
+	KindGeometryCollection Kind = 7
+)
+
+var kindNames = map[Kind]string{
+	KindPoint:              "POINT",
+	KindLineString:         "LINESTRING",
+	KindPolygon:            "POLYGON",
+	KindMultiPoint:         "MULTIPOINT",
+	KindMultiLineString:    "MULTILINESTRING",
+	KindMultiPolygon:       "MULTIPOLYGON",
+	KindGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// String implements the fmt.Stringer interface, it returns the name of the kind used by WKT.
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// memberKind returns the kind of the members of a multi geometry.
+func (k Kind) memberKind() Kind {
+	switch k {
+	case KindMultiPoint:
+		return KindPoint
+	case KindMultiLineString:
+		return KindLineString
+	case KindMultiPolygon:
+		return KindPolygon
+	}
+	return 0
+}
+
+// SRIDLength is the length of the SRID prefix of the stored geometry.
+const SRIDLength = 4
+
+// ErrInvalidData is returned when the data can't be parsed as a geometry.
+var ErrInvalidData = errors.New("invalid geometry data")
+
+// Point is a point of a geometry.
+type Point struct {
+	X, Y float64
+}
+
+// Geometry is a geometry value.
+type Geometry struct {
+	Kind Kind
+	SRID uint32
+	// Points are the point of a Point, or the points of a LineString.
+	Points []Point
+	// Rings are the rings of a Polygon, the first ring is the exterior ring and the others are
+	// the holes. Every ring is closed.
+	Rings [][]Point
+	// Geoms are the members of a MultiPoint, a MultiLineString, a MultiPolygon or a
+	// GeometryCollection.
+	Geoms []*Geometry
+}
+
+// NewPoint returns a Point.
+func NewPoint(x, y float64, srid uint32) *Geometry {
+	return &Geometry{Kind: KindPoint, SRID: srid, Points: []Point{{x, y}}}
+}
+
+// IsEmpty returns whether the geometry contains no point, which can only be an empty
+// GeometryCollection or a collection of empty GeometryCollections.
+func (g *Geometry) IsEmpty() bool {
+	switch g.Kind {
+	case KindPoint, KindLineString, KindPolygon:
+		return false
+	}
+	for _, m := range g.Geoms {
+		if !m.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSRID sets the SRID of the geometry and its members.
+func (g *Geometry) SetSRID(srid uint32) {
+	g.SRID = srid
+	for _, m := range g.Geoms {
+		m.SetSRID(srid)
+	}
+}
+
+// validate checks the structure of the geometry, such as a LineString has at least 2 points and
+// the rings of a Polygon are closed.
+func (g *Geometry) validate() error {
+	switch g.Kind {
+	case KindPoint:
+		if len(g.Points) != 1 {
+			return ErrInvalidData
+		}
+	case KindLineString:
+		if len(g.Points) < 2 {
+			return ErrInvalidData
+		}
+	case KindPolygon:
+		if len(g.Rings) == 0 {
+			return ErrInvalidData
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return ErrInvalidData
+			}
+		}
+	case KindMultiPoint, KindMultiLineString, KindMultiPolygon:
+		if len(g.Geoms) == 0 {
+			return ErrInvalidData
+		}
+		for _, m := range g.Geoms {
+			if m.Kind != g.Kind.memberKind() {
+				return ErrInvalidData
+			}
+			if err := m.validate(); err != nil {
+				return err
+			}
+		}
+	case KindGeometryCollection:
+		for _, m := range g.Geoms {
+			if err := m.validate(); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrInvalidData
+	}
+	for _, p := range g.Points {
+		if !isFinite(p) {
+			return ErrInvalidData
+		}
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			if !isFinite(p) {
+				return ErrInvalidData
+			}
+		}
+	}
+	return nil
+}
+
+func isFinite(p Point) bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// Encode returns the stored format of the geometry, which is the same as MySQL: the 4 bytes
+// little-endian SRID followed by the WKB of the geometry.
+func (g *Geometry) Encode() []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, g.SRID)
+	return g.appendWKB(buf)
+}
+
+// Decode decodes the stored format of a geometry.
+func Decode(data []byte) (*Geometry, error) {
+	if len(data) < SRIDLength {
+		return nil, ErrInvalidData
+	}
+	g, err := ParseWKB(data[SRIDLength:])
+	if err != nil {
+		return nil, err
+	}
+	g.SetSRID(binary.LittleEndian.Uint32(data))
+	return g, nil
+}
+
+// WKB returns the well-known binary of the geometry in little-endian.
+func (g *Geometry) WKB() []byte {
+	return g.appendWKB(nil)
+}
+
+func (g *Geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, 1)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Kind))
+	switch g.Kind {
+	case KindPoint:
+		buf = appendPoints(buf, g.Points)
+	case KindLineString:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Points)))
+		buf = appendPoints(buf, g.Points)
+	case KindPolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(ring)))
+			buf = appendPoints(buf, ring)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Geoms)))
+		for _, m := range g.Geoms {
+			buf = m.appendWKB(buf)
+		}
+	}
+	return buf
+}
+
+func appendPoints(buf []byte, points []Point) []byte {
+	for _, p := range points {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+	}
+	return buf
+}
+
+// ParseWKB parses the well-known binary of a geometry, the SRID of the result is 0.
+func ParseWKB(data []byte) (*Geometry, error) {
+	r := &wkbReader{data: data}
+	g, err := r.readGeometry(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, ErrInvalidData
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// maxNestingDepth limits the nesting of the GeometryCollections.
+const maxNestingDepth = 64
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, ErrInvalidData
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// readCount reads the number of the elements and checks the data is long enough for them.
+func (r *wkbReader) readCount(minElemSize int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minElemSize) > uint64(len(r.data)-r.pos) {
+		return 0, ErrInvalidData
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readPoints(n int) ([]Point, error) {
+	if r.pos+16*n > len(r.data) {
+		return nil, ErrInvalidData
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i].X = math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+		points[i].Y = math.Float64frombits(r.order.Uint64(r.data[r.pos+8:]))
+		r.pos += 16
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readGeometry(depth int) (*Geometry, error) {
+	if depth > maxNestingDepth || r.pos >= len(r.data) {
+		return nil, ErrInvalidData
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, ErrInvalidData
+	}
+	r.pos++
+	kind, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	g := &Geometry{Kind: Kind(kind)}
+	switch g.Kind {
+	case KindPoint:
+		g.Points, err = r.readPoints(1)
+	case KindLineString:
+		var n int
+		if n, err = r.readCount(16); err == nil {
+			g.Points, err = r.readPoints(n)
+		}
+	case KindPolygon:
+		var n int
+		if n, err = r.readCount(4); err != nil {
+			return nil, err
+		}
+		g.Rings = make([][]Point, n)
+		for i := range g.Rings {
+			var m int
+			if m, err = r.readCount(16); err != nil {
+				return nil, err
+			}
+			if g.Rings[i], err = r.readPoints(m); err != nil {
+				return nil, err
+			}
+		}
+	case KindMultiPoint, KindMultiLineString, KindMultiPolygon, KindGeometryCollection:
+		var n int
+		if n, err = r.readCount(5); err != nil {
+			return nil, err
+		}
+		g.Geoms = make([]*Geometry, n)
+		for i := range g.Geoms {
+			if g.Geoms[i], err = r.readGeometry(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, ErrInvalidData
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
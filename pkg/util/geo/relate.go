@@ -0,0 +1,469 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+// Rect is a rectangle whose edges are parallel to the axes, it's the minimum bounding rectangle
+// (MBR) of a geometry.
+type Rect struct {
+	Min, Max Point
+}
+
+// Contains returns whether r contains o, the boundary is included.
+func (r Rect) Contains(o Rect) bool {
+	return r.Min.X <= o.Min.X && r.Min.Y <= o.Min.Y && o.Max.X <= r.Max.X && o.Max.Y <= r.Max.Y
+}
+
+// Intersects returns whether r and o have any common point.
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X && r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+func (r Rect) extend(p Point) Rect {
+	return Rect{
+		Min: Point{math.Min(r.Min.X, p.X), math.Min(r.Min.Y, p.Y)},
+		Max: Point{math.Max(r.Max.X, p.X), math.Max(r.Max.Y, p.Y)},
+	}
+}
+
+// Bound returns the MBR of the geometry, ok is false if the geometry is empty.
+func (g *Geometry) Bound() (r Rect, ok bool) {
+	g.forEachPoint(func(p Point) {
+		if !ok {
+			r, ok = Rect{p, p}, true
+		} else {
+			r = r.extend(p)
+		}
+	})
+	return r, ok
+}
+
+func (g *Geometry) forEachPoint(fn func(Point)) {
+	for _, p := range g.Points {
+		fn(p)
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			fn(p)
+		}
+	}
+	for _, m := range g.Geoms {
+		m.forEachPoint(fn)
+	}
+}
+
+type segment [2]Point
+
+// shape is a geometry decomposed to the points, the segments of the LineStrings and the polygons.
+type shape struct {
+	points   []Point
+	segments []segment
+	polygons [][][]Point
+	// endpoints are the boundary of the LineStrings, which are the endpoints of the open ones.
+	endpoints []Point
+}
+
+func (g *Geometry) shape() *shape {
+	s := &shape{}
+	g.collect(s)
+	return s
+}
+
+func (g *Geometry) collect(s *shape) {
+	switch g.Kind {
+	case KindPoint:
+		s.points = append(s.points, g.Points[0])
+	case KindLineString:
+		s.segments = append(s.segments, segments(g.Points)...)
+		if first, last := g.Points[0], g.Points[len(g.Points)-1]; first != last {
+			s.endpoints = append(s.endpoints, first, last)
+		}
+	case KindPolygon:
+		s.polygons = append(s.polygons, g.Rings)
+	default:
+		for _, m := range g.Geoms {
+			m.collect(s)
+		}
+	}
+}
+
+func segments(points []Point) []segment {
+	segs := make([]segment, 0, len(points)-1)
+	for i := 0; i+1 < len(points); i++ {
+		segs = append(segs, segment{points[i], points[i+1]})
+	}
+	return segs
+}
+
+// edges returns the segments of the LineStrings and the rings of the polygons.
+func (s *shape) edges() []segment {
+	edges := slices.Clone(s.segments)
+	for _, rings := range s.polygons {
+		for _, ring := range rings {
+			edges = append(edges, segments(ring)...)
+		}
+	}
+	return edges
+}
+
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// epsilon is the relative tolerance of checking whether a point is on a segment, which absorbs the
+// rounding errors of the points computed on the segments.
+const epsilon = 1e-12
+
+func onSegment(p Point, s segment) bool {
+	scale := math.Abs(s[1].X-s[0].X) + math.Abs(s[1].Y-s[0].Y)
+	if math.Abs(cross(s[0], s[1], p)) > epsilon*scale*(math.Abs(p.X-s[0].X)+math.Abs(p.Y-s[0].Y)) {
+		return false
+	}
+	tol := epsilon * scale
+	return math.Min(s[0].X, s[1].X)-tol <= p.X && p.X <= math.Max(s[0].X, s[1].X)+tol &&
+		math.Min(s[0].Y, s[1].Y)-tol <= p.Y && p.Y <= math.Max(s[0].Y, s[1].Y)+tol
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1 := sign(cross(t[0], t[1], s[0]))
+	d2 := sign(cross(t[0], t[1], s[1]))
+	d3 := sign(cross(s[0], s[1], t[0]))
+	d4 := sign(cross(s[0], s[1], t[1]))
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return onSegment(s[0], t) || onSegment(s[1], t) || onSegment(t[0], s) || onSegment(t[1], s)
+}
+
+// pointInPolygon returns 1 if p is in the interior of the polygon, 0 if p is on the boundary,
+// and -1 if p is in the exterior.
+func pointInPolygon(p Point, rings [][]Point) int {
+	inside := false
+	for _, ring := range rings {
+		for i := 0; i+1 < len(ring); i++ {
+			a, b := ring[i], ring[i+1]
+			if onSegment(p, segment{a, b}) {
+				return 0
+			}
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+				inside = !inside
+			}
+		}
+	}
+	if inside {
+		return 1
+	}
+	return -1
+}
+
+// intersectsPoint returns whether p is a point of the shape.
+func (s *shape) intersectsPoint(p Point) bool {
+	for _, q := range s.points {
+		if p == q {
+			return true
+		}
+	}
+	for _, seg := range s.segments {
+		if onSegment(p, seg) {
+			return true
+		}
+	}
+	for _, rings := range s.polygons {
+		if pointInPolygon(p, rings) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// interiorContainsPoint returns whether p is in the interior of the shape.
+func (s *shape) interiorContainsPoint(p Point) bool {
+	for _, rings := range s.polygons {
+		if pointInPolygon(p, rings) > 0 {
+			return true
+		}
+	}
+	if slices.Contains(s.endpoints, p) {
+		return false
+	}
+	for _, seg := range s.segments {
+		if onSegment(p, seg) {
+			return true
+		}
+	}
+	return slices.Contains(s.points, p)
+}
+
+// Intersects returns whether the geometries have any common point.
+func Intersects(a, b *Geometry) bool {
+	ra, ok1 := a.Bound()
+	rb, ok2 := b.Bound()
+	if !ok1 || !ok2 || !ra.Intersects(rb) {
+		return false
+	}
+	sa, sb := a.shape(), b.shape()
+	return sa.intersects(sb) || sb.intersects(sa)
+}
+
+// intersects checks the points and the edges of o, and the vertices of the polygons of o. It
+// must be checked in both directions to find the polygons containing the other.
+func (s *shape) intersects(o *shape) bool {
+	for _, p := range o.points {
+		if s.intersectsPoint(p) {
+			return true
+		}
+	}
+	edges := s.edges()
+	for _, e := range o.edges() {
+		if s.intersectsPoint(e[0]) {
+			return true
+		}
+		for _, f := range edges {
+			if segmentsIntersect(e, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Contains returns whether a contains b, i.e. no point of b is in the exterior of a, and at least
+// one point of the interior of b is in the interior of a. The geometry collections are handled
+// as the union of their members, except that a polygon of b must be covered by the polygons of
+// a without their common boundary.
+func Contains(a, b *Geometry) bool {
+	ra, ok1 := a.Bound()
+	rb, ok2 := b.Bound()
+	if !ok1 || !ok2 || !ra.Contains(rb) {
+		return false
+	}
+	sa, sb := a.shape(), b.shape()
+	interior := false
+	for _, p := range sb.points {
+		if !sa.intersectsPoint(p) {
+			return false
+		}
+		interior = interior || sa.interiorContainsPoint(p)
+	}
+	edges := sa.edges()
+	for _, seg := range sb.segments {
+		covered, hit := sa.coversSegment(seg, edges)
+		if !covered {
+			return false
+		}
+		interior = interior || hit
+	}
+	for _, rings := range sb.polygons {
+		if !sa.coversPolygon(rings, edges) {
+			return false
+		}
+		interior = true
+	}
+	return interior
+}
+
+// coversSegment returns whether every point of seg is a point of the shape, and whether any point
+// of seg is in the interior of the shape. The segment is split by the edges of the shape, then
+// each piece is either covered or not as a whole.
+func (s *shape) coversSegment(seg segment, edges []segment) (covered bool, interior bool) {
+	ts := []float64{0, 1}
+	for _, e := range edges {
+		ts = append(ts, splitParams(seg, e)...)
+	}
+	slices.Sort(ts)
+	ts = slices.Compact(ts)
+	at := func(t float64) Point {
+		if t == 1 {
+			return seg[1]
+		}
+		return Point{seg[0].X + (seg[1].X-seg[0].X)*t, seg[0].Y + (seg[1].Y-seg[0].Y)*t}
+	}
+	for i, t := range ts {
+		if !s.intersectsPoint(at(t)) {
+			return false, false
+		}
+		if i == 0 {
+			continue
+		}
+		mid := at((ts[i-1] + t) / 2)
+		if !s.intersectsPoint(mid) {
+			return false, false
+		}
+		interior = interior || s.interiorContainsPoint(mid)
+	}
+	return true, interior
+}
+
+// splitParams returns the parameters in (0, 1) of the points where e meets seg.
+func splitParams(seg, e segment) []float64 {
+	if !segmentsIntersect(seg, e) {
+		return nil
+	}
+	dx, dy := seg[1].X-seg[0].X, seg[1].Y-seg[0].Y
+	length2 := dx*dx + dy*dy
+	if length2 == 0 {
+		return nil
+	}
+	param := func(p Point) float64 {
+		return ((p.X-seg[0].X)*dx + (p.Y-seg[0].Y)*dy) / length2
+	}
+	var ts []float64
+	denom := dx*(e[1].Y-e[0].Y) - dy*(e[1].X-e[0].X)
+	if denom != 0 {
+		ts = append(ts, ((e[0].X-seg[0].X)*(e[1].Y-e[0].Y)-(e[0].Y-seg[0].Y)*(e[1].X-e[0].X))/denom)
+	} else {
+		// The collinear segments overlap between the endpoints.
+		ts = append(ts, param(e[0]), param(e[1]))
+	}
+	return slices.DeleteFunc(ts, func(t float64) bool { return t <= 0 || t >= 1 })
+}
+
+// coversPolygon returns whether the polygon is covered by the polygons of the shape. The boundary
+// of the polygon must be covered, and no vertex of the boundary of the shape is in the interior
+// of the polygon, otherwise there is a hole of the shape in the polygon.
+func (s *shape) coversPolygon(rings [][]Point, edges []segment) bool {
+	if len(s.polygons) == 0 {
+		return false
+	}
+	for _, ring := range rings {
+		for _, seg := range segments(ring) {
+			if covered, _ := s.coversSegment(seg, edges); !covered {
+				return false
+			}
+		}
+	}
+	for _, other := range s.polygons {
+		for _, ring := range other {
+			for _, p := range ring {
+				if pointInPolygon(p, rings) > 0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Distance returns the minimum Cartesian distance between the points of the geometries, ok is
+// false if any of them is empty.
+func Distance(a, b *Geometry) (dist float64, ok bool) {
+	if a.IsEmpty() || b.IsEmpty() {
+		return 0, false
+	}
+	if Intersects(a, b) {
+		return 0, true
+	}
+	// The geometries are disjoint, so the minimum distance is between their boundaries.
+	sa, sb := a.shape(), b.shape()
+	ea, eb := sa.edges(), sb.edges()
+	dist = math.Inf(1)
+	for _, p := range sa.points {
+		for _, q := range sb.points {
+			dist = math.Min(dist, pointDistance(p, q))
+		}
+		for _, e := range eb {
+			dist = math.Min(dist, pointSegmentDistance(p, e))
+		}
+	}
+	for _, e := range ea {
+		for _, q := range sb.points {
+			dist = math.Min(dist, pointSegmentDistance(q, e))
+		}
+		for _, f := range eb {
+			dist = math.Min(dist, math.Min(
+				math.Min(pointSegmentDistance(e[0], f), pointSegmentDistance(e[1], f)),
+				math.Min(pointSegmentDistance(f[0], e), pointSegmentDistance(f[1], e))))
+		}
+	}
+	return dist, true
+}
+
+func pointDistance(p, q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+func pointSegmentDistance(p Point, s segment) float64 {
+	dx, dy := s[1].X-s[0].X, s[1].Y-s[0].Y
+	length2 := dx*dx + dy*dy
+	if length2 == 0 {
+		return pointDistance(p, s[0])
+	}
+	t := ((p.X-s[0].X)*dx + (p.Y-s[0].Y)*dy) / length2
+	t = math.Max(0, math.Min(1, t))
+	return pointDistance(p, Point{s[0].X + t*dx, s[0].Y + t*dy})
+}
+
+// Area returns the area of the polygons of the geometry.
+func (g *Geometry) Area() float64 {
+	area := 0.0
+	for _, rings := range g.shape().polygons {
+		for i, ring := range rings {
+			a := math.Abs(ringArea(ring))
+			if i == 0 {
+				area += a
+			} else {
+				area -= a
+			}
+		}
+	}
+	return area
+}
+
+// ringArea returns the signed area of the ring by the shoelace formula.
+func ringArea(ring []Point) float64 {
+	sum := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		sum += ring[i].X*ring[i+1].Y - ring[i+1].X*ring[i].Y
+	}
+	return sum / 2
+}
+
+// EarthRadius is the default radius in meters of the sphere used by DistanceSphere.
+const EarthRadius = 6370986
+
+// DistanceSphere returns the minimum spherical distance between the points of the geometries,
+// which must be Points or MultiPoints, the X and Y of the points are the longitude and the
+// latitude in degrees.
+func DistanceSphere(a, b *Geometry, radius float64) float64 {
+	dist := math.Inf(1)
+	a.forEachPoint(func(p Point) {
+		b.forEachPoint(func(q Point) {
+			dist = math.Min(dist, haversine(p, q, radius))
+		})
+	})
+	return dist
+}
+
+func haversine(p, q Point, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (q.X - p.X) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
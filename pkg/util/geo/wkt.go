@@ -0,0 +1,295 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WKT returns the well-known text of the geometry in the format of MySQL, e.g.
+// `POLYGON((0 0,1 0,1 1,0 0))` and `MULTIPOINT((1 1),(2 2))`.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder) {
+	sb.WriteString(g.Kind.String())
+	if g.Kind == KindGeometryCollection && len(g.Geoms) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	g.writeWKTBody(sb)
+}
+
+// writeWKTBody writes the parenthesized part of the WKT.
+func (g *Geometry) writeWKTBody(sb *strings.Builder) {
+	sb.WriteByte('(')
+	switch g.Kind {
+	case KindPoint, KindLineString:
+		writeWKTPoints(sb, g.Points)
+	case KindPolygon:
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteByte('(')
+			writeWKTPoints(sb, ring)
+			sb.WriteByte(')')
+		}
+	case KindGeometryCollection:
+		for i, m := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			m.writeWKT(sb)
+		}
+	default:
+		for i, m := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			m.writeWKTBody(sb)
+		}
+	}
+	sb.WriteByte(')')
+}
+
+func writeWKTPoints(sb *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(formatCoord(p.X))
+		sb.WriteByte(' ')
+		sb.WriteString(formatCoord(p.Y))
+	}
+}
+
+func formatCoord(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// ParseWKT parses the well-known text of a geometry, the SRID of the result is 0. The keywords
+// are case-insensitive, and the points of a MultiPoint can be written with or without the
+// parentheses.
+func ParseWKT(text string) (*Geometry, error) {
+	p := &wktParser{text: text}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.text) {
+		return nil, ErrInvalidData
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips the spaces and consumes c if it's the next character.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	if !p.consume(c) {
+		return ErrInvalidData
+	}
+	return nil
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.text[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) && strings.IndexByte("+-.0123456789eE", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+	v, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+	if err != nil {
+		return 0, ErrInvalidData
+	}
+	return v, nil
+}
+
+func (p *wktParser) point() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{x, y}, nil
+}
+
+// points parses `(x y, x y, ...)`.
+func (p *wktParser) points() ([]Point, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var points []Point
+	for {
+		pt, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return points, p.expect(')')
+}
+
+// list parses `(elem, elem, ...)`.
+func (p *wktParser) list(elem func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := elem(); err != nil {
+			return err
+		}
+		if !p.consume(',') {
+			break
+		}
+	}
+	return p.expect(')')
+}
+
+var wktKinds = map[string]Kind{
+	"POINT":              KindPoint,
+	"LINESTRING":         KindLineString,
+	"POLYGON":            KindPolygon,
+	"MULTIPOINT":         KindMultiPoint,
+	"MULTILINESTRING":    KindMultiLineString,
+	"MULTIPOLYGON":       KindMultiPolygon,
+	"GEOMETRYCOLLECTION": KindGeometryCollection,
+	"GEOMCOLLECTION":     KindGeometryCollection,
+}
+
+func (p *wktParser) parseGeometry(depth int) (*Geometry, error) {
+	kind, ok := wktKinds[p.word()]
+	if !ok || depth > maxNestingDepth {
+		return nil, ErrInvalidData
+	}
+	return p.parseBody(kind, depth)
+}
+
+func (p *wktParser) parseBody(kind Kind, depth int) (*Geometry, error) {
+	g := &Geometry{Kind: kind}
+	var err error
+	switch kind {
+	case KindPoint:
+		if err = p.expect('('); err != nil {
+			return nil, err
+		}
+		var pt Point
+		if pt, err = p.point(); err != nil {
+			return nil, err
+		}
+		g.Points = []Point{pt}
+		err = p.expect(')')
+	case KindLineString:
+		g.Points, err = p.points()
+	case KindPolygon:
+		err = p.list(func() error {
+			ring, err := p.points()
+			g.Rings = append(g.Rings, ring)
+			return err
+		})
+	case KindMultiPoint:
+		err = p.list(func() error {
+			// The point may be parenthesized or not.
+			parenthesized := p.consume('(')
+			pt, err := p.point()
+			if err != nil {
+				return err
+			}
+			g.Geoms = append(g.Geoms, &Geometry{Kind: KindPoint, Points: []Point{pt}})
+			if parenthesized {
+				return p.expect(')')
+			}
+			return nil
+		})
+	case KindMultiLineString, KindMultiPolygon:
+		err = p.list(func() error {
+			m, err := p.parseBody(kind.memberKind(), depth+1)
+			g.Geoms = append(g.Geoms, m)
+			return err
+		})
+	case KindGeometryCollection:
+		if p.word() == "EMPTY" {
+			return g, nil
+		}
+		// `GEOMETRYCOLLECTION()` is also an empty collection.
+		if err = p.expect('('); err != nil {
+			return nil, err
+		}
+		if p.consume(')') {
+			return g, nil
+		}
+		for {
+			m, err := p.parseGeometry(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, m)
+			if !p.consume(',') {
+				break
+			}
+		}
+		err = p.expect(')')
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -843,6 +843,8 @@ const (
 	LabelForChunkDataInDiskByChunks int = -30
 	// LabelForSortPartition represents the label of the sort partition
 	LabelForSortPartition = -31
+	// LabelForStoredProcedure represents the label of the result sets and cursors of a stored procedure
+	LabelForStoredProcedure = -32
 )
 
 // MetricsTypes is used to get label for metrics
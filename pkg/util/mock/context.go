@@ -329,6 +329,9 @@ func (c *Context) GoCtx() context.Context {
 // UpdateColStatsUsage updates the column stats usage.
 func (*Context) UpdateColStatsUsage(_ []model.TableItemID) {}
 
+// UpdateIndexUsage updates the index usage.
+func (*Context) UpdateIndexUsage(_ []model.TableItemID) {}
+
 // StoreIndexUsage strores the index usage information.
 func (*Context) StoreIndexUsage(_ int64, _ int64, _ int64) {}
 
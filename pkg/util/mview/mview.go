@@ -0,0 +1,430 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+const (
+	// exprRestoreFlags is used to restore the expressions in a view, the column names are
+	// restored without qualifiers so that the expressions can be evaluated on the change log.
+	exprRestoreFlags = format.DefaultRestoreFlags | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+
+	viewAlias  = "_mv"
+	deltaAlias = "_d"
+)
+
+// FieldKind is the kind of a field of a materialized view.
+type FieldKind int
+
+// Kinds of the fields of a materialized view.
+const (
+	// FieldGroupBy is a column in the GROUP BY clause.
+	FieldGroupBy FieldKind = iota
+	// FieldCountAll is COUNT(*).
+	FieldCountAll
+	// FieldCount is COUNT(expr).
+	FieldCount
+	// FieldSum is SUM(expr).
+	FieldSum
+)
+
+// Field is a field of a materialized view which can be refreshed fast.
+type Field struct {
+	Kind FieldKind
+	// Column is the name of the column for FieldGroupBy.
+	Column model.CIStr
+	// Text is the normalized text of the argument for FieldCount and FieldSum.
+	Text string
+	// CountOffset is the offset of the COUNT field with the same argument for FieldSum.
+	CountOffset int
+}
+
+// Definition is the definition of a materialized view which aggregates a single table,
+// only such views can be refreshed fast or used to answer queries.
+type Definition struct {
+	Schema model.CIStr
+	Table  model.CIStr
+	// WhereText is the normalized text of the WHERE clause, it's empty if there is no WHERE clause.
+	WhereText string
+	Fields    []Field
+	// CountAllOffset is the offset of COUNT(*) in Fields.
+	CountAllOffset int
+}
+
+// Parse parses the SELECT statement of a materialized view, it returns
+// dbterror.ErrMaterializedViewNoFastRefresh if the statement is not a single SELECT.
+func Parse(sql string) (*ast.SelectStmt, error) {
+	cs, coll := charset.GetDefaultCharsetAndCollate()
+	stmt, err := parser.New().ParseOneStmt(sql, cs, coll)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return nil, noFastRefresh("only SELECT is supported")
+	}
+	return sel, nil
+}
+
+func restore(node ast.Node, flags format.RestoreFlags) (string, error) {
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(flags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+func noFastRefresh(format string, args ...any) error {
+	return dbterror.ErrMaterializedViewNoFastRefresh.GenWithStackByArgs(fmt.Sprintf(format, args...))
+}
+
+// singleTable returns the table selected by sel if it selects from a single table.
+func singleTable(sel *ast.SelectStmt) *ast.TableName {
+	if sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.AsOf != nil || tn.TableSample != nil || len(tn.PartitionNames) > 0 || len(tn.IndexHints) > 0 {
+		return nil
+	}
+	return tn
+}
+
+// unsupportedExprChecker checks whether an expression contains subqueries, parameters,
+// window functions or variable assignments, which are not supported in the views.
+type unsupportedExprChecker struct {
+	unsupported bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *unsupportedExprChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, ast.ParamMarkerExpr, *ast.WindowFuncExpr:
+		c.unsupported = true
+	case *ast.VariableExpr:
+		c.unsupported = x.Value != nil
+	}
+	return in, c.unsupported
+}
+
+// Leave implements ast.Visitor interface.
+func (c *unsupportedExprChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, !c.unsupported
+}
+
+func isSupportedExpr(expr ast.Node) bool {
+	if expr == nil {
+		return true
+	}
+	c := &unsupportedExprChecker{}
+	expr.Accept(c)
+	return !c.unsupported
+}
+
+// isCountAll returns whether the arguments of COUNT is a not null constant.
+func isCountAll(args []ast.ExprNode) bool {
+	if len(args) != 1 {
+		return false
+	}
+	v, ok := args[0].(ast.ValueExpr)
+	return ok && v.GetValue() != nil
+}
+
+// Analyze analyzes the SELECT statement of a materialized view, it returns
+// dbterror.ErrMaterializedViewNoFastRefresh if the view can't be refreshed fast.
+func Analyze(sel *ast.SelectStmt) (*Definition, error) {
+	if sel.Kind != ast.SelectStmtKindSelect || sel.With != nil || sel.Distinct || len(sel.WindowSpecs) > 0 ||
+		sel.Having != nil || sel.OrderBy != nil || sel.Limit != nil || sel.LockInfo != nil || sel.SelectIntoOpt != nil {
+		return nil, noFastRefresh("only SELECT with WHERE and GROUP BY is supported")
+	}
+	tn := singleTable(sel)
+	if tn == nil {
+		return nil, noFastRefresh("only a single table can be selected")
+	}
+	if !isSupportedExpr(sel.Where) {
+		return nil, noFastRefresh("subqueries and variable assignments are not supported")
+	}
+	def := &Definition{Schema: tn.Schema, Table: tn.Name, CountAllOffset: -1}
+	if sel.Where != nil {
+		text, err := restore(sel.Where, exprRestoreFlags)
+		if err != nil {
+			return nil, err
+		}
+		def.WhereText = text
+	}
+
+	groupBy := make(map[string]bool)
+	if sel.GroupBy != nil {
+		if sel.GroupBy.Rollup {
+			return nil, noFastRefresh("WITH ROLLUP is not supported")
+		}
+		for _, item := range sel.GroupBy.Items {
+			col, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok {
+				return nil, noFastRefresh("only columns are supported in the GROUP BY clause")
+			}
+			groupBy[col.Name.Name.L] = false
+		}
+	}
+	counts := make(map[string]int)
+	for i, f := range sel.Fields.Fields {
+		if f.WildCard != nil {
+			return nil, noFastRefresh("wildcard is not supported")
+		}
+		field := Field{}
+		switch x := f.Expr.(type) {
+		case *ast.ColumnNameExpr:
+			if _, ok := groupBy[x.Name.Name.L]; !ok {
+				return nil, noFastRefresh("column %s is not in the GROUP BY clause", x.Name.Name.O)
+			}
+			groupBy[x.Name.Name.L] = true
+			field.Kind = FieldGroupBy
+			field.Column = x.Name.Name
+		case *ast.AggregateFuncExpr:
+			if x.Distinct || len(x.Args) != 1 || !isSupportedExpr(x.Args[0]) {
+				return nil, noFastRefresh("only COUNT and SUM on a single expression without subqueries are supported")
+			}
+			switch strings.ToLower(x.F) {
+			case ast.AggFuncCount:
+				field.Kind = FieldCount
+				if isCountAll(x.Args) {
+					field.Kind = FieldCountAll
+					def.CountAllOffset = i
+				}
+			case ast.AggFuncSum:
+				field.Kind = FieldSum
+			default:
+				return nil, noFastRefresh("aggregate function %s is not supported", strings.ToUpper(x.F))
+			}
+			text, err := restore(x.Args[0], exprRestoreFlags)
+			if err != nil {
+				return nil, err
+			}
+			field.Text = text
+			if field.Kind == FieldCount {
+				counts[text] = i
+			}
+		default:
+			return nil, noFastRefresh("only columns in the GROUP BY clause, COUNT and SUM can be selected")
+		}
+		def.Fields = append(def.Fields, field)
+	}
+	if sel.GroupBy != nil {
+		for _, item := range sel.GroupBy.Items {
+			col := item.Expr.(*ast.ColumnNameExpr).Name.Name
+			if !groupBy[col.L] {
+				return nil, noFastRefresh("column %s in the GROUP BY clause is not selected", col.O)
+			}
+		}
+	}
+	if def.CountAllOffset < 0 {
+		return nil, noFastRefresh("COUNT(*) is not selected")
+	}
+	for i := range def.Fields {
+		field := &def.Fields[i]
+		if field.Kind != FieldSum {
+			continue
+		}
+		offset, ok := counts[field.Text]
+		if !ok {
+			return nil, noFastRefresh("COUNT(%s) is not selected along with SUM(%s)", field.Text, field.Text)
+		}
+		field.CountOffset = offset
+	}
+	return def, nil
+}
+
+// IsScalar returns whether the view has no GROUP BY clause, so it always has exactly one row.
+func (d *Definition) IsScalar() bool {
+	for _, f := range d.Fields {
+		if f.Kind == FieldGroupBy {
+			return false
+		}
+	}
+	return true
+}
+
+func deltaName(i int) string {
+	return fmt.Sprintf("_d%d", i)
+}
+
+// writeDeltaQuery writes the query to aggregate the change log by the groups of the view.
+func (d *Definition) writeDeltaQuery(sb *strings.Builder, logSchema, logName string) {
+	sb.WriteString("SELECT ")
+	for i, f := range d.Fields {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		switch f.Kind {
+		case FieldGroupBy:
+			sqlescape.MustFormatSQL(sb, "%n", f.Column.O)
+		case FieldCountAll:
+			sqlescape.MustFormatSQL(sb, "IFNULL(SUM(%n), 0)", model.MaterializedViewLogOpName.O)
+		case FieldCount:
+			fmt.Fprintf(sb, "IFNULL(SUM(IF((%s) IS NULL, 0, ", f.Text)
+			sqlescape.MustFormatSQL(sb, "%n)), 0)", model.MaterializedViewLogOpName.O)
+		case FieldSum:
+			fmt.Fprintf(sb, "SUM((%s) * ", f.Text)
+			sqlescape.MustFormatSQL(sb, "%n)", model.MaterializedViewLogOpName.O)
+		}
+		sqlescape.MustFormatSQL(sb, " AS %n", deltaName(i))
+	}
+	sqlescape.MustFormatSQL(sb, " FROM %n.%n", logSchema, logName)
+	if d.WhereText != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(d.WhereText)
+	}
+	first := true
+	for _, f := range d.Fields {
+		if f.Kind != FieldGroupBy {
+			continue
+		}
+		if first {
+			sb.WriteString(" GROUP BY ")
+			first = false
+		} else {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(sb, "%n", f.Column.O)
+	}
+}
+
+// writeGroupMatch writes the condition to match the rows of the view and the delta query.
+func (d *Definition) writeGroupMatch(sb *strings.Builder, cols []model.CIStr) {
+	first := true
+	for i, f := range d.Fields {
+		if f.Kind != FieldGroupBy {
+			continue
+		}
+		if !first {
+			sb.WriteString(" AND ")
+		}
+		first = false
+		sqlescape.MustFormatSQL(sb, "%n.%n <=> %n.%n", viewAlias, cols[i].O, deltaAlias, deltaName(i))
+	}
+}
+
+// FastRefreshSQLs returns the statements to apply the change log to the view, they should
+// be executed in a transaction in order. cols are the columns of the view.
+func (d *Definition) FastRefreshSQLs(viewSchema, viewName string, cols []model.CIStr, logSchema, logName string) []string {
+	var delta strings.Builder
+	d.writeDeltaQuery(&delta, logSchema, logName)
+	sqls := make([]string, 0, 4)
+
+	// Update the existing groups. The sums are assigned before the counts, so they are
+	// calculated by the counts before updating.
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "UPDATE %n.%n AS %n, (", viewSchema, viewName, viewAlias)
+	sb.WriteString(delta.String())
+	sqlescape.MustFormatSQL(&sb, ") AS %n SET ", deltaAlias)
+	first := true
+	for _, kind := range []FieldKind{FieldSum, FieldCount, FieldCountAll} {
+		for i, f := range d.Fields {
+			if f.Kind != kind {
+				continue
+			}
+			if !first {
+				sb.WriteString(", ")
+			}
+			first = false
+			if kind == FieldSum {
+				sqlescape.MustFormatSQL(&sb, "%n.%n = IF(%n.%n + %n.%n = 0, NULL, IFNULL(%n.%n, 0) + IFNULL(%n.%n, 0))",
+					viewAlias, cols[i].O,
+					viewAlias, cols[f.CountOffset].O, deltaAlias, deltaName(f.CountOffset),
+					viewAlias, cols[i].O, deltaAlias, deltaName(i))
+			} else {
+				sqlescape.MustFormatSQL(&sb, "%n.%n = %n.%n + %n.%n",
+					viewAlias, cols[i].O, viewAlias, cols[i].O, deltaAlias, deltaName(i))
+			}
+		}
+	}
+	if !d.IsScalar() {
+		sb.WriteString(" WHERE ")
+		d.writeGroupMatch(&sb, cols)
+	}
+	sqls = append(sqls, sb.String())
+
+	if !d.IsScalar() {
+		// Insert the new groups.
+		sb.Reset()
+		sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", viewSchema, viewName)
+		for i, col := range cols {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sqlescape.MustFormatSQL(&sb, "%n", col.O)
+		}
+		sb.WriteString(") SELECT ")
+		for i, f := range d.Fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if f.Kind == FieldSum {
+				sqlescape.MustFormatSQL(&sb, "IF(%n.%n = 0, NULL, %n.%n)",
+					deltaAlias, deltaName(f.CountOffset), deltaAlias, deltaName(i))
+			} else {
+				sqlescape.MustFormatSQL(&sb, "%n.%n", deltaAlias, deltaName(i))
+			}
+		}
+		sb.WriteString(" FROM (")
+		sb.WriteString(delta.String())
+		sqlescape.MustFormatSQL(&sb, ") AS %n WHERE %n.%n > 0 AND NOT EXISTS (SELECT 1 FROM %n.%n AS %n WHERE ",
+			deltaAlias, deltaAlias, deltaName(d.CountAllOffset), viewSchema, viewName, viewAlias)
+		d.writeGroupMatch(&sb, cols)
+		sb.WriteString(")")
+		sqls = append(sqls, sb.String())
+
+		// Delete the groups without any rows.
+		sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n WHERE %n <= 0",
+			viewSchema, viewName, cols[d.CountAllOffset].O))
+	}
+	return append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n", logSchema, logName))
+}
+
+// CompleteRefreshSQLs returns the statements to refresh the view by its SELECT statement, they
+// should be executed in a transaction in order. logName is empty if the view has no change log.
+func CompleteRefreshSQLs(viewSchema, viewName string, cols []model.CIStr, selectSQL string, logSchema, logName string) []string {
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", viewSchema, viewName)
+	for i, col := range cols {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.O)
+	}
+	sb.WriteString(") ")
+	sb.WriteString(selectSQL)
+	sqls := []string{sqlescape.MustEscapeSQL("DELETE FROM %n.%n", viewSchema, viewName), sb.String()}
+	if logName != "" {
+		sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n", logSchema, logName))
+	}
+	return sqls
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	_ "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	sel, err := Parse("select t.a, count(*), count(b+1), sum(b+1) from test.t where c > 1 group by a")
+	require.NoError(t, err)
+	def, err := Analyze(sel)
+	require.NoError(t, err)
+	require.Equal(t, "test", def.Schema.L)
+	require.Equal(t, "t", def.Table.L)
+	require.Equal(t, "`c`>1", def.WhereText)
+	require.Equal(t, 1, def.CountAllOffset)
+	require.Equal(t, []Field{
+		{Kind: FieldGroupBy, Column: model.NewCIStr("a")},
+		{Kind: FieldCountAll, Text: "1"},
+		{Kind: FieldCount, Text: "`b`+1"},
+		{Kind: FieldSum, Text: "`b`+1", CountOffset: 2},
+	}, def.Fields)
+	require.False(t, def.IsScalar())
+
+	for _, sql := range []string{
+		"select a, count(*) from t1 join t2 group by a",
+		"select a, count(*) from (select * from t) t group by a",
+		"select a, count(*) from t group by a having count(*) > 1",
+		"select a, count(*) from t group by a order by a",
+		"select a, count(*) from t group by a with rollup",
+		"select a, count(*) from t where a in (select a from t2) group by a",
+		"select a+1, count(*) from t group by a",
+		"select a, count(*) from t group by a, b",
+		"select a, count(*) from t group by a+1",
+		"select count(*), max(b) from t",
+		"select count(*), count(distinct b) from t",
+		"select count(*), sum(b) from t",
+		"select sum(b), count(b) from t",
+		"select distinct count(*) from t",
+		"select * from t",
+	} {
+		sel, err := Parse(sql)
+		require.NoError(t, err)
+		_, err = Analyze(sel)
+		require.True(t, dbterror.ErrMaterializedViewNoFastRefresh.Equal(err), sql)
+	}
+}
+
+func TestRefreshSQLs(t *testing.T) {
+	sel, err := Parse("SELECT a, COUNT(*), COUNT(b), SUM(b) FROM test.t WHERE c > 1 GROUP BY a")
+	require.NoError(t, err)
+	def, err := Analyze(sel)
+	require.NoError(t, err)
+	cols := []model.CIStr{model.NewCIStr("a"), model.NewCIStr("cnt"), model.NewCIStr("cnt_b"), model.NewCIStr("sum_b")}
+	delta := "SELECT `a` AS `_d0`, IFNULL(SUM(`_tidb_mlog_op`), 0) AS `_d1`, IFNULL(SUM(IF((`b`) IS NULL, 0, `_tidb_mlog_op`)), 0) AS `_d2`, " +
+		"SUM((`b`) * `_tidb_mlog_op`) AS `_d3` FROM `test`.`_tidb_mlog$1` WHERE `c`>1 GROUP BY `a`"
+	require.Equal(t, []string{
+		"UPDATE `test`.`v` AS `_mv`, (" + delta + ") AS `_d` SET " +
+			"`_mv`.`sum_b` = IF(`_mv`.`cnt_b` + `_d`.`_d2` = 0, NULL, IFNULL(`_mv`.`sum_b`, 0) + IFNULL(`_d`.`_d3`, 0)), " +
+			"`_mv`.`cnt_b` = `_mv`.`cnt_b` + `_d`.`_d2`, `_mv`.`cnt` = `_mv`.`cnt` + `_d`.`_d1` WHERE `_mv`.`a` <=> `_d`.`_d0`",
+		"INSERT INTO `test`.`v` (`a`, `cnt`, `cnt_b`, `sum_b`) SELECT `_d`.`_d0`, `_d`.`_d1`, `_d`.`_d2`, IF(`_d`.`_d2` = 0, NULL, `_d`.`_d3`) FROM (" + delta +
+			") AS `_d` WHERE `_d`.`_d1` > 0 AND NOT EXISTS (SELECT 1 FROM `test`.`v` AS `_mv` WHERE `_mv`.`a` <=> `_d`.`_d0`)",
+		"DELETE FROM `test`.`v` WHERE `cnt` <= 0",
+		"DELETE FROM `test`.`_tidb_mlog$1`",
+	}, def.FastRefreshSQLs("test", "v", cols, "test", "_tidb_mlog$1"))
+
+	require.Equal(t, []string{
+		"DELETE FROM `test`.`v`",
+		"INSERT INTO `test`.`v` (`a`, `cnt`) SELECT a, count(*) FROM t GROUP BY a",
+	}, CompleteRefreshSQLs("test", "v", cols[:2], "SELECT a, count(*) FROM t GROUP BY a", "", ""))
+}
+
+func TestRewriteQuery(t *testing.T) {
+	sel, err := Parse("select a, count(*), count(b), sum(b) from test.t where c > 1 group by a")
+	require.NoError(t, err)
+	def, err := Analyze(sel)
+	require.NoError(t, err)
+	view := &model.TableInfo{Name: model.NewCIStr("v")}
+	for _, name := range []string{"a", "cnt", "cnt_b", "sum_b"} {
+		view.Columns = append(view.Columns, &model.ColumnInfo{Name: model.NewCIStr(name)})
+	}
+
+	cases := []struct {
+		sql      string
+		rewitten string
+	}{
+		{"select sum(b), a from t where t.c > 1 group by t.a", "SELECT `_mv`.`sum_b`,`_mv`.`a` FROM `test`.`v` AS `_mv`"},
+		{"select a, sum(b) / count(*) as x from test.t where c > 1 group by a having x > 1 order by x desc limit 2",
+			"SELECT `_mv`.`a`,`_mv`.`sum_b`/`_mv`.`cnt` AS `x` FROM `test`.`v` AS `_mv` WHERE `_mv`.`sum_b`/`_mv`.`cnt`>1 ORDER BY `x` DESC LIMIT 2"},
+		{"select distinct count(b) from t where c > 1 group by a order by sum(b)",
+			"SELECT DISTINCT `_mv`.`cnt_b` FROM `test`.`v` AS `_mv` ORDER BY `_mv`.`sum_b`"},
+		{"select a, max(b) from t where c > 1 group by a", ""},
+		{"select a, count(*) from t where c > 2 group by a", ""},
+		{"select count(*) from t where c > 1", ""},
+		{"select a, count(*) from t where c > 1 group by a, b", ""},
+		{"select a, count(*) from test2.t where c > 1 group by a", ""},
+		{"select a, count(*) from t where c > 1 group by a for update", ""},
+		{"select a, b from t where c > 1 group by a", ""},
+		{"select a, count(*) as a from t where c > 1 group by a order by a", ""},
+	}
+	for _, c := range cases {
+		sel, err := Parse(c.sql)
+		require.NoError(t, err)
+		ok := RewriteQuery(sel, "test", def, model.NewCIStr("test"), view)
+		require.Equal(t, c.rewitten != "", ok, c.sql)
+		if ok {
+			var sb strings.Builder
+			require.NoError(t, sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)))
+			require.Equal(t, c.rewitten, sb.String(), c.sql)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/event"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// refreshTimerKeyPrefix is the key prefix of the timers refreshing the materialized
+	// views, the key of a timer is `refreshTimerKeyPrefix + viewID`.
+	refreshTimerKeyPrefix = "/tidb/mview/"
+	// refreshTimerHookClass is the hook class of the materialized view timers.
+	refreshTimerHookClass = "tidb.mview"
+
+	closeEventRetryInterval = 10 * time.Second
+)
+
+func refreshTimerKey(viewID int64) string {
+	return refreshTimerKeyPrefix + strconv.FormatInt(viewID, 10)
+}
+
+// RegisterRefreshHook registers the hook refreshing the materialized views to the scheduler,
+// so the views are refreshed by the owner of the events.
+func RegisterRefreshHook(s *event.Scheduler) {
+	s.RegisterHook(refreshTimerKeyPrefix, refreshTimerHookClass, func(cli timerapi.TimerClient, sessFactory event.SessionFactory) timerapi.Hook {
+		return newRefreshHook(cli, sessFactory)
+	})
+}
+
+// CreateRefreshTimer creates the timer to refresh the materialized view
+// every interval seconds, the first refresh runs an interval after now.
+func CreateRefreshTimer(ctx context.Context, cli timerapi.TimerClient, viewID, interval int64, now time.Time) error {
+	_, err := cli.CreateTimer(ctx, timerapi.TimerSpec{
+		Key:             refreshTimerKey(viewID),
+		SchedPolicyType: timerapi.SchedEventInterval,
+		SchedPolicyExpr: fmt.Sprintf("%ds", interval),
+		HookClass:       refreshTimerHookClass,
+		Watermark:       now,
+		Enable:          true,
+	})
+	return err
+}
+
+// DropRefreshTimer drops the timer of the materialized view if it exists.
+func DropRefreshTimer(ctx context.Context, cli timerapi.TimerClient, viewID int64) error {
+	timer, err := cli.GetTimerByKey(ctx, refreshTimerKey(viewID))
+	if err != nil {
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+			return nil
+		}
+		return err
+	}
+	_, err = cli.DeleteTimer(ctx, timer.ID)
+	return err
+}
+
+type refreshHook struct {
+	cli         timerapi.TimerClient
+	sessFactory event.SessionFactory
+	ctx         context.Context
+	cancel      func()
+	wg          sync.WaitGroup
+	nowFunc     func() time.Time
+}
+
+func newRefreshHook(cli timerapi.TimerClient, sessFactory event.SessionFactory) *refreshHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &refreshHook{
+		cli:         cli,
+		sessFactory: sessFactory,
+		ctx:         ctx,
+		cancel:      cancel,
+		nowFunc:     time.Now,
+	}
+}
+
+func (*refreshHook) Start() {}
+
+func (h *refreshHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*refreshHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	return
+}
+
+func (h *refreshHook) OnSchedEvent(_ context.Context, schedEvent timerapi.TimerShedEvent) error {
+	timer := schedEvent.Timer()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", schedEvent.EventID()),
+		zap.Time("eventStart", timer.EventStart),
+	)
+
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	viewID, err := strconv.ParseInt(strings.TrimPrefix(timer.Key, refreshTimerKeyPrefix), 10, 64)
+	if err != nil {
+		logger.Error("invalid materialized view timer key")
+		return err
+	}
+
+	h.wg.Add(1)
+	go h.refresh(logger, viewID, timer, schedEvent.EventID())
+	return nil
+}
+
+func (h *refreshHook) refresh(logger *zap.Logger, viewID int64, timer *timerapi.TimerRecord, eventID string) {
+	defer h.wg.Done()
+
+	exists, err := h.execute(viewID)
+	if err != nil {
+		logger.Warn("failed to refresh materialized view", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(closeEventRetryInterval)
+	defer ticker.Stop()
+	watermark := h.nowFunc()
+	for {
+		if exists {
+			err = h.cli.CloseTimerEvent(h.ctx, timer.ID, eventID, timerapi.WithSetWatermark(watermark))
+		} else {
+			// The materialized view is dropped without dropping its timer, e.g. its schema is dropped.
+			_, err = h.cli.DeleteTimer(h.ctx, timer.ID)
+		}
+		if err == nil {
+			return
+		}
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) || errors.ErrorEqual(err, timerapi.ErrEventIDNotMatch) {
+			logger.Warn("stop closing event because the timer is changed", zap.Error(err))
+			return
+		}
+		logger.Error("failed to close event", zap.Error(err))
+
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// execute refreshes the materialized view, it returns false if the view doesn't exist.
+func (h *refreshHook) execute(viewID int64) (bool, error) {
+	se, err := h.sessFactory()
+	if err != nil {
+		return true, err
+	}
+	defer se.Close()
+
+	maxChunkSize := se.GetSessionVars().MaxChunkSize
+	rows, err := h.query(se, sqlescape.MustEscapeSQL(
+		"SELECT TABLE_SCHEMA, TABLE_NAME FROM information_schema.tables WHERE TIDB_TABLE_ID = %?", viewID), maxChunkSize)
+	if err != nil {
+		return true, err
+	}
+	if len(rows) == 0 {
+		return false, nil
+	}
+	_, err = h.query(se, sqlescape.MustEscapeSQL("REFRESH MATERIALIZED VIEW %n.%n", rows[0].GetString(0), rows[0].GetString(1)), maxChunkSize)
+	return true, err
+}
+
+func (h *refreshHook) query(se event.Session, sql string, maxChunkSize int) ([]chunk.Row, error) {
+	rss, err := se.Execute(h.ctx, sql)
+	var rows []chunk.Row
+	for _, rs := range rss {
+		chunkRows, drainErr := sqlexec.DrainRecordSet(h.ctx, rs, maxChunkSize)
+		if drainErr != nil && err == nil {
+			err = drainErr
+		}
+		rows = append(rows, chunkRows...)
+		if closeErr := rs.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return rows, err
+}
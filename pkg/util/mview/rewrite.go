@@ -0,0 +1,279 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// queryRewriter replaces the aggregate functions and the GROUP BY columns of a query
+// with the columns of a materialized view.
+type queryRewriter struct {
+	def  *Definition
+	cols []model.CIStr
+	// aliases are the aliases of the fields which can be referenced by HAVING and ORDER BY,
+	// an alias is mapped to the rewritten field.
+	aliases map[string]ast.ExprNode
+	// inHaving indicates an alias is replaced by the rewritten field, since HAVING is
+	// rewritten as WHERE, which can't reference aliases.
+	inHaving bool
+	ok       bool
+}
+
+func (r *queryRewriter) viewColumn(offset int) *ast.ColumnNameExpr {
+	return &ast.ColumnNameExpr{Name: &ast.ColumnName{Table: model.NewCIStr(viewAlias), Name: r.cols[offset]}}
+}
+
+func (r *queryRewriter) groupByOffset(col model.CIStr) int {
+	for i, f := range r.def.Fields {
+		if f.Kind == FieldGroupBy && f.Column.L == col.L {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *queryRewriter) aggregateOffset(agg *ast.AggregateFuncExpr) int {
+	if agg.Distinct || len(agg.Args) != 1 {
+		return -1
+	}
+	var kind FieldKind
+	switch strings.ToLower(agg.F) {
+	case ast.AggFuncCount:
+		kind = FieldCount
+		if isCountAll(agg.Args) {
+			return r.def.CountAllOffset
+		}
+	case ast.AggFuncSum:
+		kind = FieldSum
+	default:
+		return -1
+	}
+	text, err := restore(agg.Args[0], exprRestoreFlags)
+	if err != nil {
+		return -1
+	}
+	for i, f := range r.def.Fields {
+		if f.Kind == kind && f.Text == text {
+			return i
+		}
+	}
+	return -1
+}
+
+// Enter implements ast.Visitor interface.
+func (r *queryRewriter) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.AggregateFuncExpr:
+		offset := r.aggregateOffset(x)
+		if offset < 0 {
+			r.ok = false
+			return in, true
+		}
+		return r.viewColumn(offset), true
+	case *ast.ColumnNameExpr:
+		if r.aliases != nil && x.Name.Table.L == "" {
+			if field, ok := r.aliases[x.Name.Name.L]; ok {
+				if r.groupByOffset(x.Name.Name) >= 0 {
+					// It's ambiguous whether the alias or the column is referenced.
+					r.ok = false
+				} else if r.inHaving {
+					expr, err := copyExpr(field)
+					if err != nil {
+						r.ok = false
+						return in, true
+					}
+					return expr, true
+				}
+				return in, true
+			}
+		}
+		offset := r.groupByOffset(x.Name.Name)
+		if offset < 0 {
+			r.ok = false
+			return in, true
+		}
+		return r.viewColumn(offset), true
+	case *ast.WindowFuncExpr, *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
+		r.ok = false
+		return in, true
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (r *queryRewriter) Leave(in ast.Node) (ast.Node, bool) {
+	return in, r.ok
+}
+
+func (r *queryRewriter) rewrite(expr ast.ExprNode) ast.ExprNode {
+	node, ok := expr.Accept(r)
+	if !ok || !r.ok {
+		r.ok = false
+		return expr
+	}
+	return node.(ast.ExprNode)
+}
+
+// copyExpr copies the expression by restoring and parsing it.
+func copyExpr(expr ast.ExprNode) (ast.ExprNode, error) {
+	text, err := restore(expr, format.DefaultRestoreFlags)
+	if err != nil {
+		return nil, err
+	}
+	sel, err := Parse("SELECT " + text)
+	if err != nil {
+		return nil, err
+	}
+	return sel.Fields.Fields[0].Expr, nil
+}
+
+// RewriteQuery rewrites the query to select from the materialized view instead of its base table,
+// sel is modified in place. defaultDB is the schema of the tables without schema names, view is the
+// materialized view defined by def. It returns false if the query can't be answered by the view.
+func RewriteQuery(sel *ast.SelectStmt, defaultDB string, def *Definition, viewSchema model.CIStr, view *model.TableInfo) bool {
+	if sel.Kind != ast.SelectStmtKindSelect || sel.With != nil || len(sel.WindowSpecs) > 0 || sel.LockInfo != nil ||
+		sel.SelectIntoOpt != nil || len(sel.TableHints) > 0 || sel.Fields == nil {
+		return false
+	}
+	tn := singleTable(sel)
+	if tn == nil {
+		return false
+	}
+	schema := tn.Schema.L
+	if schema == "" {
+		schema = model.NewCIStr(defaultDB).L
+	}
+	if schema != def.Schema.L || tn.Name.L != def.Table.L {
+		return false
+	}
+	if !isSupportedExpr(sel.Where) || !isSupportedExpr(sel.Fields) ||
+		(sel.Having != nil && !isSupportedExpr(sel.Having)) || (sel.OrderBy != nil && !isSupportedExpr(sel.OrderBy)) {
+		return false
+	}
+	whereText := ""
+	if sel.Where != nil {
+		text, err := restore(sel.Where, exprRestoreFlags)
+		if err != nil {
+			return false
+		}
+		whereText = text
+	}
+	if whereText != def.WhereText {
+		return false
+	}
+
+	// The query must be grouped by the same columns as the view.
+	groupBy := make(map[string]struct{})
+	if sel.GroupBy != nil {
+		if sel.GroupBy.Rollup {
+			return false
+		}
+		for _, item := range sel.GroupBy.Items {
+			col, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok {
+				return false
+			}
+			groupBy[col.Name.Name.L] = struct{}{}
+		}
+	}
+	groupByCnt := 0
+	for _, f := range def.Fields {
+		if f.Kind != FieldGroupBy {
+			continue
+		}
+		groupByCnt++
+		if _, ok := groupBy[f.Column.L]; !ok {
+			return false
+		}
+	}
+	if groupByCnt != len(groupBy) {
+		return false
+	}
+	if groupByCnt == 0 && !hasAggregate(sel.Fields) {
+		return false
+	}
+
+	cols := make([]model.CIStr, 0, len(view.Columns))
+	for _, col := range view.Columns {
+		cols = append(cols, col.Name)
+	}
+	r := &queryRewriter{def: def, cols: cols, ok: true}
+	for _, f := range sel.Fields.Fields {
+		if f.WildCard != nil {
+			return false
+		}
+		f.Expr = r.rewrite(f.Expr)
+	}
+	r.aliases = make(map[string]ast.ExprNode)
+	for _, f := range sel.Fields.Fields {
+		if f.AsName.L != "" {
+			r.aliases[f.AsName.L] = f.Expr
+		}
+	}
+	sel.Where = nil
+	if sel.Having != nil {
+		r.inHaving = true
+		sel.Where = r.rewrite(sel.Having.Expr)
+		r.inHaving = false
+	}
+	if sel.OrderBy != nil {
+		for _, item := range sel.OrderBy.Items {
+			item.Expr = r.rewrite(item.Expr)
+		}
+	}
+	if !r.ok {
+		return false
+	}
+	sel.GroupBy = nil
+	sel.Having = nil
+	sel.From.TableRefs.Left = &ast.TableSource{
+		Source: &ast.TableName{Schema: viewSchema, Name: view.Name},
+		AsName: model.NewCIStr(viewAlias),
+	}
+	return true
+}
+
+// aggregateChecker checks whether there are aggregate functions in the fields.
+type aggregateChecker struct {
+	found bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *aggregateChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.AggregateFuncExpr:
+		c.found = true
+		return in, true
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
+		return in, true
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *aggregateChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, !c.found
+}
+
+func hasAggregate(fields *ast.FieldList) bool {
+	c := &aggregateChecker{}
+	fields.Accept(c)
+	return c.found
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
@@ -155,7 +156,8 @@ func (rd *RowDecoder) DecodeTheExistedColumnMap(ctx sessionctx.Context, handle k
 	for _, dCol := range rd.colMap {
 		colInfo := dCol.Col.ColumnInfo
 		val, ok := row[colInfo.ID]
-		if ok || dCol.GenExpr != nil || dCol.Col.ChangeStateInfo != nil {
+		// The auto_increment column being added has no default value, its value is allocated by the reorganization.
+		if ok || dCol.GenExpr != nil || dCol.Col.ChangeStateInfo != nil || isAddingAutoIncrementCol(dCol.Col) {
 			rd.mutRow.SetValue(colInfo.Offset, val.GetValue())
 			continue
 		}
@@ -203,3 +205,7 @@ func (rd *RowDecoder) EvalRemainedExprColumnMap(ctx sessionctx.Context, row map[
 	// return the existed and evaluated column map here.
 	return row, nil
 }
+
+func isAddingAutoIncrementCol(col *table.Column) bool {
+	return col.State != model.StatePublic && mysql.HasAutoIncrementFlag(col.GetFlag())
+}
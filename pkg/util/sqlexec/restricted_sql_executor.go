@@ -206,6 +206,14 @@ type RecordSet interface {
 	Close() error
 }
 
+// MultiRecordSet is a RecordSet which contains several result sets, e.g. the results of a stored procedure.
+type MultiRecordSet interface {
+	RecordSet
+
+	// NextRecordSet switches to the next result set, it returns false if there is no more result set.
+	NextRecordSet() bool
+}
+
 // MultiQueryNoDelayResult is an interface for one no-delay result for one statement in multi-queries.
 type MultiQueryNoDelayResult interface {
 	// AffectedRows return affected row for one statement in multi-queries.
@@ -434,6 +434,65 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 	return stmts
 }
 
+// WorkloadStmt is a wrapper struct for a user query that is extracted from statements_summary and
+// used as the workload of the index advisor.
+type WorkloadStmt struct {
+	Schema     string
+	Query      string
+	ExecCount  int64
+	SumLatency time.Duration
+}
+
+// IsWorkloadStmtType returns whether the statement type can be used as the workload of the index advisor.
+func IsWorkloadStmtType(stmtType string) bool {
+	return stmtType == "Select" || stmtType == "Delete" || stmtType == "Update"
+}
+
+// TopWorkloadStmts sorts the statements by the total latency in descending order and returns at most n of them.
+func TopWorkloadStmts(stmts []*WorkloadStmt, n int) []*WorkloadStmt {
+	slices.SortStableFunc(stmts, func(a, b *WorkloadStmt) int {
+		return cmp.Compare(b.SumLatency, a.SumLatency)
+	})
+	if len(stmts) > n {
+		stmts = stmts[:n]
+	}
+	return stmts
+}
+
+// GetTopWorkloadStmts gets at most n users' select/update/delete SQLs which take the most total latency in the current window.
+func (ssMap *stmtSummaryByDigestMap) GetTopWorkloadStmts(n int) []*WorkloadStmt {
+	ssMap.Lock()
+	values := ssMap.summaryMap.Values()
+	ssMap.Unlock()
+
+	stmts := make([]*WorkloadStmt, 0, len(values))
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		func() {
+			ssbd.Lock()
+			defer ssbd.Unlock()
+			if !ssbd.initialized || !IsWorkloadStmtType(ssbd.stmtType) || ssbd.history.Len() == 0 {
+				return
+			}
+			ssElement := ssbd.history.Back().Value.(*stmtSummaryByDigestElement)
+			ssElement.Lock()
+			defer ssElement.Unlock()
+			// Empty auth users means that it is an internal query, and the sample SQL of
+			// a prepared statement may be `execute ...`, which can not be explained.
+			if len(ssElement.authUsers) == 0 || ssElement.prepared {
+				return
+			}
+			stmts = append(stmts, &WorkloadStmt{
+				Schema:     ssbd.schemaName,
+				Query:      ssElement.sampleSQL,
+				ExecCount:  ssElement.execCount,
+				SumLatency: ssElement.sumLatency,
+			})
+		}()
+	}
+	return TopWorkloadStmts(stmts, n)
+}
+
 // SetEnabled enables or disables statement summary
 func (ssMap *stmtSummaryByDigestMap) SetEnabled(value bool) error {
 	// `optEnabled` and `ssMap` don't need to be strictly atomically updated.
@@ -1216,6 +1216,40 @@ func TestGetMoreThanCntBindableStmt(t *testing.T) {
 	require.Equal(t, 1, len(stmts))
 }
 
+// Test GetTopWorkloadStmts.
+func TestGetTopWorkloadStmts(t *testing.T) {
+	ssMap := newStmtSummaryByDigestMap()
+
+	stmtExecInfo1 := generateAnyExecInfo()
+	stmtExecInfo1.OriginalSQL = "insert 1"
+	stmtExecInfo1.NormalizedSQL = "insert ?"
+	stmtExecInfo1.StmtCtx.StmtType = "Insert"
+	ssMap.AddStatement(stmtExecInfo1)
+	require.Len(t, ssMap.GetTopWorkloadStmts(10), 0)
+
+	stmtExecInfo2 := generateAnyExecInfo()
+	stmtExecInfo2.OriginalSQL = "select 1"
+	stmtExecInfo2.Digest = "digest2"
+	stmtExecInfo2.TotalLatency = 100
+	ssMap.AddStatement(stmtExecInfo2)
+	stmtExecInfo3 := generateAnyExecInfo()
+	stmtExecInfo3.OriginalSQL = "delete from t"
+	stmtExecInfo3.Digest = "digest3"
+	stmtExecInfo3.StmtCtx.StmtType = "Delete"
+	stmtExecInfo3.TotalLatency = 1000
+	ssMap.AddStatement(stmtExecInfo3)
+	stmts := ssMap.GetTopWorkloadStmts(10)
+	require.Len(t, stmts, 2)
+	require.Equal(t, "delete from t", stmts[0].Query)
+	require.Equal(t, "select 1", stmts[1].Query)
+	require.Equal(t, "schema_name", stmts[1].Schema)
+	require.Equal(t, int64(1), stmts[1].ExecCount)
+
+	stmts = ssMap.GetTopWorkloadStmts(1)
+	require.Len(t, stmts, 1)
+	require.Equal(t, "delete from t", stmts[0].Query)
+}
+
 // Test `formatBackoffTypes`.
 func TestFormatBackoffTypes(t *testing.T) {
 	backoffMap := make(map[string]int)
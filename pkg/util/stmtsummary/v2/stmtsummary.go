@@ -378,6 +378,35 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 	return stmts
 }
 
+// GetTopWorkloadStmts is used to get the statements which take the most
+// total latency as the workload of the index advisor. Like
+// GetMoreThanCntBindableStmt, only the current window in memory is used.
+func (s *StmtSummary) GetTopWorkloadStmts(n int) []*stmtsummary.WorkloadStmt {
+	s.windowLock.Lock()
+	values := s.window.lru.Values()
+	s.windowLock.Unlock()
+	stmts := make([]*stmtsummary.WorkloadStmt, 0, len(values))
+	for _, value := range values {
+		record := value.(*lockedStmtRecord)
+		func() {
+			record.Lock()
+			defer record.Unlock()
+			// Skip the internal queries and the prepared statements whose
+			// sample SQL may be `execute ...`.
+			if !stmtsummary.IsWorkloadStmtType(record.StmtType) || len(record.AuthUsers) == 0 || record.Prepared {
+				return
+			}
+			stmts = append(stmts, &stmtsummary.WorkloadStmt{
+				Schema:     record.SchemaName,
+				Query:      record.SampleSQL,
+				ExecCount:  record.ExecCount,
+				SumLatency: record.SumLatency,
+			})
+		}()
+	}
+	return stmtsummary.TopWorkloadStmts(stmts, n)
+}
+
 func (s *StmtSummary) rotateLoop() {
 	tick := time.NewTicker(defaultRotateCheckInterval * time.Second)
 	defer tick.Stop()
@@ -615,3 +644,12 @@ func GetMoreThanCntBindableStmt(frequency int64) []*stmtsummary.BindableStmt {
 	}
 	return stmtsummary.StmtSummaryByDigestMap.GetMoreThanCntBindableStmt(frequency)
 }
+
+// GetTopWorkloadStmts wraps GlobalStmtSummary.GetTopWorkloadStmts and
+// stmtsummary.StmtSummaryByDigestMap.GetTopWorkloadStmts.
+func GetTopWorkloadStmts(n int) []*stmtsummary.WorkloadStmt {
+	if config.GetGlobalConfig().Instance.StmtSummaryEnablePersistent {
+		return GlobalStmtSummary.GetTopWorkloadStmts(n)
+	}
+	return stmtsummary.StmtSummaryByDigestMap.GetTopWorkloadStmts(n)
+}
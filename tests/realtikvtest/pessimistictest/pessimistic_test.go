@@ -647,6 +647,58 @@ func TestSelectForUpdateNoWait(t *testing.T) {
 	tk2.MustExec("commit")
 }
 
+func TestSelectForUpdateSkipLocked(t *testing.T) {
+	store := realtikvtest.CreateMockStoreAndSetup(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk2.MustExec("use test")
+
+	tk.MustExec("drop table if exists tk")
+	tk.MustExec("create table tk (c1 int primary key, c2 int, c3 int, unique key uk(c2))")
+	tk.MustExec("insert into tk values(1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5)")
+
+	for _, isolation := range []string{"REPEATABLE-READ", "READ-COMMITTED"} {
+		tk.MustExec(fmt.Sprintf("set @@tx_isolation = '%s'", isolation))
+		tk2.MustExec(fmt.Sprintf("set @@tx_isolation = '%s'", isolation))
+
+		tk.MustExec("begin pessimistic")
+		tk.MustQuery("select * from tk where c1 in (2, 4) for update").Check(testkit.Rows("2 2 2", "4 4 4"))
+
+		tk2.MustExec("begin pessimistic")
+		// point get
+		tk2.MustQuery("select * from tk where c1 = 2 for update skip locked").Check(testkit.Rows())
+		tk2.MustQuery("select * from tk where c2 = 4 for update skip locked").Check(testkit.Rows())
+		tk2.MustQuery("select * from tk where c1 = 3 for update skip locked").Check(testkit.Rows("3 3 3"))
+		// batch point get
+		tk2.MustQuery("select * from tk where c1 in (1, 2, 4) for update skip locked").Check(testkit.Rows("1 1 1"))
+		tk2.MustQuery("select * from tk where c2 in (2, 4, 5) for update skip locked").Check(testkit.Rows("5 5 5"))
+		// scan
+		tk2.MustQuery("select * from tk where c3 > 0 for update skip locked").Sort().Check(testkit.Rows("1 1 1", "3 3 3", "5 5 5"))
+		tk2.MustQuery("select * from tk where c3 > 0 order by c1 limit 1 for update skip locked").Check(testkit.Rows("1 1 1"))
+		tk2.MustExec("commit")
+
+		// The rows locked by SKIP LOCKED are skipped by others as well.
+		tk2.MustExec("begin pessimistic")
+		tk2.MustQuery("select * from tk where c3 >= 3 for update skip locked").Sort().Check(testkit.Rows("3 3 3", "5 5 5"))
+		tk.MustExec("commit")
+		tk.MustExec("begin pessimistic")
+		tk.MustQuery("select * from tk where c3 > 0 for update skip locked").Sort().Check(testkit.Rows("1 1 1", "2 2 2", "4 4 4"))
+		tk.MustExec("commit")
+		tk2.MustExec("commit")
+	}
+
+	// The rows locked by a pending update are skipped.
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("update tk set c3 = c3 + 10 where c1 = 1")
+	tk2.MustExec("begin pessimistic")
+	tk2.MustQuery("select c1 from tk for update skip locked").Sort().Check(testkit.Rows("2", "3", "4", "5"))
+	tk.MustExec("commit")
+	tk2.MustExec("commit")
+	tk2.MustQuery("select c3 from tk where c1 = 1 for update skip locked").Check(testkit.Rows("11"))
+}
+
 func TestAsyncRollBackNoWait(t *testing.T) {
 	store := realtikvtest.CreateMockStoreAndSetup(t)
 